@@ -3,8 +3,10 @@ package main
 import (
 	"log"
 	"os"
+	"time"
 
 	"go-nextjs-api/internal/database"
+	"go-nextjs-api/internal/interfaces"
 	"go-nextjs-api/internal/middleware"
 
 	"github.com/gin-contrib/cors"
@@ -27,6 +29,19 @@ func main() {
 		log.Fatal("Failed to initialize application:", err)
 	}
 
+	// ベンダー紐付けの契約期間による自動遷移（有効化・期限切れ）を定期実行
+	go runVendorRelationScheduler(app.VendorRelationService)
+
+	// CSPアカウントの認証情報の定期ローテーション・旧鍵の失効を定期実行
+	go runCredentialRotationScheduler(app.CredentialRotationService)
+	go runCSPAccountClosureScheduler(app.CSPLifecycleService)
+
+	// プロバイダーの棚卸し（アカウント・メンバーのドリフト検出）を定期実行
+	go runCSPReconciliationScheduler(app.ReconciliationService)
+
+	// CSPアカウントのメンバーのプロバイダーへの反映（未反映・失敗したメンバーの再試行）を定期実行
+	go runCSPMemberSyncScheduler(app.MemberSyncService)
+
 	// Ginエンジンを作成
 	r := gin.Default()
 
@@ -59,6 +74,10 @@ func main() {
 		// 認証関連
 		api.POST("/register", app.AuthHandler.Register)
 		api.POST("/login", app.AuthHandler.Login)
+
+		// 招待リンク（未登録ユーザーも利用）
+		api.GET("/invitations/preview", app.InvitationHandler.PreviewInvitation)    // 招待内容の確認
+		api.POST("/invitations/register", app.InvitationHandler.RegisterWithInvitation) // 招待リンクからのユーザー登録
 	}
 
 	// 認証が必要なAPI routes
@@ -75,26 +94,67 @@ func main() {
 		protected.PUT("/users/:id", app.UserHandler.UpdateUser)
 
 		// プロジェクト管理（認証必須）
-		protected.GET("/projects", app.ProjectHandler.GetProjectsByType)         // プロジェクト一覧（type=vendorフィルタ付き）
-		protected.GET("/projects/me", app.ProjectHandler.GetUserProjects)        // 自分が所属するプロジェクト一覧
+		protected.GET("/projects", app.ProjectHandler.SearchProjects)            // プロジェクト検索（キーワード・各種フィルタ・ソート・ページング）
+		protected.GET("/projects/me", app.ProjectHandler.GetUserProjects)        // 自分が所属するプロジェクト一覧（検索・ページング指定時のみページング）
 		protected.GET("/projects/:id", app.ProjectHandler.GetProject)            // プロジェクト詳細
 		protected.GET("/projects/:id/members", app.ProjectHandler.GetProjectMembers) // プロジェクトメンバー一覧
-		protected.GET("/projects/:id/vendor-relations", app.ProjectHandler.GetVendorRelations) // ベンダープロジェクト紐付け一覧
+		protected.GET("/projects/:id/vendor-relations", app.VendorRelationHandler.GetVendorRelations) // ベンダープロジェクト紐付け一覧（紐付け元・ベンダー側の両方）
 		protected.POST("/projects", app.ProjectHandler.CreateProject)            // プロジェクト作成
-		protected.POST("/projects/:id/vendor-relations", app.ProjectHandler.CreateVendorRelation) // ベンダープロジェクト紐付け作成
+		protected.POST("/projects/:id/vendor-relations", app.VendorRelationHandler.ProposeVendorRelation) // ベンダープロジェクトへの紐付け提案
 		protected.PUT("/projects/:id", app.ProjectHandler.UpdateProject)         // プロジェクト更新
-		protected.DELETE("/projects/:id", app.ProjectHandler.DeleteProject)      // プロジェクト削除（オーナーのみ）
-		protected.DELETE("/projects/:id/vendor-relations/:relationId", app.ProjectHandler.DeleteVendorRelation) // ベンダープロジェクト紐付け削除
+		protected.DELETE("/projects/:id", app.ProjectHandler.DeleteProject)      // プロジェクト削除（オーナーのみ、ゴミ箱へ移動）
+		protected.POST("/projects/:id/status", app.ProjectHandler.TransitionProjectStatus) // ステータス変更（アーカイブ・アーカイブ解除）
+		protected.POST("/projects/:id/clone", app.ProjectCloneHandler.CloneProject)         // プロジェクト複製（メタデータ・メンバー・ベンダー紐付け・CSP申請から選択）
+		protected.GET("/projects/:id/deletion-impact", app.DeletionHandler.GetProjectDeletionImpact) // プロジェクト削除の影響確認（オーナーのみ）
+		protected.GET("/projects/:id/environments", app.EnvironmentHandler.GetEnvironments)                                      // 環境一覧
+		protected.POST("/projects/:id/environments", app.EnvironmentHandler.CreateEnvironment)                                   // 環境追加（承認ルール・許可リージョン・メンバーのデフォルト設定）
+		protected.GET("/projects/:id/environments/:environmentId", app.EnvironmentHandler.GetEnvironment)                        // 環境詳細（所属CSPアカウント・メンバーを含む）
+		protected.PUT("/projects/:id/environments/:environmentId", app.EnvironmentHandler.UpdateEnvironment)                     // 環境の設定更新
+		protected.DELETE("/projects/:id/environments/:environmentId", app.EnvironmentHandler.DeleteEnvironment)                  // 環境削除（CSPアカウントが所属していない場合のみ）
+		protected.POST("/projects/:id/environments/:environmentId/members", app.EnvironmentHandler.AddEnvironmentMember)         // 環境へのアクセス付与
+		protected.DELETE("/projects/:id/environments/:environmentId/members/:userId", app.EnvironmentHandler.RemoveEnvironmentMember) // 環境へのアクセス取り消し
+		protected.PUT("/projects/:id/csp-accounts/:cspAccountId/environment", app.EnvironmentHandler.AssignCSPAccount)           // CSPアカウントの所属環境の変更
+		protected.DELETE("/projects/:id/vendor-relations/:relationId", app.VendorRelationHandler.WithdrawVendorRelation) // 承諾前の紐付け提案の取り下げ
+		protected.POST("/projects/:id/vendor-relations/:relationId/accept", app.VendorRelationHandler.AcceptVendorRelation)       // 紐付け提案の承諾（ベンダー側）
+		protected.POST("/projects/:id/vendor-relations/:relationId/decline", app.VendorRelationHandler.DeclineVendorRelation)     // 紐付け提案の辞退（ベンダー側）
+		protected.POST("/projects/:id/vendor-relations/:relationId/terminate", app.VendorRelationHandler.TerminateVendorRelation) // 紐付けの終了（双方）
+		protected.PUT("/projects/:id/vendor-relations/:relationId/grant", app.VendorRelationHandler.UpdateAccessGrant)            // ベンダーへの委任権限の設定（紐付け元）
+		protected.GET("/projects/:id/vendor-relations/:relationId/staff", app.VendorRelationHandler.GetStaffAssignments)          // 担当者への権限割り当て一覧
+		protected.PUT("/projects/:id/vendor-relations/:relationId/staff", app.VendorRelationHandler.AssignStaff)                  // 担当者への権限割り当て（ベンダー側）
+		protected.DELETE("/projects/:id/vendor-relations/:relationId/staff/:userId", app.VendorRelationHandler.RemoveStaff)       // 担当者への権限割り当て削除（ベンダー側）
+		protected.GET("/projects/:id/delegated-access", app.VendorRelationHandler.GetDelegatedAccess)                             // 自分の委任権限
+		protected.GET("/vendor-portfolio", app.VendorRelationHandler.GetVendorPortfolio)                                          // ベンダーとして関わる依頼元プロジェクト一覧
 		protected.POST("/projects/:id/members", app.ProjectHandler.AddProjectMember)           // メンバー追加
 		protected.PUT("/projects/:id/members/:memberId", func(c *gin.Context) {
 			log.Printf("Route handler called: PUT /projects/:id/members/:memberId - %s", c.Request.URL.Path)
 			app.ProjectHandler.UpdateProjectMemberRole(c)
 		}) // メンバーロール更新
-		protected.DELETE("/projects/:id/members/:memberId", app.ProjectHandler.RemoveProjectMember)  // メンバー削除
+		protected.DELETE("/projects/:id/members/:memberId", app.ProjectHandler.RemoveProjectMember)  // メンバー削除（CSPアカウントメンバーシップも無効化）
+		protected.POST("/projects/:id/members/import", app.MemberImportHandler.ImportProjectMembers)                // メンバー一括登録（CSV/XLSX、dry_run対応）
+		protected.GET("/projects/:id/members/export", app.MemberImportHandler.ExportProjectMembers)                 // メンバー一括出力（CSV/XLSX）
+		protected.POST("/projects/:id/csp-account-members/import", app.MemberImportHandler.ImportCSPAccountMembers) // CSPアカウントメンバー一括登録（CSV/XLSX、dry_run対応）
+		protected.GET("/projects/:id/csp-account-members/export", app.MemberImportHandler.ExportCSPAccountMembers)  // CSPアカウントメンバー一括出力（CSV/XLSX）
+		protected.POST("/projects/:id/transfer-ownership", app.ProjectHandler.TransferOwnership)     // オーナー権限の移譲
+		protected.GET("/projects/:id/invitations", app.InvitationHandler.GetProjectInvitations)     // 招待一覧
+		protected.POST("/projects/:id/invitations", app.InvitationHandler.CreateProjectInvitation)  // メールアドレスで招待
+		protected.POST("/projects/:id/invitations/:invitationId/resend", app.InvitationHandler.ResendProjectInvitation) // 招待再送
+		protected.DELETE("/projects/:id/invitations/:invitationId", app.InvitationHandler.RevokeProjectInvitation)      // 招待取り消し
+		protected.POST("/invitations/accept", app.InvitationHandler.AcceptInvitation)               // 招待承諾
+		
+		// 組織のカスタム属性定義（プロジェクト作成・編集フォーム用）
+		protected.GET("/organizations/:id/attribute-definitions", app.CustomAttributeHandler.GetDefinitions)
 		
+		// プロジェクトテンプレート（プロジェクト作成フォーム用）
+		protected.GET("/project-templates", app.ProjectTemplateHandler.GetTemplates)
+		protected.GET("/project-templates/:id", app.ProjectTemplateHandler.GetTemplate)
 		
+		
+		// CSPプロバイダー（表示名・アカウントIDの形式・リージョン・対応機能）
+		protected.GET("/csp-providers", app.CSPHandler.GetCSPProviders)
+
 		// Project CSP Account関連（認証必須 - ユーザーは自分のプロジェクトのみアクセス可能）
 		protected.GET("/project-csp-accounts", app.CSPHandler.GetProjectCSPAccounts) // プロジェクトCSPアカウント関連一覧
+		protected.POST("/csp-accounts/:id/temporary-credentials", app.CredentialVendingHandler.IssueTemporaryCredentials) // メンバーへの短期の認証情報の払い出し（ロールに応じた権限）
 		
 		// CSP Account Member関連（認証必須）
 		protected.GET("/csp-account-members", app.CSPHandler.GetCSPAccountMembers)       // CSPアカウントメンバー一覧
@@ -105,10 +165,14 @@ func main() {
 		
 		// 内部API（マイクロサービス間通信用）
 		internal := r.Group("/api/internal")
+		internal.Use(middleware.InternalAuthMiddleware())
 		{
 			internal.GET("/projects/:id/can-manage", app.InternalHandler.CanManageProject)
 			internal.GET("/projects/:id/type", app.InternalHandler.GetProjectType)
+			internal.GET("/projects/:id/access", app.InternalHandler.GetProjectAccess) // メンバー権限・ベンダー委任権限
+			internal.GET("/projects/:id/environments/:environmentId", app.InternalHandler.GetEnvironmentSettings) // 環境の承認ルール・許可リージョン
 			internal.POST("/csp-accounts/auto-create", app.InternalHandler.AutoCreateCSPAccount)
+			internal.GET("/csp-providers", app.CSPHandler.GetCSPProviders) // プロバイダーレジストリ（CSPプロビジョニングサービスが申請の検証に使う）
 		}
 		
 		// システム管理者のみ
@@ -117,20 +181,70 @@ func main() {
 		{
 			adminOnly.POST("/users", app.UserHandler.CreateUser)
 			adminOnly.DELETE("/users/:id", app.UserHandler.DeleteUser)
+			adminOnly.GET("/users/:id/deletion-impact", app.DeletionHandler.GetUserDeletionImpact) // ユーザー削除の影響確認
+			
+			// プロジェクトのゴミ箱（管理者のみ）
+			adminOnly.GET("/projects/trash", app.ProjectHandler.GetTrashedProjects)          // 削除済みプロジェクト一覧
+			adminOnly.POST("/projects/:id/restore", app.ProjectHandler.RestoreProject)       // 削除済みプロジェクトの復元
 			
 			// CSP Account関連（管理者のみ）
 			adminOnly.GET("/csp-accounts", app.CSPHandler.GetCSPAccounts)                     // CSPアカウント一覧
 			adminOnly.GET("/csp-accounts/:id", app.CSPHandler.GetCSPAccount)                  // CSPアカウント詳細
 			adminOnly.POST("/csp-accounts", app.CSPHandler.CreateCSPAccount)                  // CSPアカウント作成
 			adminOnly.PUT("/csp-accounts/:id", app.CSPHandler.UpdateCSPAccount)               // CSPアカウント更新
-			adminOnly.DELETE("/csp-accounts/:id", app.CSPHandler.DeleteCSPAccount)            // CSPアカウント削除
+			adminOnly.DELETE("/csp-accounts/:id", app.CSPHandler.DeleteCSPAccount)            // CSPアカウント削除（作成に失敗したアカウントのみ）
+			adminOnly.POST("/csp-accounts/:id/sync-status", app.CSPHandler.SyncCSPAccountStatus) // プロバイダー側の状態の反映（作成完了・停止・閉鎖）
+			adminOnly.POST("/csp-accounts/:id/suspend", app.CSPLifecycleHandler.SuspendCSPAccount)      // 一時停止（理由必須）
+			adminOnly.POST("/csp-accounts/:id/resume", app.CSPLifecycleHandler.ResumeCSPAccount)        // 一時停止からの再開
+			adminOnly.GET("/csp-accounts/:id/closure-check", app.CSPLifecycleHandler.GetClosureCheck)   // 閉鎖の事前確認（残っているメンバー・当月の費用）
+			adminOnly.POST("/csp-accounts/:id/close", app.CSPLifecycleHandler.CloseCSPAccount)          // プロバイダー側のアカウントの閉鎖（閉鎖後も記録を保持）
+			adminOnly.GET("/csp-accounts/:id/lifecycle-events", app.CSPLifecycleHandler.GetLifecycleEvents) // ステータス遷移の履歴
+			adminOnly.GET("/csp-accounts/:id/credential-rotations", app.CredentialRotationHandler.GetRotationHistory)       // 認証情報の発行・ローテーション履歴
+			adminOnly.PUT("/csp-accounts/:id/credential-rotation-policy", app.CredentialRotationHandler.UpdateRotationPolicy) // 認証情報の最大有効日数の設定
+			adminOnly.POST("/csp-accounts/:id/rotate-credentials", app.CredentialRotationHandler.RotateCredentials)        // 認証情報の手動ローテーション（旧鍵は猶予期間の間だけ有効）
+			adminOnly.POST("/csp-accounts/:id/sync-members", app.MemberSyncHandler.SyncCSPAccountMembers)                // 全メンバーのプロバイダーへの再反映
+			adminOnly.GET("/csp-accounts/:id/credential-issuances", app.CredentialVendingHandler.GetIssuances)            // メンバーへの一時的な認証情報の払い出し記録
+			adminOnly.GET("/csp-accounts/:id/deletion-impact", app.DeletionHandler.GetCSPAccountDeletionImpact) // CSPアカウント削除の影響確認
+			
+			// 長期の認証情報の開示（ブレークグラス、管理者のみ）
+			adminOnly.POST("/csp-accounts/:id/secret-reveals", app.SecretRevealHandler.RequestReveal)      // 開示申請（理由必須、承認者の指定は任意）
+			adminOnly.GET("/csp-accounts/:id/secret-reveals", app.SecretRevealHandler.GetReveals)          // 開示申請一覧（認証情報は含まない）
+			adminOnly.POST("/secret-reveals/:revealId/approve", app.SecretRevealHandler.ApproveReveal)     // 開示申請の承認（申請者以外）
+			adminOnly.POST("/secret-reveals/:revealId/reject", app.SecretRevealHandler.RejectReveal)       // 開示申請の却下
+			adminOnly.POST("/secret-reveals/:revealId/reveal", app.SecretRevealHandler.Reveal)             // 認証情報の開示（申請者のみ、一度だけ）
+			adminOnly.GET("/secret-reveals/audit/verify", app.SecretRevealHandler.VerifyAuditLog)          // 監査記録の改ざん検証
+			
+			// クラウドの棚卸し（管理者のみ）
+			adminOnly.GET("/csp-reconciliation/runs", app.ReconciliationHandler.GetRuns)            // 棚卸しの実行履歴
+			adminOnly.POST("/csp-reconciliation/runs", app.ReconciliationHandler.RunReconciliation) // 棚卸しの実行（providerを省略した場合はすべてのプロバイダー）
+			adminOnly.GET("/csp-drift", app.ReconciliationHandler.GetDriftItems)                   // 検出したドリフト一覧（provider・status・kindで絞り込み）
+			adminOnly.POST("/csp-drift/:id/resolve", app.ReconciliationHandler.ResolveDriftItem)   // ドリフトへの対応（import・fix・ignore）
+			
 			adminOnly.GET("/project-csp-accounts", app.CSPHandler.GetProjectCSPAccounts)      // プロジェクトCSPアカウント関連一覧
 			adminOnly.POST("/project-csp-accounts", app.CSPHandler.CreateProjectCSPAccount)   // プロジェクトCSPアカウント関連作成
 			adminOnly.DELETE("/project-csp-accounts/:id", app.CSPHandler.DeleteProjectCSPAccount) // プロジェクトCSPアカウント関連削除
 			
+			// カスタム属性定義の管理（管理者のみ）
+			adminOnly.POST("/organizations/:id/attribute-definitions", app.CustomAttributeHandler.CreateDefinition)
+			adminOnly.PUT("/organizations/:id/attribute-definitions/:definitionId", app.CustomAttributeHandler.UpdateDefinition)
+			adminOnly.DELETE("/organizations/:id/attribute-definitions/:definitionId", app.CustomAttributeHandler.DeleteDefinition)
+			
+			// プロジェクトテンプレートの管理（管理者のみ）
+			adminOnly.POST("/project-templates", app.ProjectTemplateHandler.CreateTemplate)
+			adminOnly.PUT("/project-templates/:id", app.ProjectTemplateHandler.UpdateTemplate)
+			adminOnly.DELETE("/project-templates/:id", app.ProjectTemplateHandler.DeleteTemplate)
+			
+			// レポート（管理者のみ）
+			adminOnly.GET("/reports/project-csp-accounts", app.CSPHandler.GetProjectCSPAccountReport) // タグ・カスタム属性で絞り込んだCSPアカウント関連一覧
+			
 			// CSP Account Member関連（管理者のみ）
 			adminOnly.GET("/csp-account-members", app.CSPHandler.GetCSPAccountMembers)       // CSPアカウントメンバー一覧（管理者）
 			adminOnly.GET("/csp-account-members/:id", app.CSPHandler.GetCSPAccountMember)   // CSPアカウントメンバー詳細（管理者）
+			adminOnly.POST("/csp-account-members/:id/sync", app.MemberSyncHandler.SyncCSPAccountMember) // メンバーのプロバイダーへの再反映
+			
+			// 状態定義の同期（管理者のみ）
+			adminOnly.POST("/state/plan", app.StateSyncHandler.PlanState)   // 状態定義（YAML）とDBの差分の確認（prune対応）
+			adminOnly.POST("/state/apply", app.StateSyncHandler.ApplyState) // 状態定義の適用（expected_fingerprintで確認済みプランと照合）
 		}
 	}
 
@@ -144,4 +258,109 @@ func main() {
 	if err := r.Run(":" + port); err != nil {
 		log.Fatal("Failed to start server:", err)
 	}
-}
\ No newline at end of file
+}
+
+// runVendorRelationScheduler はベンダー紐付けの契約期間による遷移を定期的に処理
+// 間隔はVENDOR_RELATION_SWEEP_INTERVAL（例: 30m）で変更できる（デフォルト1時間）
+func runVendorRelationScheduler(vendorRelationService interfaces.VendorRelationService) {
+	interval := time.Hour
+	if v, err := time.ParseDuration(os.Getenv("VENDOR_RELATION_SWEEP_INTERVAL")); err == nil && v > 0 {
+		interval = v
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if count, err := vendorRelationService.ProcessScheduledTransitions(); err != nil {
+			log.Printf("Failed to process vendor relation schedule: %v", err)
+		} else if count > 0 {
+			log.Printf("Vendor relation schedule: %d relation(s) transitioned", count)
+		}
+		<-ticker.C
+	}
+}
+
+// runCredentialRotationScheduler はCSPアカウントの認証情報のローテーションと旧鍵の失効を定期的に処理
+// 間隔はCSP_CREDENTIAL_ROTATION_SWEEP_INTERVAL（例: 30m）で変更できる（デフォルト1時間）
+func runCredentialRotationScheduler(credentialRotationService interfaces.CredentialRotationService) {
+	interval := time.Hour
+	if v, err := time.ParseDuration(os.Getenv("CSP_CREDENTIAL_ROTATION_SWEEP_INTERVAL")); err == nil && v > 0 {
+		interval = v
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if count, err := credentialRotationService.ProcessScheduledRotations(); err != nil {
+			log.Printf("Failed to process credential rotation schedule: %v", err)
+		} else if count > 0 {
+			log.Printf("Credential rotation schedule: %d CSP account(s) rotated", count)
+		}
+		<-ticker.C
+	}
+}
+
+// runCSPAccountClosureScheduler は閉鎖処理中のCSPアカウントについて、プロバイダーでの閉鎖の完了を定期的に反映
+// 間隔はCSP_ACCOUNT_CLOSURE_SWEEP_INTERVAL（例: 30m）で変更できる（デフォルト1時間）
+func runCSPAccountClosureScheduler(cspLifecycleService interfaces.CSPLifecycleService) {
+	interval := time.Hour
+	if v, err := time.ParseDuration(os.Getenv("CSP_ACCOUNT_CLOSURE_SWEEP_INTERVAL")); err == nil && v > 0 {
+		interval = v
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if count, err := cspLifecycleService.ProcessClosingAccounts(); err != nil {
+			log.Printf("Failed to process closing CSP accounts: %v", err)
+		} else if count > 0 {
+			log.Printf("CSP account closure: %d CSP account(s) closed", count)
+		}
+		<-ticker.C
+	}
+}
+
+// runCSPReconciliationScheduler はプロバイダーの棚卸しを定期的に実行し、ドリフトを記録する
+// 間隔はCSP_RECONCILIATION_INTERVAL（例: 1h）で変更できる（デフォルト6時間）
+func runCSPReconciliationScheduler(reconciliationService interfaces.ReconciliationService) {
+	interval := 6 * time.Hour
+	if v, err := time.ParseDuration(os.Getenv("CSP_RECONCILIATION_INTERVAL")); err == nil && v > 0 {
+		interval = v
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		<-ticker.C
+		runs, err := reconciliationService.RunReconciliation("", nil)
+		if err != nil {
+			log.Printf("Failed to run CSP reconciliation: %v", err)
+			continue
+		}
+		for _, run := range runs {
+			if run.DriftDetected > 0 || run.DriftResolved > 0 {
+				log.Printf("CSP reconciliation (%s): %d open drift item(s), %d resolved", run.Provider, run.DriftDetected, run.DriftResolved)
+			}
+		}
+	}
+}
+
+// runCSPMemberSyncScheduler は未反映・反映に失敗したCSPアカウントのメンバーを定期的にプロバイダーに反映
+// 間隔はCSP_MEMBER_SYNC_INTERVAL（例: 5m）で変更できる（デフォルト15分）
+func runCSPMemberSyncScheduler(memberSyncService interfaces.MemberSyncService) {
+	interval := 15 * time.Minute
+	if v, err := time.ParseDuration(os.Getenv("CSP_MEMBER_SYNC_INTERVAL")); err == nil && v > 0 {
+		interval = v
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if count, err := memberSyncService.ProcessPendingSync(); err != nil {
+			log.Printf("Failed to sync CSP account members: %v", err)
+		} else if count > 0 {
+			log.Printf("CSP member sync: %d member(s) synced", count)
+		}
+		<-ticker.C
+	}
+}
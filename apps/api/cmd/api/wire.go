@@ -4,8 +4,12 @@
 package main
 
 import (
+	"go-nextjs-api/internal/client"
 	"go-nextjs-api/internal/database"
 	"go-nextjs-api/internal/handler"
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/notification"
+	"go-nextjs-api/internal/provider"
 	"go-nextjs-api/internal/repository"
 	"go-nextjs-api/internal/service"
 
@@ -15,11 +19,33 @@ import (
 
 // ApplicationContainer はアプリケーションの依存関係をまとめる構造体
 type ApplicationContainer struct {
-	UserHandler     *handler.UserHandler
-	AuthHandler     *handler.AuthHandler
-	ProjectHandler  *handler.ProjectHandler
-	CSPHandler      *handler.CSPHandler
-	InternalHandler *handler.InternalHandler
+	UserHandler               *handler.UserHandler
+	AuthHandler               *handler.AuthHandler
+	ProjectHandler            *handler.ProjectHandler
+	CSPHandler                *handler.CSPHandler
+	InternalHandler           *handler.InternalHandler
+	InvitationHandler         *handler.InvitationHandler
+	CustomAttributeHandler    *handler.CustomAttributeHandler
+	ProjectTemplateHandler    *handler.ProjectTemplateHandler
+	VendorRelationHandler     *handler.VendorRelationHandler
+	MemberImportHandler       *handler.MemberImportHandler
+	StateSyncHandler          *handler.StateSyncHandler
+	ProjectCloneHandler       *handler.ProjectCloneHandler
+	DeletionHandler           *handler.DeletionHandler
+	EnvironmentHandler        *handler.EnvironmentHandler
+	CredentialRotationHandler *handler.CredentialRotationHandler
+	CredentialVendingHandler  *handler.CredentialVendingHandler
+	SecretRevealHandler       *handler.SecretRevealHandler
+	CSPLifecycleHandler       *handler.CSPLifecycleHandler
+	ReconciliationHandler     *handler.ReconciliationHandler
+	MemberSyncHandler         *handler.MemberSyncHandler
+
+	// バックグラウンド処理用
+	VendorRelationService     interfaces.VendorRelationService
+	CredentialRotationService interfaces.CredentialRotationService
+	CSPLifecycleService       interfaces.CSPLifecycleService
+	ReconciliationService     interfaces.ReconciliationService
+	MemberSyncService         interfaces.MemberSyncService
 }
 
 // initializeApplication はWireを使って依存関係を注入したApplicationContainerを作成
@@ -29,12 +55,43 @@ func initializeApplication(db *gorm.DB) (*ApplicationContainer, error) {
 		repository.NewUserRepository,
 		repository.NewProjectRepository,
 		repository.NewCSPRepository,
+		repository.NewCustomAttributeRepository,
+		repository.NewProjectTemplateRepository,
+		repository.NewStateRepository,
+		repository.NewDeletionRepository,
+		repository.NewEnvironmentRepository,
+		repository.NewSecretRevealRepository,
+		repository.NewReconciliationRepository,
+		
+		// 通知送信
+		notification.NewNotifier,
+		
+		// 外部サービスクライアント
+		client.NewProvisioningClient,
+
+		// CSPプロバイダードライバー
+		provider.NewProviderDrivers,
 		
 		// Service層のプロバイダー
 		service.NewUserService,
 		service.NewAuthService,
 		service.NewProjectService,
 		service.NewCSPService,
+		service.NewInvitationService,
+		service.NewCustomAttributeService,
+		service.NewProjectTemplateService,
+		service.NewVendorRelationService,
+		service.NewMemberImportService,
+		service.NewStateSyncService,
+		service.NewProjectCloneService,
+		service.NewDeletionService,
+		service.NewEnvironmentService,
+		service.NewCredentialRotationService,
+		service.NewCredentialVendingService,
+		service.NewSecretRevealService,
+		service.NewCSPLifecycleService,
+		service.NewReconciliationService,
+		service.NewMemberSyncService,
 		
 		// Handler層のプロバイダー
 		handler.NewUserHandler,
@@ -42,6 +99,21 @@ func initializeApplication(db *gorm.DB) (*ApplicationContainer, error) {
 		handler.NewProjectHandler,
 		handler.NewCSPHandler,
 		handler.NewInternalHandler,
+		handler.NewInvitationHandler,
+		handler.NewCustomAttributeHandler,
+		handler.NewProjectTemplateHandler,
+		handler.NewVendorRelationHandler,
+		handler.NewMemberImportHandler,
+		handler.NewStateSyncHandler,
+		handler.NewProjectCloneHandler,
+		handler.NewDeletionHandler,
+		handler.NewEnvironmentHandler,
+		handler.NewCredentialRotationHandler,
+		handler.NewCredentialVendingHandler,
+		handler.NewSecretRevealHandler,
+		handler.NewCSPLifecycleHandler,
+		handler.NewReconciliationHandler,
+		handler.NewMemberSyncHandler,
 		
 		// ApplicationContainerの構築
 		wire.Struct(new(ApplicationContainer), "*"),
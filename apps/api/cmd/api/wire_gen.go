@@ -7,8 +7,12 @@
 package main
 
 import (
+	"go-nextjs-api/internal/client"
 	"go-nextjs-api/internal/database"
 	"go-nextjs-api/internal/handler"
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/notification"
+	"go-nextjs-api/internal/provider"
 	"go-nextjs-api/internal/repository"
 	"go-nextjs-api/internal/service"
 	"gorm.io/gorm"
@@ -19,23 +23,89 @@ import (
 // initializeApplication はWireを使って依存関係を注入したApplicationContainerを作成
 func initializeApplication(db *gorm.DB) (*ApplicationContainer, error) {
 	userRepository := repository.NewUserRepository(db)
-	userService := service.NewUserService(userRepository)
+	projectRepository := repository.NewProjectRepository(db)
+	cspRepository := repository.NewCSPRepository(db)
+	deletionRepository := repository.NewDeletionRepository(db)
+	provisioningClient := client.NewProvisioningClient()
+	deletionService := service.NewDeletionService(userRepository, projectRepository, cspRepository, deletionRepository, provisioningClient)
+	userService := service.NewUserService(userRepository, deletionService)
 	userHandler := handler.NewUserHandler(userService)
 	authService := service.NewAuthService(userRepository)
 	authHandler := handler.NewAuthHandler(authService)
-	projectRepository := repository.NewProjectRepository(db)
-	projectService := service.NewProjectService(userRepository, projectRepository)
+	customAttributeRepository := repository.NewCustomAttributeRepository(db)
+	projectTemplateRepository := repository.NewProjectTemplateRepository(db)
+	notifier := notification.NewNotifier()
+	projectService := service.NewProjectService(userRepository, projectRepository, customAttributeRepository, projectTemplateRepository, provisioningClient, notifier, deletionService)
 	projectHandler := handler.NewProjectHandler(projectService)
-	cspRepository := repository.NewCSPRepository(db)
-	cspService := service.NewCSPService(cspRepository, projectRepository, userRepository)
+	environmentRepository := repository.NewEnvironmentRepository(db)
+	providerDrivers, err := provider.NewProviderDrivers()
+	if err != nil {
+		return nil, err
+	}
+	memberSyncService := service.NewMemberSyncService(cspRepository, providerDrivers)
+	cspService := service.NewCSPService(cspRepository, projectRepository, userRepository, environmentRepository, deletionService, providerDrivers, memberSyncService)
 	cspHandler := handler.NewCSPHandler(cspService)
-	internalHandler := handler.NewInternalHandler(projectService, cspService)
+	environmentService := service.NewEnvironmentService(userRepository, projectRepository, cspRepository, environmentRepository, projectService)
+	internalHandler := handler.NewInternalHandler(projectService, cspService, environmentService)
+	invitationService := service.NewInvitationService(userRepository, projectRepository, projectService, authService, notifier)
+	invitationHandler := handler.NewInvitationHandler(invitationService)
+	customAttributeService := service.NewCustomAttributeService(customAttributeRepository)
+	customAttributeHandler := handler.NewCustomAttributeHandler(customAttributeService)
+	projectTemplateService := service.NewProjectTemplateService(projectTemplateRepository, projectRepository)
+	projectTemplateHandler := handler.NewProjectTemplateHandler(projectTemplateService)
+	vendorRelationService := service.NewVendorRelationService(projectRepository, cspRepository, projectService, provisioningClient, notifier)
+	vendorRelationHandler := handler.NewVendorRelationHandler(vendorRelationService)
+	memberImportService := service.NewMemberImportService(userRepository, projectRepository, cspRepository, projectService)
+	memberImportHandler := handler.NewMemberImportHandler(memberImportService)
+	stateRepository := repository.NewStateRepository(db)
+	stateSyncService := service.NewStateSyncService(stateRepository, projectRepository, deletionService, provisioningClient)
+	stateSyncHandler := handler.NewStateSyncHandler(stateSyncService)
+	projectCloneService := service.NewProjectCloneService(userRepository, projectRepository, cspRepository, customAttributeRepository, projectService, provisioningClient, notifier)
+	projectCloneHandler := handler.NewProjectCloneHandler(projectCloneService)
+	deletionHandler := handler.NewDeletionHandler(deletionService)
+	environmentHandler := handler.NewEnvironmentHandler(environmentService)
+	credentialRotationService := service.NewCredentialRotationService(cspRepository, projectRepository, providerDrivers, notifier)
+	credentialRotationHandler := handler.NewCredentialRotationHandler(credentialRotationService)
+	credentialVendingService := service.NewCredentialVendingService(cspRepository, projectRepository, providerDrivers)
+	credentialVendingHandler := handler.NewCredentialVendingHandler(credentialVendingService)
+	secretRevealRepository := repository.NewSecretRevealRepository(db)
+	secretRevealService, err := service.NewSecretRevealService(secretRevealRepository, cspRepository, projectRepository, notifier)
+	if err != nil {
+		return nil, err
+	}
+	secretRevealHandler := handler.NewSecretRevealHandler(secretRevealService)
+	cspLifecycleService := service.NewCSPLifecycleService(cspRepository, cspService, providerDrivers)
+	cspLifecycleHandler := handler.NewCSPLifecycleHandler(cspLifecycleService)
+	reconciliationRepository := repository.NewReconciliationRepository(db)
+	reconciliationService := service.NewReconciliationService(reconciliationRepository, cspRepository, userRepository, cspService, memberSyncService, providerDrivers)
+	reconciliationHandler := handler.NewReconciliationHandler(reconciliationService)
+	memberSyncHandler := handler.NewMemberSyncHandler(memberSyncService)
 	applicationContainer := &ApplicationContainer{
-		UserHandler:     userHandler,
-		AuthHandler:     authHandler,
-		ProjectHandler:  projectHandler,
-		CSPHandler:      cspHandler,
-		InternalHandler: internalHandler,
+		UserHandler:               userHandler,
+		AuthHandler:               authHandler,
+		ProjectHandler:            projectHandler,
+		CSPHandler:                cspHandler,
+		InternalHandler:           internalHandler,
+		InvitationHandler:         invitationHandler,
+		CustomAttributeHandler:    customAttributeHandler,
+		ProjectTemplateHandler:    projectTemplateHandler,
+		VendorRelationHandler:     vendorRelationHandler,
+		MemberImportHandler:       memberImportHandler,
+		StateSyncHandler:          stateSyncHandler,
+		ProjectCloneHandler:       projectCloneHandler,
+		DeletionHandler:           deletionHandler,
+		EnvironmentHandler:        environmentHandler,
+		CredentialRotationHandler: credentialRotationHandler,
+		CredentialVendingHandler:  credentialVendingHandler,
+		SecretRevealHandler:       secretRevealHandler,
+		CSPLifecycleHandler:       cspLifecycleHandler,
+		ReconciliationHandler:     reconciliationHandler,
+		MemberSyncHandler:         memberSyncHandler,
+		VendorRelationService:     vendorRelationService,
+		CredentialRotationService: credentialRotationService,
+		CSPLifecycleService:       cspLifecycleService,
+		ReconciliationService:     reconciliationService,
+		MemberSyncService:         memberSyncService,
 	}
 	return applicationContainer, nil
 }
@@ -44,11 +114,33 @@ func initializeApplication(db *gorm.DB) (*ApplicationContainer, error) {
 
 // ApplicationContainer はアプリケーションの依存関係をまとめる構造体
 type ApplicationContainer struct {
-	UserHandler     *handler.UserHandler
-	AuthHandler     *handler.AuthHandler
-	ProjectHandler  *handler.ProjectHandler
-	CSPHandler      *handler.CSPHandler
-	InternalHandler *handler.InternalHandler
+	UserHandler               *handler.UserHandler
+	AuthHandler               *handler.AuthHandler
+	ProjectHandler            *handler.ProjectHandler
+	CSPHandler                *handler.CSPHandler
+	InternalHandler           *handler.InternalHandler
+	InvitationHandler         *handler.InvitationHandler
+	CustomAttributeHandler    *handler.CustomAttributeHandler
+	ProjectTemplateHandler    *handler.ProjectTemplateHandler
+	VendorRelationHandler     *handler.VendorRelationHandler
+	MemberImportHandler       *handler.MemberImportHandler
+	StateSyncHandler          *handler.StateSyncHandler
+	ProjectCloneHandler       *handler.ProjectCloneHandler
+	DeletionHandler           *handler.DeletionHandler
+	EnvironmentHandler        *handler.EnvironmentHandler
+	CredentialRotationHandler *handler.CredentialRotationHandler
+	CredentialVendingHandler  *handler.CredentialVendingHandler
+	SecretRevealHandler       *handler.SecretRevealHandler
+	CSPLifecycleHandler       *handler.CSPLifecycleHandler
+	ReconciliationHandler     *handler.ReconciliationHandler
+	MemberSyncHandler         *handler.MemberSyncHandler
+
+	// バックグラウンド処理用
+	VendorRelationService     interfaces.VendorRelationService
+	CredentialRotationService interfaces.CredentialRotationService
+	CSPLifecycleService       interfaces.CSPLifecycleService
+	ReconciliationService     interfaces.ReconciliationService
+	MemberSyncService         interfaces.MemberSyncService
 }
 
 // DatabaseProvider はデータベースインスタンスを提供
@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"go-nextjs-api/internal/database"
+	"go-nextjs-api/internal/kms"
 
 	"github.com/joho/godotenv"
 )
@@ -20,16 +21,28 @@ func main() {
 		reset       = flag.Bool("reset", false, "Reset database (drop + migrate + seed)")
 		testData    = flag.Bool("test-data", false, "Create pagination test data (50+ users)")
 		cleanupTest = flag.Bool("cleanup-test", false, "Cleanup test data")
+		rotateKey   = flag.Bool("rotate-key", false, "Re-encrypt all CSP account secrets under the master key given by -new-key-file (or the current one)")
+		newKeyFile  = flag.String("new-key-file", "", "Master key file to re-encrypt secrets with (used with -rotate-key)")
+		generateKey = flag.String("generate-key", "", "Generate a new master key file at the given path")
 		help        = flag.Bool("help", false, "Show help message")
 	)
 	flag.Parse()
 
 	// ヘルプメッセージ
-	if *help || (!*migrate && !*seed && !*drop && !*reset && !*testData && !*cleanupTest) {
+	if *help || (!*migrate && !*seed && !*drop && !*reset && !*testData && !*cleanupTest && !*rotateKey && *generateKey == "") {
 		showHelp()
 		return
 	}
 
+	// マスターキーの生成（データベース接続は不要）
+	if *generateKey != "" {
+		if err := kms.GenerateKeyFile(*generateKey); err != nil {
+			log.Fatal("❌ Failed to generate master key:", err)
+		}
+		log.Printf("✅ Master key generated at %s", *generateKey)
+		return
+	}
+
 	// 環境変数を読み込み
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found, using environment variables")
@@ -89,9 +102,43 @@ func main() {
 			log.Fatal("❌ Failed to cleanup test data:", err)
 		}
 		log.Println("✅ Test data cleaned up successfully!")
+
+	case *rotateKey:
+		log.Println("🔐 Re-encrypting CSP account secrets...")
+		count, err := rotateMasterKey(*newKeyFile)
+		if err != nil {
+			log.Fatal("❌ Failed to re-encrypt secrets:", err)
+		}
+		log.Printf("✅ Re-encrypted %d CSP accounts successfully!", count)
+		if *newKeyFile != "" {
+			log.Printf("👉 Set KMS_KEY_FILE=%s before restarting the API server", *newKeyFile)
+		}
 	}
 }
 
+// rotateMasterKey は全CSPアカウントの暗号化カラムを新しいマスターキーで暗号化し直す
+// newKeyFileが空の場合は現在のマスターキーで暗号化し直す（平文や旧キーで暗号化された値の移行）
+func rotateMasterKey(newKeyFile string) (int, error) {
+	primary, previous, err := kms.KeyFilesFromEnv()
+	if err != nil {
+		return 0, err
+	}
+	current, err := kms.NewLocalKeyManager(primary, previous...)
+	if err != nil {
+		return 0, err
+	}
+
+	target := current
+	if newKeyFile != "" {
+		// 移行中に実行中のサーバーが書き込んだ値も復号できるよう、現在の鍵も保持する
+		if target, err = kms.NewLocalKeyManager(newKeyFile, append([]string{primary}, previous...)...); err != nil {
+			return 0, err
+		}
+	}
+
+	return database.ReencryptCSPAccountSecrets(kms.NewEnvelope(target))
+}
+
 func showHelp() {
 	fmt.Println("🗃️  Database Migration Tool")
 	fmt.Println("Usage: go run cmd/migrate/main.go [options]")
@@ -103,6 +150,8 @@ func showHelp() {
 	fmt.Println("  -reset        Reset database (drop + migrate + seed)")
 	fmt.Println("  -test-data    Create pagination test data (50+ users)")
 	fmt.Println("  -cleanup-test Cleanup test data")
+	fmt.Println("  -rotate-key   Re-encrypt all CSP account secrets (with -new-key-file <path> to rotate the master key)")
+	fmt.Println("  -generate-key <path> Generate a new master key file")
 	fmt.Println("  -help         Show this help message")
 	fmt.Println()
 	fmt.Println("Examples:")
@@ -111,10 +160,14 @@ func showHelp() {
 	fmt.Println("  go run cmd/migrate/main.go -reset")
 	fmt.Println("  go run cmd/migrate/main.go -test-data")
 	fmt.Println("  go run cmd/migrate/main.go -cleanup-test")
+	fmt.Println("  go run cmd/migrate/main.go -generate-key .keys/master-2.key")
+	fmt.Println("  go run cmd/migrate/main.go -rotate-key -new-key-file .keys/master-2.key")
 	fmt.Println("  docker exec -it api-1 go run cmd/migrate/main.go -migrate")
 	fmt.Println()
 	fmt.Println("Environment Variables:")
 	fmt.Printf("  DATABASE_URL (current: %s)\n", getDBURL())
+	fmt.Printf("  KMS_KEY_FILE (current: %s)\n", getKeyFile())
+	fmt.Println("  KMS_PREVIOUS_KEY_FILES (comma separated, used only for decryption)")
 }
 
 func getDBURL() string {
@@ -123,4 +176,12 @@ func getDBURL() string {
 		return "host=localhost user=postgres password=password dbname=go_nextjs_db port=5432 sslmode=disable TimeZone=Asia/Tokyo"
 	}
 	return dbURL
-}
\ No newline at end of file
+}
+
+func getKeyFile() string {
+	keyFile := os.Getenv("KMS_KEY_FILE")
+	if keyFile == "" {
+		return "not set, " + kms.DefaultKeyFile + " is used only with KMS_DEV_KEY=true"
+	}
+	return keyFile
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"go-nextjs-api/internal/model"
+)
+
+func main() {
+	// フラグの定義
+	var (
+		file        = flag.String("file", "", "Path to the platform state YAML file")
+		prune       = flag.Bool("prune", false, "Delete resources that are not declared in the file")
+		apply       = flag.Bool("apply", false, "Apply the plan after confirmation")
+		autoApprove = flag.Bool("auto-approve", false, "Skip the confirmation prompt when applying")
+		help        = flag.Bool("help", false, "Show help message")
+	)
+	flag.Parse()
+
+	if *help || *file == "" {
+		showHelp()
+		return
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		log.Fatal("❌ Failed to read state file:", err)
+	}
+
+	client := &stateClient{
+		baseURL: strings.TrimRight(getAPIURL(), "/"),
+		token:   os.Getenv("API_TOKEN"),
+		http:    &http.Client{Timeout: 2 * time.Minute},
+	}
+
+	plan, err := client.request("/api/admin/state/plan", data, *prune, "")
+	if err != nil {
+		log.Fatal("❌ Failed to plan:", err)
+	}
+	printPlan(plan)
+
+	if !plan.HasChanges() || !*apply {
+		return
+	}
+
+	if !*autoApprove {
+		fmt.Print("Do you want to apply these changes? (y/N): ")
+		var response string
+		fmt.Scanln(&response)
+		if response != "y" && response != "Y" && response != "yes" {
+			log.Println("❌ Operation cancelled")
+			return
+		}
+	}
+
+	// 確認したプランと同じ内容のときのみ適用される
+	applied, err := client.request("/api/admin/state/apply", data, *prune, plan.Fingerprint)
+	if err != nil {
+		log.Fatal("❌ Failed to apply:", err)
+	}
+	log.Printf("✅ Applied: %d to create, %d to update, %d to delete",
+		applied.Summary.Create, applied.Summary.Update, applied.Summary.Delete)
+}
+
+// stateClient は状態同期APIのクライアント
+type stateClient struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+func (c *stateClient) request(path string, data []byte, prune bool, fingerprint string) (*model.StatePlan, error) {
+	query := url.Values{}
+	if prune {
+		query.Set("prune", "true")
+	}
+	if fingerprint != "" {
+		query.Set("expected_fingerprint", fingerprint)
+	}
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/yaml")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error    string   `json:"error"`
+			Details  string   `json:"details"`
+			Problems []string `json:"problems"`
+		}
+		if json.Unmarshal(body, &apiErr) != nil || apiErr.Error == "" {
+			return nil, fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+		message := apiErr.Error
+		if apiErr.Details != "" {
+			message += ": " + apiErr.Details
+		}
+		for _, problem := range apiErr.Problems {
+			message += "\n  - " + problem
+		}
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, message)
+	}
+
+	var plan model.StatePlan
+	if err := json.Unmarshal(body, &plan); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// printPlan は変更内容を「+ 作成 / ~ 更新 / - 削除」の形式で表示
+func printPlan(plan *model.StatePlan) {
+	if !plan.HasChanges() {
+		fmt.Println("No changes. The platform state matches the file.")
+		return
+	}
+
+	markers := map[model.StateChangeAction]string{
+		model.StateChangeCreate: "+",
+		model.StateChangeUpdate: "~",
+		model.StateChangeDelete: "-",
+	}
+	for _, change := range plan.Changes {
+		fmt.Printf("  %s %s %s\n", markers[change.Action], change.Kind, change.Key)
+		for _, field := range change.Changes {
+			if change.Action == model.StateChangeCreate {
+				fmt.Printf("      %s: %q\n", field.Field, field.To)
+			} else {
+				fmt.Printf("      %s: %q => %q\n", field.Field, field.From, field.To)
+			}
+		}
+	}
+	fmt.Println()
+	fmt.Printf("Plan: %d to create, %d to update, %d to delete.\n", plan.Summary.Create, plan.Summary.Update, plan.Summary.Delete)
+	if plan.Prune {
+		fmt.Println("Prune is enabled: resources not declared in the file will be deleted.")
+	}
+}
+
+func showHelp() {
+	fmt.Println("🗂️  Platform State Sync Tool")
+	fmt.Println("Usage: go run cmd/statesync/main.go -file state.yaml [options]")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  -file          Path to the platform state YAML file (required)")
+	fmt.Println("  -prune         Delete resources that are not declared in the file")
+	fmt.Println("  -apply         Apply the plan after confirmation (default: plan only)")
+	fmt.Println("  -auto-approve  Skip the confirmation prompt when applying")
+	fmt.Println("  -help          Show this help message")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  go run cmd/statesync/main.go -file state.yaml")
+	fmt.Println("  go run cmd/statesync/main.go -file state.yaml -apply")
+	fmt.Println("  go run cmd/statesync/main.go -file state.yaml -prune -apply -auto-approve")
+	fmt.Println()
+	fmt.Println("Environment Variables:")
+	fmt.Printf("  API_URL   (current: %s)\n", getAPIURL())
+	fmt.Println("  API_TOKEN Bearer token of an admin user")
+}
+
+func getAPIURL() string {
+	apiURL := os.Getenv("API_URL")
+	if apiURL == "" {
+		return "http://localhost:8080"
+	}
+	return apiURL
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+)
+
+type provisioningClient struct {
+	baseURL       string
+	internalToken string
+	httpClient    *http.Client
+}
+
+// NewProvisioningClient はCSPプロビジョニングサービスのクライアントを作成
+func NewProvisioningClient() interfaces.ProvisioningClient {
+	baseURL := os.Getenv("CSP_PROVISIONING_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8081"
+	}
+
+	return &provisioningClient{
+		baseURL:       strings.TrimRight(baseURL, "/"),
+		internalToken: os.Getenv("INTERNAL_API_TOKEN"),
+		httpClient:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// do は内部APIの共有シークレットを付けてリクエストを送信
+func (c *provisioningClient) do(method, url string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("X-Internal-Token", c.internalToken)
+	return c.httpClient.Do(req)
+}
+
+// CountPendingCSPRequests はプロジェクトの承認待ちCSP申請数を取得（スポンサー承認待ちを含む）
+func (c *provisioningClient) CountPendingCSPRequests(projectID uint) (int, error) {
+	url := fmt.Sprintf("%s/api/internal/projects/%d/csp-requests/summary", c.baseURL, projectID)
+
+	resp, err := c.do(http.MethodGet, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reach CSP provisioning service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("CSP provisioning service returned status %d", resp.StatusCode)
+	}
+
+	var summary struct {
+		Counts map[string]int `json:"counts"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
+		return 0, fmt.Errorf("failed to decode CSP request summary: %w", err)
+	}
+
+	// スポンサーの承認待ち（ベンダー提出の申請）も未処理として数える
+	return summary.Counts["pending"] + summary.Counts["awaiting_sponsor"], nil
+}
+
+// CreateCSPRequest は申請者を指定してCSPアカウント申請を作成
+func (c *provisioningClient) CreateCSPRequest(projectID uint, requestedBy string, request *model.TemplateCSPRequest) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"project_id":   projectID,
+		"requested_by": requestedBy,
+		"provider":     request.Provider,
+		"account_name": request.AccountName,
+		"reason":       request.Reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/api/internal/csp-requests", c.baseURL)
+	resp, err := c.do(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to reach CSP provisioning service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		var errorBody struct {
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&errorBody); err == nil && errorBody.Error != "" {
+			return fmt.Errorf("CSP provisioning service returned status %d: %s", resp.StatusCode, errorBody.Error)
+		}
+		return fmt.Errorf("CSP provisioning service returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// ListPendingCSPRequests はプロジェクトの未処理（スポンサー承認待ち・レビュー待ち）のCSP申請一覧を取得
+func (c *provisioningClient) ListPendingCSPRequests(projectID uint) ([]model.PendingCSPRequest, error) {
+	url := fmt.Sprintf("%s/api/internal/projects/%d/csp-requests/pending", c.baseURL, projectID)
+
+	resp, err := c.do(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach CSP provisioning service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("CSP provisioning service returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data []model.PendingCSPRequest `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode pending CSP requests: %w", err)
+	}
+
+	return body.Data, nil
+}
+
+// ListCSPRequestReferences はプロジェクト（projectID）またはユーザー（email）を参照するCSP申請一覧を取得（削除の影響確認用）
+func (c *provisioningClient) ListCSPRequestReferences(projectID uint, email string) ([]model.CSPRequestReference, error) {
+	query := neturl.Values{}
+	if projectID != 0 {
+		query.Set("project_id", strconv.FormatUint(uint64(projectID), 10))
+	} else {
+		query.Set("email", email)
+	}
+	url := fmt.Sprintf("%s/api/internal/csp-requests/references?%s", c.baseURL, query.Encode())
+
+	resp, err := c.do(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach CSP provisioning service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("CSP provisioning service returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data []model.CSPRequestReference `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode CSP request references: %w", err)
+	}
+
+	return body.Data, nil
+}
@@ -45,4 +45,7 @@ func InitDB() {
 	}
 
 	log.Println("Database connected successfully")
+
+	// 暗号化カラムの読み書きに使う鍵を設定
+	initEncryption()
 }
\ No newline at end of file
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"fmt"
+	"log"
+
+	"go-nextjs-api/internal/kms"
+
+	"gorm.io/gorm"
+)
+
+// initEncryption は暗号化カラム（CSPアカウントのアクセスキー・シークレットキー）の暗号化を設定
+func initEncryption() {
+	keyManager, err := kms.NewKeyManager()
+	if err != nil {
+		log.Fatal("Failed to initialize KMS:", err)
+	}
+	kms.UseEnvelope(kms.NewEnvelope(keyManager))
+	log.Printf("Column encryption enabled (master key: %s)", keyManager.PrimaryKeyID())
+}
+
+// cspAccountSecret は再暗号化のためにCSPアカウントの暗号化カラムをそのまま読み書きする構造体
+type cspAccountSecret struct {
+	ID        uint
+	AccessKey string
+	SecretKey string
+}
+
+// ReencryptCSPAccountSecrets は全CSPアカウント（削除済みを含む）の暗号化カラムのうち、
+// envelopeのプライマリ以外の鍵で暗号化された値と、暗号化導入前の平文・行に結び付けていない旧形式の値をプライマリの鍵で暗号化し直す
+// 1件でも失敗した場合は全体をロールバックする
+func ReencryptCSPAccountSecrets(envelope *kms.Envelope) (int, error) {
+	if DB == nil {
+		log.Fatal("Database connection is not initialized. Call InitDB() first.")
+	}
+
+	reencrypted := 0
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		var rows []cspAccountSecret
+		result := tx.Table("csp_accounts").Select("id, access_key, secret_key").
+			FindInBatches(&rows, 100, func(batch *gorm.DB, _ int) error {
+				for _, row := range rows {
+					updates := map[string]interface{}{}
+					for column, value := range map[string]string{"access_key": row.AccessKey, "secret_key": row.SecretKey} {
+						if value == "" || !envelope.NeedsReencryption(value) {
+							continue
+						}
+						aad := kms.ColumnAAD("csp_accounts", column, row.ID)
+						plaintext, err := envelope.Decrypt(value, aad)
+						if err != nil {
+							return fmt.Errorf("csp account %d: %s: %w", row.ID, column, err)
+						}
+						if updates[column], err = envelope.Encrypt(plaintext, aad); err != nil {
+							return fmt.Errorf("csp account %d: %s: %w", row.ID, column, err)
+						}
+					}
+
+					if len(updates) == 0 {
+						continue
+					}
+					if err := tx.Table("csp_accounts").Where("id = ?", row.ID).UpdateColumns(updates).Error; err != nil {
+						return err
+					}
+					reencrypted++
+				}
+				return nil
+			})
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return reencrypted, nil
+}
@@ -21,15 +21,30 @@ func RunMigrations() error {
 		&model.Project{},
 		&model.UserProjectRole{},
 
+		&model.ProjectEnvironment{},       // プロジェクトの環境テーブル（CSPアカウントの紐付けより前に作成）
+		&model.ProjectEnvironmentMember{}, // 環境のメンバーテーブル
 		&model.CSPAccount{},       // CSPアカウントテーブル
 		&model.ProjectCSPAccount{}, // プロジェクトCSPアカウント関連テーブル
 		&model.CSPAccountMember{}, // CSPアカウントメンバーテーブル
+		&model.CSPMemberRevocation{},          // プロバイダー側のロールを外す必要があるメールアドレスのテーブル
+		&model.CSPAccountLifecycleEvent{},     // CSPアカウントのステータス遷移の履歴テーブル
+		&model.CSPAccountCredentialRotation{}, // CSPアカウントの認証情報のローテーション履歴テーブル
+		&model.CSPCredentialIssuance{},        // メンバーへの一時的な認証情報の払い出し記録テーブル
+		&model.CSPSecretReveal{},              // 長期の認証情報の開示申請テーブル
+		&model.SecretRevealAuditEntry{},       // 開示申請の監査記録テーブル（ハッシュチェーン）
+		&model.SecretRevealAuditHead{},        // 開示申請の監査記録の先頭テーブル
+		&model.CSPReconciliationRun{},         // クラウドの棚卸しの実行履歴テーブル
+		&model.CSPDriftItem{},                 // 棚卸しで検出したドリフトテーブル
 		&model.ProjectVendorRelation{}, // ベンダープロジェクトと他プロジェクトの紐付けテーブル
+		&model.ProjectInvitation{},     // プロジェクト招待テーブル
+		&model.CustomAttributeDefinition{}, // 組織ごとのカスタム属性定義テーブル
+		&model.ProjectTemplate{},           // プロジェクトテンプレートテーブル
+		&model.VendorStaffAssignment{},     // ベンダー担当者への権限割り当てテーブル
 	); err != nil {
 		log.Printf("Failed to create new tables: %v", err)
 		return err
 	}
-	log.Println("✅ New tables (organizations, projects, user_project_roles, csp_accounts, project_csp_accounts, csp_account_members, project_vendor_relations) created successfully")
+	log.Println("✅ New tables (organizations, projects, user_project_roles, csp_accounts, project_csp_accounts, csp_account_members, csp_account_lifecycle_events, project_vendor_relations, project_invitations, custom_attribute_definitions, project_templates, vendor_staff_assignments, project_environments, project_environment_members, csp_account_credential_rotations, csp_credential_issuances, csp_secret_reveals, csp_secret_reveal_audit_entries, csp_secret_reveal_audit_heads, csp_reconciliation_runs, csp_drift_items, csp_member_revocations) created successfully")
 
 	// 2. Userテーブルからroleカラムを削除する前に、既存データを移行
 	fixturesManager := fixtures.NewFixtures(DB)
@@ -50,10 +65,37 @@ func RunMigrations() error {
 		// エラーでも続行（カラムが既に存在しない場合もある）
 	}
 
+	// 5. プロジェクト検索用のインデックスを作成
+	if err := createProjectSearchIndexes(); err != nil {
+		log.Printf("Warning: Could not create project search indexes: %v", err)
+		// エラーでも続行（検索は動作するがインデックスは使われない）
+	}
+
+	// 6. ライフサイクル導入前の自由形式のCSPアカウントステータスを正規化
+	if err := normalizeCSPAccountStatuses(); err != nil {
+		log.Printf("Failed to normalize CSP account statuses: %v", err)
+		return err
+	}
+
 	log.Println("✅ Database migration completed successfully")
 	return nil
 }
 
+// normalizeCSPAccountStatuses は定義されていないCSPアカウントステータス（inactiveなど）を一時停止に置き換える
+// プロバイダー側の実際の状態は状態の反映（sync-status）で確定する
+func normalizeCSPAccountStatuses() error {
+	result := DB.Model(&model.CSPAccount{}).
+		Where("status NOT IN ?", model.ValidCSPAccountStatuses).
+		Update("status", model.CSPAccountStatusSuspended)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("✅ Normalized status of %d CSP account(s) to %s", result.RowsAffected, model.CSPAccountStatusSuspended)
+	}
+	return nil
+}
+
 // dropUserRoleColumn はusersテーブルからroleカラムを削除
 func dropUserRoleColumn() error {
 	if DB.Migrator().HasColumn(&model.User{}, "role") {
@@ -67,6 +109,33 @@ func dropUserRoleColumn() error {
 	return nil
 }
 
+// projectSearchIndexes はプロジェクト検索APIで使うインデックス
+// 部分一致検索はpg_trgmのGINインデックス、タグの包含検索はjsonbのGINインデックスを利用する
+var projectSearchIndexes = []string{
+	`CREATE INDEX IF NOT EXISTS idx_projects_name_trgm ON projects USING gin (name gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_projects_description_trgm ON projects USING gin (description gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_projects_tags ON projects USING gin (tags jsonb_path_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_projects_status ON projects (status) WHERE deleted_at IS NULL`,
+	`CREATE INDEX IF NOT EXISTS idx_projects_project_type ON projects (project_type) WHERE deleted_at IS NULL`,
+	`CREATE INDEX IF NOT EXISTS idx_projects_created_at ON projects (created_at) WHERE deleted_at IS NULL`,
+	`CREATE INDEX IF NOT EXISTS idx_user_project_roles_user_project ON user_project_roles (user_id, project_id) WHERE deleted_at IS NULL`,
+	`CREATE INDEX IF NOT EXISTS idx_csp_accounts_provider ON csp_accounts (provider) WHERE deleted_at IS NULL`,
+}
+
+// createProjectSearchIndexes はpg_trgm拡張とプロジェクト検索用インデックスを作成
+func createProjectSearchIndexes() error {
+	if err := DB.Exec(`CREATE EXTENSION IF NOT EXISTS pg_trgm`).Error; err != nil {
+		return err
+	}
+	for _, statement := range projectSearchIndexes {
+		if err := DB.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	log.Println("✅ Project search indexes created successfully")
+	return nil
+}
+
 // SeedData は初期データを投入
 func SeedData() error {
 	if DB == nil {
@@ -83,10 +83,16 @@ func (f *Fixtures) DropAllTables() error {
 		// CSP関連テーブル（依存関係の順番で削除、但しCSP Requestは除外）
 		&model.CSPAccountMember{}, // CSPアカウントメンバー
 		&model.ProjectCSPAccount{}, // プロジェクトCSPアカウント関連
+		&model.ProjectEnvironmentMember{}, // 環境のメンバー
+		&model.ProjectEnvironment{},       // プロジェクトの環境
 		&model.CSPAccount{},        // CSPアカウント
 
 		
 		// 基本テーブル
+		&model.VendorStaffAssignment{},     // ベンダー担当者への権限割り当て
+		&model.ProjectInvitation{}, // プロジェクト招待
+		&model.CustomAttributeDefinition{}, // カスタム属性定義
+		&model.ProjectTemplate{},           // プロジェクトテンプレート
 		&model.UserProjectRole{}, // ユーザープロジェクトロール
 		&model.Project{},         // プロジェクトテーブル
 		&model.User{},            // ユーザーテーブル
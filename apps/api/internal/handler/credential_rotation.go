@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CredentialRotationHandler struct {
+	credentialRotationService interfaces.CredentialRotationService
+}
+
+func NewCredentialRotationHandler(credentialRotationService interfaces.CredentialRotationService) *CredentialRotationHandler {
+	return &CredentialRotationHandler{credentialRotationService: credentialRotationService}
+}
+
+// GetRotationHistory はCSPアカウントの認証情報の発行・ローテーション履歴を取得
+func (h *CredentialRotationHandler) GetRotationHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	rotations, err := h.credentialRotationService.GetRotationHistory(uint(id))
+	if err != nil {
+		respondCredentialRotationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rotations})
+}
+
+// UpdateRotationPolicy はCSPアカウントの認証情報の最大有効日数を設定
+func (h *CredentialRotationHandler) UpdateRotationPolicy(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req model.CredentialRotationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	account, err := h.credentialRotationService.UpdateRotationPolicy(uint(id), adminID.(uint), &req)
+	if err != nil {
+		respondCredentialRotationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": account})
+}
+
+// RotateCredentials はCSPアカウントの認証情報を手動でローテーション（旧鍵は猶予期間の間だけ有効）
+func (h *CredentialRotationHandler) RotateCredentials(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req model.CredentialRotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	rotation, err := h.credentialRotationService.RotateCredentials(uint(id), adminID.(uint), &req)
+	if err != nil {
+		respondCredentialRotationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rotation})
+}
+
+// respondCredentialRotationError はローテーション関連のエラーをHTTPステータスに変換して返す
+func respondCredentialRotationError(c *gin.Context, err error) {
+	switch err {
+	case model.ErrCSPAccountNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case model.ErrInvalidCredentialRotationPolicy, model.ErrCredentialRotationNotSupported:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case model.ErrCSPAccountNotActive:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CredentialVendingHandler struct {
+	credentialVendingService interfaces.CredentialVendingService
+}
+
+func NewCredentialVendingHandler(credentialVendingService interfaces.CredentialVendingService) *CredentialVendingHandler {
+	return &CredentialVendingHandler{credentialVendingService: credentialVendingService}
+}
+
+// IssueTemporaryCredentials はCSPアカウントのメンバーに短期の認証情報を払い出す
+func (h *CredentialVendingHandler) IssueTemporaryCredentials(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req model.TemporaryCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	credentials, err := h.credentialVendingService.IssueTemporaryCredentials(userID.(uint), uint(id), &req, c.ClientIP())
+	if err != nil {
+		switch err {
+		case model.ErrInvalidCredentialDuration, model.ErrTemporaryCredentialsNotSupported:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case model.ErrNotCSPAccountMember, model.ErrCSPAccountNotInProject:
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case model.ErrProjectArchived, model.ErrCSPAccountNotActive:
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	// 認証情報はキャッシュさせない
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{"data": credentials})
+}
+
+// GetIssuances はCSPアカウントの一時的な認証情報の払い出し記録を取得
+func (h *CredentialVendingHandler) GetIssuances(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	issuances, err := h.credentialVendingService.GetIssuances(uint(id))
+	if err != nil {
+		if err == model.ErrCSPAccountNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": issuances})
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -87,6 +88,11 @@ func (h *CSPHandler) GetCSPAccount(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": account})
 }
 
+// GetCSPProviders はプロバイダーレジストリに登録されているプロバイダーの一覧を取得
+func (h *CSPHandler) GetCSPProviders(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": model.CSPProviders()})
+}
+
 // CreateCSPAccount はCSPアカウントを作成
 func (h *CSPHandler) CreateCSPAccount(c *gin.Context) {
 	var req model.CSPAccountCreateRequest
@@ -104,6 +110,11 @@ func (h *CSPHandler) CreateCSPAccount(c *gin.Context) {
 
 	account, err := h.cspService.CreateCSPAccount(adminID.(uint), &req)
 	if err != nil {
+		if err == model.ErrInvalidCSPProvider || err == model.ErrInvalidCSPAccountID || err == model.ErrRegionNotOffered ||
+			errors.Is(err, model.ErrInvalidCSPAccountAttribute) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -135,6 +146,14 @@ func (h *CSPHandler) UpdateCSPAccount(c *gin.Context) {
 
 	updatedAccount, err := h.cspService.UpdateCSPAccount(uint(id), adminID.(uint), &account)
 	if err != nil {
+		if err == model.ErrInvalidCSPProvider || errors.Is(err, model.ErrInvalidCSPAccountAttribute) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err == model.ErrCSPAccountClosed {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -160,6 +179,17 @@ func (h *CSPHandler) DeleteCSPAccount(c *gin.Context) {
 
 	err = h.cspService.DeleteCSPAccount(uint(id), adminID.(uint))
 	if err != nil {
+		if respondDeletionBlocked(c, err) {
+			return
+		}
+		if err == model.ErrCSPAccountNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == model.ErrCSPAccountMustBeClosed || err == model.ErrCSPAccountRetained {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -167,6 +197,28 @@ func (h *CSPHandler) DeleteCSPAccount(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "CSP account deleted successfully"})
 }
 
+// SyncCSPAccountStatus はプロバイダー側のアカウントの状態をCSPアカウントに反映
+func (h *CSPHandler) SyncCSPAccountStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	account, err := h.cspService.SyncCSPAccountStatus(uint(id))
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrCSPAccountNotFound), errors.Is(err, model.ErrProviderAccountNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": account})
+}
+
 // ProjectCSPAccount Handlers
 
 // GetProjectCSPAccounts はプロジェクトCSPアカウント関連一覧を取得
@@ -227,6 +279,17 @@ func (h *CSPHandler) GetProjectCSPAccounts(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": relations})
 }
 
+// GetProjectCSPAccountReport はプロジェクトのタグ・カスタム属性で絞り込んだCSPアカウント関連一覧を取得（管理者用）
+func (h *CSPHandler) GetProjectCSPAccountReport(c *gin.Context) {
+	relations, err := h.cspService.GetProjectCSPAccountReport(parseProjectMetadataFilter(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": relations})
+}
+
 // CreateProjectCSPAccount はプロジェクトCSPアカウント関連を作成
 func (h *CSPHandler) CreateProjectCSPAccount(c *gin.Context) {
 	type CreateProjectCSPAccountRequest struct {
@@ -249,6 +312,10 @@ func (h *CSPHandler) CreateProjectCSPAccount(c *gin.Context) {
 
 	relation, err := h.cspService.CreateProjectCSPAccount(adminID.(uint), req.ProjectID, req.CSPAccountID)
 	if err != nil {
+		if err == model.ErrProjectArchived || err == model.ErrCSPAccountClosed {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -359,6 +426,18 @@ func (h *CSPHandler) CreateCSPAccountMember(c *gin.Context) {
 
 	member, err := h.cspService.CreateCSPAccountMember(creatorID.(uint), &req)
 	if err != nil {
+		if err == model.ErrVendorPermissionNotGranted {
+			c.JSON(http.StatusForbidden, gin.H{"error": "User is neither a project member nor a vendor staff delegated to this CSP account"})
+			return
+		}
+		if err == model.ErrNotEnvironmentMember {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err == model.ErrProjectArchived || err == model.ErrCSPAccountClosed {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -388,7 +467,20 @@ func (h *CSPHandler) UpdateCSPAccountMember(c *gin.Context) {
 	}
 
 	member, err := h.cspService.UpdateCSPAccountMember(uint(id), userID.(uint), &req)
+	if errors.Is(err, model.ErrInsufficientPermissions) || errors.Is(err, model.ErrCannotChangeOwnCSPAccountMember) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, model.ErrCSPMemberRevocationPending) {
+		// 変更は保存済み。変更前のメールアドレスのロールは定期実行で外す
+		c.JSON(http.StatusAccepted, gin.H{"data": member, "warning": err.Error()})
+		return
+	}
 	if err != nil {
+		if err == model.ErrProjectArchived {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -412,7 +504,20 @@ func (h *CSPHandler) DeleteCSPAccountMember(c *gin.Context) {
 	}
 
 	err = h.cspService.DeleteCSPAccountMember(uint(id), userID.(uint))
+	if errors.Is(err, model.ErrInsufficientPermissions) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, model.ErrCSPMemberRevocationPending) {
+		// 削除は完了済み。プロバイダー側のロールは定期実行で外す
+		c.JSON(http.StatusAccepted, gin.H{"message": "CSP account member deleted", "warning": err.Error()})
+		return
+	}
 	if err != nil {
+		if err == model.ErrProjectArchived {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CSPLifecycleHandler struct {
+	cspLifecycleService interfaces.CSPLifecycleService
+}
+
+func NewCSPLifecycleHandler(cspLifecycleService interfaces.CSPLifecycleService) *CSPLifecycleHandler {
+	return &CSPLifecycleHandler{cspLifecycleService: cspLifecycleService}
+}
+
+// SuspendCSPAccount はCSPアカウントを一時停止する
+func (h *CSPLifecycleHandler) SuspendCSPAccount(c *gin.Context) {
+	h.changeStatus(c, h.cspLifecycleService.SuspendCSPAccount)
+}
+
+// ResumeCSPAccount は一時停止中のCSPアカウントを再開する
+func (h *CSPLifecycleHandler) ResumeCSPAccount(c *gin.Context) {
+	h.changeStatus(c, h.cspLifecycleService.ResumeCSPAccount)
+}
+
+func (h *CSPLifecycleHandler) changeStatus(c *gin.Context, change func(cspAccountID, adminID uint, req *model.CSPAccountStatusChangeRequest) (*model.CSPAccount, error)) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req model.CSPAccountStatusChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := change(uint(id), adminID.(uint), &req)
+	if err != nil {
+		respondCSPLifecycleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": account})
+}
+
+// GetClosureCheck はCSPアカウントを閉鎖できるかどうか（残っているメンバー・当月の費用）を確認する
+func (h *CSPLifecycleHandler) GetClosureCheck(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	check, err := h.cspLifecycleService.GetClosureCheck(uint(id))
+	if err != nil {
+		respondCSPLifecycleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": check})
+}
+
+// CloseCSPAccount はCSPアカウントを閉鎖する
+func (h *CSPLifecycleHandler) CloseCSPAccount(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req model.CSPAccountClosureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := h.cspLifecycleService.CloseCSPAccount(uint(id), adminID.(uint), &req)
+	if err != nil {
+		respondCSPLifecycleError(c, err)
+		return
+	}
+
+	// プロバイダーでの閉鎖が非同期の場合は閉鎖処理中（closing）のまま返る
+	c.JSON(http.StatusAccepted, gin.H{"data": account})
+}
+
+// GetLifecycleEvents はCSPアカウントのステータス遷移の履歴を取得
+func (h *CSPLifecycleHandler) GetLifecycleEvents(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	events, err := h.cspLifecycleService.GetLifecycleEvents(uint(id))
+	if err != nil {
+		respondCSPLifecycleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": events})
+}
+
+func respondCSPLifecycleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, model.ErrCSPAccountNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, model.ErrInvalidCSPAccountStatusTransition),
+		errors.Is(err, model.ErrCSPAccountHasActiveMembers),
+		errors.Is(err, model.ErrCSPAccountCostNotAcknowledged):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, model.ErrProviderDriverNotFound),
+		errors.Is(err, model.ErrProvisioningNotSupported):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	}
+}
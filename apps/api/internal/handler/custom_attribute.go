@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CustomAttributeHandler struct {
+	customAttributeService interfaces.CustomAttributeService
+}
+
+func NewCustomAttributeHandler(customAttributeService interfaces.CustomAttributeService) *CustomAttributeHandler {
+	return &CustomAttributeHandler{customAttributeService: customAttributeService}
+}
+
+// GetDefinitions は組織のカスタム属性定義一覧を取得
+func (h *CustomAttributeHandler) GetDefinitions(c *gin.Context) {
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID"})
+		return
+	}
+
+	definitions, err := h.customAttributeService.GetDefinitions(uint(organizationID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get custom attribute definitions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"definitions": definitions})
+}
+
+// CreateDefinition は組織にカスタム属性定義を追加（管理者用）
+func (h *CustomAttributeHandler) CreateDefinition(c *gin.Context) {
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID"})
+		return
+	}
+
+	var req model.CustomAttributeDefinitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	definition, err := h.customAttributeService.CreateDefinition(uint(organizationID), &req)
+	if err != nil {
+		respondCustomAttributeError(c, err, "Failed to create custom attribute definition")
+		return
+	}
+
+	c.JSON(http.StatusCreated, definition)
+}
+
+// UpdateDefinition はカスタム属性定義を更新（管理者用）
+func (h *CustomAttributeHandler) UpdateDefinition(c *gin.Context) {
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID"})
+		return
+	}
+
+	definitionID, err := strconv.ParseUint(c.Param("definitionId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid definition ID"})
+		return
+	}
+
+	var req model.CustomAttributeDefinitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	definition, err := h.customAttributeService.UpdateDefinition(uint(organizationID), uint(definitionID), &req)
+	if err != nil {
+		respondCustomAttributeError(c, err, "Failed to update custom attribute definition")
+		return
+	}
+
+	c.JSON(http.StatusOK, definition)
+}
+
+// DeleteDefinition はカスタム属性定義を削除（管理者用）
+func (h *CustomAttributeHandler) DeleteDefinition(c *gin.Context) {
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID"})
+		return
+	}
+
+	definitionID, err := strconv.ParseUint(c.Param("definitionId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid definition ID"})
+		return
+	}
+
+	if err := h.customAttributeService.DeleteDefinition(uint(organizationID), uint(definitionID)); err != nil {
+		respondCustomAttributeError(c, err, "Failed to delete custom attribute definition")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Custom attribute definition deleted successfully"})
+}
+
+// respondCustomAttributeError はカスタム属性定義関連のエラーをHTTPステータスに変換して返す
+func respondCustomAttributeError(c *gin.Context, err error, fallbackMessage string) {
+	switch {
+	case errors.Is(err, model.ErrCustomAttributeDefinitionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, model.ErrCustomAttributeDefinitionAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, model.ErrInvalidCustomAttributeDefinition):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallbackMessage})
+	}
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeletionHandler struct {
+	deletionService interfaces.DeletionService
+}
+
+func NewDeletionHandler(deletionService interfaces.DeletionService) *DeletionHandler {
+	return &DeletionHandler{deletionService: deletionService}
+}
+
+// GetProjectDeletionImpact はプロジェクトを削除した場合の影響を取得（オーナーのみ）
+func (h *DeletionHandler) GetProjectDeletionImpact(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	impact, err := h.deletionService.PreviewProjectDeletion(userID.(uint), uint(projectID))
+	if err != nil {
+		respondDeletionImpactError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, impact)
+}
+
+// GetUserDeletionImpact はユーザーを削除した場合の影響を取得（管理者用）
+func (h *DeletionHandler) GetUserDeletionImpact(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	impact, err := h.deletionService.PreviewUserDeletion(uint(id))
+	if err != nil {
+		respondDeletionImpactError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, impact)
+}
+
+// GetCSPAccountDeletionImpact はCSPアカウントを削除した場合の影響を取得（管理者用）
+func (h *DeletionHandler) GetCSPAccountDeletionImpact(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	impact, err := h.deletionService.PreviewCSPAccountDeletion(uint(id))
+	if err != nil {
+		respondDeletionImpactError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, impact)
+}
+
+func respondDeletionImpactError(c *gin.Context, err error) {
+	switch err {
+	case model.ErrInsufficientPermissions:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+	case model.ErrProjectNotFound, model.ErrUserNotFound, model.ErrCSPAccountNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get deletion impact"})
+	}
+}
+
+// respondDeletionBlocked は削除をブロックする依存レコードがある場合に409と影響の内容を返す
+func respondDeletionBlocked(c *gin.Context, err error) bool {
+	var blocked *model.DeletionBlockedError
+	if !errors.As(err, &blocked) {
+		return false
+	}
+	c.JSON(http.StatusConflict, gin.H{
+		"error":  err.Error(),
+		"impact": blocked.Impact,
+	})
+	return true
+}
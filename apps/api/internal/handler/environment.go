@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EnvironmentHandler struct {
+	environmentService interfaces.EnvironmentService
+}
+
+func NewEnvironmentHandler(environmentService interfaces.EnvironmentService) *EnvironmentHandler {
+	return &EnvironmentHandler{environmentService: environmentService}
+}
+
+// GetEnvironments はプロジェクトの環境一覧を取得
+func (h *EnvironmentHandler) GetEnvironments(c *gin.Context) {
+	userID, projectID, ok := environmentRequestContext(c)
+	if !ok {
+		return
+	}
+
+	environments, err := h.environmentService.GetEnvironments(userID, projectID)
+	if err != nil {
+		respondEnvironmentError(c, err, "Failed to get environments")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"environments": environments})
+}
+
+// GetEnvironment は環境の設定と所属するCSPアカウント・メンバーを取得
+func (h *EnvironmentHandler) GetEnvironment(c *gin.Context) {
+	userID, projectID, ok := environmentRequestContext(c)
+	if !ok {
+		return
+	}
+	environmentID, ok := parseEnvironmentID(c)
+	if !ok {
+		return
+	}
+
+	environment, err := h.environmentService.GetEnvironment(userID, projectID, environmentID)
+	if err != nil {
+		respondEnvironmentError(c, err, "Failed to get environment")
+		return
+	}
+
+	c.JSON(http.StatusOK, environment)
+}
+
+// CreateEnvironment はプロジェクトに環境を追加（管理権限が必要）
+func (h *EnvironmentHandler) CreateEnvironment(c *gin.Context) {
+	userID, projectID, ok := environmentRequestContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.ProjectEnvironmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	environment, err := h.environmentService.CreateEnvironment(userID, projectID, &req)
+	if err != nil {
+		respondEnvironmentError(c, err, "Failed to create environment")
+		return
+	}
+
+	c.JSON(http.StatusCreated, environment)
+}
+
+// UpdateEnvironment は環境の設定を更新（管理権限が必要）
+func (h *EnvironmentHandler) UpdateEnvironment(c *gin.Context) {
+	userID, projectID, ok := environmentRequestContext(c)
+	if !ok {
+		return
+	}
+	environmentID, ok := parseEnvironmentID(c)
+	if !ok {
+		return
+	}
+
+	var req model.ProjectEnvironmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	environment, err := h.environmentService.UpdateEnvironment(userID, projectID, environmentID, &req)
+	if err != nil {
+		respondEnvironmentError(c, err, "Failed to update environment")
+		return
+	}
+
+	c.JSON(http.StatusOK, environment)
+}
+
+// DeleteEnvironment は環境を削除（管理権限が必要）
+func (h *EnvironmentHandler) DeleteEnvironment(c *gin.Context) {
+	userID, projectID, ok := environmentRequestContext(c)
+	if !ok {
+		return
+	}
+	environmentID, ok := parseEnvironmentID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.environmentService.DeleteEnvironment(userID, projectID, environmentID); err != nil {
+		respondEnvironmentError(c, err, "Failed to delete environment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Environment deleted successfully"})
+}
+
+// AddEnvironmentMember はプロジェクトメンバーに環境へのアクセスを付与（管理権限が必要）
+func (h *EnvironmentHandler) AddEnvironmentMember(c *gin.Context) {
+	userID, projectID, ok := environmentRequestContext(c)
+	if !ok {
+		return
+	}
+	environmentID, ok := parseEnvironmentID(c)
+	if !ok {
+		return
+	}
+
+	var req model.ProjectEnvironmentMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	member, err := h.environmentService.AddEnvironmentMember(userID, projectID, environmentID, &req)
+	if err != nil {
+		respondEnvironmentError(c, err, "Failed to add environment member")
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+// RemoveEnvironmentMember は環境へのアクセスを取り消す（管理権限が必要）
+func (h *EnvironmentHandler) RemoveEnvironmentMember(c *gin.Context) {
+	userID, projectID, ok := environmentRequestContext(c)
+	if !ok {
+		return
+	}
+	environmentID, ok := parseEnvironmentID(c)
+	if !ok {
+		return
+	}
+
+	memberUserID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := h.environmentService.RemoveEnvironmentMember(userID, projectID, environmentID, uint(memberUserID)); err != nil {
+		respondEnvironmentError(c, err, "Failed to remove environment member")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Environment member removed successfully"})
+}
+
+// AssignCSPAccount はプロジェクトに紐付くCSPアカウントの所属環境を変更（管理権限が必要）
+func (h *EnvironmentHandler) AssignCSPAccount(c *gin.Context) {
+	userID, projectID, ok := environmentRequestContext(c)
+	if !ok {
+		return
+	}
+
+	cspAccountID, err := strconv.ParseUint(c.Param("cspAccountId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid CSP account ID"})
+		return
+	}
+
+	var req model.ProjectCSPAccountEnvironmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	link, err := h.environmentService.AssignCSPAccount(userID, projectID, uint(cspAccountID), &req)
+	if err != nil {
+		respondEnvironmentError(c, err, "Failed to assign CSP account to environment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": link})
+}
+
+// environmentRequestContext は認証ユーザーIDとパスのプロジェクトIDを取得（失敗時はレスポンス済み）
+func environmentRequestContext(c *gin.Context) (uint, uint, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return 0, 0, false
+	}
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return 0, 0, false
+	}
+
+	return userID.(uint), uint(projectID), true
+}
+
+// parseEnvironmentID はパスの環境IDを取得（失敗時はレスポンス済み）
+func parseEnvironmentID(c *gin.Context) (uint, bool) {
+	environmentID, err := strconv.ParseUint(c.Param("environmentId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid environment ID"})
+		return 0, false
+	}
+	return uint(environmentID), true
+}
+
+// respondEnvironmentError は環境関連のエラーをHTTPステータスに変換して返す
+func respondEnvironmentError(c *gin.Context, err error, fallbackMessage string) {
+	switch err {
+	case model.ErrInsufficientPermissions:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+	case model.ErrProjectNotFound, model.ErrEnvironmentNotFound, model.ErrEnvironmentMemberNotFound, model.ErrUserNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case model.ErrEnvironmentAlreadyExists, model.ErrEnvironmentMemberAlreadyExists, model.ErrEnvironmentInUse, model.ErrProjectArchived:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case model.ErrInvalidEnvironment, model.ErrInvalidRole, model.ErrRegionNotAllowed, model.ErrUserNotProjectMember, model.ErrCSPAccountNotInProject:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallbackMessage})
+	}
+}
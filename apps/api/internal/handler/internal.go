@@ -1,11 +1,10 @@
 package handler
 
 import (
-	"fmt"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
-	"time"
 
 	"go-nextjs-api/internal/interfaces"
 	"go-nextjs-api/internal/model"
@@ -14,14 +13,16 @@ import (
 )
 
 type InternalHandler struct {
-	projectService interfaces.ProjectService
-	cspService     interfaces.CSPService
+	projectService     interfaces.ProjectService
+	cspService         interfaces.CSPService
+	environmentService interfaces.EnvironmentService
 }
 
-func NewInternalHandler(projectService interfaces.ProjectService, cspService interfaces.CSPService) *InternalHandler {
+func NewInternalHandler(projectService interfaces.ProjectService, cspService interfaces.CSPService, environmentService interfaces.EnvironmentService) *InternalHandler {
 	return &InternalHandler{
-		projectService: projectService,
-		cspService:     cspService,
+		projectService:     projectService,
+		cspService:         cspService,
+		environmentService: environmentService,
 	}
 }
 
@@ -61,6 +62,56 @@ func (h *InternalHandler) CanManageProject(c *gin.Context) {
 		"project_id":   project.ID,
 		"project_name": project.Name,
 		"project_type": project.ProjectType,
+		"status":       project.Status,
+	})
+}
+
+// GetProjectAccess はメールアドレスで指定したユーザーのプロジェクト権限を取得（内部API用）
+// メンバーとしてのロールに加え、ベンダー紐付け経由の委任権限を返す
+func (h *InternalHandler) GetProjectAccess(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	email := c.Query("email")
+	if email == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "email is required"})
+		return
+	}
+
+	project, err := h.projectService.GetProjectByID(uint(projectID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	permission, err := h.projectService.CheckProjectPermissionByEmail(email, uint(projectID))
+	if err != nil {
+		if err == model.ErrUserNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	delegatedPermissions := model.VendorPermissions{}
+	if permission.Delegated != nil {
+		delegatedPermissions = permission.Delegated.Permissions
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id":            project.ID,
+		"project_type":          project.ProjectType,
+		"status":                project.Status,
+		"is_member":             permission.Role != "",
+		"role":                  permission.Role,
+		"can_view":              permission.CanView,
+		"can_manage":            permission.CanManage,
+		"delegated_permissions": delegatedPermissions,
+		"vendor_project_ids":    permission.VendorProjectIDs,
 	})
 }
 
@@ -85,9 +136,38 @@ func (h *InternalHandler) GetProjectType(c *gin.Context) {
 		"project_id":   project.ID,
 		"project_name": project.Name,
 		"project_type": project.ProjectType,
+		"status":       project.Status,
 	})
 }
 
+// GetEnvironmentSettings はプロジェクトの環境の設定を取得（内部API用）
+// CSPプロビジョニングサービスが申請の承認ルール・許可リージョンの確認に使う
+func (h *InternalHandler) GetEnvironmentSettings(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	environmentID, err := strconv.Atoi(c.Param("environmentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid environment ID"})
+		return
+	}
+
+	environment, err := h.environmentService.GetEnvironmentSettings(uint(projectID), uint(environmentID))
+	if err != nil {
+		if err == model.ErrEnvironmentNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, environment)
+}
+
 // AutoCreateCSPAccount はCSP申請承認時に自動でCSPアカウントを作成（内部API用）
 func (h *InternalHandler) AutoCreateCSPAccount(c *gin.Context) {
 	type AutoCreateRequest struct {
@@ -95,6 +175,9 @@ func (h *InternalHandler) AutoCreateCSPAccount(c *gin.Context) {
 		Provider     string `json:"provider" binding:"required"`
 		AccountName  string `json:"account_name" binding:"required"`
 		ProjectID    int    `json:"project_id" binding:"required"`
+		EnvironmentID *uint `json:"environment_id"` // 申請で指定された環境
+		Region       string `json:"region"`         // 申請で指定されたリージョン（省略時はプロバイダーのデフォルト）
+		Attributes   model.CSPAccountAttributes `json:"attributes"` // 申請で指定されたプロバイダー固有の属性
 	}
 
 	var req AutoCreateRequest
@@ -120,19 +203,21 @@ func (h *InternalHandler) AutoCreateCSPAccount(c *gin.Context) {
 		log.Printf("[INFO] Using default admin ID for email: %s", creatorIDStr)
 	}
 
-	// CSPアカウント作成リクエストを構築
-	createReq := &model.CSPAccountCreateRequest{
-		Provider:    model.CSPProvider(req.Provider),
-		AccountName: req.AccountName,
-		AccountID:   generateAccountID(req.Provider, req.AccountName), // 自動生成
-		AccessKey:   generateAccessKey(),                               // 自動生成
-		SecretKey:   generateSecretKey(),                               // 自動生成
-		Region:      getDefaultRegion(req.Provider),                    // デフォルト地域
-	}
-
-	// CSPアカウントを作成
-	cspAccount, err := h.cspService.CreateCSPAccount(uint(creatorID), createReq)
+	// プロバイダードライバーでアカウントを作成してCSPアカウントとして登録
+	cspAccount, err := h.cspService.ProvisionCSPAccount(uint(creatorID), &model.CSPAccountProvisionRequest{
+		Provider:     model.CSPProvider(req.Provider),
+		AccountName:  req.AccountName,
+		ProjectID:    uint(req.ProjectID),
+		Region:       req.Region,
+		CSPRequestID: req.CSPRequestID,
+		Attributes:   req.Attributes,
+	})
 	if err != nil {
+		if errors.Is(err, model.ErrInvalidCSPProvider) || errors.Is(err, model.ErrProvisioningNotSupported) ||
+			errors.Is(err, model.ErrRegionNotOffered) || errors.Is(err, model.ErrInvalidCSPAccountAttribute) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -150,38 +235,21 @@ func (h *InternalHandler) AutoCreateCSPAccount(c *gin.Context) {
 		return
 	}
 
+	// 申請で環境が指定されている場合は環境に所属させる（環境のメンバーがCSPアカウントのメンバーになる）
+	if req.EnvironmentID != nil {
+		if err := h.environmentService.AttachProvisionedCSPAccount(uint(creatorID), uint(req.ProjectID), cspAccount.ID, *req.EnvironmentID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":          "Failed to assign CSP account to environment",
+				"csp_account_id": cspAccount.ID,
+				"details":        err.Error(),
+			})
+			return
+		}
+	}
+
 	c.JSON(http.StatusCreated, gin.H{
 		"message":        "CSP account created and associated successfully",
 		"csp_account":    cspAccount,
 		"csp_request_id": req.CSPRequestID,
 	})
 }
-
-// Helper functions for auto-generation
-func generateAccountID(provider, accountName string) string {
-	// 実際の実装では、より適切なアカウントID生成ロジックを使用
-	return fmt.Sprintf("%s-%s-%d", provider, accountName, time.Now().Unix())
-}
-
-func generateAccessKey() string {
-	// 実際の実装では、より安全なキー生成ロジックを使用
-	return fmt.Sprintf("AK%d%d", time.Now().Unix(), time.Now().Nanosecond()%10000)
-}
-
-func generateSecretKey() string {
-	// 実際の実装では、より安全なキー生成ロジックを使用
-	return fmt.Sprintf("SK%d%d", time.Now().Unix(), time.Now().Nanosecond()%10000)
-}
-
-func getDefaultRegion(provider string) string {
-	switch provider {
-	case "aws":
-		return "ap-northeast-1"
-	case "gcp":
-		return "asia-northeast1"
-	case "azure":
-		return "japaneast"
-	default:
-		return "default"
-	}
-}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InvitationHandler struct {
+	invitationService interfaces.InvitationService
+}
+
+func NewInvitationHandler(invitationService interfaces.InvitationService) *InvitationHandler {
+	return &InvitationHandler{invitationService: invitationService}
+}
+
+// GetProjectInvitations はプロジェクトの招待一覧を取得
+func (h *InvitationHandler) GetProjectInvitations(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	// デフォルトは承諾待ちのみ。status=allで全件
+	status := model.InvitationStatus(c.DefaultQuery("status", string(model.InvitationStatusPending)))
+	if status == "all" {
+		status = ""
+	}
+
+	invitations, err := h.invitationService.GetProjectInvitations(userID.(uint), uint(projectID), status)
+	if err != nil {
+		respondInvitationError(c, err, "Failed to get invitations")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"invitations": invitations})
+}
+
+// CreateProjectInvitation はメールアドレス宛てにプロジェクト招待を送信
+func (h *InvitationHandler) CreateProjectInvitation(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var req model.ProjectInvitationCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	invitation, err := h.invitationService.CreateInvitation(userID.(uint), uint(projectID), &req)
+	if err != nil {
+		respondInvitationError(c, err, "Failed to create invitation")
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// ResendProjectInvitation は招待を再送（招待リンクを再発行）
+func (h *InvitationHandler) ResendProjectInvitation(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	invitationID, err := strconv.ParseUint(c.Param("invitationId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invitation ID"})
+		return
+	}
+
+	invitation, err := h.invitationService.ResendInvitation(userID.(uint), uint(projectID), uint(invitationID))
+	if err != nil {
+		respondInvitationError(c, err, "Failed to resend invitation")
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+// RevokeProjectInvitation は招待を取り消す
+func (h *InvitationHandler) RevokeProjectInvitation(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	invitationID, err := strconv.ParseUint(c.Param("invitationId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invitation ID"})
+		return
+	}
+
+	if err := h.invitationService.RevokeInvitation(userID.(uint), uint(projectID), uint(invitationID)); err != nil {
+		respondInvitationError(c, err, "Failed to revoke invitation")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation revoked successfully"})
+}
+
+// PreviewInvitation は招待トークンから招待内容を取得（認証不要）
+func (h *InvitationHandler) PreviewInvitation(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Token is required"})
+		return
+	}
+
+	preview, err := h.invitationService.PreviewInvitation(token)
+	if err != nil {
+		respondInvitationError(c, err, "Failed to get invitation")
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// AcceptInvitation はログイン中のユーザーとして招待を承諾
+func (h *InvitationHandler) AcceptInvitation(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req model.InvitationAcceptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	invitation, err := h.invitationService.AcceptInvitation(userID.(uint), req.Token)
+	if err != nil {
+		respondInvitationError(c, err, "Failed to accept invitation")
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+// RegisterWithInvitation は招待リンクからユーザー登録して招待を承諾（認証不要）
+func (h *InvitationHandler) RegisterWithInvitation(c *gin.Context) {
+	var req model.InvitationRegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	response, err := h.invitationService.RegisterWithInvitation(&req)
+	if err != nil {
+		respondInvitationError(c, err, "Failed to register with invitation")
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// respondInvitationError は招待関連のエラーをHTTPステータスに変換して返す
+func respondInvitationError(c *gin.Context, err error, fallbackMessage string) {
+	switch err {
+	case model.ErrProjectNotFound, model.ErrInvitationNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case model.ErrInsufficientPermissions, model.ErrInvitationEmailMismatch:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case model.ErrInvitationAlreadyExists, model.ErrUserProjectRoleAlreadyExists, model.ErrUserAlreadyExists, model.ErrInvitationNotPending, model.ErrProjectArchived:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case model.ErrInvitationExpired:
+		c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+	case model.ErrInvalidInvitationToken, model.ErrInvalidRole, model.ErrInvalidInvitationStatus:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallbackMessage})
+	}
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+	"go-nextjs-api/internal/spreadsheet"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxMemberImportFileSize は取り込みファイルの最大サイズ（5MB）
+const maxMemberImportFileSize = 5 << 20
+
+type MemberImportHandler struct {
+	memberImportService interfaces.MemberImportService
+}
+
+func NewMemberImportHandler(memberImportService interfaces.MemberImportService) *MemberImportHandler {
+	return &MemberImportHandler{memberImportService: memberImportService}
+}
+
+// ImportProjectMembers はCSV/XLSXファイルからプロジェクトメンバーを一括登録（dry_run=trueで結果の確認のみ）
+func (h *MemberImportHandler) ImportProjectMembers(c *gin.Context) {
+	userID, projectID, ok := parseMemberImportParams(c)
+	if !ok {
+		return
+	}
+
+	rows, ok := readMemberImportFile(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.memberImportService.ImportProjectMembers(userID, projectID, rows, c.Query("dry_run") == "true")
+	if err != nil {
+		respondMemberImportError(c, err, "Failed to import project members")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ExportProjectMembers はプロジェクトメンバーを取り込みと同じ形式で出力（format=csv|xlsx）
+func (h *MemberImportHandler) ExportProjectMembers(c *gin.Context) {
+	userID, projectID, ok := parseMemberImportParams(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.memberImportService.ExportProjectMembers(userID, projectID)
+	if err != nil {
+		respondMemberImportError(c, err, "Failed to export project members")
+		return
+	}
+
+	writeMemberExport(c, fmt.Sprintf("project-%d-members", projectID), rows)
+}
+
+// ImportCSPAccountMembers はCSV/XLSXファイルからCSPアカウントメンバーを一括登録（dry_run=trueで結果の確認のみ）
+func (h *MemberImportHandler) ImportCSPAccountMembers(c *gin.Context) {
+	userID, projectID, ok := parseMemberImportParams(c)
+	if !ok {
+		return
+	}
+
+	rows, ok := readMemberImportFile(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.memberImportService.ImportCSPAccountMembers(userID, projectID, rows, c.Query("dry_run") == "true")
+	if err != nil {
+		respondMemberImportError(c, err, "Failed to import CSP account members")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ExportCSPAccountMembers はCSPアカウントメンバーを取り込みと同じ形式で出力（format=csv|xlsx）
+func (h *MemberImportHandler) ExportCSPAccountMembers(c *gin.Context) {
+	userID, projectID, ok := parseMemberImportParams(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.memberImportService.ExportCSPAccountMembers(userID, projectID)
+	if err != nil {
+		respondMemberImportError(c, err, "Failed to export CSP account members")
+		return
+	}
+
+	writeMemberExport(c, fmt.Sprintf("project-%d-csp-account-members", projectID), rows)
+}
+
+// parseMemberImportParams は認証ユーザーIDとプロジェクトIDを取得（失敗時はレスポンス済み）
+func parseMemberImportParams(c *gin.Context) (uint, uint, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return 0, 0, false
+	}
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return 0, 0, false
+	}
+
+	return userID.(uint), uint(projectID), true
+}
+
+// readMemberImportFile はアップロードされたファイル（フォームの"file"）を読み込む（失敗時はレスポンス済み）
+func readMemberImportFile(c *gin.Context) ([][]string, bool) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return nil, false
+	}
+	if fileHeader.Size > maxMemberImportFileSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File is too large"})
+		return nil, false
+	}
+
+	format, err := spreadsheet.FormatFromFilename(fileHeader.Filename)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": model.ErrInvalidImportFile.Error()})
+		return nil, false
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxMemberImportFileSize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": model.ErrInvalidImportFile.Error()})
+		return nil, false
+	}
+
+	rows, err := spreadsheet.Read(data, format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return rows, true
+}
+
+// writeMemberExport は出力形式（format=csv|xlsx）に応じてファイルとして返す
+func writeMemberExport(c *gin.Context, basename string, rows [][]string) {
+	format, err := spreadsheet.ParseFormat(c.Query("format"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := spreadsheet.Write(&buf, format, rows); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write export file"})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, basename, format))
+	c.Data(http.StatusOK, format.ContentType(), buf.Bytes())
+}
+
+// respondMemberImportError はサービスのエラーをHTTPレスポンスに変換
+func respondMemberImportError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case model.ErrInsufficientPermission:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case model.ErrProjectNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case model.ErrProjectArchived:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case model.ErrInvalidImportHeader, model.ErrImportTooManyRows:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MemberSyncHandler struct {
+	memberSyncService interfaces.MemberSyncService
+}
+
+func NewMemberSyncHandler(memberSyncService interfaces.MemberSyncService) *MemberSyncHandler {
+	return &MemberSyncHandler{memberSyncService: memberSyncService}
+}
+
+// SyncCSPAccountMember はメンバーをプロバイダーに反映し直す（反映に失敗したメンバーの再試行）
+func (h *MemberSyncHandler) SyncCSPAccountMember(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	member, err := h.memberSyncService.SyncCSPAccountMember(uint(id))
+	if err != nil {
+		respondMemberSyncError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": member})
+}
+
+// SyncCSPAccountMembers はCSPアカウントのすべてのメンバーをプロバイダーに反映し直す
+// 一部のメンバーの反映に失敗した場合も、各メンバーの反映状況を返す
+func (h *MemberSyncHandler) SyncCSPAccountMembers(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	members, err := h.memberSyncService.SyncCSPAccountMembers(uint(id))
+	if err != nil {
+		respondMemberSyncError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": members})
+}
+
+func respondMemberSyncError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, model.ErrCSPAccountMemberNotFound),
+		errors.Is(err, model.ErrCSPAccountNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, model.ErrCSPAccountNotActive):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, model.ErrProviderMembersNotSupported),
+		errors.Is(err, model.ErrProviderUserNotFound),
+		errors.Is(err, model.ErrProviderDriverNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	}
+}
@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"go-nextjs-api/internal/interfaces"
 	"go-nextjs-api/internal/model"
@@ -29,7 +31,22 @@ func (h *ProjectHandler) GetUserProjects(c *gin.Context) {
 		return
 	}
 
-	projects, err := h.projectService.GetUserProjects(userID.(uint))
+	// 検索・ページングの指定がない場合は従来通り全件を参加日時の新しい順に返す
+	if !hasProjectQueryParams(c) {
+		projects, err := h.projectService.GetAllUserProjects(userID.(uint), parseProjectMetadataFilter(c))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to fetch user projects",
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"projects": projects,
+		})
+		return
+	}
+
+	projects, pagination, err := h.projectService.GetUserProjects(userID.(uint), parseProjectQuery(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to fetch user projects",
@@ -38,7 +55,8 @@ func (h *ProjectHandler) GetUserProjects(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"projects": projects,
+		"projects":   projects,
+		"pagination": pagination,
 	})
 }
 
@@ -176,6 +194,18 @@ func (h *ProjectHandler) CreateProject(c *gin.Context) {
 
 	project, err := h.projectService.CreateProject(userID.(uint), &req)
 	if err != nil {
+		if err == model.ErrProjectTemplateNotFound {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		if isProjectMetadataError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to create project",
 		})
@@ -225,6 +255,18 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 			})
 			return
 		}
+		if err == model.ErrInvalidProjectStatus || err == model.ErrInvalidProjectStatusTransition || isProjectMetadataError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		if err == model.ErrProjectArchived || err == model.ErrProjectHasActiveCSPAccounts || err == model.ErrProjectHasPendingCSPRequests {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to update project",
 		})
@@ -253,6 +295,9 @@ func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 	}
 
 	if err := h.projectService.DeleteProject(userID.(uint), uint(projectID)); err != nil {
+		if respondDeletionBlocked(c, err) {
+			return
+		}
 		if err == model.ErrInsufficientPermissions {
 			c.JSON(http.StatusForbidden, gin.H{
 				"error": "Only project owners can delete projects",
@@ -270,6 +315,121 @@ func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 	})
 }
 
+// TransitionProjectStatus はプロジェクトのステータスを変更（アーカイブ・アーカイブ解除など）
+func (h *ProjectHandler) TransitionProjectStatus(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project ID",
+		})
+		return
+	}
+
+	var req model.ProjectStatusTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid JSON data",
+		})
+		return
+	}
+
+	project, err := h.projectService.TransitionProjectStatus(userID.(uint), uint(projectID), req.Status)
+	if err != nil {
+		if err == model.ErrInsufficientPermissions {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "Insufficient permissions",
+			})
+			return
+		}
+		if err == model.ErrProjectNotFound {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Project not found",
+			})
+			return
+		}
+		if err == model.ErrInvalidProjectStatus || err == model.ErrInvalidProjectStatusTransition {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		if err == model.ErrProjectHasActiveCSPAccounts || err == model.ErrProjectHasPendingCSPRequests {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to change project status",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// GetTrashedProjects は復元可能な削除済みプロジェクト一覧を取得（管理者用）
+func (h *ProjectHandler) GetTrashedProjects(c *gin.Context) {
+	projects, err := h.projectService.GetTrashedProjects()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get trashed projects",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"projects": projects})
+}
+
+// RestoreProject は削除済みプロジェクトを復元（管理者用）
+func (h *ProjectHandler) RestoreProject(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project ID",
+		})
+		return
+	}
+
+	project, err := h.projectService.RestoreProject(userID.(uint), uint(projectID))
+	if err != nil {
+		if err == model.ErrProjectNotInTrash {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		if err == model.ErrProjectRestoreWindowExpired {
+			c.JSON(http.StatusGone, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to restore project",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
 // AddProjectMember はプロジェクトにメンバーを追加
 func (h *ProjectHandler) AddProjectMember(c *gin.Context) {
 	userID, exists := c.Get("user_id")
@@ -325,6 +485,12 @@ func (h *ProjectHandler) AddProjectMember(c *gin.Context) {
 			})
 			return
 		}
+		if err == model.ErrProjectArchived {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Project is archived and read-only",
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to add project member",
 		})
@@ -397,6 +563,12 @@ func (h *ProjectHandler) UpdateProjectMemberRole(c *gin.Context) {
 			})
 			return
 		}
+		if err == model.ErrProjectArchived {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Project is archived and read-only",
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to update member role",
 		})
@@ -450,7 +622,22 @@ func (h *ProjectHandler) RemoveProjectMember(c *gin.Context) {
 		return
 	}
 
-	if err := h.projectService.RemoveUserFromProject(uint(projectID), uint(memberID)); err != nil {
+	// 作成リソースの引き継ぎ先（任意）
+	var removalReq model.MemberRemovalRequest
+	if reassignToStr := c.Query("reassign_to"); reassignToStr != "" {
+		reassignTo, err := strconv.ParseUint(reassignToStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid reassign_to parameter",
+			})
+			return
+		}
+		reassignToID := uint(reassignTo)
+		removalReq.ReassignTo = &reassignToID
+	}
+
+	summary, err := h.projectService.RemoveUserFromProject(uint(projectID), uint(memberID), &removalReq)
+	if err != nil {
 		if err == model.ErrUserProjectRoleNotFound {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": "Project member not found",
@@ -463,6 +650,18 @@ func (h *ProjectHandler) RemoveProjectMember(c *gin.Context) {
 			})
 			return
 		}
+		if err == model.ErrInvalidReassignTarget {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		if err == model.ErrProjectArchived {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Project is archived and read-only",
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to remove project member",
 		})
@@ -471,121 +670,170 @@ func (h *ProjectHandler) RemoveProjectMember(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Member removed from project successfully",
+		"summary": summary,
 	})
 }
 
-// GetProjectsByType はプロジェクトタイプでプロジェクト一覧を取得
-func (h *ProjectHandler) GetProjectsByType(c *gin.Context) {
-	projectType := c.Query("type")
-	if projectType == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Project type is required"})
+// TransferOwnership はプロジェクトのオーナー権限を別メンバーに移譲
+func (h *ProjectHandler) TransferOwnership(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
 		return
 	}
 
-	projects, err := h.projectService.GetProjectsByType(projectType)
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get projects"})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{"projects": projects})
-}
-
-// GetVendorRelations はプロジェクトのベンダー紐付け一覧を取得
-func (h *ProjectHandler) GetVendorRelations(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project ID",
+		})
 		return
 	}
 
-	projectIDParam := c.Param("id")
-	projectID, err := strconv.ParseUint(projectIDParam, 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+	var req model.OwnershipTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid JSON data",
+		})
 		return
 	}
 
-	relations, err := h.projectService.GetVendorRelations(userID.(uint), uint(projectID))
-	if err != nil {
+	if err := h.projectService.TransferOwnership(userID.(uint), uint(projectID), &req); err != nil {
+		if err == model.ErrInsufficientPermissions {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "Only project owners can transfer ownership",
+			})
+			return
+		}
 		if err == model.ErrUserNotProjectMember {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to this project"})
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "New owner must be a member of this project",
+			})
+			return
+		}
+		if err == model.ErrInvalidOwnershipTransfer || err == model.ErrInvalidRole {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		if err == model.ErrProjectArchived {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Project is archived and read-only",
+			})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get vendor relations"})
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to transfer ownership",
+		})
 		return
 	}
 
-	c.JSON(http.StatusOK, relations)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Project ownership transferred successfully",
+	})
 }
 
-// CreateVendorRelation はベンダープロジェクト紐付けを作成
-func (h *ProjectHandler) CreateVendorRelation(c *gin.Context) {
+// SearchProjects はプロジェクトを検索（キーワード・組織・ステータス・種類・タグ・ロール・CSPプロバイダーで絞り込み）
+func (h *ProjectHandler) SearchProjects(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
 		return
 	}
 
-	projectIDParam := c.Param("id")
-	projectID, err := strconv.ParseUint(projectIDParam, 10, 32)
+	projects, pagination, err := h.projectService.SearchProjects(userID.(uint), parseProjectQuery(c))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		if err == model.ErrInvalidRole || err == model.ErrInvalidProjectStatus || err == model.ErrInvalidCSPProvider {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get projects"})
 		return
 	}
 
-	var req struct {
-		VendorProjectID uint `json:"vendor_project_id" binding:"required"`
-	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
-		return
+	c.JSON(http.StatusOK, gin.H{
+		"projects":   projects,
+		"pagination": pagination,
+	})
+}
+
+// parseProjectQuery はクエリパラメータからプロジェクト検索条件を作成
+// 複数値を取るstatus/typeはカンマ区切りで指定する
+func parseProjectQuery(c *gin.Context) *model.ProjectQuery {
+	query := &model.ProjectQuery{
+		Keyword:     strings.TrimSpace(c.Query("q")),
+		Role:        model.Role(c.Query("role")),
+		CSPProvider: model.CSPProvider(c.Query("csp_provider")),
+		MemberOnly:  c.Query("member_only") == "true",
+		Metadata:    parseProjectMetadataFilter(c),
+		Sort:        c.Query("sort"),
+		Order:       c.Query("order"),
 	}
 
-	err = h.projectService.CreateVendorRelation(userID.(uint), uint(projectID), req.VendorProjectID)
-	if err != nil {
-		if err == model.ErrInsufficientPermission {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permission"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create vendor relation"})
-		return
+	if organizationID, err := strconv.ParseUint(c.Query("organization_id"), 10, 32); err == nil {
+		query.OrganizationID = uint(organizationID)
+	}
+	for _, status := range splitQueryList(c.Query("status")) {
+		query.Statuses = append(query.Statuses, model.ProjectStatus(status))
+	}
+	for _, projectType := range splitQueryList(c.Query("type")) {
+		query.ProjectTypes = append(query.ProjectTypes, model.ProjectType(projectType))
 	}
+	query.Page, _ = strconv.Atoi(c.Query("page"))
+	query.Limit, _ = strconv.Atoi(c.Query("limit"))
 
-	c.JSON(http.StatusCreated, gin.H{"message": "Vendor relation created successfully"})
+	return query
 }
 
-// DeleteVendorRelation はベンダープロジェクト紐付けを削除
-func (h *ProjectHandler) DeleteVendorRelation(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
-		return
-	}
+// projectQueryParams はプロジェクト検索・ページングに使うクエリパラメータ（タグ・カスタム属性の絞り込みは除く）
+var projectQueryParams = []string{"q", "organization_id", "status", "type", "role", "csp_provider", "member_only", "sort", "order", "page", "limit"}
 
-	projectIDParam := c.Param("id")
-	projectID, err := strconv.ParseUint(projectIDParam, 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
-		return
+// hasProjectQueryParams は検索・ページングのクエリパラメータが指定されているかどうかを判定
+func hasProjectQueryParams(c *gin.Context) bool {
+	for _, name := range projectQueryParams {
+		if _, ok := c.GetQuery(name); ok {
+			return true
+		}
 	}
+	return false
+}
 
-	relationIDParam := c.Param("relationId")
-	relationID, err := strconv.ParseUint(relationIDParam, 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid relation ID"})
-		return
+// splitQueryList はカンマ区切りのクエリパラメータを分割（空要素は除外）
+func splitQueryList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
 	}
+	return values
+}
 
-	err = h.projectService.DeleteVendorRelation(userID.(uint), uint(projectID), uint(relationID))
-	if err != nil {
-		if err == model.ErrInsufficientPermission {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permission"})
-			return
+// parseProjectMetadataFilter はクエリパラメータ tag.<key>=<value> / attr.<key>=<value> から絞り込み条件を作成
+func parseProjectMetadataFilter(c *gin.Context) *model.ProjectMetadataFilter {
+	filter := &model.ProjectMetadataFilter{
+		Tags:       map[string]string{},
+		Attributes: map[string]string{},
+	}
+	for param, values := range c.Request.URL.Query() {
+		if len(values) == 0 {
+			continue
+		}
+		if key := strings.TrimPrefix(param, "tag."); key != param && key != "" {
+			filter.Tags[key] = values[0]
+		} else if key := strings.TrimPrefix(param, "attr."); key != param && key != "" {
+			filter.Attributes[key] = values[0]
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete vendor relation"})
-		return
 	}
+	return filter
+}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Vendor relation deleted successfully"})
-}
\ No newline at end of file
+// isProjectMetadataError はタグ・カスタム属性の検証エラーかどうかを判定
+func isProjectMetadataError(err error) bool {
+	return errors.Is(err, model.ErrInvalidProjectTag) ||
+		errors.Is(err, model.ErrInvalidCustomAttribute) ||
+		errors.Is(err, model.ErrMissingRequiredCustomAttribute)
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectCloneHandler struct {
+	projectCloneService interfaces.ProjectCloneService
+}
+
+func NewProjectCloneHandler(projectCloneService interfaces.ProjectCloneService) *ProjectCloneHandler {
+	return &ProjectCloneHandler{projectCloneService: projectCloneService}
+}
+
+// CloneProject はプロジェクトを複製（includeで複製する項目を選択）
+func (h *ProjectCloneHandler) CloneProject(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var req model.ProjectCloneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON data"})
+		return
+	}
+
+	project, err := h.projectCloneService.CloneProject(userID.(uint), uint(projectID), &req)
+	if err != nil {
+		switch {
+		case err == model.ErrInsufficientPermissions:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		case err == model.ErrProjectNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case err == model.ErrInvalidProjectClone, err == model.ErrProjectNotCloneable, isProjectMetadataError(err):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clone project"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, project)
+}
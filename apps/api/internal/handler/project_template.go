@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectTemplateHandler struct {
+	projectTemplateService interfaces.ProjectTemplateService
+}
+
+func NewProjectTemplateHandler(projectTemplateService interfaces.ProjectTemplateService) *ProjectTemplateHandler {
+	return &ProjectTemplateHandler{projectTemplateService: projectTemplateService}
+}
+
+// GetTemplates はプロジェクトテンプレート一覧を取得
+func (h *ProjectTemplateHandler) GetTemplates(c *gin.Context) {
+	templates, err := h.projectTemplateService.GetTemplates()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project templates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"templates": templates})
+}
+
+// GetTemplate はプロジェクトテンプレートを取得
+func (h *ProjectTemplateHandler) GetTemplate(c *gin.Context) {
+	templateID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	template, err := h.projectTemplateService.GetTemplate(uint(templateID))
+	if err != nil {
+		respondProjectTemplateError(c, err, "Failed to get project template")
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// CreateTemplate はプロジェクトテンプレートを作成（管理者用）
+func (h *ProjectTemplateHandler) CreateTemplate(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req model.ProjectTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	template, err := h.projectTemplateService.CreateTemplate(userID.(uint), &req)
+	if err != nil {
+		respondProjectTemplateError(c, err, "Failed to create project template")
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// UpdateTemplate はプロジェクトテンプレートを更新（管理者用）
+func (h *ProjectTemplateHandler) UpdateTemplate(c *gin.Context) {
+	templateID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	var req model.ProjectTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	template, err := h.projectTemplateService.UpdateTemplate(uint(templateID), &req)
+	if err != nil {
+		respondProjectTemplateError(c, err, "Failed to update project template")
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteTemplate はプロジェクトテンプレートを削除（管理者用）
+func (h *ProjectTemplateHandler) DeleteTemplate(c *gin.Context) {
+	templateID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	if err := h.projectTemplateService.DeleteTemplate(uint(templateID)); err != nil {
+		respondProjectTemplateError(c, err, "Failed to delete project template")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Project template deleted successfully"})
+}
+
+// respondProjectTemplateError はプロジェクトテンプレート関連のエラーをHTTPステータスに変換して返す
+func respondProjectTemplateError(c *gin.Context, err error, fallbackMessage string) {
+	switch {
+	case errors.Is(err, model.ErrProjectTemplateNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, model.ErrProjectTemplateAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, model.ErrInvalidProjectTemplate), errors.Is(err, model.ErrInvalidProjectTag):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallbackMessage})
+	}
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReconciliationHandler struct {
+	reconciliationService interfaces.ReconciliationService
+}
+
+func NewReconciliationHandler(reconciliationService interfaces.ReconciliationService) *ReconciliationHandler {
+	return &ReconciliationHandler{reconciliationService: reconciliationService}
+}
+
+// RunReconciliation はプロバイダーの棚卸しを実行する（providerを省略した場合はすべてのプロバイダー）
+func (h *ReconciliationHandler) RunReconciliation(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req model.CSPReconciliationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	triggeredBy := adminID.(uint)
+	runs, err := h.reconciliationService.RunReconciliation(req.Provider, &triggeredBy)
+	if err != nil {
+		respondReconciliationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": runs})
+}
+
+// GetRuns は棚卸しの実行履歴を取得
+func (h *ReconciliationHandler) GetRuns(c *gin.Context) {
+	runs, err := h.reconciliationService.GetRuns()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": runs})
+}
+
+// GetDriftItems はドリフトの一覧を取得（provider・status・kind・csp_account_idで絞り込み）
+func (h *ReconciliationHandler) GetDriftItems(c *gin.Context) {
+	filter := &model.CSPDriftFilter{
+		Provider: model.CSPProvider(c.Query("provider")),
+		Status:   model.CSPDriftStatus(c.Query("status")),
+		Kind:     model.CSPDriftKind(c.Query("kind")),
+	}
+	if cspAccountIDStr := c.Query("csp_account_id"); cspAccountIDStr != "" {
+		cspAccountID, err := strconv.Atoi(cspAccountIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid CSP account ID"})
+			return
+		}
+		id := uint(cspAccountID)
+		filter.CSPAccountID = &id
+	}
+
+	items, err := h.reconciliationService.GetDriftItems(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": items})
+}
+
+// ResolveDriftItem はドリフトに対応する（import・fix・ignore）
+func (h *ReconciliationHandler) ResolveDriftItem(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req model.CSPDriftResolveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.reconciliationService.ResolveDriftItem(uint(id), adminID.(uint), &req)
+	if err != nil {
+		respondReconciliationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": item})
+}
+
+func respondReconciliationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, model.ErrDriftItemNotFound),
+		errors.Is(err, model.ErrCSPAccountNotFound),
+		errors.Is(err, model.ErrProjectNotFound),
+		errors.Is(err, model.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, model.ErrDriftItemNotOpen),
+		errors.Is(err, model.ErrReconciliationInProgress),
+		errors.Is(err, model.ErrCSPAccountClosed),
+		errors.Is(err, model.ErrProjectArchived),
+		errors.Is(err, model.ErrProjectCSPAccountAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, model.ErrInvalidDriftAction),
+		errors.Is(err, model.ErrDriftProjectRequired),
+		errors.Is(err, model.ErrInvalidCSPProvider),
+		errors.Is(err, model.ErrInvalidCSPAccountID),
+		errors.Is(err, model.ErrProviderDriverNotFound),
+		errors.Is(err, model.ErrProviderMembersNotSupported),
+		errors.Is(err, model.ErrProviderUserNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	}
+}
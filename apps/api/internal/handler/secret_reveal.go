@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SecretRevealHandler struct {
+	secretRevealService interfaces.SecretRevealService
+}
+
+func NewSecretRevealHandler(secretRevealService interfaces.SecretRevealService) *SecretRevealHandler {
+	return &SecretRevealHandler{secretRevealService: secretRevealService}
+}
+
+// RequestReveal はCSPアカウントの長期の認証情報の開示を申請する
+func (h *SecretRevealHandler) RequestReveal(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req model.SecretRevealCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reveal, err := h.secretRevealService.RequestReveal(uint(id), userID.(uint), &req, c.ClientIP())
+	if err != nil {
+		respondSecretRevealError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": reveal})
+}
+
+// GetReveals はCSPアカウントの開示申請の一覧を取得（認証情報は含まない）
+func (h *SecretRevealHandler) GetReveals(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	reveals, err := h.secretRevealService.GetReveals(uint(id))
+	if err != nil {
+		respondSecretRevealError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": reveals})
+}
+
+// ApproveReveal は開示申請を承認する（申請者本人は承認できない）
+func (h *SecretRevealHandler) ApproveReveal(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	revealID, err := strconv.Atoi(c.Param("revealId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reveal ID"})
+		return
+	}
+
+	reveal, err := h.secretRevealService.ApproveReveal(uint(revealID), userID.(uint), c.ClientIP())
+	if err != nil {
+		respondSecretRevealError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": reveal})
+}
+
+// RejectReveal は開示申請を却下する
+func (h *SecretRevealHandler) RejectReveal(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	revealID, err := strconv.Atoi(c.Param("revealId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reveal ID"})
+		return
+	}
+
+	var req model.SecretRevealRejectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reveal, err := h.secretRevealService.RejectReveal(uint(revealID), userID.(uint), &req, c.ClientIP())
+	if err != nil {
+		respondSecretRevealError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": reveal})
+}
+
+// Reveal は承認済みの開示申請について、申請者に長期の認証情報を一度だけ返す
+func (h *SecretRevealHandler) Reveal(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	revealID, err := strconv.Atoi(c.Param("revealId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reveal ID"})
+		return
+	}
+
+	secret, err := h.secretRevealService.Reveal(uint(revealID), userID.(uint), c.ClientIP())
+	if err != nil {
+		respondSecretRevealError(c, err)
+		return
+	}
+
+	// 認証情報はキャッシュさせない
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{"data": secret})
+}
+
+// VerifyAuditLog は開示申請の監査記録のハッシュチェーンを検証する
+func (h *SecretRevealHandler) VerifyAuditLog(c *gin.Context) {
+	result, err := h.secretRevealService.VerifyAuditLog()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+func respondSecretRevealError(c *gin.Context, err error) {
+	switch err {
+	case model.ErrCSPAccountNotFound, model.ErrSecretRevealNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case model.ErrInvalidRevealJustification:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case model.ErrSecretRevealSelfApproval, model.ErrSecretRevealNotRequester:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case model.ErrSecretRevealNotPending, model.ErrSecretRevealNotApproved, model.ErrSecretRevealStateChanged, model.ErrCSPAccountHasNoSecret:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case model.ErrSecretRevealExpired:
+		c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxPlatformStateSize は状態定義の最大サイズ（10MB）
+const maxPlatformStateSize = 10 << 20
+
+type StateSyncHandler struct {
+	stateSyncService interfaces.StateSyncService
+}
+
+func NewStateSyncHandler(stateSyncService interfaces.StateSyncService) *StateSyncHandler {
+	return &StateSyncHandler{stateSyncService: stateSyncService}
+}
+
+// PlanState はリクエストボディの状態定義（YAML）とDBの差分から変更計画を返す（prune=trueで定義外のリソースの削除を含める）
+func (h *StateSyncHandler) PlanState(c *gin.Context) {
+	data, ok := readPlatformState(c)
+	if !ok {
+		return
+	}
+
+	plan, err := h.stateSyncService.Plan(data, c.Query("prune") == "true")
+	if err != nil {
+		respondStateSyncError(c, err, "Failed to plan platform state")
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+// ApplyState は状態定義を適用する（expected_fingerprintを指定した場合は確認済みのプランと一致するときのみ適用）
+func (h *StateSyncHandler) ApplyState(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	data, ok := readPlatformState(c)
+	if !ok {
+		return
+	}
+
+	plan, err := h.stateSyncService.Apply(userID.(uint), data, c.Query("prune") == "true", c.Query("expected_fingerprint"))
+	if err != nil {
+		respondStateSyncError(c, err, "Failed to apply platform state")
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+// readPlatformState はリクエストボディの状態定義を読み込む（失敗時はレスポンス済み）
+func readPlatformState(c *gin.Context) ([]byte, bool) {
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxPlatformStateSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+		return nil, false
+	}
+	if len(data) > maxPlatformStateSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Platform state is too large"})
+		return nil, false
+	}
+	if len(data) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Platform state is required"})
+		return nil, false
+	}
+	return data, true
+}
+
+// respondStateSyncError はサービスのエラーをHTTPレスポンスに変換（検証エラーは問題点の一覧を返す）
+func respondStateSyncError(c *gin.Context, err error, fallback string) {
+	var validationErr *model.StateValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid platform state", "problems": validationErr.Problems})
+		return
+	}
+
+	switch err {
+	case model.ErrStatePlanOutdated:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback, "details": err.Error()})
+	}
+}
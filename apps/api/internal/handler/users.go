@@ -142,8 +142,17 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	}
 
 	if err := h.userService.DeleteUser(uint(id)); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "User not found",
+		if respondDeletionBlocked(c, err) {
+			return
+		}
+		if err == model.ErrUserNotFound {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "User not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete user",
 		})
 		return
 	}
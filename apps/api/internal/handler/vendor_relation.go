@@ -0,0 +1,316 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VendorRelationHandler struct {
+	vendorRelationService interfaces.VendorRelationService
+}
+
+func NewVendorRelationHandler(vendorRelationService interfaces.VendorRelationService) *VendorRelationHandler {
+	return &VendorRelationHandler{vendorRelationService: vendorRelationService}
+}
+
+// GetVendorRelations はプロジェクトのベンダー紐付け一覧を取得
+func (h *VendorRelationHandler) GetVendorRelations(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	relations, err := h.vendorRelationService.GetVendorRelations(userID.(uint), uint(projectID))
+	if err != nil {
+		if err == model.ErrUserNotProjectMember {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to this project"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get vendor relations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, relations)
+}
+
+// ProposeVendorRelation はベンダープロジェクトへの紐付けを提案
+func (h *VendorRelationHandler) ProposeVendorRelation(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var req model.VendorRelationCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	relation, err := h.vendorRelationService.ProposeVendorRelation(userID.(uint), uint(projectID), &req)
+	if err != nil {
+		respondVendorRelationError(c, err, "Failed to propose vendor relation")
+		return
+	}
+
+	c.JSON(http.StatusCreated, relation)
+}
+
+// AcceptVendorRelation はベンダー側が紐付けの提案を承諾
+func (h *VendorRelationHandler) AcceptVendorRelation(c *gin.Context) {
+	userID, projectID, relationID, ok := parseVendorRelationParams(c)
+	if !ok {
+		return
+	}
+
+	relation, err := h.vendorRelationService.AcceptVendorRelation(userID, projectID, relationID)
+	if err != nil {
+		respondVendorRelationError(c, err, "Failed to accept vendor relation")
+		return
+	}
+
+	c.JSON(http.StatusOK, relation)
+}
+
+// DeclineVendorRelation はベンダー側が紐付けの提案を辞退
+func (h *VendorRelationHandler) DeclineVendorRelation(c *gin.Context) {
+	userID, projectID, relationID, ok := parseVendorRelationParams(c)
+	if !ok {
+		return
+	}
+
+	var req model.VendorRelationReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil && c.Request.ContentLength > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	relation, err := h.vendorRelationService.DeclineVendorRelation(userID, projectID, relationID, req.Reason)
+	if err != nil {
+		respondVendorRelationError(c, err, "Failed to decline vendor relation")
+		return
+	}
+
+	c.JSON(http.StatusOK, relation)
+}
+
+// TerminateVendorRelation は紐付けを終了（紐付け元・ベンダー側のどちらからも可能）
+func (h *VendorRelationHandler) TerminateVendorRelation(c *gin.Context) {
+	userID, projectID, relationID, ok := parseVendorRelationParams(c)
+	if !ok {
+		return
+	}
+
+	var req model.VendorRelationReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil && c.Request.ContentLength > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	relation, err := h.vendorRelationService.TerminateVendorRelation(userID, projectID, relationID, req.Reason)
+	if err != nil {
+		respondVendorRelationError(c, err, "Failed to terminate vendor relation")
+		return
+	}
+
+	c.JSON(http.StatusOK, relation)
+}
+
+// WithdrawVendorRelation はベンダーの承諾前の提案を取り下げ
+func (h *VendorRelationHandler) WithdrawVendorRelation(c *gin.Context) {
+	userID, projectID, relationID, ok := parseVendorRelationParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.vendorRelationService.WithdrawVendorRelation(userID, projectID, relationID); err != nil {
+		respondVendorRelationError(c, err, "Failed to withdraw vendor relation")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Vendor relation proposal withdrawn successfully"})
+}
+
+// UpdateAccessGrant は紐付け元プロジェクトがベンダーに委任する権限を設定
+func (h *VendorRelationHandler) UpdateAccessGrant(c *gin.Context) {
+	userID, projectID, relationID, ok := parseVendorRelationParams(c)
+	if !ok {
+		return
+	}
+
+	var req model.VendorAccessGrantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	relation, err := h.vendorRelationService.UpdateAccessGrant(userID, projectID, relationID, &req)
+	if err != nil {
+		respondVendorRelationError(c, err, "Failed to update vendor access grant")
+		return
+	}
+
+	c.JSON(http.StatusOK, relation)
+}
+
+// GetStaffAssignments はベンダー担当者への権限割り当て一覧を取得
+func (h *VendorRelationHandler) GetStaffAssignments(c *gin.Context) {
+	userID, projectID, relationID, ok := parseVendorRelationParams(c)
+	if !ok {
+		return
+	}
+
+	assignments, err := h.vendorRelationService.GetStaffAssignments(userID, projectID, relationID)
+	if err != nil {
+		respondVendorRelationError(c, err, "Failed to get vendor staff assignments")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"assignments": assignments})
+}
+
+// AssignStaff はベンダー担当者に委任範囲内の権限を割り当て
+func (h *VendorRelationHandler) AssignStaff(c *gin.Context) {
+	userID, projectID, relationID, ok := parseVendorRelationParams(c)
+	if !ok {
+		return
+	}
+
+	var req model.VendorStaffAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	assignment, err := h.vendorRelationService.AssignStaff(userID, projectID, relationID, &req)
+	if err != nil {
+		respondVendorRelationError(c, err, "Failed to assign vendor staff")
+		return
+	}
+
+	c.JSON(http.StatusOK, assignment)
+}
+
+// RemoveStaff はベンダー担当者への権限割り当てを削除
+func (h *VendorRelationHandler) RemoveStaff(c *gin.Context) {
+	userID, projectID, relationID, ok := parseVendorRelationParams(c)
+	if !ok {
+		return
+	}
+
+	staffUserID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := h.vendorRelationService.RemoveStaff(userID, projectID, relationID, uint(staffUserID)); err != nil {
+		respondVendorRelationError(c, err, "Failed to remove vendor staff")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Vendor staff assignment removed successfully"})
+}
+
+// GetDelegatedAccess は現在のユーザーがベンダー紐付け経由でプロジェクトに持つ権限を取得
+func (h *VendorRelationHandler) GetDelegatedAccess(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	access, err := h.vendorRelationService.GetDelegatedAccess(userID.(uint), uint(projectID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get delegated access"})
+		return
+	}
+
+	c.JSON(http.StatusOK, access)
+}
+
+// GetVendorPortfolio は現在のユーザーがベンダーとして関わる全ての依頼元プロジェクトを取得
+func (h *VendorRelationHandler) GetVendorPortfolio(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	portfolio, err := h.vendorRelationService.GetVendorPortfolio(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get vendor portfolio"})
+		return
+	}
+
+	c.JSON(http.StatusOK, portfolio)
+}
+
+// parseVendorRelationParams はユーザーID・プロジェクトID・紐付けIDを取得（失敗時はレスポンス済み）
+func parseVendorRelationParams(c *gin.Context) (uint, uint, uint, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return 0, 0, 0, false
+	}
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return 0, 0, 0, false
+	}
+
+	relationID, err := strconv.ParseUint(c.Param("relationId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid relation ID"})
+		return 0, 0, 0, false
+	}
+
+	return userID.(uint), uint(projectID), uint(relationID), true
+}
+
+// respondVendorRelationError はベンダー紐付け関連のエラーをHTTPステータスに変換して返す
+func respondVendorRelationError(c *gin.Context, err error, fallbackMessage string) {
+	switch err {
+	case model.ErrInsufficientPermission:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permission"})
+	case model.ErrUserNotProjectMember:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case model.ErrProjectNotFound, model.ErrVendorRelationNotFound, model.ErrVendorStaffNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case model.ErrNotVendorProject, model.ErrInvalidVendorRelationPeriod, model.ErrInvalidVendorPermission, model.ErrCSPAccountNotInProject:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case model.ErrVendorPermissionNotGranted:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case model.ErrVendorRelationAlreadyExists, model.ErrInvalidVendorRelationTransition:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case model.ErrProjectArchived:
+		c.JSON(http.StatusConflict, gin.H{"error": "Project is archived and read-only"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallbackMessage})
+	}
+}
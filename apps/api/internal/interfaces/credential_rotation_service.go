@@ -0,0 +1,10 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type CredentialRotationService interface {
+	GetRotationHistory(cspAccountID uint) ([]model.CSPAccountCredentialRotation, error)
+	UpdateRotationPolicy(cspAccountID, adminID uint, req *model.CredentialRotationPolicyRequest) (*model.CSPAccount, error)
+	RotateCredentials(cspAccountID, adminID uint, req *model.CredentialRotationRequest) (*model.CSPAccountCredentialRotation, error)
+	ProcessScheduledRotations() (int, error)
+}
@@ -0,0 +1,8 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type CredentialVendingService interface {
+	IssueTemporaryCredentials(userID, cspAccountID uint, req *model.TemporaryCredentialRequest, sourceIP string) (*model.TemporaryCredentials, error)
+	GetIssuances(cspAccountID uint) ([]model.CSPCredentialIssuance, error)
+}
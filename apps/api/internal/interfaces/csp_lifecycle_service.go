@@ -0,0 +1,13 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type CSPLifecycleService interface {
+	SuspendCSPAccount(cspAccountID, adminID uint, req *model.CSPAccountStatusChangeRequest) (*model.CSPAccount, error)
+	ResumeCSPAccount(cspAccountID, adminID uint, req *model.CSPAccountStatusChangeRequest) (*model.CSPAccount, error)
+	GetClosureCheck(cspAccountID uint) (*model.CSPAccountClosureCheck, error)
+	CloseCSPAccount(cspAccountID, adminID uint, req *model.CSPAccountClosureRequest) (*model.CSPAccount, error)
+	GetLifecycleEvents(cspAccountID uint) ([]model.CSPAccountLifecycleEvent, error)
+	// ProcessClosingAccounts は閉鎖処理中のアカウントについて、プロバイダーでの閉鎖の完了を反映する（定期実行用）
+	ProcessClosingAccounts() (int, error)
+}
@@ -1,6 +1,10 @@
 package interfaces
 
-import "go-nextjs-api/internal/model"
+import (
+	"time"
+
+	"go-nextjs-api/internal/model"
+)
 
 type CSPRepository interface {
 	// CSPAccount related methods
@@ -11,12 +15,29 @@ type CSPRepository interface {
 	InsertCSPAccount(account *model.CSPAccount) error
 	UpdateCSPAccount(account *model.CSPAccount) error
 	DeleteCSPAccount(id uint) error
+	SelectCSPAccountsForCredentialRotation() ([]model.CSPAccount, error)
+	SelectCSPAccountsByStatus(status model.CSPAccountStatus) ([]model.CSPAccount, error)
+
+	// CSPAccountLifecycleEvent related methods
+	// UpdateCSPAccountStatus はCSPアカウントを更新し、ステータス遷移を履歴に記録する
+	UpdateCSPAccountStatus(account *model.CSPAccount, event *model.CSPAccountLifecycleEvent) error
+	SelectLifecycleEventsByCSPAccountID(cspAccountID uint) ([]model.CSPAccountLifecycleEvent, error)
+
+	// CSPAccountCredentialRotation related methods
+	SelectCredentialRotationsByCSPAccountID(cspAccountID uint) ([]model.CSPAccountCredentialRotation, error)
+	InsertCredentialRotation(rotation *model.CSPAccountCredentialRotation) error
+	MarkPreviousCredentialRevoked(cspAccountID uint, keyID string, revokedAt time.Time) error
+
+	// CSPCredentialIssuance related methods
+	SelectCredentialIssuancesByCSPAccountID(cspAccountID uint) ([]model.CSPCredentialIssuance, error)
+	InsertCredentialIssuance(issuance *model.CSPCredentialIssuance) error
 
 	// ProjectCSPAccount related methods
 	SelectProjectCSPAccountAll() ([]model.ProjectCSPAccount, error)
 	SelectProjectCSPAccountByID(id uint) (*model.ProjectCSPAccount, error)
 	SelectProjectCSPAccountsByProjectID(projectID uint) ([]model.ProjectCSPAccount, error)
 	SelectProjectCSPAccountsByCSPAccountID(cspAccountID uint) ([]model.ProjectCSPAccount, error)
+	SelectProjectCSPAccountsByProjectMetadata(filter *model.ProjectMetadataFilter) ([]model.ProjectCSPAccount, error)
 	SelectProjectCSPAccountByProjectAndCSPAccount(projectID, cspAccountID uint) (*model.ProjectCSPAccount, error)
 	InsertProjectCSPAccount(relation *model.ProjectCSPAccount) error
 	UpdateProjectCSPAccount(relation *model.ProjectCSPAccount) error
@@ -35,4 +56,11 @@ type CSPRepository interface {
 	UpdateCSPAccountMember(member *model.CSPAccountMember) error
 	DeleteCSPAccountMember(id uint) error
 	DeleteCSPAccountMemberByCSPAccountAndUser(cspAccountID, userID uint) error
+	// UpdateCSPAccountMemberSyncStatus はメンバーのプロバイダーへの反映状況だけを更新する
+	UpdateCSPAccountMemberSyncStatus(ids []uint, status model.CSPAccountMemberSyncStatus, syncError string, attemptedAt time.Time) error
+	// CSPMemberRevocation related methods（削除・メールアドレスの変更で、プロバイダー側のロールを外す必要があるメールアドレス）
+	SelectCSPMemberRevocationsByCSPAccountID(cspAccountID uint) ([]model.CSPMemberRevocation, error)
+	InsertCSPMemberRevocation(cspAccountID uint, email string) error
+	UpdateCSPMemberRevocationFailure(cspAccountID uint, email, lastError string, attemptedAt time.Time) error
+	DeleteCSPMemberRevocation(cspAccountID uint, email string) error
 }
@@ -9,6 +9,8 @@ type CSPService interface {
 	GetCSPAccountByID(id uint) (*model.CSPAccount, error)
 	GetCSPAccountsByProvider(provider model.CSPProvider) ([]model.CSPAccount, error)
 	CreateCSPAccount(adminID uint, req *model.CSPAccountCreateRequest) (*model.CSPAccount, error)
+	ProvisionCSPAccount(creatorID uint, req *model.CSPAccountProvisionRequest) (*model.CSPAccount, error)
+	SyncCSPAccountStatus(id uint) (*model.CSPAccount, error)
 	UpdateCSPAccount(id uint, adminID uint, account *model.CSPAccount) (*model.CSPAccount, error)
 	DeleteCSPAccount(id uint, adminID uint) error
 
@@ -17,6 +19,7 @@ type CSPService interface {
 	GetProjectCSPAccountByID(id uint) (*model.ProjectCSPAccount, error)
 	GetProjectCSPAccountsByProjectID(projectID uint) ([]model.ProjectCSPAccount, error)
 	GetProjectCSPAccountsByCSPAccountID(cspAccountID uint) ([]model.ProjectCSPAccount, error)
+	GetProjectCSPAccountReport(filter *model.ProjectMetadataFilter) ([]model.ProjectCSPAccount, error)
 	CreateProjectCSPAccount(adminID uint, projectID, cspAccountID uint) (*model.ProjectCSPAccount, error)
 	DeleteProjectCSPAccount(id uint, adminID uint) error
 	DeleteProjectCSPAccountByProjectAndCSPAccount(projectID, cspAccountID uint, adminID uint) error
@@ -34,5 +37,5 @@ type CSPService interface {
 	// Permission checking methods
 	CanUserManageCSPAccount(userID, cspAccountID uint) (bool, error)
 	CanUserManageProjectCSPAccount(userID, projectID uint) (bool, error)
-	CanUserManageCSPAccountMember(userID, cspAccountID uint) (bool, error)
+	CanUserManageCSPAccountMember(userID, projectID uint) (bool, error)
 }
@@ -0,0 +1,12 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type CustomAttributeRepository interface {
+	SelectDefinitionsByOrganizationID(organizationID uint) ([]model.CustomAttributeDefinition, error)
+	SelectDefinitionByID(id uint) (*model.CustomAttributeDefinition, error)
+	SelectDefinitionByKey(organizationID uint, key string) (*model.CustomAttributeDefinition, error)
+	InsertDefinition(definition *model.CustomAttributeDefinition) error
+	UpdateDefinition(definition *model.CustomAttributeDefinition) error
+	DeleteDefinition(id uint) error
+}
@@ -0,0 +1,10 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type CustomAttributeService interface {
+	GetDefinitions(organizationID uint) ([]model.CustomAttributeDefinition, error)
+	CreateDefinition(organizationID uint, req *model.CustomAttributeDefinitionRequest) (*model.CustomAttributeDefinition, error)
+	UpdateDefinition(organizationID, definitionID uint, req *model.CustomAttributeDefinitionRequest) (*model.CustomAttributeDefinition, error)
+	DeleteDefinition(organizationID, definitionID uint) error
+}
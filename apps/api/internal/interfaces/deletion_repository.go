@@ -0,0 +1,9 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type DeletionRepository interface {
+	SelectProjectDependents(projectID uint) (*model.ProjectDeletionDependents, error)
+	SelectUserDependents(userID uint) (*model.UserDeletionDependents, error)
+	SelectCSPAccountDependents(cspAccountID uint) (*model.CSPAccountDeletionDependents, error)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type DeletionService interface {
+	PreviewProjectDeletion(userID, projectID uint) (*model.DeletionImpact, error)
+	PreviewUserDeletion(userID uint) (*model.DeletionImpact, error)
+	PreviewCSPAccountDeletion(cspAccountID uint) (*model.DeletionImpact, error)
+	EnsureProjectDeletable(projectID uint) error
+	EnsureUserDeletable(userID uint) error
+	EnsureCSPAccountDeletable(cspAccountID uint) error
+}
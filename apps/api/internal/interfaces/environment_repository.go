@@ -0,0 +1,21 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type EnvironmentRepository interface {
+	SelectByProjectID(projectID uint) ([]model.ProjectEnvironment, error)
+	SelectByID(id uint) (*model.ProjectEnvironment, error)
+	SelectByProjectAndName(projectID uint, name string) (*model.ProjectEnvironment, error)
+	Insert(environment *model.ProjectEnvironment) error
+	Update(environment *model.ProjectEnvironment) error
+	Delete(id uint) error
+	CountCSPAccounts(environmentID uint) (int64, error)
+	SelectCSPAccounts(environmentID uint) ([]model.ProjectCSPAccount, error)
+
+	SelectMembers(environmentID uint) ([]model.ProjectEnvironmentMember, error)
+	SelectMember(environmentID, userID uint) (*model.ProjectEnvironmentMember, error)
+	InsertMember(member *model.ProjectEnvironmentMember, cspAccountMembers []model.CSPAccountMember) error
+	DeleteMember(member *model.ProjectEnvironmentMember) error
+
+	UpdateCSPAccountEnvironment(link *model.ProjectCSPAccount, environmentID *uint, cspAccountMembers []model.CSPAccountMember) error
+}
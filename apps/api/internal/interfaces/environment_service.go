@@ -0,0 +1,16 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type EnvironmentService interface {
+	GetEnvironments(userID, projectID uint) ([]model.ProjectEnvironment, error)
+	GetEnvironment(userID, projectID, environmentID uint) (*model.ProjectEnvironmentDetail, error)
+	GetEnvironmentSettings(projectID, environmentID uint) (*model.ProjectEnvironment, error)
+	CreateEnvironment(userID, projectID uint, req *model.ProjectEnvironmentRequest) (*model.ProjectEnvironment, error)
+	UpdateEnvironment(userID, projectID, environmentID uint, req *model.ProjectEnvironmentRequest) (*model.ProjectEnvironment, error)
+	DeleteEnvironment(userID, projectID, environmentID uint) error
+	AddEnvironmentMember(userID, projectID, environmentID uint, req *model.ProjectEnvironmentMemberRequest) (*model.ProjectEnvironmentMember, error)
+	RemoveEnvironmentMember(userID, projectID, environmentID, memberUserID uint) error
+	AssignCSPAccount(userID, projectID, cspAccountID uint, req *model.ProjectCSPAccountEnvironmentRequest) (*model.ProjectCSPAccount, error)
+	AttachProvisionedCSPAccount(creatorID, projectID, cspAccountID, environmentID uint) error
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type InvitationService interface {
+	GetProjectInvitations(userID, projectID uint, status model.InvitationStatus) ([]model.ProjectInvitationResponse, error)
+	CreateInvitation(inviterID, projectID uint, req *model.ProjectInvitationCreateRequest) (*model.ProjectInvitationResponse, error)
+	ResendInvitation(userID, projectID, invitationID uint) (*model.ProjectInvitationResponse, error)
+	RevokeInvitation(userID, projectID, invitationID uint) error
+
+	// 招待リンク経由の操作
+	PreviewInvitation(token string) (*model.InvitationPreviewResponse, error)
+	AcceptInvitation(userID uint, token string) (*model.ProjectInvitationResponse, error)
+	RegisterWithInvitation(req *model.InvitationRegisterRequest) (*model.AuthResponse, error)
+}
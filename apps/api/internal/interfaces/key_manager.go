@@ -0,0 +1,12 @@
+package interfaces
+
+// KeyManager はデータ暗号鍵（DEK）をマスターキーで暗号化・復号するKMSの抽象
+// ローカルの鍵ファイル実装のほか、クラウドKMSの実装に差し替えられる
+type KeyManager interface {
+	// PrimaryKeyID は新規の暗号化に使うマスターキーのID
+	PrimaryKeyID() string
+	// WrapKey はDEKをプライマリのマスターキーで暗号化し、使用したキーIDとともに返す
+	WrapKey(dek []byte) (keyID string, wrapped []byte, err error)
+	// UnwrapKey は指定したキーIDのマスターキーでDEKを復号する
+	UnwrapKey(keyID string, wrapped []byte) ([]byte, error)
+}
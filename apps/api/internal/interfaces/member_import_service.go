@@ -0,0 +1,10 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type MemberImportService interface {
+	ImportProjectMembers(userID, projectID uint, rows [][]string, dryRun bool) (*model.MemberImportResult, error)
+	ExportProjectMembers(userID, projectID uint) ([][]string, error)
+	ImportCSPAccountMembers(userID, projectID uint, rows [][]string, dryRun bool) (*model.MemberImportResult, error)
+	ExportCSPAccountMembers(userID, projectID uint) ([][]string, error)
+}
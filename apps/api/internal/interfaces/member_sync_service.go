@@ -0,0 +1,13 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+// MemberSyncService はCSPアカウントのメンバーをプロバイダーのID基盤（AWS IAM Identity Center・GCP IAM・Azure RBAC）に反映する
+type MemberSyncService interface {
+	SyncCSPAccountMember(memberID uint) (*model.CSPAccountMember, error)
+	// SyncMemberEmail は削除したメンバーや変更前のメールアドレスのロールを外す場合にも使う
+	SyncMemberEmail(cspAccountID uint, email string) error
+	SyncCSPAccountMembers(cspAccountID uint) ([]model.CSPAccountMember, error)
+	// ProcessPendingSync は未反映・反映に失敗したメンバーを反映する（定期実行用）
+	ProcessPendingSync() (int, error)
+}
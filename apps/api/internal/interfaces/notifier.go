@@ -0,0 +1,7 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type Notifier interface {
+	Send(notification *model.Notification) error
+}
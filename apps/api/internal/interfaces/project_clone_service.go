@@ -0,0 +1,7 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type ProjectCloneService interface {
+	CloneProject(userID, sourceProjectID uint, req *model.ProjectCloneRequest) (*model.ProjectResponse, error)
+}
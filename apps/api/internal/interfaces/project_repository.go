@@ -1,29 +1,68 @@
 package interfaces
 
-import "go-nextjs-api/internal/model"
+import (
+	"time"
+
+	"go-nextjs-api/internal/model"
+)
 
 type ProjectRepository interface {
 	// プロジェクト関連
-	SelectUserProjects(userID uint) ([]model.UserProjectResponse, error)
+	SelectUserProjects(userID uint, filter *model.ProjectMetadataFilter) ([]model.UserProjectResponse, error)
+	SearchProjects(userID uint, includeAll bool, query *model.ProjectQuery) ([]model.ProjectListItem, *model.PaginationInfo, error)
 	SelectByID(id uint) (*model.ProjectDetails, error)
-	SelectByType(projectType string) ([]model.Project, error)
 	Insert(project *model.Project) error
+	InsertWithSetup(project *model.Project, members []model.UserProjectRole, vendorRelations []model.ProjectVendorRelation) error
 	Update(project *model.Project) error
-	Delete(id uint) error
+	UpdateStatus(id uint, status model.ProjectStatus, changedBy uint) error
+	Delete(id uint, deletedBy uint) error
+	CountActiveCSPAccounts(projectID uint) (int64, error)
+
+	// ゴミ箱（ソフトデリート済みプロジェクト）関連
+	SelectDeleted(deletedSince time.Time) ([]model.Project, error)
+	SelectDeletedByID(id uint) (*model.Project, error)
+	Restore(id uint) error
 	
 	// プロジェクトメンバー関連
 	SelectProjectMembers(projectID uint, page, limit int) ([]model.ProjectMemberResponse, *model.PaginationInfo, error)
+	SelectAllProjectMembers(projectID uint) ([]model.ProjectMemberResponse, error)
 	SelectUserRole(projectID, userID uint) (string, error)
 	InsertMember(projectID, userID uint, role string) error
 	UpdateMemberRole(projectID, userID uint, role string) error
 	DeleteMember(projectID, userID uint) error
+	DeleteMemberWithCascade(projectID, userID uint, reassignTo *uint) (*model.MemberRemovalSummary, error)
+	TransferOwnership(projectID, fromUserID, toUserID uint, previousOwnerRole model.Role) error
 	CountProjectOwners(projectID uint) (int64, error)
 	IsMember(projectID, userID uint) (bool, error)
 	
 	// ベンダープロジェクト紐付け関連
 	SelectVendorRelationsByProjectID(projectID uint) ([]model.ProjectVendorRelation, error)
+	SelectVendorRelationsForVendorMember(userID uint) ([]model.ProjectVendorRelation, error)
+	SelectVendorRelationByID(relationID uint) (*model.ProjectVendorRelation, error)
+	SelectOpenVendorRelation(projectID, vendorProjectID uint) (*model.ProjectVendorRelation, error)
+	SelectScheduledVendorRelations() ([]model.ProjectVendorRelation, error)
 	InsertVendorRelation(relation *model.ProjectVendorRelation) error
+	UpdateVendorRelation(relation *model.ProjectVendorRelation) error
 	DeleteVendorRelation(relationID uint) error
+	SelectManagerEmails(projectID uint) ([]string, error)
+	SelectOwnerEmails(projectID uint) ([]string, error)
+
+	// ベンダー担当者への委任権限割り当て関連
+	SelectVendorStaffAssignments(relationID uint) ([]model.VendorStaffAssignment, error)
+	SelectVendorStaffAssignment(relationID, userID uint) (*model.VendorStaffAssignment, error)
+	SaveVendorStaffAssignment(assignment *model.VendorStaffAssignment) error
+	DeleteVendorStaffAssignment(id uint) error
+	SelectActiveStaffAssignments(projectID, userID uint) ([]model.VendorStaffAssignment, error)
+	SelectActiveVendorProjectIDsForMember(projectID, userID uint) ([]uint, error)
+
+	// プロジェクト招待関連
+	SelectInvitationsByProjectID(projectID uint, status model.InvitationStatus) ([]model.ProjectInvitation, error)
+	SelectInvitationByID(id uint) (*model.ProjectInvitation, error)
+	SelectPendingInvitationByEmail(projectID uint, email string) (*model.ProjectInvitation, error)
+	InsertInvitation(invitation *model.ProjectInvitation) error
+	UpdateInvitation(invitation *model.ProjectInvitation) error
+	AcceptInvitation(invitation *model.ProjectInvitation, userID uint) error
+	InsertUserWithInvitation(user *model.User, invitation *model.ProjectInvitation) error
 	
 	// 管理者権限関連
 	CheckAdminProjectPermission(userID uint) (*model.ProjectPermissionResponse, error)
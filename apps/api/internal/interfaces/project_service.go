@@ -3,22 +3,25 @@ package interfaces
 import "go-nextjs-api/internal/model"
 
 type ProjectService interface {
-	GetUserProjects(userID uint) ([]model.UserProjectResponse, error)
-	GetProjectsByType(projectType string) ([]model.Project, error)
+	GetAllUserProjects(userID uint, filter *model.ProjectMetadataFilter) ([]model.UserProjectResponse, error)
+	GetUserProjects(userID uint, query *model.ProjectQuery) ([]model.UserProjectResponse, *model.PaginationInfo, error)
+	SearchProjects(userID uint, query *model.ProjectQuery) ([]model.ProjectListItem, *model.PaginationInfo, error)
 	GetProjectByID(projectID uint) (*model.ProjectResponse, error)
 	GetProjectMembers(projectID uint, page, limit int) ([]model.ProjectMemberResponse, int, error)
 	CreateProject(userID uint, req *model.ProjectCreateRequest) (*model.ProjectResponse, error)
 	UpdateProject(userID, projectID uint, req *model.ProjectUpdateRequest) (*model.ProjectResponse, error)
 	DeleteProject(userID, projectID uint) error
+	TransitionProjectStatus(userID, projectID uint, status model.ProjectStatus) (*model.ProjectResponse, error)
 	AddUserToProject(projectID, userID uint, role model.Role) error
 	UpdateUserProjectRole(projectID, userID uint, role model.Role) error
-	RemoveUserFromProject(projectID, userID uint) error
+	RemoveUserFromProject(projectID, userID uint, req *model.MemberRemovalRequest) (*model.MemberRemovalSummary, error)
+	TransferOwnership(currentOwnerID, projectID uint, req *model.OwnershipTransferRequest) error
 	CheckProjectPermission(userID, projectID uint) (*model.ProjectPermissionResponse, error)
+	CheckProjectPermissionByEmail(email string, projectID uint) (*model.ProjectPermissionResponse, error)
 	CheckAdminProjectPermission(userID uint, projectID uint) (*model.ProjectPermissionResponse, error)
 	CanUserManageProject(userID, projectID uint) (bool, error)
 	
-	// ベンダープロジェクト紐付け関連
-	GetVendorRelations(userID, projectID uint) ([]model.ProjectVendorRelation, error)
-	CreateVendorRelation(userID, projectID, vendorProjectID uint) error
-	DeleteVendorRelation(userID, projectID, relationID uint) error
+	// ゴミ箱関連（管理者用）
+	GetTrashedProjects() ([]model.TrashedProjectResponse, error)
+	RestoreProject(adminID, projectID uint) (*model.ProjectResponse, error)
 }
\ No newline at end of file
@@ -0,0 +1,12 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type ProjectTemplateRepository interface {
+	SelectAll() ([]model.ProjectTemplate, error)
+	SelectByID(id uint) (*model.ProjectTemplate, error)
+	SelectByName(name string) (*model.ProjectTemplate, error)
+	Insert(template *model.ProjectTemplate) error
+	Update(template *model.ProjectTemplate) error
+	Delete(id uint) error
+}
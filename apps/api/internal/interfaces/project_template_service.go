@@ -0,0 +1,11 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type ProjectTemplateService interface {
+	GetTemplates() ([]model.ProjectTemplate, error)
+	GetTemplate(templateID uint) (*model.ProjectTemplate, error)
+	CreateTemplate(adminID uint, req *model.ProjectTemplateRequest) (*model.ProjectTemplate, error)
+	UpdateTemplate(templateID uint, req *model.ProjectTemplateRequest) (*model.ProjectTemplate, error)
+	DeleteTemplate(templateID uint) error
+}
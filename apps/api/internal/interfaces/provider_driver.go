@@ -0,0 +1,38 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+// ProviderDriver はCSPプロバイダーでアカウントを実際に操作するドライバー
+// AWSアカウント・GCPプロジェクト・Azureサブスクリプションをそれぞれ「アカウント」として扱う
+type ProviderDriver interface {
+	Provider() model.CSPProvider
+	DefaultRegion() string
+	CreateAccount(input *model.ProviderAccountInput) (*model.ProviderAccount, error)
+	GetAccount(accountID string) (*model.ProviderAccount, error)
+	CloseAccount(accountID string) error
+	// RotateCredentials は新しい認証情報を発行し、keepKeyID以外の古い鍵を削除する
+	// keepKeyIDの鍵（使用中の鍵）はローテーションの猶予期間中も使えるよう残す
+	RotateCredentials(accountID, keepKeyID string) (*model.ProviderCredentials, error)
+	// RevokeCredentials は猶予期間を過ぎた鍵を削除する（既に存在しない場合は何もしない）
+	RevokeCredentials(accountID, keyID string) error
+	// IssueTemporaryCredentials はメンバーのロールに応じた権限の短期の認証情報を発行する
+	IssueTemporaryCredentials(accountID string, input *model.TemporaryCredentialInput) (*model.TemporaryCredentials, error)
+	// GetCurrentMonthCost は当月（UTC）の月初から現在までに発生した費用を返す
+	// プロバイダーのAPIで取得できない場合はmodel.ErrProviderCostNotAvailableを返す
+	GetCurrentMonthCost(accountID string) (*model.ProviderCost, error)
+
+	// ListAccounts はプラットフォームの管理範囲（組織・フォルダなど）にあるアカウントの一覧を返す
+	ListAccounts() ([]model.ProviderAccount, error)
+	// ListAccountMembers はアカウントでプラットフォームのロールを付与されているユーザーの一覧を返す
+	// メンバーの管理が設定されていない場合はmodel.ErrProviderMembersNotSupportedを返す
+	ListAccountMembers(accountID string) ([]model.ProviderAccountMember, error)
+	// GrantAccountMember はユーザーにロールを付与する（別のプラットフォームのロールが付与されている場合は置き換える）
+	GrantAccountMember(accountID string, member *model.ProviderAccountMember) error
+	// RevokeAccountMember はユーザーからプラットフォームのロールをすべて外す
+	RevokeAccountMember(accountID, email string) error
+}
+
+// ProviderDrivers はプロバイダーごとのドライバーの集合
+type ProviderDrivers interface {
+	Driver(provider model.CSPProvider) (ProviderDriver, error)
+}
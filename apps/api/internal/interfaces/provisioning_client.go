@@ -0,0 +1,11 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+// ProvisioningClient はCSPプロビジョニングサービスへの内部APIクライアント
+type ProvisioningClient interface {
+	CountPendingCSPRequests(projectID uint) (int, error)
+	CreateCSPRequest(projectID uint, requestedBy string, request *model.TemplateCSPRequest) error
+	ListPendingCSPRequests(projectID uint) ([]model.PendingCSPRequest, error)
+	ListCSPRequestReferences(projectID uint, email string) ([]model.CSPRequestReference, error)
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type ReconciliationRepository interface {
+	// CSPReconciliationRun related methods
+	InsertRun(run *model.CSPReconciliationRun) error
+	UpdateRun(run *model.CSPReconciliationRun) error
+	SelectRuns(limit int) ([]model.CSPReconciliationRun, error)
+
+	// CSPDriftItem related methods
+	SelectDriftItems(filter *model.CSPDriftFilter) ([]model.CSPDriftItem, error)
+	SelectDriftItemByID(id uint) (*model.CSPDriftItem, error)
+	SelectDriftItemsByProvider(provider model.CSPProvider) ([]model.CSPDriftItem, error)
+	InsertDriftItem(item *model.CSPDriftItem) error
+	UpdateDriftItem(item *model.CSPDriftItem) error
+	// ResolveDriftItem はドリフトが未対応のままである場合のみ対応結果を記録する
+	ResolveDriftItem(item *model.CSPDriftItem) error
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type ReconciliationService interface {
+	// RunReconciliation はプロバイダーの棚卸しを実行し、ドリフトを記録する（providerが空の場合はすべてのプロバイダー）
+	RunReconciliation(provider model.CSPProvider, triggeredBy *uint) ([]model.CSPReconciliationRun, error)
+	GetRuns() ([]model.CSPReconciliationRun, error)
+	GetDriftItems(filter *model.CSPDriftFilter) ([]model.CSPDriftItem, error)
+	ResolveDriftItem(id, adminID uint, req *model.CSPDriftResolveRequest) (*model.CSPDriftItem, error)
+}
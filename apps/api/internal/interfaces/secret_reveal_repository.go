@@ -0,0 +1,15 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type SecretRevealRepository interface {
+	SelectByID(id uint) (*model.CSPSecretReveal, error)
+	SelectByCSPAccountID(cspAccountID uint) ([]model.CSPSecretReveal, error)
+	// Insert は開示申請を作成し、監査記録をauditKeyでハッシュチェーンにつないで追記する
+	Insert(reveal *model.CSPSecretReveal, entry *model.SecretRevealAuditEntry, auditKey []byte) error
+	// Transition は開示申請がfromのステータスのままである場合のみ更新し、監査記録を追記する
+	Transition(reveal *model.CSPSecretReveal, from model.SecretRevealStatus, entry *model.SecretRevealAuditEntry, auditKey []byte) error
+	SelectAuditEntries() ([]model.SecretRevealAuditEntry, error)
+	// SelectAuditHead は保存した監査記録の先頭を取得（まだ記録がない場合はnil）
+	SelectAuditHead() (*model.SecretRevealAuditHead, error)
+}
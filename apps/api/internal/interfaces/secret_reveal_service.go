@@ -0,0 +1,12 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type SecretRevealService interface {
+	RequestReveal(cspAccountID, requesterID uint, req *model.SecretRevealCreateRequest, sourceIP string) (*model.CSPSecretReveal, error)
+	ApproveReveal(revealID, approverID uint, sourceIP string) (*model.CSPSecretReveal, error)
+	RejectReveal(revealID, approverID uint, req *model.SecretRevealRejectRequest, sourceIP string) (*model.CSPSecretReveal, error)
+	Reveal(revealID, requesterID uint, sourceIP string) (*model.RevealedSecret, error)
+	GetReveals(cspAccountID uint) ([]model.CSPSecretReveal, error)
+	VerifyAuditLog() (*model.SecretRevealAuditVerification, error)
+}
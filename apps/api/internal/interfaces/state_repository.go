@@ -0,0 +1,8 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type StateRepository interface {
+	LoadSnapshot(emails []string) (*model.StateSnapshot, error)
+	ApplyPlan(plan *model.StatePlan, actorID uint) error
+}
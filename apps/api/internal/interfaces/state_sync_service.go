@@ -0,0 +1,8 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type StateSyncService interface {
+	Plan(data []byte, prune bool) (*model.StatePlan, error)
+	Apply(actorID uint, data []byte, prune bool, expectedFingerprint string) (*model.StatePlan, error)
+}
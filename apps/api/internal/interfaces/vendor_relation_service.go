@@ -0,0 +1,25 @@
+package interfaces
+
+import "go-nextjs-api/internal/model"
+
+type VendorRelationService interface {
+	GetVendorRelations(userID, projectID uint) ([]model.ProjectVendorRelation, error)
+	ProposeVendorRelation(userID, projectID uint, req *model.VendorRelationCreateRequest) (*model.ProjectVendorRelation, error)
+	AcceptVendorRelation(userID, vendorProjectID, relationID uint) (*model.ProjectVendorRelation, error)
+	DeclineVendorRelation(userID, vendorProjectID, relationID uint, reason string) (*model.ProjectVendorRelation, error)
+	TerminateVendorRelation(userID, projectID, relationID uint, reason string) (*model.ProjectVendorRelation, error)
+	WithdrawVendorRelation(userID, projectID, relationID uint) error
+
+	// 委任権限関連
+	UpdateAccessGrant(userID, projectID, relationID uint, req *model.VendorAccessGrantRequest) (*model.ProjectVendorRelation, error)
+	GetStaffAssignments(userID, projectID, relationID uint) ([]model.VendorStaffAssignment, error)
+	AssignStaff(userID, vendorProjectID, relationID uint, req *model.VendorStaffAssignmentRequest) (*model.VendorStaffAssignment, error)
+	RemoveStaff(userID, vendorProjectID, relationID, staffUserID uint) error
+	GetDelegatedAccess(userID, projectID uint) (*model.DelegatedAccess, error)
+
+	// ベンダー担当者のポートフォリオ（全ての依頼元プロジェクト）
+	GetVendorPortfolio(userID uint) (*model.VendorPortfolio, error)
+
+	// 契約期間による自動遷移（定期実行）
+	ProcessScheduledTransitions() (int, error)
+}
@@ -0,0 +1,140 @@
+package kms
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-nextjs-api/internal/interfaces"
+)
+
+// 暗号化関連のエラー
+var (
+	ErrUnknownKey          = errors.New("unknown master key")
+	ErrMalformedCiphertext = errors.New("malformed ciphertext")
+	ErrDecryptionFailed    = errors.New("failed to decrypt value")
+)
+
+// envelopePrefix は暗号化済みの値の先頭に付けるプレフィックス（形式のバージョンを含む）
+// v2は暗号文を保存先（テーブル・カラム・行）に結び付ける。v1は結び付けのない旧形式で、復号のみ対応する
+const (
+	envelopePrefix       = "enc:v2:"
+	legacyEnvelopePrefix = "enc:v1:"
+)
+
+// dataKeySize はデータ暗号鍵（DEK）の長さ（AES-256）
+const dataKeySize = 32
+
+// Envelope はエンベロープ暗号化を行う
+// 値ごとにランダムなDEKで暗号化し、DEKはKMSのマスターキーで暗号化して値と一緒に保存する
+// 暗号文は保存先を表す追加認証データ（AAD）に結び付け、別の行・カラムに移し替えた値は復号できないようにする
+//
+// 保存形式: enc:v2:<base64(キーID)>:<base64(暗号化したDEK)>:<base64(暗号文)>
+type Envelope struct {
+	keyManager interfaces.KeyManager
+}
+
+// NewEnvelope はKMSを使うエンベロープ暗号化を作成
+func NewEnvelope(keyManager interfaces.KeyManager) *Envelope {
+	return &Envelope{keyManager: keyManager}
+}
+
+// ColumnAAD は暗号文を結び付ける保存先（テーブル・カラム・行のID）を表す追加認証データ
+func ColumnAAD(table, column string, id uint) string {
+	return fmt.Sprintf("%s.%s:%d", table, column, id)
+}
+
+// Encrypt は平文を暗号化して保存用の文字列を返す（aadは復号時にも同じ値を指定する）
+func (e *Envelope) Encrypt(plaintext, aad string) (string, error) {
+	dek := make([]byte, dataKeySize)
+	if _, err := rand.Read(dek); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := seal(aead, []byte(plaintext), []byte(aad))
+	if err != nil {
+		return "", err
+	}
+
+	keyID, wrapped, err := e.keyManager.WrapKey(dek)
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	return envelopePrefix + strings.Join([]string{
+		base64.RawURLEncoding.EncodeToString([]byte(keyID)),
+		base64.RawURLEncoding.EncodeToString(wrapped),
+		base64.RawURLEncoding.EncodeToString(ciphertext),
+	}, ":"), nil
+}
+
+// Decrypt は保存用の文字列を復号する（aadが暗号化時と異なる場合は失敗する）
+// 暗号化導入前の平文の値と旧形式（v1）の値はそのまま読み込める（cmd/migrateの再暗号化でv2に移行される）
+func (e *Envelope) Decrypt(value, aad string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	var additionalData []byte
+	if !strings.HasPrefix(value, legacyEnvelopePrefix) {
+		additionalData = []byte(aad)
+	}
+
+	keyID, wrapped, ciphertext, err := parseEnvelope(value)
+	if err != nil {
+		return "", err
+	}
+
+	dek, err := e.keyManager.UnwrapKey(keyID, wrapped)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(aead, ciphertext, additionalData)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// NeedsReencryption は値がプライマリ以外のマスターキーで暗号化されているか（または平文・旧形式か）を判定
+func (e *Envelope) NeedsReencryption(value string) bool {
+	if !strings.HasPrefix(value, envelopePrefix) {
+		return true
+	}
+	keyID, _, _, err := parseEnvelope(value)
+	return err != nil || keyID != e.keyManager.PrimaryKeyID()
+}
+
+// IsEncrypted は値がエンベロープ暗号化済みかどうかを判定（旧形式を含む）
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, envelopePrefix) || strings.HasPrefix(value, legacyEnvelopePrefix)
+}
+
+// parseEnvelope は保存用の文字列をキーID・暗号化したDEK・暗号文に分解
+func parseEnvelope(value string) (string, []byte, []byte, error) {
+	value = strings.TrimPrefix(strings.TrimPrefix(value, envelopePrefix), legacyEnvelopePrefix)
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return "", nil, nil, ErrMalformedCiphertext
+	}
+
+	decoded := make([][]byte, len(parts))
+	for i, part := range parts {
+		bytes, err := base64.RawURLEncoding.DecodeString(part)
+		if err != nil {
+			return "", nil, nil, ErrMalformedCiphertext
+		}
+		decoded[i] = bytes
+	}
+	return string(decoded[0]), decoded[1], decoded[2], nil
+}
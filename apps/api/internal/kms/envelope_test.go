@@ -0,0 +1,105 @@
+package kms
+
+import (
+	"encoding/base64"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestEnvelope(t *testing.T) *Envelope {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "master.key")
+	if err := GenerateKeyFile(path); err != nil {
+		t.Fatalf("GenerateKeyFile: %v", err)
+	}
+	keyManager, err := NewLocalKeyManager(path)
+	if err != nil {
+		t.Fatalf("NewLocalKeyManager: %v", err)
+	}
+	return NewEnvelope(keyManager)
+}
+
+func TestEnvelopeBindsCiphertextToColumn(t *testing.T) {
+	envelope := newTestEnvelope(t)
+	aad := ColumnAAD("csp_accounts", "secret_key", 1)
+
+	encrypted, err := envelope.Encrypt("secret", aad)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if !strings.HasPrefix(encrypted, envelopePrefix) {
+		t.Errorf("Encrypt = %q, want prefix %q", encrypted, envelopePrefix)
+	}
+
+	plaintext, err := envelope.Decrypt(encrypted, aad)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if plaintext != "secret" {
+		t.Errorf("Decrypt = %q, want %q", plaintext, "secret")
+	}
+
+	for _, other := range []string{
+		ColumnAAD("csp_accounts", "secret_key", 2),
+		ColumnAAD("csp_accounts", "access_key", 1),
+	} {
+		if _, err := envelope.Decrypt(encrypted, other); !errors.Is(err, ErrDecryptionFailed) {
+			t.Errorf("Decrypt with %q error = %v, want %v", other, err, ErrDecryptionFailed)
+		}
+	}
+}
+
+func TestEnvelopeReadsLegacyValues(t *testing.T) {
+	envelope := newTestEnvelope(t)
+
+	// 旧形式（v1）は追加認証データなしで暗号化されている
+	dek := make([]byte, dataKeySize)
+	aead, err := newAEAD(dek)
+	if err != nil {
+		t.Fatalf("newAEAD: %v", err)
+	}
+	ciphertext, err := seal(aead, []byte("legacy"), nil)
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+	keyID, wrapped, err := envelope.keyManager.WrapKey(dek)
+	if err != nil {
+		t.Fatalf("WrapKey: %v", err)
+	}
+	legacy := legacyEnvelopePrefix + strings.Join([]string{
+		base64.RawURLEncoding.EncodeToString([]byte(keyID)),
+		base64.RawURLEncoding.EncodeToString(wrapped),
+		base64.RawURLEncoding.EncodeToString(ciphertext),
+	}, ":")
+	current, err := envelope.Encrypt("legacy", "aad")
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	plaintext, err := envelope.Decrypt(legacy, ColumnAAD("csp_accounts", "access_key", 1))
+	if err != nil {
+		t.Fatalf("Decrypt legacy value: %v", err)
+	}
+	if plaintext != "legacy" {
+		t.Errorf("Decrypt legacy value = %q, want %q", plaintext, "legacy")
+	}
+	if !envelope.NeedsReencryption(legacy) {
+		t.Error("NeedsReencryption(legacy) = false, want true")
+	}
+	if envelope.NeedsReencryption(current) {
+		t.Error("NeedsReencryption(current) = true, want false")
+	}
+	if !envelope.NeedsReencryption("plaintext") {
+		t.Error("NeedsReencryption(plaintext) = false, want true")
+	}
+}
+
+func TestKeyFilesFromEnvRequiresKeyFile(t *testing.T) {
+	t.Setenv("KMS_KEY_FILE", "")
+	t.Setenv("KMS_DEV_KEY", "")
+	if _, _, err := KeyFilesFromEnv(); err == nil {
+		t.Fatal("KeyFilesFromEnv succeeded without KMS_KEY_FILE, want an error")
+	}
+}
@@ -0,0 +1,195 @@
+package kms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go-nextjs-api/internal/interfaces"
+)
+
+// DefaultKeyFile はKMS_KEY_FILE未設定時に使う開発用の鍵ファイル
+const DefaultKeyFile = ".keys/master.key"
+
+// masterKeySize はマスターキーの長さ（AES-256）
+const masterKeySize = 32
+
+// NewKeyManager は環境変数に応じたKMS実装を返す
+// 現在はローカル鍵ファイル実装のみ対応（KMS_PROVIDER=local）
+func NewKeyManager() (interfaces.KeyManager, error) {
+	provider := os.Getenv("KMS_PROVIDER")
+	if provider != "" && provider != "local" {
+		return nil, fmt.Errorf("unsupported KMS provider: %s", provider)
+	}
+
+	primary, previous, err := KeyFilesFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewLocalKeyManager(primary, previous...)
+}
+
+// KeyFilesFromEnv は環境変数から現在の鍵ファイルとローテーション前の鍵ファイルの一覧を取得
+// KMS_KEY_FILEが未設定の場合は、KMS_DEV_KEY=trueのときだけ開発用の鍵ファイルを使い、存在しなければ生成する
+// 作業ディレクトリに生成した鍵はコンテナの再起動で失われ、暗号化した値を復号できなくなるため、それ以外はエラーにする
+func KeyFilesFromEnv() (string, []string, error) {
+	keyFile := os.Getenv("KMS_KEY_FILE")
+	if keyFile == "" {
+		if os.Getenv("KMS_DEV_KEY") != "true" {
+			return "", nil, errors.New("KMS_KEY_FILE is not set; set it to a persistent master key file (or KMS_DEV_KEY=true for development)")
+		}
+		keyFile = DefaultKeyFile
+		if _, err := os.Stat(keyFile); errors.Is(err, os.ErrNotExist) {
+			log.Printf("⚠️  KMS_KEY_FILE is not set, generating development master key at %s", keyFile)
+			if err := GenerateKeyFile(keyFile); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+
+	return keyFile, splitKeyFiles(os.Getenv("KMS_PREVIOUS_KEY_FILES")), nil
+}
+
+// splitKeyFiles はカンマ区切りの鍵ファイル一覧を分割
+func splitKeyFiles(value string) []string {
+	var paths []string
+	for _, path := range strings.Split(value, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// localKeyManager はローカルの鍵ファイルをマスターキーとして使うKMS実装（開発・テスト用）
+type localKeyManager struct {
+	primaryID string
+	keys      map[string]cipher.AEAD
+}
+
+// NewLocalKeyManager は鍵ファイルからKMSを作成
+// primaryPathの鍵で新規の暗号化を行い、previousPathsの鍵はローテーション前のデータの復号にのみ使う
+func NewLocalKeyManager(primaryPath string, previousPaths ...string) (interfaces.KeyManager, error) {
+	km := &localKeyManager{keys: make(map[string]cipher.AEAD)}
+
+	for i, path := range append([]string{primaryPath}, previousPaths...) {
+		keyID, aead, err := loadKeyFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			km.primaryID = keyID
+		}
+		km.keys[keyID] = aead
+	}
+
+	return km, nil
+}
+
+// loadKeyFile は鍵ファイル（base64エンコードした32バイトの鍵）を読み込む
+func loadKeyFile(path string) (string, cipher.AEAD, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read master key file %s: %w", path, err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid master key file %s: %w", path, err)
+	}
+	if len(key) != masterKeySize {
+		return "", nil, fmt.Errorf("invalid master key file %s: key must be %d bytes", path, masterKeySize)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// キーIDは鍵のフィンガープリントから導出する（鍵そのものは含まない）
+	fingerprint := sha256.Sum256(key)
+	return "local-" + hex.EncodeToString(fingerprint[:8]), aead, nil
+}
+
+// GenerateKeyFile はランダムなマスターキーを生成して鍵ファイルに書き込む（既存のファイルは上書きしない）
+func GenerateKeyFile(path string) error {
+	key := make([]byte, masterKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return fmt.Errorf("failed to generate master key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create master key directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create master key file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(base64.StdEncoding.EncodeToString(key) + "\n"); err != nil {
+		return fmt.Errorf("failed to write master key file %s: %w", path, err)
+	}
+	return nil
+}
+
+func (km *localKeyManager) PrimaryKeyID() string {
+	return km.primaryID
+}
+
+func (km *localKeyManager) WrapKey(dek []byte) (string, []byte, error) {
+	wrapped, err := seal(km.keys[km.primaryID], dek, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	return km.primaryID, wrapped, nil
+}
+
+func (km *localKeyManager) UnwrapKey(keyID string, wrapped []byte) ([]byte, error) {
+	aead, ok := km.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+	return open(aead, wrapped, nil)
+}
+
+// newAEAD はAES-256-GCMの暗号器を作成
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal はランダムなnonceで暗号化し、nonceを先頭に付けて返す（additionalDataは暗号文に含めずに認証する）
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open はsealで暗号化したデータを復号（additionalDataは暗号化時と同じ値を指定する）
+func open(aead cipher.AEAD, data, additionalData []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, ErrMalformedCiphertext
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	return plaintext, nil
+}
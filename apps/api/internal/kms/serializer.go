@@ -0,0 +1,109 @@
+package kms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+
+	"gorm.io/gorm/schema"
+)
+
+// SerializerName はモデルのタグで指定するシリアライザ名（gorm:"serializer:encrypted"）
+const SerializerName = "encrypted"
+
+// ErrEncryptionNotConfigured はUseEnvelopeを呼ばずに暗号化カラムを読み書きした場合のエラー
+var ErrEncryptionNotConfigured = errors.New("column encryption is not configured")
+
+// ErrMissingPrimaryKey は行のIDが分からない状態で暗号化カラムを読み書きした場合のエラー
+// （暗号文を行に結び付けるため、保存前にIDを採番し、読み込み時はIDも選択する必要がある）
+var ErrMissingPrimaryKey = errors.New("encrypted column requires the primary key of the row")
+
+// activeEnvelope は暗号化カラムの読み書きに使うエンベロープ暗号化
+var activeEnvelope atomic.Pointer[Envelope]
+
+func init() {
+	schema.RegisterSerializer(SerializerName, encryptedSerializer{})
+}
+
+// UseEnvelope は暗号化カラムの読み書きに使うエンベロープ暗号化を設定
+func UseEnvelope(envelope *Envelope) {
+	activeEnvelope.Store(envelope)
+}
+
+// encryptedSerializer は文字列カラムを保存時に暗号化し、読み込み時に復号するGORMシリアライザ
+// リポジトリやPreloadから読み込んだ値は常に平文になる
+// 暗号文はテーブル・カラム・行のIDに結び付けるため、IDは暗号化カラムより先に読み込まれている必要がある
+type encryptedSerializer struct{}
+
+// columnAAD は暗号化カラムの値を結び付ける追加認証データ（テーブル・カラム・行のID）を返す
+func columnAAD(ctx context.Context, field *schema.Field, dst reflect.Value) (string, error) {
+	primaryField := field.Schema.PrioritizedPrimaryField
+	if primaryField == nil {
+		return "", ErrMissingPrimaryKey
+	}
+	id, isZero := primaryField.ValueOf(ctx, dst)
+	if isZero {
+		return "", fmt.Errorf("%w: %s.%s", ErrMissingPrimaryKey, field.Schema.Table, field.DBName)
+	}
+	var rowID uint
+	switch v := id.(type) {
+	case uint:
+		rowID = v
+	default:
+		return "", fmt.Errorf("%w: unsupported primary key type %T", ErrMissingPrimaryKey, id)
+	}
+	return ColumnAAD(field.Schema.Table, field.DBName, rowID), nil
+}
+
+func (encryptedSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var value string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("failed to scan encrypted column %s: %#v", field.DBName, dbValue)
+	}
+
+	if value != "" {
+		envelope := activeEnvelope.Load()
+		if envelope == nil {
+			return ErrEncryptionNotConfigured
+		}
+		aad, err := columnAAD(ctx, field, dst)
+		if err != nil {
+			return err
+		}
+		plaintext, err := envelope.Decrypt(value, aad)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt column %s: %w", field.DBName, err)
+		}
+		value = plaintext
+	}
+
+	return field.Set(ctx, dst, value)
+}
+
+func (encryptedSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	value, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("encrypted column %s must be a string", field.DBName)
+	}
+	if value == "" {
+		return "", nil
+	}
+
+	envelope := activeEnvelope.Load()
+	if envelope == nil {
+		return nil, ErrEncryptionNotConfigured
+	}
+	aad, err := columnAAD(ctx, field, dst)
+	if err != nil {
+		return nil, err
+	}
+	return envelope.Encrypt(value, aad)
+}
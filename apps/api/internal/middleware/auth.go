@@ -65,13 +65,14 @@ func GenerateJWT(user model.User) (string, error) {
 func ValidateJWT(tokenString string) (*JWTClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
 		return jwtSecret, nil
-	})
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
 	if err != nil {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid {
+	// ユーザーIDを持たないトークン（招待トークンなど）はセッションとして受け付けない
+	if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid && claims.UserID != 0 {
 		return claims, nil
 	}
 
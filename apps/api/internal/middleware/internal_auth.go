@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InternalTokenHeader はサービス間通信で共有シークレットを送るヘッダー
+const InternalTokenHeader = "X-Internal-Token"
+
+// InternalAuthMiddleware は内部API（マイクロサービス間通信）の呼び出し元を共有シークレットで認証するミドルウェア
+// INTERNAL_API_TOKENが未設定の場合は、誰でも呼び出せる状態にしないためすべて拒否する
+func InternalAuthMiddleware() gin.HandlerFunc {
+	expected := os.Getenv("INTERNAL_API_TOKEN")
+	return func(c *gin.Context) {
+		if expected == "" {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Internal API is not configured"})
+			c.Abort()
+			return
+		}
+
+		token := c.GetHeader(InternalTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid internal API token"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// invitationTokenAudience は招待トークンのaudience（セッショントークンと区別する）
+const invitationTokenAudience = "project_invitation"
+
+// invitationSecret は招待トークンの署名鍵
+// セッショントークンとは別の鍵にし、招待トークンがセッションとして受け付けられないようにする
+var invitationSecret = deriveInvitationSecret(jwtSecret)
+
+func deriveInvitationSecret(secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(invitationTokenAudience))
+	return mac.Sum(nil)
+}
+
+// InvitationClaims はプロジェクト招待トークンのクレーム
+type InvitationClaims struct {
+	InvitationID uint   `json:"invitation_id"`
+	Email        string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+// GenerateInvitationToken は招待リンクに埋め込む署名付きトークンを生成
+// tokenIDはDBに保存されたIDと照合され、再送や取り消しで古いトークンを無効化する
+func GenerateInvitationToken(invitationID uint, email, tokenID string, expiresAt time.Time) (string, error) {
+	claims := InvitationClaims{
+		InvitationID: invitationID,
+		Email:        email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Subject:   "project_invitation",
+			Audience:  jwt.ClaimStrings{invitationTokenAudience},
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(invitationSecret)
+}
+
+// ValidateInvitationToken は招待トークンの署名と有効期限を検証
+func ValidateInvitationToken(tokenString string) (*InvitationClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &InvitationClaims{}, func(token *jwt.Token) (interface{}, error) {
+		return invitationSecret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithAudience(invitationTokenAudience))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if claims, ok := token.Claims.(*InvitationClaims); ok && token.Valid && claims.Subject == "project_invitation" {
+		return claims, nil
+	}
+
+	return nil, jwt.ErrTokenInvalidClaims
+}
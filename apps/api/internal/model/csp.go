@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -10,27 +11,19 @@ import (
 type CSPProvider string
 
 // クラウドサービスプロバイダー定数
+// 表示名・アカウントIDの形式・リージョン・対応機能はプロバイダーレジストリ（csp_provider_registry.go）で定義する
 const (
-	CSPProviderAWS   CSPProvider = "aws"
-	CSPProviderGCP   CSPProvider = "gcp"
-	CSPProviderAzure CSPProvider = "azure"
+	CSPProviderAWS    CSPProvider = "aws"
+	CSPProviderGCP    CSPProvider = "gcp"
+	CSPProviderAzure  CSPProvider = "azure"
+	CSPProviderOCI    CSPProvider = "oci"
+	CSPProviderSakura CSPProvider = "sakura"
 )
 
-// ValidCSPProviders は有効なCSPプロバイダーの一覧
-var ValidCSPProviders = []CSPProvider{
-	CSPProviderAWS,
-	CSPProviderGCP,
-	CSPProviderAzure,
-}
-
-// IsValid はCSPプロバイダーが有効かどうかをチェック
+// IsValid はCSPプロバイダーがレジストリに登録されているかどうかをチェック
 func (cp CSPProvider) IsValid() bool {
-	for _, validProvider := range ValidCSPProviders {
-		if cp == validProvider {
-			return true
-		}
-	}
-	return false
+	_, ok := LookupCSPProvider(cp)
+	return ok
 }
 
 // String はCSPプロバイダーの文字列表現を返す
@@ -38,21 +31,40 @@ func (cp CSPProvider) String() string {
 	return string(cp)
 }
 
+// CSPAccountAttributes はプロバイダー固有のアカウント属性（キーはプロバイダーレジストリの属性定義のKey）
+type CSPAccountAttributes map[string]string
+
 // CSPAccount はCSPアカウント情報を表す構造体
 type CSPAccount struct {
 	ID            uint           `json:"id" gorm:"primaryKey"`
 	Provider      CSPProvider    `json:"provider" gorm:"not null;type:varchar(50)" validate:"required"`
 	AccountName   string         `json:"account_name" gorm:"not null;size:255" validate:"required,min=1,max=255"`
 	AccountID     string         `json:"account_id" gorm:"not null;size:255" validate:"required"` // CSPプロバイダーでのアカウントID
-	AccessKey     string         `json:"access_key" gorm:"not null;size:255"`
-	SecretKey     string         `json:"-" gorm:"not null;size:255"` // JSONには含めない（セキュリティ）
+	AccessKey     string         `json:"-" gorm:"not null;type:text;serializer:encrypted"`          // JSONには含めない（メンバーには一時的な認証情報を払い出す）、エンベロープ暗号化して保存
+	SecretKey     string         `json:"-" gorm:"not null;type:text;serializer:encrypted"`          // JSONには含めない（セキュリティ）、エンベロープ暗号化して保存
 	Region        string         `json:"region" gorm:"size:100"`
-	Status        string         `json:"status" gorm:"not null;default:'active';size:50"`
+	Attributes    CSPAccountAttributes `json:"attributes" gorm:"type:jsonb;serializer:json"` // プロバイダー固有の属性（レジストリのスキーマで検証）
+	Status        CSPAccountStatus `json:"status" gorm:"not null;default:'active';size:50"` // ライフサイクルの遷移でのみ更新する
 	CreatedBy     uint           `json:"created_by" gorm:"not null;index"` // 作成者（管理者）
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
 	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
 
+	// 認証情報のローテーション（状態はローテーションでのみ更新する）
+	CredentialMaxAgeDays             *int       `json:"credential_max_age_days"`                     // 認証情報の最大有効日数（未設定の場合は自動ローテーションしない）
+	CredentialKeyID                  string     `json:"-" gorm:"size:255"`                           // プロバイダー側の現在の鍵の識別子
+	CredentialIssuedAt               *time.Time `json:"credential_issued_at"`                        // 現在の認証情報の発行日時
+	PreviousCredentialKeyID          string     `json:"-" gorm:"size:255"`                           // 猶予期間中の旧鍵の識別子
+	PreviousCredentialExpiresAt      *time.Time `json:"previous_credential_expires_at" gorm:"index"` // 旧鍵を失効させる日時
+	CredentialRotationFailedAt       *time.Time `json:"credential_rotation_failed_at"`               // ローテーションが失敗し続けている場合の最後の失敗日時（成功すると解除）
+	PreviousCredentialRevokeFailedAt *time.Time `json:"previous_credential_revoke_failed_at"`        // 旧鍵の失効が失敗し続けている場合の最後の失敗日時（成功すると解除）
+
+	// ライフサイクル（閉鎖後も記録として保持する）
+	StatusReason    string     `json:"status_reason" gorm:"type:text"` // 直近のステータス変更の理由
+	StatusChangedAt *time.Time `json:"status_changed_at"`
+	ClosedAt        *time.Time `json:"closed_at"`
+	ClosedBy        *uint      `json:"closed_by"`
+
 	// リレーション
 	CreatedByUser User               `json:"created_by_user,omitempty" gorm:"foreignKey:CreatedBy"`
 	ProjectCSPAccounts []ProjectCSPAccount `json:"project_csp_accounts,omitempty" gorm:"foreignKey:CSPAccountID"`
@@ -68,6 +80,7 @@ type ProjectCSPAccount struct {
 	ID           uint           `json:"id" gorm:"primaryKey"`
 	ProjectID    uint           `json:"project_id" gorm:"not null;index"`
 	CSPAccountID uint           `json:"csp_account_id" gorm:"not null;index"`
+	EnvironmentID *uint         `json:"environment_id" gorm:"index"` // 所属する環境（未設定の場合はプロジェクト直下）
 	CreatedBy    uint           `json:"created_by" gorm:"not null;index"` // 関連付け実行者
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
@@ -76,6 +89,7 @@ type ProjectCSPAccount struct {
 	// リレーション
 	Project    Project    `json:"project,omitempty" gorm:"foreignKey:ProjectID"`
 	CSPAccount CSPAccount `json:"csp_account,omitempty" gorm:"foreignKey:CSPAccountID"`
+	Environment *ProjectEnvironment `json:"environment,omitempty" gorm:"foreignKey:EnvironmentID"`
 	CreatedByUser User     `json:"created_by_user,omitempty" gorm:"foreignKey:CreatedBy"`
 }
 
@@ -100,6 +114,12 @@ type CSPAccountMember struct {
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
 
+	// プロバイダーのID基盤への反映状況（メンバーの変更時と定期実行で反映する）
+	SyncStatus      CSPAccountMemberSyncStatus `json:"sync_status" gorm:"not null;default:'pending';size:20;index"`
+	SyncError       string                     `json:"sync_error,omitempty" gorm:"type:text"` // 直近の反映に失敗した理由
+	SyncAttemptedAt *time.Time                 `json:"sync_attempted_at"`
+	SyncedAt        *time.Time                 `json:"synced_at"` // 直近に反映できた日時
+
 	// リレーション
 	CSPAccount CSPAccount `json:"csp_account,omitempty" gorm:"foreignKey:CSPAccountID"`
 	Project    Project    `json:"project,omitempty" gorm:"foreignKey:ProjectID"`
@@ -149,11 +169,53 @@ func (s CSPAccountMemberStatus) IsValid() bool {
 	}
 }
 
+// CSPAccountMemberSyncStatus はメンバーのプロバイダーへの反映状況を定義
+type CSPAccountMemberSyncStatus string
+
+const (
+	CSPAccountMemberSyncStatusPending      CSPAccountMemberSyncStatus = "pending"       // 未反映（変更後、または利用中でないアカウント）
+	CSPAccountMemberSyncStatusSynced       CSPAccountMemberSyncStatus = "synced"        // 反映済み
+	CSPAccountMemberSyncStatusFailed       CSPAccountMemberSyncStatus = "failed"        // 反映に失敗（定期実行で再試行する）
+	CSPAccountMemberSyncStatusNotSupported CSPAccountMemberSyncStatus = "not_supported" // プロバイダーでメンバーの管理が設定されていない
+)
+
+// ProviderEmail はプロバイダー側で照合するメンバーのメールアドレス（SSOのメールアドレスを優先）
+func (m *CSPAccountMember) ProviderEmail() string {
+	if m.SSOEmail != "" {
+		return strings.ToLower(m.SSOEmail)
+	}
+	return strings.ToLower(m.User.Email)
+}
+
+// CSPMemberRevocation はメンバーの削除・メールアドレスの変更で、プロバイダー側のロールを外す必要があるメールアドレス
+// メンバーの行が残らないため、反映できるまで記録を残して定期実行で再試行する
+type CSPMemberRevocation struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	CSPAccountID uint       `json:"csp_account_id" gorm:"not null;uniqueIndex:idx_csp_member_revocations_account_email"`
+	Email        string     `json:"email" gorm:"not null;size:255;uniqueIndex:idx_csp_member_revocations_account_email"`
+	LastError    string     `json:"last_error,omitempty" gorm:"type:text"` // 直近の反映に失敗した理由
+	AttemptedAt  *time.Time `json:"attempted_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// TableName はテーブル名を指定
+func (CSPMemberRevocation) TableName() string {
+	return "csp_member_revocations"
+}
+
 // BeforeCreate はレコード作成前のバリデーション
+// 暗号化カラム（アクセスキー・シークレットキー）を行のIDに結び付けて暗号化するため、保存前にIDを採番する
 func (ca *CSPAccount) BeforeCreate(tx *gorm.DB) error {
 	if !ca.Provider.IsValid() {
 		return ErrInvalidCSPProvider
 	}
+	if ca.ID == 0 {
+		if err := tx.Session(&gorm.Session{NewDB: true}).
+			Raw("SELECT nextval(pg_get_serial_sequence('csp_accounts', 'id'))").Scan(&ca.ID).Error; err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -173,6 +235,7 @@ type CSPAccountCreateRequest struct {
 	AccessKey    string      `json:"access_key" validate:"required"`
 	SecretKey    string      `json:"secret_key" validate:"required"`
 	Region       string      `json:"region"`
+	Attributes   CSPAccountAttributes `json:"attributes"`
 }
 
 // CSPAccountResponse はCSPアカウントレスポンスの構造体
@@ -181,8 +244,8 @@ type CSPAccountResponse struct {
 	Provider     CSPProvider  `json:"provider"`
 	AccountName  string       `json:"account_name"`
 	AccountID    string       `json:"account_id"`
-	AccessKey    string       `json:"access_key"`
 	Region       string       `json:"region"`
+	Attributes   CSPAccountAttributes `json:"attributes"`
 	Status       string       `json:"status"`
 	CreatedBy    uint         `json:"created_by"`
 	CreatedAt    time.Time    `json:"created_at"`
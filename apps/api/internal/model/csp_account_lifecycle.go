@@ -0,0 +1,151 @@
+package model
+
+import "time"
+
+// CSPAccountStatus はCSPアカウントのライフサイクル上のステータス
+type CSPAccountStatus string
+
+// CSPアカウントステータス定数
+const (
+	CSPAccountStatusProvisioning CSPAccountStatus = "provisioning" // プロバイダーで作成中
+	CSPAccountStatusActive       CSPAccountStatus = "active"       // 利用中
+	CSPAccountStatusSuspended    CSPAccountStatus = "suspended"    // 一時停止（認証情報の払い出し・ローテーションを止める）
+	CSPAccountStatusClosing      CSPAccountStatus = "closing"      // プロバイダーで閉鎖処理中
+	CSPAccountStatusClosed       CSPAccountStatus = "closed"       // 閉鎖済み（記録として保持する）
+	CSPAccountStatusFailed       CSPAccountStatus = "failed"       // プロバイダーでの作成に失敗
+)
+
+// ValidCSPAccountStatuses は有効なCSPアカウントステータスの一覧
+var ValidCSPAccountStatuses = []CSPAccountStatus{
+	CSPAccountStatusProvisioning,
+	CSPAccountStatusActive,
+	CSPAccountStatusSuspended,
+	CSPAccountStatusClosing,
+	CSPAccountStatusClosed,
+	CSPAccountStatusFailed,
+}
+
+// IsValid はCSPアカウントステータスが有効かどうかをチェック
+func (s CSPAccountStatus) IsValid() bool {
+	for _, validStatus := range ValidCSPAccountStatuses {
+		if s == validStatus {
+			return true
+		}
+	}
+	return false
+}
+
+// String はCSPアカウントステータスの文字列表現を返す
+func (s CSPAccountStatus) String() string {
+	return string(s)
+}
+
+// cspAccountStatusTransitions は許可されるCSPアカウントステータス遷移
+var cspAccountStatusTransitions = map[CSPAccountStatus][]CSPAccountStatus{
+	CSPAccountStatusProvisioning: {CSPAccountStatusActive, CSPAccountStatusFailed},
+	CSPAccountStatusActive:       {CSPAccountStatusSuspended, CSPAccountStatusClosing},
+	CSPAccountStatusSuspended:    {CSPAccountStatusActive, CSPAccountStatusClosing},
+	CSPAccountStatusClosing:      {CSPAccountStatusClosed},
+}
+
+// CanTransitionTo は指定したステータスへ遷移できるかどうかをチェック
+func (s CSPAccountStatus) CanTransitionTo(next CSPAccountStatus) bool {
+	for _, allowed := range cspAccountStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTerminal はこれ以上遷移しない（プロバイダー側にアカウントが存在しない）ステータスかどうかを判定
+func (s CSPAccountStatus) IsTerminal() bool {
+	return s == CSPAccountStatusClosed || s == CSPAccountStatusFailed
+}
+
+// AcceptsChanges はメンバーの追加やプロジェクトへの紐付けを受け付けるステータスかどうかを判定
+func (s CSPAccountStatus) AcceptsChanges() bool {
+	return s != CSPAccountStatusClosing && !s.IsTerminal()
+}
+
+// StatusFromProvider はプロバイダー側のアカウントの状態から、遷移先のステータスを決定する
+// 管理者による一時停止はプロバイダー側が利用可能でも維持し、閉鎖済みのアカウントは変更しない
+func (s CSPAccountStatus) StatusFromProvider(providerStatus ProviderAccountStatus) CSPAccountStatus {
+	if s.IsTerminal() {
+		return s
+	}
+	switch providerStatus {
+	case ProviderAccountStatusActive:
+		if s == CSPAccountStatusProvisioning {
+			return CSPAccountStatusActive
+		}
+	case ProviderAccountStatusSuspended:
+		// AWSでは閉鎖したアカウントが一定期間SUSPENDEDとして残る
+		if s == CSPAccountStatusClosing {
+			return CSPAccountStatusClosed
+		}
+		if s == CSPAccountStatusActive {
+			return CSPAccountStatusSuspended
+		}
+	case ProviderAccountStatusClosed:
+		// プラットフォーム外で閉鎖された場合も閉鎖済みとして記録する
+		return CSPAccountStatusClosed
+	case ProviderAccountStatusFailed:
+		if s == CSPAccountStatusProvisioning {
+			return CSPAccountStatusFailed
+		}
+	}
+	return s
+}
+
+// CSPAccountLifecycleEvent はCSPアカウントのステータス遷移の履歴
+type CSPAccountLifecycleEvent struct {
+	ID           uint             `json:"id" gorm:"primaryKey"`
+	CSPAccountID uint             `json:"csp_account_id" gorm:"not null;index"`
+	FromStatus   CSPAccountStatus `json:"from_status" gorm:"not null;size:50"`
+	ToStatus     CSPAccountStatus `json:"to_status" gorm:"not null;size:50"`
+	Reason       string           `json:"reason" gorm:"type:text"`
+	ChangedBy    *uint            `json:"changed_by" gorm:"index"` // プロバイダー側の状態の反映の場合はnil
+	CreatedAt    time.Time        `json:"created_at"`
+
+	// リレーション
+	ChangedByUser *User `json:"changed_by_user,omitempty" gorm:"foreignKey:ChangedBy"`
+}
+
+// TableName はテーブル名を指定
+func (CSPAccountLifecycleEvent) TableName() string {
+	return "csp_account_lifecycle_events"
+}
+
+// ProviderCost はプロバイダー側で発生しているアカウントの費用
+type ProviderCost struct {
+	Amount      float64   `json:"amount"`
+	Currency    string    `json:"currency"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+}
+
+// CSPAccountStatusChangeRequest はCSPアカウントの一時停止・再開リクエストの構造体
+type CSPAccountStatusChangeRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// CSPAccountClosureRequest はCSPアカウントの閉鎖リクエストの構造体
+type CSPAccountClosureRequest struct {
+	Reason string `json:"reason" binding:"required"`
+	// AcknowledgeCost は当月の費用が発生している（または取得できない）ことを確認した上で閉鎖する場合にtrue
+	AcknowledgeCost bool `json:"acknowledge_cost"`
+}
+
+// CSPAccountClosureCheck はCSPアカウントを閉鎖できるかどうかの事前確認結果
+type CSPAccountClosureCheck struct {
+	CSPAccountID     uint               `json:"csp_account_id"`
+	Status           CSPAccountStatus   `json:"status"`
+	ActiveMembers    []CSPAccountMember `json:"active_members"` // 閉鎖前に削除・無効化が必要なメンバー
+	LinkedProjectIDs []uint             `json:"linked_project_ids"`
+	Cost             *ProviderCost      `json:"cost"`                 // 取得できない場合はnil
+	CostError        string             `json:"cost_error,omitempty"` // 費用を取得できなかった理由
+	RequiresCostAck  bool               `json:"requires_cost_ack"`    // 閉鎖時にacknowledge_costが必要
+	Blockers         []string           `json:"blockers"`             // 閉鎖を妨げている理由
+	Closable         bool               `json:"closable"`
+}
@@ -0,0 +1,107 @@
+package model
+
+import "time"
+
+// CredentialRotationTrigger は認証情報を発行・ローテーションした契機
+type CredentialRotationTrigger string
+
+const (
+	CredentialRotationTriggerInitial   CredentialRotationTrigger = "initial"   // アカウント作成完了時の初回発行
+	CredentialRotationTriggerScheduled CredentialRotationTrigger = "scheduled" // 最大有効日数による定期ローテーション
+	CredentialRotationTriggerManual    CredentialRotationTrigger = "manual"    // 管理者による手動ローテーション
+)
+
+// CredentialRotationStatus はローテーションの結果
+type CredentialRotationStatus string
+
+const (
+	CredentialRotationStatusSucceeded CredentialRotationStatus = "succeeded"
+	CredentialRotationStatusFailed    CredentialRotationStatus = "failed"
+)
+
+// CSPAccountCredentialRotation はCSPアカウントの認証情報のローテーション履歴
+// 鍵の識別子のみを記録し、シークレットは記録しない
+type CSPAccountCredentialRotation struct {
+	ID                   uint                      `json:"id" gorm:"primaryKey"`
+	CSPAccountID         uint                      `json:"csp_account_id" gorm:"not null;index"`
+	Trigger              CredentialRotationTrigger `json:"trigger" gorm:"not null;size:20"`
+	Reason               string                    `json:"reason" gorm:"type:text"`
+	RotatedBy            *uint                     `json:"rotated_by" gorm:"index"` // 実行したユーザー（定期ローテーションの場合はnil）
+	Status               CredentialRotationStatus  `json:"status" gorm:"not null;size:20"`
+	Error                string                    `json:"error,omitempty" gorm:"type:text"` // 失敗した場合のエラー
+	KeyID                string                    `json:"key_id" gorm:"size:255"`           // 発行した鍵の識別子
+	PreviousKeyID        string                    `json:"previous_key_id" gorm:"size:255"`  // 置き換えた鍵の識別子
+	PreviousKeyExpiresAt *time.Time                `json:"previous_key_expires_at"`          // 置き換えた鍵の猶予期限
+	PreviousKeyRevokedAt *time.Time                `json:"previous_key_revoked_at"`          // 置き換えた鍵を失効させた日時
+	CreatedAt            time.Time                 `json:"created_at"`
+
+	// リレーション
+	RotatedByUser *User `json:"rotated_by_user,omitempty" gorm:"foreignKey:RotatedBy"`
+}
+
+// TableName はテーブル名を指定
+func (CSPAccountCredentialRotation) TableName() string {
+	return "csp_account_credential_rotations"
+}
+
+// CredentialRotationPolicyRequest はCSPアカウントのローテーションポリシー設定リクエスト
+type CredentialRotationPolicyRequest struct {
+	MaxAgeDays *int `json:"max_age_days"` // nilまたは0の場合は自動ローテーションを無効にする
+}
+
+// CredentialRotationRequest は手動ローテーションのリクエスト
+type CredentialRotationRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// CSPCredentialIssuance はメンバーへの一時的な認証情報の払い出し記録（認証情報そのものは記録しない）
+type CSPCredentialIssuance struct {
+	ID           uint                 `json:"id" gorm:"primaryKey"`
+	CSPAccountID uint                 `json:"csp_account_id" gorm:"not null;index"`
+	ProjectID    uint                 `json:"project_id" gorm:"not null;index"`
+	UserID       uint                 `json:"user_id" gorm:"not null;index"`
+	Role         CSPAccountMemberRole `json:"role" gorm:"not null;size:50"`
+	ExpiresAt    time.Time            `json:"expires_at"`
+	SourceIP     string               `json:"source_ip" gorm:"size:64"`
+	CreatedAt    time.Time            `json:"created_at"`
+
+	// リレーション
+	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// TableName はテーブル名を指定
+func (CSPCredentialIssuance) TableName() string {
+	return "csp_credential_issuances"
+}
+
+// TemporaryCredentialRequest は一時的な認証情報の払い出しリクエスト
+type TemporaryCredentialRequest struct {
+	ProjectID       uint `json:"project_id" binding:"required"` // メンバーとして所属するプロジェクト
+	DurationSeconds int  `json:"duration_seconds"`              // 有効期間（秒、省略時は1時間）
+}
+
+// CurrentCredentialKeyID は現在の鍵の識別子を返す
+// 識別子が記録されていない（手動で登録した）場合はアクセスキーを識別子とみなす
+func (a *CSPAccount) CurrentCredentialKeyID() string {
+	if a.CredentialKeyID != "" {
+		return a.CredentialKeyID
+	}
+	return a.AccessKey
+}
+
+// CredentialRotationDue は最大有効日数を過ぎて認証情報のローテーションが必要かどうかを判定
+func (a *CSPAccount) CredentialRotationDue(now time.Time) bool {
+	if a.CredentialMaxAgeDays == nil || *a.CredentialMaxAgeDays <= 0 || a.AccessKey == "" {
+		return false
+	}
+	issuedAt := a.CreatedAt
+	if a.CredentialIssuedAt != nil {
+		issuedAt = *a.CredentialIssuedAt
+	}
+	return !now.Before(issuedAt.AddDate(0, 0, *a.CredentialMaxAgeDays))
+}
+
+// PreviousCredentialExpired は猶予期間中の旧鍵を失効させる時期を過ぎているかどうかを判定
+func (a *CSPAccount) PreviousCredentialExpired(now time.Time) bool {
+	return a.PreviousCredentialKeyID != "" && a.PreviousCredentialExpiresAt != nil && !now.Before(*a.PreviousCredentialExpiresAt)
+}
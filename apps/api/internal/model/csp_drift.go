@@ -0,0 +1,150 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// CSPDriftKind はプラットフォームの記録とプロバイダー側の状態の差分（ドリフト）の種類
+type CSPDriftKind string
+
+const (
+	CSPDriftKindUnknownAccount CSPDriftKind = "unknown_account" // プロバイダーにあるが、プラットフォームに登録されていないアカウント
+	CSPDriftKindMissingAccount CSPDriftKind = "missing_account" // プラットフォームに登録されているが、プロバイダーに存在しないアカウント
+	CSPDriftKindStatusMismatch CSPDriftKind = "status_mismatch" // アカウントのステータスがプロバイダー側と異なる
+	CSPDriftKindMissingMember  CSPDriftKind = "missing_member"  // プラットフォームのメンバーにプロバイダー側のロールが付与されていない
+	CSPDriftKindExtraMember    CSPDriftKind = "extra_member"    // プラットフォームのメンバーではないユーザーにプロバイダー側のロールが付与されている
+	CSPDriftKindRoleMismatch   CSPDriftKind = "role_mismatch"   // メンバーのロールがプロバイダー側と異なる
+)
+
+// CSPDriftStatus はドリフトの対応状況
+type CSPDriftStatus string
+
+const (
+	CSPDriftStatusOpen     CSPDriftStatus = "open"     // 未対応
+	CSPDriftStatusResolved CSPDriftStatus = "resolved" // 解消済み（対応済み、または次の検出で差分がなくなった）
+	CSPDriftStatusIgnored  CSPDriftStatus = "ignored"  // 無視（再検出されても未対応に戻さない）
+)
+
+// CSPDriftAction はドリフトへの対応方法
+type CSPDriftAction string
+
+const (
+	// CSPDriftActionImport はプロバイダー側の状態をプラットフォームに取り込む
+	CSPDriftActionImport CSPDriftAction = "import"
+	// CSPDriftActionFix はプラットフォームの状態をプロバイダー側に反映する
+	CSPDriftActionFix CSPDriftAction = "fix"
+	// CSPDriftActionIgnore は差分を許容し、以後の検出でも未対応に戻さない
+	CSPDriftActionIgnore CSPDriftAction = "ignore"
+)
+
+// cspDriftActions はドリフトの種類ごとに選べる対応方法（無視はすべての種類で選べる）
+var cspDriftActions = map[CSPDriftKind][]CSPDriftAction{
+	CSPDriftKindUnknownAccount: {CSPDriftActionImport},
+	CSPDriftKindMissingAccount: {CSPDriftActionImport},
+	CSPDriftKindStatusMismatch: {CSPDriftActionImport},
+	CSPDriftKindMissingMember:  {CSPDriftActionImport, CSPDriftActionFix},
+	CSPDriftKindExtraMember:    {CSPDriftActionImport, CSPDriftActionFix},
+	CSPDriftKindRoleMismatch:   {CSPDriftActionImport, CSPDriftActionFix},
+}
+
+// AllowsAction はドリフトの種類に対して対応方法を選べるかどうかを判定
+func (k CSPDriftKind) AllowsAction(action CSPDriftAction) bool {
+	if action == CSPDriftActionIgnore {
+		return true
+	}
+	for _, allowed := range cspDriftActions[k] {
+		if allowed == action {
+			return true
+		}
+	}
+	return false
+}
+
+// CSPDriftItem は棚卸しで検出したドリフト
+// 同じ差分は指紋（Fingerprint）で識別し、検出のたびに最終検出日時を更新する
+type CSPDriftItem struct {
+	ID                 uint           `json:"id" gorm:"primaryKey"`
+	Provider           CSPProvider    `json:"provider" gorm:"not null;size:50;index"`
+	Kind               CSPDriftKind   `json:"kind" gorm:"not null;size:50"`
+	Status             CSPDriftStatus `json:"status" gorm:"not null;size:20;index"`
+	Fingerprint        string         `json:"-" gorm:"not null;size:512;uniqueIndex"`
+	CSPAccountID       *uint          `json:"csp_account_id" gorm:"index"` // プラットフォームに登録されていないアカウントの場合はnil
+	ProviderAccountID  string         `json:"provider_account_id" gorm:"not null;size:255"`
+	AccountName        string         `json:"account_name" gorm:"size:255"`
+	CSPAccountMemberID *uint          `json:"csp_account_member_id"` // プラットフォームのメンバーに関する差分の場合
+	Email              string         `json:"email,omitempty" gorm:"size:255"`
+	Expected           string         `json:"expected,omitempty" gorm:"size:100"` // プラットフォームの記録（ステータス・ロール）
+	Actual             string         `json:"actual,omitempty" gorm:"size:100"`   // プロバイダー側の状態
+	Detail             string         `json:"detail" gorm:"type:text"`
+	Resolution         CSPDriftAction `json:"resolution,omitempty" gorm:"size:20"` // 自動で解消された場合は空
+	ResolutionNote     string         `json:"resolution_note,omitempty" gorm:"type:text"`
+	ResolvedBy         *uint          `json:"resolved_by"`
+	ResolvedAt         *time.Time     `json:"resolved_at"`
+	FirstDetectedAt    time.Time      `json:"first_detected_at"`
+	LastDetectedAt     time.Time      `json:"last_detected_at"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+
+	// リレーション
+	ResolvedByUser *User `json:"resolved_by_user,omitempty" gorm:"foreignKey:ResolvedBy"`
+}
+
+// TableName はテーブル名を指定
+func (CSPDriftItem) TableName() string {
+	return "csp_drift_items"
+}
+
+// DriftFingerprint はドリフトを識別する指紋（プロバイダー・種類・アカウント・メールアドレス）
+func DriftFingerprint(provider CSPProvider, kind CSPDriftKind, providerAccountID, email string) string {
+	return strings.Join([]string{string(provider), string(kind), providerAccountID, strings.ToLower(email)}, "|")
+}
+
+// CSPReconciliationRunStatus は棚卸しの実行状況
+type CSPReconciliationRunStatus string
+
+const (
+	CSPReconciliationRunStatusRunning   CSPReconciliationRunStatus = "running"
+	CSPReconciliationRunStatusSucceeded CSPReconciliationRunStatus = "succeeded"
+	CSPReconciliationRunStatusFailed    CSPReconciliationRunStatus = "failed"
+)
+
+// CSPReconciliationRun はプロバイダーごとの棚卸しの実行履歴
+type CSPReconciliationRun struct {
+	ID              uint                       `json:"id" gorm:"primaryKey"`
+	Provider        CSPProvider                `json:"provider" gorm:"not null;size:50;index"`
+	Status          CSPReconciliationRunStatus `json:"status" gorm:"not null;size:20"`
+	TriggeredBy     *uint                      `json:"triggered_by"` // 定期実行の場合はnil
+	AccountsScanned int                        `json:"accounts_scanned"`
+	DriftDetected   int                        `json:"drift_detected"`                             // 検出した（無視していない）ドリフトの件数
+	DriftResolved   int                        `json:"drift_resolved"`                             // 差分がなくなり自動で解消した件数
+	Warnings        []string                   `json:"warnings" gorm:"type:jsonb;serializer:json"` // 一部のアカウントを確認できなかった理由など
+	Error           string                     `json:"error,omitempty" gorm:"type:text"`
+	StartedAt       time.Time                  `json:"started_at"`
+	FinishedAt      *time.Time                 `json:"finished_at"`
+}
+
+// TableName はテーブル名を指定
+func (CSPReconciliationRun) TableName() string {
+	return "csp_reconciliation_runs"
+}
+
+// CSPReconciliationRequest は棚卸しの実行リクエストの構造体
+type CSPReconciliationRequest struct {
+	Provider CSPProvider `json:"provider"` // 未指定の場合はすべてのプロバイダー
+}
+
+// CSPDriftResolveRequest はドリフトへの対応リクエストの構造体
+type CSPDriftResolveRequest struct {
+	Action    CSPDriftAction `json:"action" binding:"required,oneof=import fix ignore"`
+	ProjectID *uint          `json:"project_id"` // 取り込むアカウント・メンバーを所属させるプロジェクト
+	Note      string         `json:"note"`
+}
+
+// CSPDriftFilter はドリフトの一覧の絞り込み条件
+type CSPDriftFilter struct {
+	Provider     CSPProvider
+	Status       CSPDriftStatus
+	Kind         CSPDriftKind
+	CSPAccountID *uint
+}
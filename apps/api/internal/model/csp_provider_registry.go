@@ -0,0 +1,276 @@
+package model
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+)
+
+// CSPProviderCapability はプロバイダーが対応している機能
+type CSPProviderCapability string
+
+const (
+	CSPProviderCapabilityProvisioning         CSPProviderCapability = "account_provisioning"  // プロバイダーAPIによるアカウントの自動作成
+	CSPProviderCapabilityCredentialRotation   CSPProviderCapability = "credential_rotation"   // 認証情報の再発行
+	CSPProviderCapabilityAccountClosure       CSPProviderCapability = "account_closure"       // アカウントの閉鎖
+	CSPProviderCapabilityTemporaryCredentials CSPProviderCapability = "temporary_credentials" // メンバーへの短期の認証情報の払い出し
+)
+
+// CSPProviderRegion はプロバイダーのリージョン
+type CSPProviderRegion struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name"`
+}
+
+// CSPAccountAttributeType はプロバイダー固有属性の値の型
+type CSPAccountAttributeType string
+
+const (
+	CSPAccountAttributeTypeString CSPAccountAttributeType = "string"
+	CSPAccountAttributeTypeEmail  CSPAccountAttributeType = "email"
+	CSPAccountAttributeTypeEnum   CSPAccountAttributeType = "enum"
+)
+
+// CSPAccountAttributeDefinition はプロバイダー固有のアカウント属性（AWSのルートメールアドレス、GCPの請求先アカウントなど）のスキーマ
+type CSPAccountAttributeDefinition struct {
+	Key         string                  `json:"key"`
+	Label       string                  `json:"label"`
+	Type        CSPAccountAttributeType `json:"type"`
+	Pattern     string                  `json:"pattern,omitempty"` // string型の値の形式（正規表現）
+	Options     []string                `json:"options,omitempty"` // enum型の選択肢
+	Required    bool                    `json:"required"`          // CSPアカウントの登録に必須（申請時はドライバーが補完するため任意）
+	Description string                  `json:"description,omitempty"`
+
+	patternRegexp *regexp.Regexp
+}
+
+// ValidateValue は値が属性の型・形式に合致するかチェック
+func (a *CSPAccountAttributeDefinition) ValidateValue(value string) error {
+	switch a.Type {
+	case CSPAccountAttributeTypeEmail:
+		if address, err := mail.ParseAddress(value); err == nil && address.Address == value {
+			return nil
+		}
+	case CSPAccountAttributeTypeEnum:
+		for _, option := range a.Options {
+			if value == option {
+				return nil
+			}
+		}
+	default:
+		if a.patternRegexp == nil || a.patternRegexp.MatchString(value) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q must be a valid %s", ErrInvalidCSPAccountAttribute, a.Key, a.Label)
+}
+
+// CSPProviderDefinition はプロバイダーレジストリに登録するプロバイダーの定義
+// プロバイダーを追加する場合はcspProviderRegistryに定義を追加する（CSPプロビジョニングサービスは内部APIから取得する）
+type CSPProviderDefinition struct {
+	ID               CSPProvider                     `json:"id"`
+	DisplayName      string                          `json:"display_name"`
+	AccountUnit      string                          `json:"account_unit"`       // プロバイダーでアカウントに相当する単位
+	AccountIDPattern string                          `json:"account_id_pattern"` // アカウントIDの形式（正規表現）
+	AccountIDExample string                          `json:"account_id_example"`
+	GovernmentCloud  bool                            `json:"government_cloud"` // ガバメントクラウドの認定を受けているか
+	DefaultRegion    string                          `json:"default_region"`
+	Regions          []CSPProviderRegion             `json:"regions"`
+	Capabilities     []CSPProviderCapability         `json:"capabilities"`
+	Attributes       []CSPAccountAttributeDefinition `json:"attributes"` // プロバイダー固有のアカウント属性のスキーマ
+
+	accountIDRegexp *regexp.Regexp
+}
+
+// ValidateAttributes はアカウント属性をプロバイダーのスキーマに照らして検証
+// 未定義のキーと型・形式の不一致をエラーとし、requireAllがtrueの場合は必須属性の欠落もエラーとする
+func (d *CSPProviderDefinition) ValidateAttributes(values CSPAccountAttributes, requireAll bool) error {
+	definitionByKey := make(map[string]*CSPAccountAttributeDefinition, len(d.Attributes))
+	for i := range d.Attributes {
+		definitionByKey[d.Attributes[i].Key] = &d.Attributes[i]
+	}
+
+	for key, value := range values {
+		definition, ok := definitionByKey[key]
+		if !ok {
+			return fmt.Errorf("%w: %q is not an attribute of %s", ErrInvalidCSPAccountAttribute, key, d.ID)
+		}
+		if err := definition.ValidateValue(value); err != nil {
+			return err
+		}
+	}
+
+	if requireAll {
+		for _, definition := range d.Attributes {
+			if definition.Required && values[definition.Key] == "" {
+				return fmt.Errorf("%w: %q is required", ErrInvalidCSPAccountAttribute, definition.Key)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateAccountID はアカウントIDがプロバイダーの形式に合っているかをチェック
+func (d *CSPProviderDefinition) ValidateAccountID(accountID string) bool {
+	return d.accountIDRegexp.MatchString(accountID)
+}
+
+// HasRegion はプロバイダーがリージョンを提供しているかをチェック
+func (d *CSPProviderDefinition) HasRegion(region string) bool {
+	for _, r := range d.Regions {
+		if r.ID == region {
+			return true
+		}
+	}
+	return false
+}
+
+// Supports はプロバイダーが機能に対応しているかをチェック
+func (d *CSPProviderDefinition) Supports(capability CSPProviderCapability) bool {
+	for _, c := range d.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// cspProviderRegistry は利用できるプロバイダーの一覧（表示順）
+var cspProviderRegistry = []*CSPProviderDefinition{
+	{
+		ID:               CSPProviderAWS,
+		DisplayName:      "Amazon Web Services",
+		AccountUnit:      "AWSアカウント",
+		AccountIDPattern: `^\d{12}$`,
+		AccountIDExample: "123456789012",
+		GovernmentCloud:  true,
+		DefaultRegion:    "ap-northeast-1",
+		Regions: []CSPProviderRegion{
+			{ID: "ap-northeast-1", DisplayName: "アジアパシフィック（東京）"},
+			{ID: "ap-northeast-3", DisplayName: "アジアパシフィック（大阪）"},
+		},
+		Attributes: []CSPAccountAttributeDefinition{
+			{Key: "root_email", Label: "ルートユーザーのメールアドレス", Type: CSPAccountAttributeTypeEmail, Required: true},
+			{Key: "organizational_unit_id", Label: "組織単位（OU）ID", Type: CSPAccountAttributeTypeString, Pattern: `^ou-[0-9a-z]{4,32}-[a-z0-9]{8,32}$`},
+		},
+		Capabilities: []CSPProviderCapability{
+			CSPProviderCapabilityProvisioning,
+			CSPProviderCapabilityCredentialRotation,
+			CSPProviderCapabilityAccountClosure,
+			CSPProviderCapabilityTemporaryCredentials,
+		},
+	},
+	{
+		ID:               CSPProviderGCP,
+		DisplayName:      "Google Cloud",
+		AccountUnit:      "プロジェクト",
+		AccountIDPattern: `^[a-z][a-z0-9-]{4,28}[a-z0-9]$`,
+		AccountIDExample: "my-project-123",
+		GovernmentCloud:  true,
+		DefaultRegion:    "asia-northeast1",
+		Regions: []CSPProviderRegion{
+			{ID: "asia-northeast1", DisplayName: "東京"},
+			{ID: "asia-northeast2", DisplayName: "大阪"},
+		},
+		Attributes: []CSPAccountAttributeDefinition{
+			{Key: "billing_account_id", Label: "請求先アカウントID", Type: CSPAccountAttributeTypeString, Pattern: `^[0-9A-F]{6}-[0-9A-F]{6}-[0-9A-F]{6}$`},
+			{Key: "folder_id", Label: "フォルダID", Type: CSPAccountAttributeTypeString, Pattern: `^\d{1,20}$`, Description: "省略時は組織の既定の作成先"},
+		},
+		Capabilities: []CSPProviderCapability{
+			CSPProviderCapabilityProvisioning,
+			CSPProviderCapabilityCredentialRotation,
+			CSPProviderCapabilityAccountClosure,
+			CSPProviderCapabilityTemporaryCredentials,
+		},
+	},
+	{
+		ID:               CSPProviderAzure,
+		DisplayName:      "Microsoft Azure",
+		AccountUnit:      "サブスクリプション",
+		AccountIDPattern: `^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`,
+		AccountIDExample: "00000000-0000-0000-0000-000000000000",
+		GovernmentCloud:  true,
+		DefaultRegion:    "japaneast",
+		Regions: []CSPProviderRegion{
+			{ID: "japaneast", DisplayName: "東日本"},
+			{ID: "japanwest", DisplayName: "西日本"},
+		},
+		Attributes: []CSPAccountAttributeDefinition{
+			{Key: "tenant_id", Label: "テナントID", Type: CSPAccountAttributeTypeString, Pattern: `^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`, Required: true},
+			{Key: "management_group_id", Label: "管理グループID", Type: CSPAccountAttributeTypeString, Pattern: `^[a-zA-Z0-9_().-]{1,90}$`},
+		},
+		Capabilities: []CSPProviderCapability{
+			CSPProviderCapabilityProvisioning,
+			CSPProviderCapabilityCredentialRotation,
+			CSPProviderCapabilityAccountClosure,
+			CSPProviderCapabilityTemporaryCredentials,
+		},
+	},
+	{
+		ID:               CSPProviderOCI,
+		DisplayName:      "Oracle Cloud Infrastructure",
+		AccountUnit:      "コンパートメント",
+		AccountIDPattern: `^ocid1\.(tenancy|compartment)\.oc1\.\.[a-z0-9]+$`,
+		AccountIDExample: "ocid1.compartment.oc1..aaaaaaaaexample",
+		GovernmentCloud:  true,
+		DefaultRegion:    "ap-tokyo-1",
+		Regions: []CSPProviderRegion{
+			{ID: "ap-tokyo-1", DisplayName: "東京"},
+			{ID: "ap-osaka-1", DisplayName: "大阪"},
+		},
+		Attributes: []CSPAccountAttributeDefinition{
+			{Key: "tenancy_id", Label: "テナンシOCID", Type: CSPAccountAttributeTypeString, Pattern: `^ocid1\.tenancy\.oc1\.\.[a-z0-9]+$`, Required: true},
+			{Key: "parent_compartment_id", Label: "親コンパートメントOCID", Type: CSPAccountAttributeTypeString, Pattern: `^ocid1\.(tenancy|compartment)\.oc1\.\.[a-z0-9]+$`},
+		},
+		Capabilities: []CSPProviderCapability{},
+	},
+	{
+		ID:               CSPProviderSakura,
+		DisplayName:      "さくらのクラウド",
+		AccountUnit:      "プロジェクト",
+		AccountIDPattern: `^\d{12}$`,
+		AccountIDExample: "113000000000",
+		GovernmentCloud:  true,
+		DefaultRegion:    "is1b",
+		Regions: []CSPProviderRegion{
+			{ID: "is1a", DisplayName: "石狩第1ゾーン"},
+			{ID: "is1b", DisplayName: "石狩第2ゾーン"},
+			{ID: "tk1a", DisplayName: "東京第1ゾーン"},
+			{ID: "tk1b", DisplayName: "東京第2ゾーン"},
+		},
+		Attributes: []CSPAccountAttributeDefinition{
+			{Key: "plan", Label: "契約プラン", Type: CSPAccountAttributeTypeEnum, Options: []string{"standard", "government"}},
+		},
+		Capabilities: []CSPProviderCapability{},
+	},
+}
+
+func init() {
+	for _, definition := range cspProviderRegistry {
+		definition.accountIDRegexp = regexp.MustCompile(definition.AccountIDPattern)
+		for i := range definition.Attributes {
+			if definition.Attributes[i].Pattern != "" {
+				definition.Attributes[i].patternRegexp = regexp.MustCompile(definition.Attributes[i].Pattern)
+			}
+		}
+	}
+}
+
+// CSPProviders はレジストリに登録されているプロバイダーの定義の一覧を返す
+func CSPProviders() []CSPProviderDefinition {
+	definitions := make([]CSPProviderDefinition, len(cspProviderRegistry))
+	for i, definition := range cspProviderRegistry {
+		definitions[i] = *definition
+	}
+	return definitions
+}
+
+// LookupCSPProvider はプロバイダーの定義を取得
+func LookupCSPProvider(provider CSPProvider) (*CSPProviderDefinition, bool) {
+	for _, definition := range cspProviderRegistry {
+		if definition.ID == provider {
+			return definition, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,168 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SecretRevealStatus はCSPアカウントの長期の認証情報の開示申請のステータス
+type SecretRevealStatus string
+
+const (
+	SecretRevealStatusPendingApproval SecretRevealStatus = "pending_approval" // 別の管理者の承認待ち
+	SecretRevealStatusApproved        SecretRevealStatus = "approved"         // 開示可能（申請者が一度だけ開示できる）
+	SecretRevealStatusRejected        SecretRevealStatus = "rejected"         // 承認者が却下
+	SecretRevealStatusRevealed        SecretRevealStatus = "revealed"         // 開示済み
+)
+
+// CSPSecretReveal は緊急時（ブレークグラス）にCSPアカウントの長期の認証情報を開示する申請
+// 開示は申請者が一度だけ行え、承認が必要な場合は申請者以外の管理者の承認後に開示できる
+type CSPSecretReveal struct {
+	ID               uint               `json:"id" gorm:"primaryKey"`
+	CSPAccountID     uint               `json:"csp_account_id" gorm:"not null;index"`
+	RequestedBy      uint               `json:"requested_by" gorm:"not null;index"`
+	Justification    string             `json:"justification" gorm:"not null;type:text"`
+	RequiresApproval bool               `json:"requires_approval" gorm:"not null;default:false"`
+	Status           SecretRevealStatus `json:"status" gorm:"not null;size:30;index"`
+	ApprovedBy       *uint              `json:"approved_by"` // 承認・却下した管理者
+	ApprovedAt       *time.Time         `json:"approved_at"`
+	RejectReason     string             `json:"reject_reason,omitempty" gorm:"type:text"`
+	RevealedAt       *time.Time         `json:"revealed_at"`
+	ExpiresAt        time.Time          `json:"expires_at"` // この日時を過ぎると承認・開示できない
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+
+	// リレーション
+	RequestedByUser User  `json:"requested_by_user,omitempty" gorm:"foreignKey:RequestedBy"`
+	ApprovedByUser  *User `json:"approved_by_user,omitempty" gorm:"foreignKey:ApprovedBy"`
+}
+
+// TableName はテーブル名を指定
+func (CSPSecretReveal) TableName() string {
+	return "csp_secret_reveals"
+}
+
+// IsExpired は承認・開示の期限を過ぎているかどうかを判定
+func (r *CSPSecretReveal) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// SecretRevealEvent は開示申請の監査イベント
+type SecretRevealEvent string
+
+const (
+	SecretRevealEventRequested SecretRevealEvent = "requested"
+	SecretRevealEventApproved  SecretRevealEvent = "approved"
+	SecretRevealEventRejected  SecretRevealEvent = "rejected"
+	SecretRevealEventRevealed  SecretRevealEvent = "revealed"
+)
+
+// SecretRevealAuditGenesisHash は最初の監査記録の直前のハッシュとして使う値
+const SecretRevealAuditGenesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// SecretRevealAuditEntry は開示申請の監査記録
+// 直前の記録のハッシュを含めてサーバーの鍵でHMACを計算する（ハッシュチェーン）ため、鍵を持たない者による記録の改ざん・削除を検出できる
+type SecretRevealAuditEntry struct {
+	ID           uint              `json:"id" gorm:"primaryKey"`
+	RevealID     uint              `json:"reveal_id" gorm:"not null;index"`
+	CSPAccountID uint              `json:"csp_account_id" gorm:"not null;index"`
+	Event        SecretRevealEvent `json:"event" gorm:"not null;size:30"`
+	ActorID      uint              `json:"actor_id" gorm:"not null"`
+	Detail       string            `json:"detail" gorm:"type:text"` // 申請理由・却下理由など（認証情報は含めない）
+	SourceIP     string            `json:"source_ip" gorm:"size:64"`
+	PrevHash     string            `json:"prev_hash" gorm:"not null;size:64"`
+	Hash         string            `json:"hash" gorm:"not null;size:64;uniqueIndex"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+// TableName はテーブル名を指定
+func (SecretRevealAuditEntry) TableName() string {
+	return "csp_secret_reveal_audit_entries"
+}
+
+// ComputeHash は直前の記録のハッシュと記録の内容からサーバーの鍵でHMAC-SHA256を計算
+// 作成日時はDBの精度（マイクロ秒）に合わせて計算する
+func (e *SecretRevealAuditEntry) ComputeHash(key []byte) string {
+	fields := []string{
+		e.PrevHash,
+		fmt.Sprintf("%d", e.RevealID),
+		fmt.Sprintf("%d", e.CSPAccountID),
+		string(e.Event),
+		fmt.Sprintf("%d", e.ActorID),
+		e.Detail,
+		e.SourceIP,
+		fmt.Sprintf("%d", e.CreatedAt.UnixMicro()),
+	}
+	return auditMAC(key, fields)
+}
+
+// SecretRevealAuditHead は監査記録の最新の記録（ハッシュチェーンの先頭）
+// 記録の追記と同じトランザクションで更新し、末尾の記録を削除する切り詰めを検出できるようにする
+type SecretRevealAuditHead struct {
+	ID          uint      `json:"id" gorm:"primaryKey"` // 常に1行のみ
+	LastEntryID uint      `json:"last_entry_id" gorm:"not null"`
+	Hash        string    `json:"hash" gorm:"not null;size:64"`
+	Entries     int       `json:"entries" gorm:"not null"`
+	MAC         string    `json:"-" gorm:"not null;size:64"` // 先頭の情報に対するHMAC（鍵を持たない者による書き換えを検出する）
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName はテーブル名を指定
+func (SecretRevealAuditHead) TableName() string {
+	return "csp_secret_reveal_audit_heads"
+}
+
+// SecretRevealAuditHeadID は監査記録の先頭を保存する行のID
+const SecretRevealAuditHeadID = 1
+
+// ComputeMAC は先頭の情報からサーバーの鍵でHMAC-SHA256を計算
+func (h *SecretRevealAuditHead) ComputeMAC(key []byte) string {
+	return auditMAC(key, []string{
+		"head",
+		fmt.Sprintf("%d", h.LastEntryID),
+		h.Hash,
+		fmt.Sprintf("%d", h.Entries),
+	})
+}
+
+func auditMAC(key []byte, fields []string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strings.Join(fields, "\x1f")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SecretRevealAuditVerification は監査記録のハッシュチェーンの検証結果
+type SecretRevealAuditVerification struct {
+	Valid         bool   `json:"valid"`
+	Entries       int    `json:"entries"`
+	BrokenEntryID *uint  `json:"broken_entry_id,omitempty"` // 改ざん・欠落を検出した最初の記録
+	HeadMismatch  bool   `json:"head_mismatch,omitempty"`   // 保存した先頭と記録が一致しない（末尾の記録の削除など）
+	HeadEntryID   uint   `json:"head_entry_id"`             // 保存した先頭の記録（外部に控えた値と照合できる）
+	HeadHash      string `json:"head_hash"`
+}
+
+// SecretRevealCreateRequest は開示申請の作成リクエスト
+type SecretRevealCreateRequest struct {
+	Justification   string `json:"justification" binding:"required"`
+	RequireApproval bool   `json:"require_approval"` // 承認を必須にしていない場合でも、申請者が別の管理者の承認を求められる
+}
+
+// SecretRevealRejectRequest は開示申請の却下リクエスト
+type SecretRevealRejectRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// RevealedSecret は開示したCSPアカウントの長期の認証情報（開示時の一度だけ返す）
+type RevealedSecret struct {
+	RevealID     uint        `json:"reveal_id"`
+	CSPAccountID uint        `json:"csp_account_id"`
+	Provider     CSPProvider `json:"provider"`
+	AccountID    string      `json:"account_id"`
+	AccessKey    string      `json:"access_key"`
+	SecretKey    string      `json:"secret_key"`
+	RevealedAt   time.Time   `json:"revealed_at"`
+}
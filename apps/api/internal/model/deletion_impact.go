@@ -0,0 +1,188 @@
+package model
+
+import "fmt"
+
+// DeletionTargetType は削除対象の種別を定義する型
+type DeletionTargetType string
+
+// 削除対象種別定数
+const (
+	DeletionTargetProject    DeletionTargetType = "project"
+	DeletionTargetUser       DeletionTargetType = "user"
+	DeletionTargetCSPAccount DeletionTargetType = "csp_account"
+)
+
+// DeletionPolicy は削除対象に依存するレコードの扱いを定義する型
+type DeletionPolicy string
+
+// 削除ポリシー定数
+const (
+	DeletionPolicyCascade DeletionPolicy = "cascade" // 削除対象と一緒に削除（プロジェクトの場合は復元時に一緒に復元）
+	DeletionPolicyBlock   DeletionPolicy = "block"   // 残っている間は削除できない
+	DeletionPolicyRetain  DeletionPolicy = "retain"  // 履歴として残す
+)
+
+// 依存リソースを管理するサービス
+const (
+	DeletionServiceAPI          = "api"
+	DeletionServiceProvisioning = "csp-provisioning"
+)
+
+// 依存リソース定数
+const (
+	DeletionResourceProjectMembers         = "project_members"          // プロジェクトメンバー（user_project_roles）
+	DeletionResourceProjectOwnerships      = "project_ownerships"       // ユーザーがオーナーのプロジェクト
+	DeletionResourceProjectInvitations     = "project_invitations"      // 承諾待ちの招待
+	DeletionResourceVendorRelations        = "vendor_relations"         // 継続中のベンダー紐付け（紐付け元・ベンダー側の両方）
+	DeletionResourceVendorStaffAssignments = "vendor_staff_assignments" // ベンダー担当者への権限割り当て
+	DeletionResourceVendorAccessGrants     = "vendor_access_grants"     // ベンダー・担当者に委任したCSPアカウント
+	DeletionResourceActiveCSPAccounts      = "active_csp_accounts"      // 紐付くアクティブなCSPアカウント
+	DeletionResourceProjectCSPAccounts     = "project_csp_accounts"     // プロジェクトとCSPアカウントの紐付け
+	DeletionResourceCSPAccountMembers      = "csp_account_members"      // CSPアカウントメンバー
+	DeletionResourceProjectEnvironments    = "project_environments"     // プロジェクトの環境
+	DeletionResourceEnvironmentMemberships = "environment_memberships"  // 環境のメンバー権限
+	DeletionResourceOpenCSPRequests        = "open_csp_requests"        // 未処理（下書き・スポンサー承認待ち・レビュー待ち）のCSP申請
+	DeletionResourceClosedCSPRequests      = "closed_csp_requests"      // 処理済み（承認・却下）のCSP申請
+)
+
+// DeletionPolicies は削除対象ごとの依存リソースの扱い
+// 削除処理と影響確認はこの定義に従う
+var DeletionPolicies = map[DeletionTargetType]map[string]DeletionPolicy{
+	DeletionTargetProject: {
+		DeletionResourceProjectMembers:         DeletionPolicyCascade,
+		DeletionResourceProjectInvitations:     DeletionPolicyCascade,
+		DeletionResourceVendorRelations:        DeletionPolicyCascade,
+		DeletionResourceVendorStaffAssignments: DeletionPolicyCascade,
+		DeletionResourceActiveCSPAccounts:      DeletionPolicyBlock,
+		DeletionResourceProjectCSPAccounts:     DeletionPolicyCascade,
+		DeletionResourceCSPAccountMembers:      DeletionPolicyCascade,
+		DeletionResourceProjectEnvironments:    DeletionPolicyCascade,
+		DeletionResourceOpenCSPRequests:        DeletionPolicyBlock,
+		DeletionResourceClosedCSPRequests:      DeletionPolicyRetain,
+	},
+	DeletionTargetUser: {
+		DeletionResourceProjectOwnerships:      DeletionPolicyBlock,
+		DeletionResourceProjectMembers:         DeletionPolicyCascade,
+		DeletionResourceCSPAccountMembers:      DeletionPolicyCascade,
+		DeletionResourceVendorStaffAssignments: DeletionPolicyCascade,
+		DeletionResourceEnvironmentMemberships: DeletionPolicyCascade,
+		DeletionResourceOpenCSPRequests:        DeletionPolicyBlock,
+		DeletionResourceClosedCSPRequests:      DeletionPolicyRetain,
+	},
+	DeletionTargetCSPAccount: {
+		DeletionResourceProjectCSPAccounts: DeletionPolicyCascade,
+		DeletionResourceCSPAccountMembers:  DeletionPolicyCascade,
+		DeletionResourceVendorAccessGrants: DeletionPolicyCascade,
+	},
+}
+
+// DeletionDependencyItem は依存レコード1件の情報
+type DeletionDependencyItem struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+}
+
+// DeletionDependency は削除対象に依存するレコードの一覧とその扱い
+type DeletionDependency struct {
+	Service  string                   `json:"service"`
+	Resource string                   `json:"resource"`
+	Policy   DeletionPolicy           `json:"policy"`
+	Count    int                      `json:"count"`
+	Items    []DeletionDependencyItem `json:"items"`
+}
+
+// DeletionImpact は削除による影響（依存レコードと削除可否）
+type DeletionImpact struct {
+	TargetType              DeletionTargetType   `json:"target_type"`
+	TargetID                uint                 `json:"target_id"`
+	TargetName              string               `json:"target_name"`
+	Deletable               bool                 `json:"deletable"`
+	Dependencies            []DeletionDependency `json:"dependencies"`
+	ProvisioningUnavailable bool                 `json:"provisioning_unavailable,omitempty"` // CSP申請を確認できなかった場合は削除不可とする
+}
+
+// NewDeletionImpact は依存レコードのない削除影響を作成
+func NewDeletionImpact(targetType DeletionTargetType, targetID uint, targetName string) *DeletionImpact {
+	return &DeletionImpact{
+		TargetType:   targetType,
+		TargetID:     targetID,
+		TargetName:   targetName,
+		Deletable:    true,
+		Dependencies: []DeletionDependency{},
+	}
+}
+
+// Add は依存レコードを削除対象のポリシーに従って追加（0件の場合は追加しない）
+func (i *DeletionImpact) Add(service, resource string, items []DeletionDependencyItem) {
+	if len(items) == 0 {
+		return
+	}
+	policy := DeletionPolicies[i.TargetType][resource]
+	if policy == DeletionPolicyBlock {
+		i.Deletable = false
+	}
+	i.Dependencies = append(i.Dependencies, DeletionDependency{
+		Service:  service,
+		Resource: resource,
+		Policy:   policy,
+		Count:    len(items),
+		Items:    items,
+	})
+}
+
+// MarkProvisioningUnavailable はCSP申請を確認できなかったことを記録（削除不可とする）
+func (i *DeletionImpact) MarkProvisioningUnavailable() {
+	i.ProvisioningUnavailable = true
+	i.Deletable = false
+}
+
+// DeletionBlockedError は削除をブロックする依存レコードがある場合のエラー（影響の内容を持つ）
+type DeletionBlockedError struct {
+	Impact *DeletionImpact
+}
+
+func (e *DeletionBlockedError) Error() string {
+	return fmt.Sprintf("%s %d cannot be deleted while blocking dependencies remain", e.Impact.TargetType, e.Impact.TargetID)
+}
+
+// CSPRequestReference はCSPプロビジョニングサービスの申請が削除対象を参照している情報
+type CSPRequestReference struct {
+	ID          string `json:"id"`
+	ProjectID   uint   `json:"project_id"`
+	Provider    string `json:"provider"`
+	AccountName string `json:"account_name"`
+	Status      string `json:"status"`
+	Relation    string `json:"relation"` // project, requester, sponsor
+}
+
+// IsOpen は申請が未処理（下書き・スポンサー承認待ち・レビュー待ち）かどうかを判定
+func (r *CSPRequestReference) IsOpen() bool {
+	return r.Status == "draft" || r.Status == "awaiting_sponsor" || r.Status == "pending"
+}
+
+// ProjectDeletionDependents はプロジェクトに依存するレコード
+type ProjectDeletionDependents struct {
+	Members            []UserProjectRole       // Userを含む
+	Invitations        []ProjectInvitation     // 承諾待ちのもの
+	VendorRelations    []ProjectVendorRelation // 継続中のもの（Project・VendorProjectを含む）
+	StaffAssignments   []VendorStaffAssignment // Userを含む
+	ProjectCSPAccounts []ProjectCSPAccount     // CSPAccountを含む
+	CSPAccountMembers  []CSPAccountMember      // User・CSPAccountを含む
+	Environments       []ProjectEnvironment    // 環境のメンバー権限も一緒に削除する
+}
+
+// UserDeletionDependents はユーザーに依存するレコード
+type UserDeletionDependents struct {
+	Memberships            []UserProjectRole          // Projectを含む
+	CSPAccountMembers      []CSPAccountMember         // CSPAccountを含む
+	StaffAssignments       []VendorStaffAssignment    // Relationを含む
+	EnvironmentMemberships []ProjectEnvironmentMember // Environmentを含む
+}
+
+// CSPAccountDeletionDependents はCSPアカウントに依存するレコード
+type CSPAccountDeletionDependents struct {
+	ProjectCSPAccounts []ProjectCSPAccount     // Projectを含む
+	CSPAccountMembers  []CSPAccountMember      // Userを含む
+	VendorRelations    []ProjectVendorRelation // このアカウントを委任しているもの
+	StaffAssignments   []VendorStaffAssignment // このアカウントを割り当てているもの
+}
@@ -11,6 +11,12 @@ var (
 	ErrProjectNotFound     = errors.New("project not found")
 	ErrProjectAccessDenied = errors.New("access denied to project")
 	ErrInvalidProjectStatus = errors.New("invalid project status")
+	ErrInvalidProjectStatusTransition = errors.New("project status transition is not allowed")
+	ErrProjectArchived      = errors.New("project is archived and read-only")
+	ErrProjectHasActiveCSPAccounts = errors.New("project still has active CSP accounts")
+	ErrProjectHasPendingCSPRequests = errors.New("project still has pending CSP requests")
+	ErrProjectNotInTrash    = errors.New("project is not in trash")
+	ErrProjectRestoreWindowExpired = errors.New("project restore window has expired")
 	
 	// User related errors
 	ErrUserNotFound        = errors.New("user not found")
@@ -24,6 +30,65 @@ var (
 	ErrInsufficientPermissions     = errors.New("insufficient permissions")
 	ErrInsufficientPermission      = errors.New("insufficient permission")
 	ErrCannotRemoveLastOwner       = errors.New("cannot remove the last owner from project")
+	ErrInvalidOwnershipTransfer    = errors.New("invalid ownership transfer")
+	ErrInvalidReassignTarget       = errors.New("reassign target must be another project member who can manage the project")
+	ErrCannotChangeOwnCSPAccountMember = errors.New("cannot change your own CSP account member role or status")
+
+	// Project Invitation related errors
+	ErrInvitationNotFound       = errors.New("invitation not found")
+	ErrInvitationAlreadyExists  = errors.New("a pending invitation already exists for this email")
+	ErrInvitationNotPending     = errors.New("invitation is no longer pending")
+	ErrInvalidInvitationStatus  = errors.New("invalid invitation status")
+	ErrInvitationExpired        = errors.New("invitation has expired")
+	ErrInvalidInvitationToken   = errors.New("invalid invitation token")
+	ErrInvitationEmailMismatch  = errors.New("invitation was sent to a different email address")
+	
+	// Project metadata related errors
+	ErrInvalidProjectTag                      = errors.New("invalid project tag")
+	ErrInvalidCustomAttribute                 = errors.New("invalid custom attribute")
+	ErrMissingRequiredCustomAttribute         = errors.New("required custom attribute is missing")
+	ErrInvalidCustomAttributeDefinition       = errors.New("invalid custom attribute definition")
+	ErrCustomAttributeDefinitionNotFound      = errors.New("custom attribute definition not found")
+	ErrCustomAttributeDefinitionAlreadyExists = errors.New("custom attribute definition already exists")
+	
+	// Vendor Relation related errors
+	ErrVendorRelationNotFound          = errors.New("vendor relation not found")
+	ErrVendorRelationAlreadyExists     = errors.New("an open vendor relation already exists for this vendor project")
+	ErrNotVendorProject                = errors.New("target project is not a vendor project")
+	ErrInvalidVendorRelationTransition = errors.New("invalid vendor relation status transition")
+	ErrInvalidVendorRelationPeriod     = errors.New("invalid contract period (use YYYY-MM-DD and end date on or after start date)")
+	ErrInvalidVendorPermission         = errors.New("invalid vendor permission")
+	ErrVendorPermissionNotGranted      = errors.New("requested permissions exceed the scope granted to the vendor")
+	ErrVendorStaffNotFound             = errors.New("vendor staff assignment not found")
+	ErrCSPAccountNotInProject          = errors.New("CSP account is not associated with this project")
+	
+	// Project Environment related errors
+	ErrEnvironmentNotFound            = errors.New("environment not found")
+	ErrEnvironmentAlreadyExists       = errors.New("environment with the same name already exists in this project")
+	ErrInvalidEnvironment             = errors.New("invalid environment (name must be lowercase letters, digits or hyphens)")
+	ErrEnvironmentInUse               = errors.New("environment still has CSP accounts")
+	ErrEnvironmentMemberNotFound      = errors.New("environment member not found")
+	ErrEnvironmentMemberAlreadyExists = errors.New("user is already a member of this environment")
+	ErrNotEnvironmentMember           = errors.New("user is not a member of the environment of this CSP account")
+	ErrRegionNotAllowed               = errors.New("region is not allowed in this environment")
+	
+	// Project Template related errors
+	ErrProjectTemplateNotFound      = errors.New("project template not found")
+	ErrProjectTemplateAlreadyExists = errors.New("project template with the same name already exists")
+	ErrInvalidProjectTemplate       = errors.New("invalid project template")
+	
+	// Project clone related errors
+	ErrInvalidProjectClone    = errors.New("invalid project clone request")
+	ErrProjectNotCloneable    = errors.New("admin projects cannot be cloned")
+	
+	// Member import related errors
+	ErrUnsupportedFileFormat = errors.New("unsupported file format; use csv or xlsx")
+	ErrInvalidImportFile     = errors.New("failed to read import file")
+	ErrInvalidImportHeader   = errors.New("import file header is missing required columns")
+	ErrImportTooManyRows     = errors.New("import file has too many rows")
+	
+	// State sync related errors
+	ErrStatePlanOutdated = errors.New("platform state has changed since the plan was created; review a new plan")
 	
 	// CSP Provisioning related errors
 	ErrInvalidCSPProvider       = errors.New("invalid CSP provider specified")
@@ -33,4 +98,57 @@ var (
 	ErrCSPRequestAlreadyReviewed = errors.New("CSP provisioning already reviewed")
 	ErrProjectCSPAccountNotFound = errors.New("project CSP account relation not found")
 	ErrProjectCSPAccountAlreadyExists = errors.New("project CSP account relation already exists")
+
+	// Provider registry related errors
+	ErrInvalidCSPAccountID = errors.New("account ID does not match the format of the CSP provider")
+	ErrRegionNotOffered    = errors.New("region is not offered by the CSP provider")
+	ErrInvalidCSPAccountAttribute = errors.New("invalid CSP account attribute")
+
+	// Provider driver related errors
+	ErrProviderDriverNotFound    = errors.New("no provider driver is configured for this CSP provider")
+	ErrProviderAccountNotFound   = errors.New("account not found at the CSP provider")
+	ErrProviderCostNotAvailable  = errors.New("cost data is not available from the CSP provider")
+	ErrProviderMembersNotSupported = errors.New("member management is not configured for this CSP provider")
+	ErrProviderUserNotFound        = errors.New("user not found in the identity system of the CSP provider")
+
+	// CSP account lifecycle related errors
+	ErrInvalidCSPAccountStatusTransition = errors.New("CSP account status transition is not allowed")
+	ErrCSPAccountClosed                  = errors.New("CSP account is closed or being closed")
+	ErrCSPAccountHasActiveMembers        = errors.New("CSP account still has active members")
+	ErrCSPAccountCostNotAcknowledged     = errors.New("CSP account has incurred or unknown cost this month; set acknowledge_cost to close it")
+	ErrCSPAccountMustBeClosed            = errors.New("CSP account must be closed through the closure workflow instead of being deleted")
+	ErrCSPAccountRetained                = errors.New("closed CSP accounts are retained as records and cannot be deleted")
+	ErrProvisioningNotSupported          = errors.New("account provisioning is not supported for this CSP provider")
+
+	// Credential rotation related errors
+	ErrCredentialRotationNotSupported   = errors.New("credential rotation is not supported for this CSP provider")
+	ErrCSPAccountNotActive              = errors.New("CSP account is not active")
+	ErrInvalidCredentialRotationPolicy  = errors.New("max_age_days must be between 0 and 365")
+
+	// Credential vending related errors
+	ErrTemporaryCredentialsNotSupported = errors.New("temporary credentials are not supported for this CSP provider")
+	ErrInvalidCredentialDuration        = errors.New("duration_seconds must be between 900 and 3600")
+	ErrNotCSPAccountMember              = errors.New("user is not an active member of this CSP account")
+
+	// Secret reveal related errors
+	ErrSecretRevealNotFound          = errors.New("secret reveal request not found")
+	ErrInvalidRevealJustification    = errors.New("justification must be at least 20 characters")
+	ErrSecretRevealNotPending        = errors.New("secret reveal request is not awaiting approval")
+	ErrSecretRevealNotApproved       = errors.New("secret reveal request is not approved or has already been revealed")
+	ErrSecretRevealSelfApproval      = errors.New("secret reveal request must be approved by another administrator")
+	ErrSecretRevealNotRequester      = errors.New("only the requester can reveal the secret")
+	ErrSecretRevealExpired           = errors.New("secret reveal request has expired")
+	ErrSecretRevealStateChanged      = errors.New("secret reveal request was updated by another operation")
+	ErrCSPAccountHasNoSecret         = errors.New("CSP account has no stored credentials")
+
+	// Cloud inventory reconciliation related errors
+	ErrDriftItemNotFound          = errors.New("drift item not found")
+	ErrDriftItemNotOpen           = errors.New("drift item is already resolved or ignored")
+	ErrInvalidDriftAction         = errors.New("action is not available for this kind of drift")
+	ErrDriftProjectRequired       = errors.New("project_id is required to import this item")
+	ErrReconciliationInProgress   = errors.New("reconciliation is already running")
+
+	// Member sync related errors
+	ErrCSPAccountMemberNotFound = errors.New("CSP account member not found")
+	ErrCSPMemberRevocationPending = errors.New("member was removed but revoking access at the CSP provider failed; it will be retried")
 )
\ No newline at end of file
@@ -0,0 +1,115 @@
+package model
+
+import "strings"
+
+// MaxMemberImportRows は一度に取り込めるメンバーの最大行数
+const MaxMemberImportRows = 1000
+
+// 一括登録・出力ファイルの列名
+const (
+	MemberImportColumnEmail        = "email"
+	MemberImportColumnRole         = "role"
+	MemberImportColumnCSPAccountID = "csp_account_id"
+)
+
+// ProjectMemberImportColumns はプロジェクトメンバーの一括登録・出力ファイルの列
+var ProjectMemberImportColumns = []string{MemberImportColumnEmail, MemberImportColumnRole}
+
+// CSPAccountMemberImportColumns はCSPアカウントメンバーの一括登録・出力ファイルの列
+var CSPAccountMemberImportColumns = []string{MemberImportColumnEmail, MemberImportColumnCSPAccountID, MemberImportColumnRole}
+
+// MemberImportAction は一括登録の各行に対する処理内容を定義する型
+type MemberImportAction string
+
+// 一括登録の処理内容定数
+const (
+	MemberImportActionCreate    MemberImportAction = "create"    // 新規追加
+	MemberImportActionUpdate    MemberImportAction = "update"    // ロール変更
+	MemberImportActionUnchanged MemberImportAction = "unchanged" // 変更なし
+	MemberImportActionError     MemberImportAction = "error"     // エラー（取り込まない）
+)
+
+// MemberImportRowResult は一括登録の1行ごとの結果
+type MemberImportRowResult struct {
+	Row          int                `json:"row"` // ファイル上の行番号（ヘッダー行を1行目とする）
+	Email        string             `json:"email"`
+	CSPAccountID *uint              `json:"csp_account_id,omitempty"`
+	Role         string             `json:"role"`
+	Action       MemberImportAction `json:"action"`
+	Error        string             `json:"error,omitempty"`
+}
+
+// MemberImportResult は一括登録の結果（ドライランの場合は実行した場合の見込み）
+type MemberImportResult struct {
+	DryRun    bool                    `json:"dry_run"`
+	Total     int                     `json:"total"`
+	Created   int                     `json:"created"`
+	Updated   int                     `json:"updated"`
+	Unchanged int                     `json:"unchanged"`
+	Failed    int                     `json:"failed"`
+	Rows      []MemberImportRowResult `json:"rows"`
+}
+
+// AddRow は行の結果を追加して件数を集計
+func (r *MemberImportResult) AddRow(row MemberImportRowResult) {
+	r.Total++
+	switch row.Action {
+	case MemberImportActionCreate:
+		r.Created++
+	case MemberImportActionUpdate:
+		r.Updated++
+	case MemberImportActionUnchanged:
+		r.Unchanged++
+	case MemberImportActionError:
+		r.Failed++
+	}
+	r.Rows = append(r.Rows, row)
+}
+
+// MemberImportRow はヘッダーの列名で値を参照できる取り込みファイルの1行
+type MemberImportRow struct {
+	Number int
+	values map[string]string
+}
+
+// Get は列の値を返す（列がない場合は空文字）
+func (r MemberImportRow) Get(column string) string {
+	return r.values[column]
+}
+
+// ParseMemberImportRows はヘッダー行から列を特定し、データ行を列名で参照できる形に変換
+// 空行は読み飛ばし、必須列が欠けている場合や行数が上限を超える場合はエラー
+func ParseMemberImportRows(rows [][]string, requiredColumns []string) ([]MemberImportRow, error) {
+	if len(rows) == 0 {
+		return nil, ErrInvalidImportHeader
+	}
+
+	columns := make(map[string]int, len(rows[0]))
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range requiredColumns {
+		if _, ok := columns[required]; !ok {
+			return nil, ErrInvalidImportHeader
+		}
+	}
+
+	result := make([]MemberImportRow, 0, len(rows)-1)
+	for i, cells := range rows[1:] {
+		if strings.Join(cells, "") == "" {
+			continue
+		}
+		if len(result) == MaxMemberImportRows {
+			return nil, ErrImportTooManyRows
+		}
+
+		row := MemberImportRow{Number: i + 2, values: make(map[string]string, len(requiredColumns))}
+		for _, column := range requiredColumns {
+			if index := columns[column]; index < len(cells) {
+				row.values[column] = cells[index]
+			}
+		}
+		result = append(result, row)
+	}
+	return result, nil
+}
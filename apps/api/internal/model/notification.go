@@ -0,0 +1,8 @@
+package model
+
+// Notification はユーザーへ送信する通知（メール）を表す構造体
+type Notification struct {
+	To      []string `json:"to"`
+	Subject string   `json:"subject"`
+	Body    string   `json:"body"`
+}
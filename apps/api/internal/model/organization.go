@@ -34,6 +34,26 @@ const (
 	OrganizationTypeAdmin OrganizationType = "admin"
 )
 
+// ValidOrganizationTypes は有効な組織種類の一覧
+var ValidOrganizationTypes = []OrganizationType{
+	OrganizationTypeCentralGov,
+	OrganizationTypeLocalGov,
+	OrganizationTypePublicSaas,
+	OrganizationTypeIndependent,
+	OrganizationTypeVendor,
+	OrganizationTypeAdmin,
+}
+
+// IsValid は組織種類が有効かどうかをチェック
+func (ot OrganizationType) IsValid() bool {
+	for _, validType := range ValidOrganizationTypes {
+		if ot == validType {
+			return true
+		}
+	}
+	return false
+}
+
 // OrgStatus は組織のステータスを定義する型
 type OrgStatus string
 
@@ -0,0 +1,357 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PlatformState は組織・プロジェクト・メンバー・ベンダー紐付け・CSPアカウント割り当ての宣言的な定義（YAML）
+// 一覧項目を省略（nil）した場合はその項目を管理対象外とし、空リストを指定した場合は「何もない状態」を意味する
+type PlatformState struct {
+	Organizations []StateOrganization `yaml:"organizations" json:"organizations"`
+	Projects      []StateProject      `yaml:"projects" json:"projects"`
+}
+
+// StateOrganization は組織の定義（組織名で識別）
+type StateOrganization struct {
+	Name        string           `yaml:"name" json:"name"`
+	Type        OrganizationType `yaml:"type" json:"type"`
+	Description *string          `yaml:"description" json:"description"`
+	Status      OrgStatus        `yaml:"status" json:"status"`
+}
+
+// StateProject はプロジェクトの定義（組織名とプロジェクト名で識別）
+type StateProject struct {
+	Name         string        `yaml:"name" json:"name"`
+	Organization string        `yaml:"organization" json:"organization"`
+	Type         ProjectType   `yaml:"type" json:"type"`
+	Description  *string       `yaml:"description" json:"description"`
+	Status       ProjectStatus `yaml:"status" json:"status"`
+	Tags         ProjectTags   `yaml:"tags" json:"tags"`
+
+	Members     []StateMember         `yaml:"members" json:"members"`
+	Vendors     []StateVendorRelation `yaml:"vendors" json:"vendors"`           // このプロジェクトを紐付け元とするベンダー紐付け
+	CSPAccounts []StateCSPAccount     `yaml:"csp_accounts" json:"csp_accounts"` // プロジェクトに紐付けるCSPアカウントとそのメンバー
+}
+
+// StateMember はプロジェクトメンバーの定義
+type StateMember struct {
+	Email string `yaml:"email" json:"email"`
+	Role  Role   `yaml:"role" json:"role"`
+}
+
+// StateVendorRelation はベンダー紐付けの定義（ベンダープロジェクトを組織名とプロジェクト名で指定）
+type StateVendorRelation struct {
+	Organization string `yaml:"organization" json:"organization"`
+	Project      string `yaml:"project" json:"project"`
+	StartDate    string `yaml:"start_date" json:"start_date"`
+	EndDate      string `yaml:"end_date" json:"end_date"`
+}
+
+// StateCSPAccount はプロジェクトに紐付けるCSPアカウントの定義（プロバイダーとプロバイダー側のアカウントIDで識別）
+type StateCSPAccount struct {
+	Provider  CSPProvider             `yaml:"provider" json:"provider"`
+	AccountID string                  `yaml:"account_id" json:"account_id"`
+	Members   []StateCSPAccountMember `yaml:"members" json:"members"`
+}
+
+// StateCSPAccountMember はCSPアカウントメンバーの定義
+type StateCSPAccountMember struct {
+	Email string `yaml:"email" json:"email"`
+	Role  string `yaml:"role" json:"role"`
+}
+
+// ParsePlatformState はYAML（JSONも可）の定義を読み込む（未知のキーはエラー）
+func ParsePlatformState(data []byte) (*PlatformState, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var state PlatformState
+	if err := decoder.Decode(&state); err != nil {
+		return nil, &StateValidationError{Problems: []string{err.Error()}}
+	}
+	return &state, nil
+}
+
+// StateOrganizationKey は組織の識別キー
+func StateOrganizationKey(name string) string {
+	return name
+}
+
+// StateProjectKey はプロジェクトの識別キー
+func StateProjectKey(organization, name string) string {
+	return organization + "/" + name
+}
+
+// StateCSPAccountKey はCSPアカウントの識別キー
+func StateCSPAccountKey(provider CSPProvider, accountID string) string {
+	return string(provider) + ":" + accountID
+}
+
+// Validate は定義内の重複・値の妥当性・参照関係をチェック（DBとの整合性はプラン作成時に確認）
+func (s *PlatformState) Validate() error {
+	var problems []string
+	addProblem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	organizations := make(map[string]bool, len(s.Organizations))
+	for i, org := range s.Organizations {
+		if strings.TrimSpace(org.Name) == "" {
+			addProblem("organizations[%d]: name is required", i)
+			continue
+		}
+		if organizations[org.Name] {
+			addProblem("organization %q is declared more than once", org.Name)
+		}
+		organizations[org.Name] = true
+		if org.Type != "" && !org.Type.IsValid() {
+			addProblem("organization %q: invalid type %q", org.Name, org.Type)
+		}
+		if org.Status != "" && !org.Status.IsValid() {
+			addProblem("organization %q: invalid status %q", org.Name, org.Status)
+		}
+	}
+
+	projects := make(map[string]bool, len(s.Projects))
+	for i, project := range s.Projects {
+		if strings.TrimSpace(project.Name) == "" || strings.TrimSpace(project.Organization) == "" {
+			addProblem("projects[%d]: name and organization are required", i)
+			continue
+		}
+		key := StateProjectKey(project.Organization, project.Name)
+		if projects[key] {
+			addProblem("project %q is declared more than once", key)
+		}
+		projects[key] = true
+
+		if project.Status != "" && !project.Status.IsValid() {
+			addProblem("project %q: invalid status %q", key, project.Status)
+		}
+		if project.Type != "" && !project.Type.IsValid() {
+			addProblem("project %q: invalid type %q", key, project.Type)
+		}
+		if project.Tags != nil {
+			if err := project.Tags.Validate(); err != nil {
+				addProblem("project %q: %v", key, err)
+			}
+		}
+
+		if project.Members != nil {
+			owners := 0
+			emails := make(map[string]bool, len(project.Members))
+			for _, member := range project.Members {
+				email := strings.ToLower(member.Email)
+				if email == "" {
+					addProblem("project %q: member email is required", key)
+					continue
+				}
+				if emails[email] {
+					addProblem("project %q: member %q is declared more than once", key, member.Email)
+				}
+				emails[email] = true
+				if !member.Role.IsValid() {
+					addProblem("project %q: member %q has invalid role %q", key, member.Email, member.Role)
+				}
+				if member.Role == RoleOwner {
+					owners++
+				}
+			}
+			if owners != 1 {
+				addProblem("project %q: exactly one owner is required in members", key)
+			}
+		}
+
+		vendors := make(map[string]bool, len(project.Vendors))
+		for _, vendor := range project.Vendors {
+			vendorKey := StateProjectKey(vendor.Organization, vendor.Project)
+			if vendors[vendorKey] {
+				addProblem("project %q: vendor %q is declared more than once", key, vendorKey)
+			}
+			vendors[vendorKey] = true
+			if vendorKey == key {
+				addProblem("project %q: cannot be its own vendor", key)
+			}
+			if _, _, err := ParseContractPeriod(vendor.StartDate, vendor.EndDate); err != nil {
+				addProblem("project %q: vendor %q has invalid contract period", key, vendorKey)
+			}
+		}
+
+		accounts := make(map[string]bool, len(project.CSPAccounts))
+		for _, account := range project.CSPAccounts {
+			accountKey := StateCSPAccountKey(account.Provider, account.AccountID)
+			if !account.Provider.IsValid() || account.AccountID == "" {
+				addProblem("project %q: CSP account %q requires a valid provider and account_id", key, accountKey)
+				continue
+			}
+			if accounts[accountKey] {
+				addProblem("project %q: CSP account %q is declared more than once", key, accountKey)
+			}
+			accounts[accountKey] = true
+
+			memberEmails := make(map[string]bool, len(account.Members))
+			for _, member := range account.Members {
+				email := strings.ToLower(member.Email)
+				if email == "" {
+					addProblem("project %q: CSP account %q member email is required", key, accountKey)
+					continue
+				}
+				if memberEmails[email] {
+					addProblem("project %q: CSP account %q member %q is declared more than once", key, accountKey, member.Email)
+				}
+				memberEmails[email] = true
+				if member.Role != "" && !CSPAccountMemberRole(member.Role).IsValid() {
+					addProblem("project %q: CSP account %q member %q has invalid role %q", key, accountKey, member.Email, member.Role)
+				}
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return &StateValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// Emails は定義内で参照される全てのメールアドレス（小文字）を返す
+func (s *PlatformState) Emails() []string {
+	seen := make(map[string]bool)
+	var emails []string
+	add := func(email string) {
+		email = strings.ToLower(email)
+		if email != "" && !seen[email] {
+			seen[email] = true
+			emails = append(emails, email)
+		}
+	}
+	for _, project := range s.Projects {
+		for _, member := range project.Members {
+			add(member.Email)
+		}
+		for _, account := range project.CSPAccounts {
+			for _, member := range account.Members {
+				add(member.Email)
+			}
+		}
+	}
+	return emails
+}
+
+// StateValidationError は定義の検証エラー（問題点の一覧を持つ）
+type StateValidationError struct {
+	Problems []string
+}
+
+func (e *StateValidationError) Error() string {
+	return "invalid platform state: " + strings.Join(e.Problems, "; ")
+}
+
+// StateResourceKind は同期対象のリソース種別を定義する型
+type StateResourceKind string
+
+// リソース種別定数（プランはこの順に作成・更新し、削除は逆順に行う）
+const (
+	StateResourceOrganization      StateResourceKind = "organization"
+	StateResourceProject           StateResourceKind = "project"
+	StateResourceProjectMember     StateResourceKind = "project_member"
+	StateResourceVendorRelation    StateResourceKind = "vendor_relation"
+	StateResourceProjectCSPAccount StateResourceKind = "project_csp_account"
+	StateResourceCSPAccountMember  StateResourceKind = "csp_account_member"
+)
+
+// StateChangeAction は変更内容を定義する型
+type StateChangeAction string
+
+// 変更内容定数
+const (
+	StateChangeCreate StateChangeAction = "create"
+	StateChangeUpdate StateChangeAction = "update"
+	StateChangeDelete StateChangeAction = "delete" // ベンダー紐付けは終了、その他はソフトデリート
+)
+
+// StateFieldChange は更新される項目の変更前後の値
+type StateFieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// StateChange はプランの1件の変更
+type StateChange struct {
+	Action  StateChangeAction  `json:"action"`
+	Kind    StateResourceKind  `json:"kind"`
+	Key     string             `json:"key"`
+	Changes []StateFieldChange `json:"changes,omitempty"`
+
+	Target StateChangeTarget `json:"-"` // 適用時に使用する対象リソースの情報
+}
+
+// StateChangeTarget は変更を適用するための対象リソースの情報
+// 新規作成される組織・プロジェクトはIDが未確定のため識別キーで参照する
+type StateChangeTarget struct {
+	ID               uint // 更新・削除対象のID
+	OrganizationID   uint
+	OrganizationKey  string
+	ProjectID        uint
+	ProjectKey       string
+	VendorProjectID  uint
+	VendorProjectKey string
+	UserID           uint
+	Email            string
+	CSPAccountID     uint
+	Role             string
+
+	Organization *StateOrganization
+	Project      *StateProject
+	Vendor       *StateVendorRelation
+}
+
+// StatePlanSummary はプランの変更件数
+type StatePlanSummary struct {
+	Create int `json:"create"`
+	Update int `json:"update"`
+	Delete int `json:"delete"`
+}
+
+// StatePlan は定義とDBの差分から作成した変更計画
+// Fingerprintは変更内容のハッシュで、プラン確認後に状態が変わっていないことを適用時に検証するために使う
+type StatePlan struct {
+	Prune       bool             `json:"prune"`
+	Fingerprint string           `json:"fingerprint"`
+	Summary     StatePlanSummary `json:"summary"`
+	Changes     []StateChange    `json:"changes"`
+	Applied     bool             `json:"applied"`
+}
+
+// Add は変更を追加して件数を集計
+func (p *StatePlan) Add(change StateChange) {
+	switch change.Action {
+	case StateChangeCreate:
+		p.Summary.Create++
+	case StateChangeUpdate:
+		p.Summary.Update++
+	case StateChangeDelete:
+		p.Summary.Delete++
+	}
+	p.Changes = append(p.Changes, change)
+}
+
+// HasChanges は変更があるかどうかを判定
+func (p *StatePlan) HasChanges() bool {
+	return len(p.Changes) > 0
+}
+
+// StateSnapshot はプラン作成時に読み込む現在のDBの状態
+type StateSnapshot struct {
+	Organizations      []Organization
+	Projects           []Project
+	Members            []UserProjectRole       // Userを含む
+	VendorRelations    []ProjectVendorRelation // 継続中（提案中・承諾済み・有効）のもの
+	ProjectCSPAccounts []ProjectCSPAccount     // CSPAccountを含む
+	CSPAccountMembers  []CSPAccountMember      // User・CSPAccountを含む
+	CSPAccounts        []CSPAccount
+	Users              []User // 定義内で参照されるユーザー
+}
@@ -14,6 +14,11 @@ type Project struct {
 	Status         ProjectStatus  `json:"status" gorm:"not null;default:'active'" validate:"required"`
 	OrganizationID uint           `json:"organization_id" gorm:"not null;index"`
 	ProjectType    ProjectType    `json:"project_type" gorm:"not null;default:'normal'" validate:"required"`
+	Tags           ProjectTags    `json:"tags" gorm:"type:jsonb;serializer:json"`              // キーバリュー形式のタグ
+	CustomAttributes CustomAttributeValues `json:"custom_attributes" gorm:"type:jsonb;serializer:json"` // 組織定義のカスタム属性値
+	StatusChangedAt *time.Time    `json:"status_changed_at"`
+	StatusChangedBy *uint         `json:"status_changed_by"`
+	DeletedBy      *uint          `json:"-"` // 削除実行者（ゴミ箱表示用）
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
 	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
@@ -46,6 +51,26 @@ const (
 	ProjectTypeAdmin ProjectType = "admin" 
 )
 
+// ValidProjectTypes は有効なプロジェクト種類の一覧
+var ValidProjectTypes = []ProjectType{
+	ProjectTypeCentralGov,
+	ProjectTypeLocalGov,
+	ProjectTypePublicSaas,
+	ProjectTypeIndependent,
+	ProjectTypeVendor,
+	ProjectTypeAdmin,
+}
+
+// IsValid はプロジェクト種類が有効かどうかをチェック
+func (pt ProjectType) IsValid() bool {
+	for _, validType := range ValidProjectTypes {
+		if pt == validType {
+			return true
+		}
+	}
+	return false
+}
+
 // ValidProjectStatuses は有効なプロジェクトステータスの一覧
 var ValidProjectStatuses = []ProjectStatus{
 	ProjectStatusActive,
@@ -68,6 +93,28 @@ func (ps ProjectStatus) String() string {
 	return string(ps)
 }
 
+// projectStatusTransitions は許可されるプロジェクトステータス遷移
+var projectStatusTransitions = map[ProjectStatus][]ProjectStatus{
+	ProjectStatusActive:   {ProjectStatusInactive, ProjectStatusArchived},
+	ProjectStatusInactive: {ProjectStatusActive, ProjectStatusArchived},
+	ProjectStatusArchived: {ProjectStatusActive},
+}
+
+// CanTransitionTo は指定したステータスへ遷移できるかどうかをチェック
+func (ps ProjectStatus) CanTransitionTo(next ProjectStatus) bool {
+	for _, allowed := range projectStatusTransitions[ps] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsReadOnly はステータスが読み取り専用（更新不可）かどうかを判定
+func (ps ProjectStatus) IsReadOnly() bool {
+	return ps == ProjectStatusArchived
+}
+
 // IsVendorProject はベンダープロジェクトかどうかを判定
 func (p *Project) IsVendorProject() bool {
 	return p.ProjectType == ProjectTypeVendor
@@ -85,6 +132,9 @@ type ProjectCreateRequest struct {
 	Status         ProjectStatus `json:"status" validate:"omitempty"`
 	OrganizationID uint          `json:"organization_id" validate:"required"`
 	ProjectType    ProjectType   `json:"project_type" validate:"omitempty"`
+	Tags           ProjectTags   `json:"tags"`
+	CustomAttributes CustomAttributeValues `json:"custom_attributes"`
+	TemplateID     *uint         `json:"template_id"` // 適用するプロジェクトテンプレート
 }
 
 // ProjectUpdateRequest はプロジェクト更新リクエストの構造体
@@ -94,6 +144,26 @@ type ProjectUpdateRequest struct {
 	Status         ProjectStatus `json:"status" validate:"omitempty"`
 	OrganizationID uint          `json:"organization_id" validate:"omitempty"`
 	ProjectType    ProjectType   `json:"project_type" validate:"omitempty"`
+	Tags           ProjectTags   `json:"tags"`              // nilの場合は変更なし、空オブジェクトで全削除
+	CustomAttributes CustomAttributeValues `json:"custom_attributes"` // 指定時は全体を置き換え
+}
+
+// ProjectStatusTransitionRequest はプロジェクトのステータス遷移リクエストの構造体
+type ProjectStatusTransitionRequest struct {
+	Status ProjectStatus `json:"status" binding:"required"`
+}
+
+// TrashedProjectResponse はゴミ箱（ソフトデリート済み）プロジェクトのレスポンス構造体
+type TrashedProjectResponse struct {
+	ID              uint          `json:"id"`
+	Name            string        `json:"name"`
+	Description     string        `json:"description"`
+	Status          ProjectStatus `json:"status"`
+	OrganizationID  uint          `json:"organization_id"`
+	ProjectType     ProjectType   `json:"project_type"`
+	DeletedAt       time.Time     `json:"deleted_at"`
+	DeletedBy       *uint         `json:"deleted_by"`
+	RestoreDeadline time.Time     `json:"restore_deadline"` // この日時を過ぎると復元不可
 }
 
 // ProjectResponse はプロジェクトレスポンスの構造体
@@ -105,9 +175,13 @@ type ProjectResponse struct {
 	OrganizationID uint          `json:"organization_id"`
 	ProjectType    ProjectType   `json:"project_type"`
 	Organization   *OrganizationResponse `json:"organization,omitempty"` // 組織情報
+	Tags           ProjectTags   `json:"tags"`
+	CustomAttributes CustomAttributeValues `json:"custom_attributes"`
 	CreatedAt      time.Time     `json:"created_at"`
 	UpdatedAt      time.Time     `json:"updated_at"`
 	UserRole       Role          `json:"user_role,omitempty"` // 現在のユーザーのロール
+	TemplateResult *ProjectTemplateApplyResult `json:"template_result,omitempty"` // テンプレート適用結果（作成時のみ）
+	CloneResult    *ProjectCloneResult         `json:"clone_result,omitempty"`    // 複製結果（複製時のみ）
 }
 
 // ProjectDetails はプロジェクト詳細情報の構造体（Repository層用）
@@ -118,6 +192,8 @@ type ProjectDetails struct {
 	Status         ProjectStatus `json:"status"`
 	OrganizationID uint          `json:"organization_id"`
 	ProjectType    ProjectType   `json:"project_type"`
+	Tags           ProjectTags   `json:"tags"`
+	CustomAttributes CustomAttributeValues `json:"custom_attributes"`
 	CreatedAt      time.Time     `json:"created_at"`
 	UpdatedAt      time.Time     `json:"updated_at"`
 }
@@ -0,0 +1,101 @@
+package model
+
+import "strings"
+
+// ProjectCloneItem は複製対象の項目を定義する型
+type ProjectCloneItem string
+
+// 複製対象項目定数
+const (
+	ProjectCloneMetadata        ProjectCloneItem = "metadata"         // 説明・タグ・カスタム属性
+	ProjectCloneMembers         ProjectCloneItem = "members"          // メンバーとロール
+	ProjectCloneVendorRelations ProjectCloneItem = "vendor_relations" // ベンダー紐付け（提案として作成）
+	ProjectCloneCSPRequests     ProjectCloneItem = "csp_requests"     // 複製元のCSPアカウントと同じ構成の新規申請
+)
+
+// ValidProjectCloneItems は有効な複製対象項目の一覧
+var ValidProjectCloneItems = []ProjectCloneItem{
+	ProjectCloneMetadata,
+	ProjectCloneMembers,
+	ProjectCloneVendorRelations,
+	ProjectCloneCSPRequests,
+}
+
+// DefaultProjectCloneItems は複製対象の指定がない場合の項目（CSP申請は明示的に指定した場合のみ）
+var DefaultProjectCloneItems = []ProjectCloneItem{
+	ProjectCloneMetadata,
+	ProjectCloneMembers,
+	ProjectCloneVendorRelations,
+}
+
+// IsValid は複製対象項目が有効かどうかをチェック
+func (i ProjectCloneItem) IsValid() bool {
+	for _, valid := range ValidProjectCloneItems {
+		if i == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectCloneRequest はプロジェクト複製リクエストの構造体
+type ProjectCloneRequest struct {
+	Name                 string             `json:"name" binding:"required"`
+	Description          *string            `json:"description"`             // 指定した場合は複製元の説明より優先
+	OrganizationID       *uint              `json:"organization_id"`         // 未指定の場合は複製元と同じ組織
+	Include              []ProjectCloneItem `json:"include"`                 // 未指定の場合はDefaultProjectCloneItems
+	CSPAccountNameSuffix string             `json:"csp_account_name_suffix"` // CSP申請のアカウント名に付ける接尾辞（未指定の場合は"-"+プロジェクト名）
+}
+
+// Validate は複製リクエストの妥当性をチェック
+func (r *ProjectCloneRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return ErrInvalidProjectClone
+	}
+	for _, item := range r.Include {
+		if !item.IsValid() {
+			return ErrInvalidProjectClone
+		}
+	}
+	return nil
+}
+
+// Includes は指定した項目が複製対象かどうかを判定
+func (r *ProjectCloneRequest) Includes(item ProjectCloneItem) bool {
+	items := r.Include
+	if len(items) == 0 {
+		items = DefaultProjectCloneItems
+	}
+	for _, included := range items {
+		if included == item {
+			return true
+		}
+	}
+	return false
+}
+
+// CSPAccountNameFor は複製元のCSPアカウント名から申請するアカウント名を作成
+func (r *ProjectCloneRequest) CSPAccountNameFor(sourceAccountName string) string {
+	suffix := r.CSPAccountNameSuffix
+	if suffix == "" {
+		suffix = "-" + r.Name
+	}
+	return sourceAccountName + suffix
+}
+
+// ProjectCloneResult はプロジェクト複製の結果（各項目の処理結果はテンプレート適用と同じ形式）
+type ProjectCloneResult struct {
+	SourceProjectID uint                 `json:"source_project_id"`
+	Include         []ProjectCloneItem   `json:"include"`
+	Steps           []TemplateStepResult `json:"steps"`
+}
+
+// AddStep は複製結果にステップを追加
+func (r *ProjectCloneResult) AddStep(step, target string, status TemplateStepStatus, message string) {
+	r.Steps = append(r.Steps, TemplateStepResult{
+		Step:    step,
+		Target:  target,
+		Status:  status,
+		Message: message,
+	})
+}
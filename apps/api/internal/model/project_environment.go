@@ -0,0 +1,156 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// EnvironmentApprovalStrictness は環境のCSP申請の承認の厳しさを定義する型
+type EnvironmentApprovalStrictness string
+
+// 承認の厳しさ定数
+const (
+	EnvironmentApprovalStandard EnvironmentApprovalStrictness = "standard" // メンバー・委任されたベンダーが提出可能
+	EnvironmentApprovalStrict   EnvironmentApprovalStrictness = "strict"   // 管理権限を持つメンバーのみ提出可能、ベンダーの提出は常にスポンサー承認が必要
+)
+
+// IsValid は承認の厳しさが有効かどうかをチェック
+func (s EnvironmentApprovalStrictness) IsValid() bool {
+	return s == EnvironmentApprovalStandard || s == EnvironmentApprovalStrict
+}
+
+// environmentNamePattern は環境名（dev, staging, prodなど）の形式
+var environmentNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+// ProjectEnvironment はプロジェクトの環境（dev/staging/prodなど）を表す構造体
+// CSPアカウント・CSP申請・メンバーの割り当ては環境単位で管理できる
+type ProjectEnvironment struct {
+	ID                 uint                          `json:"id" gorm:"primaryKey"`
+	ProjectID          uint                          `json:"project_id" gorm:"not null;uniqueIndex:idx_project_environment_name,where:deleted_at IS NULL"`
+	Name               string                        `json:"name" gorm:"not null;size:50;uniqueIndex:idx_project_environment_name,where:deleted_at IS NULL"`
+	Description        string                        `json:"description" gorm:"type:text"`
+	ApprovalStrictness EnvironmentApprovalStrictness `json:"approval_strictness" gorm:"not null;default:'standard';size:20"`
+	AllowedRegions     []string                      `json:"allowed_regions" gorm:"type:jsonb;serializer:json"` // 空の場合は制限なし
+	DefaultMemberRole  CSPAccountMemberRole          `json:"default_member_role" gorm:"not null;default:'user';size:50"`
+	DefaultSSOProvider string                        `json:"default_sso_provider" gorm:"not null;default:'default';size:50"`
+	CreatedBy          uint                          `json:"created_by" gorm:"not null"`
+	CreatedAt          time.Time                     `json:"created_at"`
+	UpdatedAt          time.Time                     `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt                `json:"-" gorm:"index"`
+}
+
+// TableName はテーブル名を指定
+func (ProjectEnvironment) TableName() string {
+	return "project_environments"
+}
+
+// AllowsRegion は指定したリージョンを利用できるかどうかを判定
+func (e *ProjectEnvironment) AllowsRegion(region string) bool {
+	if len(e.AllowedRegions) == 0 {
+		return true
+	}
+	for _, allowed := range e.AllowedRegions {
+		if allowed == region {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectEnvironmentMember は環境単位で付与されたメンバー権限
+// 環境に属するCSPアカウントのメンバーには、環境のメンバーが自動で割り当てられる
+type ProjectEnvironmentMember struct {
+	ID            uint                 `json:"id" gorm:"primaryKey"`
+	EnvironmentID uint                 `json:"environment_id" gorm:"not null;uniqueIndex:idx_project_environment_member,where:deleted_at IS NULL"`
+	ProjectID     uint                 `json:"project_id" gorm:"not null;index"`
+	UserID        uint                 `json:"user_id" gorm:"not null;index;uniqueIndex:idx_project_environment_member,where:deleted_at IS NULL"`
+	Role          CSPAccountMemberRole `json:"role" gorm:"not null;default:'user';size:50"`
+	CreatedBy     uint                 `json:"created_by" gorm:"not null"`
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt       `json:"-" gorm:"index"`
+
+	// リレーション
+	Environment ProjectEnvironment `json:"-" gorm:"foreignKey:EnvironmentID"`
+	User        User               `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// TableName はテーブル名を指定
+func (ProjectEnvironmentMember) TableName() string {
+	return "project_environment_members"
+}
+
+// ProjectEnvironmentRequest は環境の作成・更新リクエスト
+type ProjectEnvironmentRequest struct {
+	Name               string                        `json:"name" binding:"required"`
+	Description        string                        `json:"description"`
+	ApprovalStrictness EnvironmentApprovalStrictness `json:"approval_strictness"`
+	AllowedRegions     []string                      `json:"allowed_regions"`
+	DefaultMemberRole  CSPAccountMemberRole          `json:"default_member_role"`
+	DefaultSSOProvider string                        `json:"default_sso_provider"`
+}
+
+// Validate は環境の設定を検証して未指定の項目にデフォルト値を設定
+func (r *ProjectEnvironmentRequest) Validate() error {
+	r.Name = strings.ToLower(strings.TrimSpace(r.Name))
+	if !environmentNamePattern.MatchString(r.Name) {
+		return ErrInvalidEnvironment
+	}
+	if r.ApprovalStrictness == "" {
+		r.ApprovalStrictness = EnvironmentApprovalStandard
+	}
+	if !r.ApprovalStrictness.IsValid() {
+		return ErrInvalidEnvironment
+	}
+	if r.DefaultMemberRole == "" {
+		r.DefaultMemberRole = CSPAccountMemberRoleUser
+	}
+	if !r.DefaultMemberRole.IsValid() {
+		return ErrInvalidEnvironment
+	}
+	if r.DefaultSSOProvider == "" {
+		r.DefaultSSOProvider = "default"
+	}
+
+	regions := []string{}
+	for _, region := range r.AllowedRegions {
+		region = strings.TrimSpace(region)
+		if region == "" {
+			return ErrInvalidEnvironment
+		}
+		regions = append(regions, region)
+	}
+	r.AllowedRegions = regions
+	return nil
+}
+
+// ApplyTo は検証済みのリクエストの内容を環境に反映
+func (r *ProjectEnvironmentRequest) ApplyTo(environment *ProjectEnvironment) {
+	environment.Name = r.Name
+	environment.Description = r.Description
+	environment.ApprovalStrictness = r.ApprovalStrictness
+	environment.AllowedRegions = r.AllowedRegions
+	environment.DefaultMemberRole = r.DefaultMemberRole
+	environment.DefaultSSOProvider = r.DefaultSSOProvider
+}
+
+// ProjectEnvironmentMemberRequest は環境へのメンバー追加リクエスト（ロール未指定の場合は環境のデフォルト）
+type ProjectEnvironmentMemberRequest struct {
+	UserID uint                 `json:"user_id" binding:"required"`
+	Role   CSPAccountMemberRole `json:"role"`
+}
+
+// ProjectCSPAccountEnvironmentRequest はプロジェクトのCSPアカウントの所属環境の変更リクエスト（nullで環境から外す）
+type ProjectCSPAccountEnvironmentRequest struct {
+	EnvironmentID *uint `json:"environment_id"`
+}
+
+// ProjectEnvironmentDetail は環境と所属するCSPアカウント・メンバー
+type ProjectEnvironmentDetail struct {
+	ProjectEnvironment
+	CSPAccounts []ProjectCSPAccount        `json:"csp_accounts"`
+	Members     []ProjectEnvironmentMember `json:"members"`
+}
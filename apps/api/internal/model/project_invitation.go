@@ -0,0 +1,139 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// InvitationStatus はプロジェクト招待のステータスを定義する型
+type InvitationStatus string
+
+// 招待ステータス定数
+const (
+	InvitationStatusPending  InvitationStatus = "pending"  // 承諾待ち
+	InvitationStatusAccepted InvitationStatus = "accepted" // 承諾済み
+	InvitationStatusRevoked  InvitationStatus = "revoked"  // 取り消し済み
+)
+
+// ValidInvitationStatuses は有効な招待ステータスの一覧
+var ValidInvitationStatuses = []InvitationStatus{
+	InvitationStatusPending,
+	InvitationStatusAccepted,
+	InvitationStatusRevoked,
+}
+
+// IsValid は招待ステータスが有効かどうかをチェック
+func (s InvitationStatus) IsValid() bool {
+	for _, validStatus := range ValidInvitationStatuses {
+		if s == validStatus {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectInvitation はメールアドレス宛てのプロジェクト招待を表す構造体
+type ProjectInvitation struct {
+	ID         uint             `json:"id" gorm:"primaryKey"`
+	ProjectID  uint             `json:"project_id" gorm:"not null;index"`
+	Email      string           `json:"email" gorm:"not null;size:255;index"`
+	Role       Role             `json:"role" gorm:"not null;type:varchar(50)"`
+	Status     InvitationStatus `json:"status" gorm:"not null;default:'pending';size:50;index"`
+	TokenID    string           `json:"-" gorm:"not null;size:64;uniqueIndex"` // 現在有効な招待トークンのID（再送時に更新）
+	ExpiresAt  time.Time        `json:"expires_at" gorm:"not null"`
+	InvitedBy  uint             `json:"invited_by" gorm:"not null;index"`
+	AcceptedBy *uint            `json:"accepted_by"`
+	AcceptedAt *time.Time       `json:"accepted_at"`
+	LastSentAt time.Time        `json:"last_sent_at"`
+	SendCount  int              `json:"send_count" gorm:"not null;default:0"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt   `json:"-" gorm:"index"`
+
+	// リレーション
+	Project       Project `json:"project,omitempty" gorm:"foreignKey:ProjectID"`
+	InvitedByUser User    `json:"invited_by_user,omitempty" gorm:"foreignKey:InvitedBy"`
+}
+
+// TableName はテーブル名を指定
+func (ProjectInvitation) TableName() string {
+	return "project_invitations"
+}
+
+// IsExpired は招待の有効期限が切れているかどうかを判定
+func (i *ProjectInvitation) IsExpired(now time.Time) bool {
+	return now.After(i.ExpiresAt)
+}
+
+// BeforeCreate はレコード作成前のバリデーション
+func (i *ProjectInvitation) BeforeCreate(tx *gorm.DB) error {
+	if !i.Role.IsValid() {
+		return ErrInvalidRole
+	}
+	return nil
+}
+
+// ProjectInvitationCreateRequest はプロジェクト招待作成リクエストの構造体
+type ProjectInvitationCreateRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  Role   `json:"role" binding:"required"`
+}
+
+// InvitationAcceptRequest は招待承諾リクエストの構造体
+type InvitationAcceptRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// InvitationRegisterRequest は招待リンク経由のユーザー登録リクエストの構造体
+type InvitationRegisterRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+// ProjectInvitationResponse はプロジェクト招待レスポンスの構造体
+type ProjectInvitationResponse struct {
+	ID         uint             `json:"id"`
+	ProjectID  uint             `json:"project_id"`
+	Email      string           `json:"email"`
+	Role       Role             `json:"role"`
+	Status     InvitationStatus `json:"status"`
+	Expired    bool             `json:"expired"`
+	ExpiresAt  time.Time        `json:"expires_at"`
+	InvitedBy  uint             `json:"invited_by"`
+	AcceptedBy *uint            `json:"accepted_by,omitempty"`
+	AcceptedAt *time.Time       `json:"accepted_at,omitempty"`
+	LastSentAt time.Time        `json:"last_sent_at"`
+	SendCount  int              `json:"send_count"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// InvitationPreviewResponse は招待リンクを開いた際に表示する招待内容
+type InvitationPreviewResponse struct {
+	ProjectID      uint      `json:"project_id"`
+	ProjectName    string    `json:"project_name"`
+	Email          string    `json:"email"`
+	Role           Role      `json:"role"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	UserRegistered bool      `json:"user_registered"` // 招待先メールアドレスのユーザーが登録済みか
+}
+
+// NewProjectInvitationResponse はProjectInvitationからレスポンスを作成
+func NewProjectInvitationResponse(invitation *ProjectInvitation, now time.Time) ProjectInvitationResponse {
+	return ProjectInvitationResponse{
+		ID:         invitation.ID,
+		ProjectID:  invitation.ProjectID,
+		Email:      invitation.Email,
+		Role:       invitation.Role,
+		Status:     invitation.Status,
+		Expired:    invitation.Status == InvitationStatusPending && invitation.IsExpired(now),
+		ExpiresAt:  invitation.ExpiresAt,
+		InvitedBy:  invitation.InvitedBy,
+		AcceptedBy: invitation.AcceptedBy,
+		AcceptedAt: invitation.AcceptedAt,
+		LastSentAt: invitation.LastSentAt,
+		SendCount:  invitation.SendCount,
+		CreatedAt:  invitation.CreatedAt,
+	}
+}
@@ -0,0 +1,194 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// タグ・カスタム属性の制約
+const (
+	MaxProjectTags        = 50
+	MaxProjectTagValueLen = 255
+)
+
+// metadataKeyPattern はタグキー・カスタム属性キーとして許可する形式
+var metadataKeyPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.:/-]{0,62}$`)
+
+// ProjectTags はプロジェクトに付与するキーバリュー形式のタグ（例: env=production）
+type ProjectTags map[string]string
+
+// Validate はタグのキー形式・値の長さ・件数をチェック
+func (t ProjectTags) Validate() error {
+	if len(t) > MaxProjectTags {
+		return fmt.Errorf("%w: too many tags (max %d)", ErrInvalidProjectTag, MaxProjectTags)
+	}
+	for key, value := range t {
+		if !metadataKeyPattern.MatchString(key) {
+			return fmt.Errorf("%w: invalid key %q", ErrInvalidProjectTag, key)
+		}
+		if len(value) > MaxProjectTagValueLen {
+			return fmt.Errorf("%w: value of %q is too long", ErrInvalidProjectTag, key)
+		}
+	}
+	return nil
+}
+
+// CustomAttributeValues はプロジェクトのカスタム属性値（キーは属性定義のKey）
+type CustomAttributeValues map[string]interface{}
+
+// CustomAttributeType はカスタム属性の値の型を定義する型
+type CustomAttributeType string
+
+// カスタム属性型定数
+const (
+	CustomAttributeTypeString  CustomAttributeType = "string"
+	CustomAttributeTypeNumber  CustomAttributeType = "number"
+	CustomAttributeTypeBoolean CustomAttributeType = "boolean"
+	CustomAttributeTypeDate    CustomAttributeType = "date" // YYYY-MM-DD
+	CustomAttributeTypeEnum    CustomAttributeType = "enum"
+)
+
+// ValidCustomAttributeTypes は有効なカスタム属性型の一覧
+var ValidCustomAttributeTypes = []CustomAttributeType{
+	CustomAttributeTypeString,
+	CustomAttributeTypeNumber,
+	CustomAttributeTypeBoolean,
+	CustomAttributeTypeDate,
+	CustomAttributeTypeEnum,
+}
+
+// IsValid はカスタム属性型が有効かどうかをチェック
+func (t CustomAttributeType) IsValid() bool {
+	for _, validType := range ValidCustomAttributeTypes {
+		if t == validType {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomAttributeDefinition は組織ごとに定義するプロジェクトのカスタム属性スキーマ
+// （例: 予算コード、コストセンター）
+type CustomAttributeDefinition struct {
+	ID             uint                `json:"id" gorm:"primaryKey"`
+	OrganizationID uint                `json:"organization_id" gorm:"not null;uniqueIndex:idx_custom_attr_defs_org_key,where:deleted_at IS NULL"`
+	Key            string              `json:"key" gorm:"not null;size:100;uniqueIndex:idx_custom_attr_defs_org_key,where:deleted_at IS NULL"`
+	Label          string              `json:"label" gorm:"not null;size:255"`
+	Description    string              `json:"description" gorm:"type:text"`
+	Type           CustomAttributeType `json:"type" gorm:"not null;type:varchar(20)"`
+	Required       bool                `json:"required" gorm:"not null;default:false"`
+	Options        []string            `json:"options,omitempty" gorm:"type:jsonb;serializer:json"` // enum型の選択肢
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt      `json:"-" gorm:"index"`
+}
+
+// TableName はテーブル名を指定
+func (CustomAttributeDefinition) TableName() string {
+	return "custom_attribute_definitions"
+}
+
+// Validate は属性定義そのものの妥当性をチェック
+func (d *CustomAttributeDefinition) Validate() error {
+	if !metadataKeyPattern.MatchString(d.Key) {
+		return fmt.Errorf("%w: invalid key %q", ErrInvalidCustomAttributeDefinition, d.Key)
+	}
+	if !d.Type.IsValid() {
+		return fmt.Errorf("%w: invalid type %q", ErrInvalidCustomAttributeDefinition, d.Type)
+	}
+	if d.Type == CustomAttributeTypeEnum && len(d.Options) == 0 {
+		return fmt.Errorf("%w: enum attribute requires options", ErrInvalidCustomAttributeDefinition)
+	}
+	return nil
+}
+
+// ValidateValue は値が属性定義の型に合致するかチェック
+func (d *CustomAttributeDefinition) ValidateValue(value interface{}) error {
+	switch d.Type {
+	case CustomAttributeTypeString:
+		if _, ok := value.(string); ok {
+			return nil
+		}
+	case CustomAttributeTypeNumber:
+		if _, ok := value.(float64); ok {
+			return nil
+		}
+	case CustomAttributeTypeBoolean:
+		if _, ok := value.(bool); ok {
+			return nil
+		}
+	case CustomAttributeTypeDate:
+		if s, ok := value.(string); ok {
+			if _, err := time.Parse("2006-01-02", s); err == nil {
+				return nil
+			}
+		}
+	case CustomAttributeTypeEnum:
+		if s, ok := value.(string); ok {
+			for _, option := range d.Options {
+				if s == option {
+					return nil
+				}
+			}
+		}
+	}
+	return fmt.Errorf("%w: %q must be a valid %s", ErrInvalidCustomAttribute, d.Key, d.Type)
+}
+
+// ValidateCustomAttributes は属性値を組織の属性定義に照らして検証
+// 未定義のキー、型の不一致、必須属性の欠落をエラーとする
+func ValidateCustomAttributes(definitions []CustomAttributeDefinition, values CustomAttributeValues) error {
+	definitionByKey := make(map[string]*CustomAttributeDefinition, len(definitions))
+	for i := range definitions {
+		definitionByKey[definitions[i].Key] = &definitions[i]
+	}
+
+	for key, value := range values {
+		definition, ok := definitionByKey[key]
+		if !ok {
+			return fmt.Errorf("%w: %q is not defined for this organization", ErrInvalidCustomAttribute, key)
+		}
+		if value == nil {
+			continue
+		}
+		if err := definition.ValidateValue(value); err != nil {
+			return err
+		}
+	}
+
+	for _, definition := range definitions {
+		if !definition.Required {
+			continue
+		}
+		if value, ok := values[definition.Key]; !ok || value == nil || value == "" {
+			return fmt.Errorf("%w: %q", ErrMissingRequiredCustomAttribute, definition.Key)
+		}
+	}
+
+	return nil
+}
+
+// CustomAttributeDefinitionRequest はカスタム属性定義の作成・更新リクエストの構造体
+type CustomAttributeDefinitionRequest struct {
+	Key         string              `json:"key" binding:"required"`
+	Label       string              `json:"label" binding:"required"`
+	Description string              `json:"description"`
+	Type        CustomAttributeType `json:"type" binding:"required"`
+	Required    bool                `json:"required"`
+	Options     []string            `json:"options"`
+}
+
+// ProjectMetadataFilter はタグ・カスタム属性によるプロジェクトの絞り込み条件
+// クエリパラメータ tag.<key>=<value> / attr.<key>=<value> から組み立てる
+type ProjectMetadataFilter struct {
+	Tags       map[string]string
+	Attributes map[string]string
+}
+
+// IsEmpty は絞り込み条件が指定されていないかどうかを判定
+func (f *ProjectMetadataFilter) IsEmpty() bool {
+	return f == nil || (len(f.Tags) == 0 && len(f.Attributes) == 0)
+}
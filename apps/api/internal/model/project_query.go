@@ -0,0 +1,72 @@
+package model
+
+import "time"
+
+// プロジェクト検索のページング設定
+const (
+	DefaultProjectQueryLimit = 20
+	MaxProjectQueryLimit     = 100
+)
+
+// projectSortColumns はソート指定に使えるキーと対応するカラム
+var projectSortColumns = map[string]string{
+	"name":       "p.name",
+	"status":     "p.status",
+	"created_at": "p.created_at",
+	"updated_at": "p.updated_at",
+}
+
+// ProjectQuery はプロジェクト検索条件を表す構造体
+type ProjectQuery struct {
+	Keyword        string          // 名前・説明の部分一致
+	OrganizationID uint            // 組織
+	Statuses       []ProjectStatus // ステータス（いずれかに一致）
+	ProjectTypes   []ProjectType   // プロジェクト種類（いずれかに一致）
+	Role           Role            // 検索ユーザーのプロジェクトロール
+	CSPProvider    CSPProvider     // 指定プロバイダーのCSPアカウントを持つプロジェクト
+	MemberOnly     bool            // 検索ユーザーが所属するプロジェクトに限定
+	Metadata       *ProjectMetadataFilter
+	Sort           string // name / status / created_at / updated_at
+	Order          string // asc / desc
+	Page           int
+	Limit          int
+}
+
+// Normalize はページング・ソート条件をデフォルト値で補正
+func (q *ProjectQuery) Normalize() {
+	if q.Page <= 0 {
+		q.Page = 1
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultProjectQueryLimit
+	}
+	if q.Limit > MaxProjectQueryLimit {
+		q.Limit = MaxProjectQueryLimit
+	}
+	if _, ok := projectSortColumns[q.Sort]; !ok {
+		q.Sort = "created_at"
+	}
+	if q.Order != "asc" {
+		q.Order = "desc"
+	}
+}
+
+// OrderClause はソート条件をORDER BY句として返す（IDで順序を安定させる）
+func (q *ProjectQuery) OrderClause() string {
+	return projectSortColumns[q.Sort] + " " + q.Order + ", p.id " + q.Order
+}
+
+// ProjectListItem はプロジェクト検索結果の1件を表す構造体
+type ProjectListItem struct {
+	ID             uint          `json:"id"`
+	Name           string        `json:"name"`
+	Description    string        `json:"description"`
+	Status         ProjectStatus `json:"status"`
+	OrganizationID uint          `json:"organization_id"`
+	ProjectType    ProjectType   `json:"project_type"`
+	Tags           ProjectTags   `json:"tags" gorm:"serializer:json"`
+	UserRole       *Role         `json:"user_role,omitempty"` // 検索ユーザーのロール（非メンバーの場合はnull）
+	JoinedAt       *time.Time    `json:"joined_at,omitempty"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ProjectTemplate はプロジェクト作成時に適用する標準構成（管理者が管理）
+type ProjectTemplate struct {
+	ID               uint                  `json:"id" gorm:"primaryKey"`
+	Name             string                `json:"name" gorm:"not null;size:255;uniqueIndex:idx_project_templates_name,where:deleted_at IS NULL"`
+	Description      string                `json:"description" gorm:"type:text"`
+	ProjectType      ProjectType           `json:"project_type" gorm:"size:50"` // 作成リクエストで未指定の場合に使う種類
+	Tags             ProjectTags           `json:"tags" gorm:"type:jsonb;serializer:json"`
+	MemberGroups     []TemplateMemberGroup `json:"member_groups" gorm:"type:jsonb;serializer:json"`
+	VendorProjectIDs []uint                `json:"vendor_project_ids" gorm:"type:jsonb;serializer:json"`
+	CSPRequests      []TemplateCSPRequest  `json:"csp_requests" gorm:"type:jsonb;serializer:json"`
+	CreatedBy        uint                  `json:"created_by" gorm:"not null;index"`
+	CreatedAt        time.Time             `json:"created_at"`
+	UpdatedAt        time.Time             `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt        `json:"-" gorm:"index"`
+}
+
+// TableName はテーブル名を指定
+func (ProjectTemplate) TableName() string {
+	return "project_templates"
+}
+
+// TemplateMemberGroup はテンプレートで同じロールを付与するメンバーのグループ
+// （例: 情報システム部門 → admin）
+type TemplateMemberGroup struct {
+	Name    string   `json:"name"`
+	Role    Role     `json:"role"`
+	UserIDs []uint   `json:"user_ids,omitempty"`
+	Emails  []string `json:"emails,omitempty"` // 登録済みユーザーのメールアドレス
+}
+
+// TemplateCSPRequest はテンプレートで初期申請するCSPアカウント
+// AccountNameの{project}はプロジェクト名に置換される
+type TemplateCSPRequest struct {
+	Provider    string `json:"provider"`
+	AccountName string `json:"account_name"`
+	Reason      string `json:"reason"`
+}
+
+// Validate はテンプレート定義の妥当性をチェック
+// オーナーはプロジェクト作成者のみとするため、メンバーグループにownerは指定できない
+func (t *ProjectTemplate) Validate() error {
+	if err := t.Tags.Validate(); err != nil {
+		return err
+	}
+	for _, group := range t.MemberGroups {
+		if !group.Role.IsValid() || group.Role == RoleOwner {
+			return fmt.Errorf("%w: invalid role %q for member group %q", ErrInvalidProjectTemplate, group.Role, group.Name)
+		}
+	}
+	for _, request := range t.CSPRequests {
+		if !CSPProvider(request.Provider).IsValid() {
+			return fmt.Errorf("%w: invalid CSP provider %q", ErrInvalidProjectTemplate, request.Provider)
+		}
+		if request.AccountName == "" || request.Reason == "" {
+			return fmt.Errorf("%w: CSP request requires account_name and reason", ErrInvalidProjectTemplate)
+		}
+	}
+	return nil
+}
+
+// AccountNameFor はプロジェクト名でプレースホルダーを置換したアカウント名を返す
+func (r *TemplateCSPRequest) AccountNameFor(projectName string) string {
+	return strings.ReplaceAll(r.AccountName, "{project}", projectName)
+}
+
+// ProjectTemplateRequest はプロジェクトテンプレートの作成・更新リクエストの構造体
+type ProjectTemplateRequest struct {
+	Name             string                `json:"name" binding:"required"`
+	Description      string                `json:"description"`
+	ProjectType      ProjectType           `json:"project_type"`
+	Tags             ProjectTags           `json:"tags"`
+	MemberGroups     []TemplateMemberGroup `json:"member_groups"`
+	VendorProjectIDs []uint                `json:"vendor_project_ids"`
+	CSPRequests      []TemplateCSPRequest  `json:"csp_requests"`
+}
+
+// TemplateStepStatus はテンプレート適用の各ステップの結果
+type TemplateStepStatus string
+
+// テンプレート適用ステップ結果定数
+const (
+	TemplateStepApplied TemplateStepStatus = "applied" // 適用済み
+	TemplateStepSkipped TemplateStepStatus = "skipped" // 対象が無効なためスキップ
+	TemplateStepFailed  TemplateStepStatus = "failed"  // 適用に失敗
+)
+
+// テンプレート適用ステップ種別定数
+const (
+	TemplateStepMember         = "member"
+	TemplateStepVendorRelation = "vendor_relation"
+	TemplateStepCSPRequest     = "csp_request"
+)
+
+// TemplateStepResult はテンプレート適用の1ステップの結果
+type TemplateStepResult struct {
+	Step    string             `json:"step"`
+	Target  string             `json:"target"` // 対象（ユーザー、ベンダープロジェクト、アカウント名など）
+	Status  TemplateStepStatus `json:"status"`
+	Message string             `json:"message,omitempty"`
+}
+
+// ProjectTemplateApplyResult はテンプレート適用結果
+type ProjectTemplateApplyResult struct {
+	TemplateID uint                 `json:"template_id"`
+	Steps      []TemplateStepResult `json:"steps"`
+}
+
+// AddStep は適用結果にステップを追加
+func (r *ProjectTemplateApplyResult) AddStep(step, target string, status TemplateStepStatus, message string) {
+	r.Steps = append(r.Steps, TemplateStepResult{
+		Step:    step,
+		Target:  target,
+		Status:  status,
+		Message: message,
+	})
+}
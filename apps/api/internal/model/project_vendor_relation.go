@@ -6,14 +6,83 @@ import (
 	"gorm.io/gorm"
 )
 
+// VendorRelationStatus はベンダー紐付けのステータスを定義する型
+type VendorRelationStatus string
+
+// ベンダー紐付けステータス定数
+const (
+	VendorRelationStatusProposed   VendorRelationStatus = "proposed"   // ベンダーの承諾待ち
+	VendorRelationStatusAccepted   VendorRelationStatus = "accepted"   // 承諾済み（契約開始日前）
+	VendorRelationStatusDeclined   VendorRelationStatus = "declined"   // ベンダーが辞退
+	VendorRelationStatusActive     VendorRelationStatus = "active"     // 契約期間中
+	VendorRelationStatusExpired    VendorRelationStatus = "expired"    // 契約終了日を経過
+	VendorRelationStatusTerminated VendorRelationStatus = "terminated" // いずれかの側が終了
+)
+
+// ValidVendorRelationStatuses は有効なベンダー紐付けステータスの一覧
+var ValidVendorRelationStatuses = []VendorRelationStatus{
+	VendorRelationStatusProposed,
+	VendorRelationStatusAccepted,
+	VendorRelationStatusDeclined,
+	VendorRelationStatusActive,
+	VendorRelationStatusExpired,
+	VendorRelationStatusTerminated,
+}
+
+// IsValid はベンダー紐付けステータスが有効かどうかをチェック
+func (s VendorRelationStatus) IsValid() bool {
+	for _, validStatus := range ValidVendorRelationStatuses {
+		if s == validStatus {
+			return true
+		}
+	}
+	return false
+}
+
+// vendorRelationTransitions は許可されるベンダー紐付けステータス遷移
+var vendorRelationTransitions = map[VendorRelationStatus][]VendorRelationStatus{
+	VendorRelationStatusProposed: {VendorRelationStatusAccepted, VendorRelationStatusActive, VendorRelationStatusDeclined},
+	VendorRelationStatusAccepted: {VendorRelationStatusActive, VendorRelationStatusExpired, VendorRelationStatusTerminated},
+	VendorRelationStatusActive:   {VendorRelationStatusExpired, VendorRelationStatusTerminated},
+}
+
+// CanTransitionTo は指定したステータスへ遷移できるかどうかをチェック
+func (s VendorRelationStatus) CanTransitionTo(next VendorRelationStatus) bool {
+	for _, allowed := range vendorRelationTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOpen は紐付けが継続中（提案中・承諾済み・有効）かどうかを判定
+// 同じプロジェクトとベンダーの組み合わせで継続中の紐付けは1件のみ許可する
+func (s VendorRelationStatus) IsOpen() bool {
+	return s == VendorRelationStatusProposed || s == VendorRelationStatusAccepted || s == VendorRelationStatusActive
+}
+
 // ProjectVendorRelation はベンダープロジェクトと他プロジェクトの紐付けを管理する構造体
+// 紐付け元プロジェクトが提案し、ベンダープロジェクトが承諾することで成立する
 type ProjectVendorRelation struct {
-	ID              uint           `json:"id" gorm:"primaryKey"`
-	ProjectID       uint           `json:"project_id" gorm:"not null;index"`        // 紐付け元プロジェクトID（通常プロジェクト）
-	VendorProjectID uint           `json:"vendor_project_id" gorm:"not null;index"` // 紐付け先ベンダープロジェクトID
-	CreatedAt       time.Time      `json:"created_at"`
-	UpdatedAt       time.Time      `json:"updated_at"`
-	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                   uint                 `json:"id" gorm:"primaryKey"`
+	ProjectID            uint                 `json:"project_id" gorm:"not null;index"`                      // 紐付け元プロジェクトID（通常プロジェクト）
+	VendorProjectID      uint                 `json:"vendor_project_id" gorm:"not null;index"`               // 紐付け先ベンダープロジェクトID
+	Status               VendorRelationStatus `json:"status" gorm:"not null;default:'active';size:20;index"` // 既存の紐付けは有効として扱う
+	StartDate            *time.Time           `json:"start_date" gorm:"type:date"`                           // 契約開始日（未指定の場合は承諾時から有効）
+	EndDate              *time.Time           `json:"end_date" gorm:"type:date"`                             // 契約終了日（この日を過ぎると自動的に期限切れ）
+	Note                 string               `json:"note" gorm:"type:text"`
+	ProposedBy           *uint                `json:"proposed_by"`
+	RespondedBy          *uint                `json:"responded_by"` // 承諾・辞退したベンダー側ユーザー
+	RespondedAt          *time.Time           `json:"responded_at"`
+	TerminatedBy         *uint                `json:"terminated_by"`
+	TerminatedAt         *time.Time           `json:"terminated_at"`
+	StatusReason         string               `json:"status_reason" gorm:"type:text"`                            // 辞退・終了の理由
+	GrantedPermissions   VendorPermissions    `json:"granted_permissions" gorm:"type:jsonb;serializer:json"`     // 紐付け元がベンダーに委任する権限
+	GrantedCSPAccountIDs []uint               `json:"granted_csp_account_ids" gorm:"type:jsonb;serializer:json"` // メンバー割り当てを委任するCSPアカウント
+	CreatedAt            time.Time            `json:"created_at"`
+	UpdatedAt            time.Time            `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt       `json:"-" gorm:"index"`
 
 	// リレーション
 	Project       Project `json:"project,omitempty" gorm:"foreignKey:ProjectID"`
@@ -24,3 +93,67 @@ type ProjectVendorRelation struct {
 func (ProjectVendorRelation) TableName() string {
 	return "project_vendor_relations"
 }
+
+// ScheduledStatus は契約期間に基づいて現在あるべきステータスを返す
+// 承諾済みで開始日に達したものは有効、終了日を過ぎたものは期限切れとなる
+func (r *ProjectVendorRelation) ScheduledStatus(now time.Time) VendorRelationStatus {
+	if r.Status != VendorRelationStatusAccepted && r.Status != VendorRelationStatusActive {
+		return r.Status
+	}
+	if r.EndDate != nil && !now.Before(r.EndDate.AddDate(0, 0, 1)) {
+		return VendorRelationStatusExpired
+	}
+	if r.Status == VendorRelationStatusAccepted && (r.StartDate == nil || !now.Before(*r.StartDate)) {
+		return VendorRelationStatusActive
+	}
+	return r.Status
+}
+
+// Covers は権限・CSPアカウントが紐付けの委任範囲内かどうかを判定
+func (r *ProjectVendorRelation) Covers(permissions VendorPermissions, cspAccountIDs []uint) bool {
+	for _, p := range permissions {
+		if !r.GrantedPermissions.Has(p) {
+			return false
+		}
+	}
+	if len(cspAccountIDs) > 0 && !r.GrantedPermissions.Has(VendorPermissionCSPAccountMember) {
+		return false
+	}
+	return len(intersectUintIDs(cspAccountIDs, r.GrantedCSPAccountIDs)) == len(uniqueUintIDs(cspAccountIDs))
+}
+
+// ParseContractPeriod は契約開始日・終了日（YYYY-MM-DD）を解析
+func ParseContractPeriod(startDate, endDate string) (*time.Time, *time.Time, error) {
+	var start, end *time.Time
+	if startDate != "" {
+		parsed, err := time.Parse("2006-01-02", startDate)
+		if err != nil {
+			return nil, nil, ErrInvalidVendorRelationPeriod
+		}
+		start = &parsed
+	}
+	if endDate != "" {
+		parsed, err := time.Parse("2006-01-02", endDate)
+		if err != nil {
+			return nil, nil, ErrInvalidVendorRelationPeriod
+		}
+		end = &parsed
+	}
+	if start != nil && end != nil && end.Before(*start) {
+		return nil, nil, ErrInvalidVendorRelationPeriod
+	}
+	return start, end, nil
+}
+
+// VendorRelationCreateRequest はベンダー紐付け提案リクエストの構造体
+type VendorRelationCreateRequest struct {
+	VendorProjectID uint   `json:"vendor_project_id" binding:"required"`
+	StartDate       string `json:"start_date"` // YYYY-MM-DD
+	EndDate         string `json:"end_date"`   // YYYY-MM-DD
+	Note            string `json:"note"`
+}
+
+// VendorRelationReasonRequest は辞退・終了リクエストの構造体
+type VendorRelationReasonRequest struct {
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,78 @@
+package model
+
+import "time"
+
+// ProviderAccountStatus はCSPプロバイダー側でのアカウント（AWSアカウント・GCPプロジェクト・Azureサブスクリプション）の状態
+type ProviderAccountStatus string
+
+const (
+	ProviderAccountStatusProvisioning ProviderAccountStatus = "provisioning" // 作成中（非同期で作成されるプロバイダー）
+	ProviderAccountStatusActive       ProviderAccountStatus = "active"
+	ProviderAccountStatusSuspended    ProviderAccountStatus = "suspended"
+	ProviderAccountStatusClosed       ProviderAccountStatus = "closed"
+	ProviderAccountStatusFailed       ProviderAccountStatus = "failed" // 作成に失敗
+)
+
+// ProviderAccountInput はプロバイダーでのアカウント作成の入力
+type ProviderAccountInput struct {
+	AccountName  string
+	Region       string
+	ProjectID    uint
+	CSPRequestID string               // 冪等性のためのキー（同じ申請から二重に作成しない）
+	Attributes   CSPAccountAttributes // 申請で指定されたプロバイダー固有の属性
+}
+
+// ProviderCredentials はプロバイダーが発行したアカウントの認証情報
+type ProviderCredentials struct {
+	AccessKey string
+	SecretKey string
+	KeyID     string // プロバイダー側の鍵の識別子（失効に使う。AWS・GCPではアクセスキーと同じ）
+}
+
+// ProviderAccount はプロバイダー側のアカウントの情報
+// 非同期で作成されるプロバイダーでは、作成完了までAccountIDが作成処理のIDになり、Credentialsは空になる
+type ProviderAccount struct {
+	AccountID   string
+	AccountName string // プロバイダー側の表示名（一覧取得時のみ）
+	Region      string
+	Status      ProviderAccountStatus
+	Credentials *ProviderCredentials
+	Attributes  CSPAccountAttributes // ドライバーが決定・補完したプロバイダー固有の属性
+}
+
+// ProviderAccountMember はプロバイダー側でアカウントに付与されているメンバーのロール
+// プラットフォームが管理するロール（adminは管理権限、userは読み取り専用）に対応するものだけを扱う
+type ProviderAccountMember struct {
+	Email string
+	Role  CSPAccountMemberRole
+}
+
+// CSPAccountProvisionRequest はプロバイダードライバーを通じたCSPアカウント作成リクエスト
+type CSPAccountProvisionRequest struct {
+	Provider     CSPProvider
+	AccountName  string
+	ProjectID    uint
+	Region       string // 省略時はプロバイダーのデフォルト
+	CSPRequestID string
+	Attributes   CSPAccountAttributes
+}
+
+// TemporaryCredentialInput は一時的な認証情報の発行条件
+type TemporaryCredentialInput struct {
+	Role        CSPAccountMemberRole // メンバーのロール（userは読み取り専用、adminは管理権限）
+	SessionName string               // プロバイダー側の監査ログに残すセッション名（利用者のメールアドレス）
+	Duration    time.Duration
+}
+
+// TemporaryCredentials はCSPアカウントのメンバーに払い出す短期の認証情報
+// 長期の認証情報（CSPAccountのアクセスキー・シークレットキー）の代わりに使う
+type TemporaryCredentials struct {
+	CSPAccountID uint                 `json:"csp_account_id"`
+	Provider     CSPProvider          `json:"provider"`
+	AccountID    string               `json:"account_id"`
+	Role         CSPAccountMemberRole `json:"role"`
+	AccessKey    string               `json:"access_key,omitempty"` // AWSの一時アクセスキー
+	SecretKey    string               `json:"secret_key,omitempty"` // AWSの一時シークレットキー
+	SessionToken string               `json:"session_token"`        // AWSのセッショントークン、GCP・Azureのアクセストークン
+	ExpiresAt    time.Time            `json:"expires_at"`
+}
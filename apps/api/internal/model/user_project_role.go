@@ -82,6 +82,7 @@ type UserProjectResponse struct {
 	Name        string        `json:"name"`
 	Description string        `json:"description"`
 	Status      ProjectStatus `json:"status"`
+	Tags        ProjectTags   `json:"tags" gorm:"serializer:json"`
 	Role        Role          `json:"role"`
 	JoinedAt    time.Time     `json:"joined_at"`
 }
@@ -99,4 +100,37 @@ type ProjectPermissionResponse struct {
 	CanView        bool `json:"can_view"`
 	CanEdit        bool `json:"can_edit"`
 	CanManage      bool `json:"can_manage"`
-}
\ No newline at end of file
+	Delegated      *DelegatedAccess `json:"delegated,omitempty"` // ベンダー紐付け経由の委任権限（非メンバーの場合）
+	VendorProjectIDs []uint         `json:"vendor_project_ids,omitempty"` // 有効な紐付けがありユーザーが所属するベンダープロジェクト（非メンバーの場合）
+}
+// OwnershipTransferRequest はプロジェクトオーナー権限の移譲リクエストの構造体
+type OwnershipTransferRequest struct {
+	NewOwnerID        uint `json:"new_owner_id" binding:"required"`
+	PreviousOwnerRole Role `json:"previous_owner_role"` // 移譲後の元オーナーのロール（省略時はadmin）
+}
+
+// MemberRemovalRequest はプロジェクトメンバー削除時のオプション
+type MemberRemovalRequest struct {
+	ReassignTo *uint `json:"reassign_to"` // 削除対象が作成したリソースの引き継ぎ先（省略時はフラグのみ）
+}
+
+// FlaggedResource は引き継ぎ先がなく確認が必要になったリソース
+type FlaggedResource struct {
+	Type   string `json:"type"` // project_csp_account, csp_account_member
+	ID     uint   `json:"id"`
+	Reason string `json:"reason"`
+}
+
+// MemberRemovalSummary はメンバー削除時に行われた変更の一覧
+type MemberRemovalSummary struct {
+	ProjectID                      uint              `json:"project_id"`
+	UserID                         uint              `json:"user_id"`
+	PreviousRole                   Role              `json:"previous_role"`
+	ReassignedTo                   *uint             `json:"reassigned_to,omitempty"`
+	DeactivatedCSPAccountMemberIDs []uint            `json:"deactivated_csp_account_member_ids"`
+	ReassignedProjectCSPAccountIDs []uint            `json:"reassigned_project_csp_account_ids"`
+	ReassignedCSPAccountMemberIDs  []uint            `json:"reassigned_csp_account_member_ids"`
+	ReassignedInvitationIDs        []uint            `json:"reassigned_invitation_ids"`
+	RevokedInvitationIDs           []uint            `json:"revoked_invitation_ids"`
+	FlaggedResources               []FlaggedResource `json:"flagged_resources"`
+}
@@ -0,0 +1,217 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// VendorPermission は紐付け元プロジェクトがベンダーに委任する権限を定義する型
+type VendorPermission string
+
+// 委任権限定数
+const (
+	VendorPermissionViewProject       VendorPermission = "view_project"        // プロジェクトの閲覧
+	VendorPermissionSubmitCSPRequests VendorPermission = "submit_csp_requests" // CSPアカウント申請の提出
+	VendorPermissionCSPAccountMember  VendorPermission = "csp_account_member"  // 指定CSPアカウントのメンバーへの割り当て
+)
+
+// ValidVendorPermissions は有効な委任権限の一覧
+var ValidVendorPermissions = []VendorPermission{
+	VendorPermissionViewProject,
+	VendorPermissionSubmitCSPRequests,
+	VendorPermissionCSPAccountMember,
+}
+
+// IsValid は委任権限が有効かどうかをチェック
+func (p VendorPermission) IsValid() bool {
+	for _, validPermission := range ValidVendorPermissions {
+		if p == validPermission {
+			return true
+		}
+	}
+	return false
+}
+
+// VendorPermissions は委任権限の集合
+type VendorPermissions []VendorPermission
+
+// Has は指定した権限を含むかどうかを判定
+func (ps VendorPermissions) Has(permission VendorPermission) bool {
+	for _, p := range ps {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate は全ての権限が有効かどうかをチェック
+func (ps VendorPermissions) Validate() error {
+	for _, p := range ps {
+		if !p.IsValid() {
+			return ErrInvalidVendorPermission
+		}
+	}
+	return nil
+}
+
+// Dedup は重複を除いた権限を返す（順序は保つ）
+func (ps VendorPermissions) Dedup() VendorPermissions {
+	result := VendorPermissions{}
+	for _, p := range ps {
+		if !result.Has(p) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// Intersect は両方に含まれる権限を返す
+func (ps VendorPermissions) Intersect(other VendorPermissions) VendorPermissions {
+	result := VendorPermissions{}
+	for _, p := range ps {
+		if other.Has(p) && !result.Has(p) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// VendorStaffAssignment はベンダー側が委任範囲内で個々の担当者に割り当てた権限
+type VendorStaffAssignment struct {
+	ID            uint              `json:"id" gorm:"primaryKey"`
+	RelationID    uint              `json:"relation_id" gorm:"not null;uniqueIndex:idx_vendor_staff_relation_user,where:deleted_at IS NULL"`
+	UserID        uint              `json:"user_id" gorm:"not null;index;uniqueIndex:idx_vendor_staff_relation_user,where:deleted_at IS NULL"`
+	Permissions   VendorPermissions `json:"permissions" gorm:"type:jsonb;serializer:json"`
+	CSPAccountIDs []uint            `json:"csp_account_ids" gorm:"type:jsonb;serializer:json"` // メンバー割り当てを許可するCSPアカウント
+	AssignedBy    uint              `json:"assigned_by" gorm:"not null"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt    `json:"-" gorm:"index"`
+
+	// リレーション
+	Relation ProjectVendorRelation `json:"-" gorm:"foreignKey:RelationID"`
+	User     User                  `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// TableName はテーブル名を指定
+func (VendorStaffAssignment) TableName() string {
+	return "vendor_staff_assignments"
+}
+
+// VendorAccessGrantRequest は紐付け元プロジェクトがベンダーに委任する権限の設定リクエスト
+type VendorAccessGrantRequest struct {
+	Permissions   VendorPermissions `json:"permissions"`
+	CSPAccountIDs []uint            `json:"csp_account_ids"`
+}
+
+// Normalize は権限・CSPアカウントIDの重複を除き、内容を検証する
+func (r *VendorAccessGrantRequest) Normalize() error {
+	var err error
+	r.Permissions, r.CSPAccountIDs, err = normalizeVendorScope(r.Permissions, r.CSPAccountIDs)
+	return err
+}
+
+// VendorStaffAssignmentRequest はベンダー担当者への権限割り当てリクエスト
+type VendorStaffAssignmentRequest struct {
+	UserID        uint              `json:"user_id" binding:"required"`
+	Permissions   VendorPermissions `json:"permissions"`
+	CSPAccountIDs []uint            `json:"csp_account_ids"`
+}
+
+// Normalize は権限・CSPアカウントIDの重複を除き、内容を検証する
+func (r *VendorStaffAssignmentRequest) Normalize() error {
+	var err error
+	r.Permissions, r.CSPAccountIDs, err = normalizeVendorScope(r.Permissions, r.CSPAccountIDs)
+	return err
+}
+
+// normalizeVendorScope は委任範囲（権限・CSPアカウントID）の重複を除いて検証する
+// CSPアカウントを指定できるのはメンバーへの割り当て権限を含む場合のみ
+func normalizeVendorScope(permissions VendorPermissions, cspAccountIDs []uint) (VendorPermissions, []uint, error) {
+	if err := permissions.Validate(); err != nil {
+		return nil, nil, err
+	}
+	permissions = permissions.Dedup()
+
+	for _, id := range cspAccountIDs {
+		if id == 0 {
+			return nil, nil, ErrInvalidVendorPermission
+		}
+	}
+	cspAccountIDs = uniqueUintIDs(cspAccountIDs)
+	if len(cspAccountIDs) > 0 && !permissions.Has(VendorPermissionCSPAccountMember) {
+		return nil, nil, ErrInvalidVendorPermission
+	}
+	return permissions, cspAccountIDs, nil
+}
+
+// DelegatedAccess はユーザーがベンダー紐付け経由で紐付け元プロジェクトに持つ権限
+// 紐付けの委任範囲と担当者への割り当ての共通部分（複数の紐付けがある場合はその和）
+type DelegatedAccess struct {
+	RelationIDs   []uint            `json:"relation_ids"`
+	Permissions   VendorPermissions `json:"permissions"`
+	CSPAccountIDs []uint            `json:"csp_account_ids"`
+}
+
+// Has は指定した権限が委任されているかどうかを判定
+func (a *DelegatedAccess) Has(permission VendorPermission) bool {
+	return a != nil && a.Permissions.Has(permission)
+}
+
+// AllowsCSPAccountMember は指定CSPアカウントのメンバーへの割り当てが委任されているかどうかを判定
+func (a *DelegatedAccess) AllowsCSPAccountMember(cspAccountID uint) bool {
+	if !a.Has(VendorPermissionCSPAccountMember) {
+		return false
+	}
+	for _, id := range a.CSPAccountIDs {
+		if id == cspAccountID {
+			return true
+		}
+	}
+	return false
+}
+
+// intersectUintIDs は両方に含まれるIDを返す
+func intersectUintIDs(a, b []uint) []uint {
+	set := make(map[uint]bool, len(b))
+	for _, id := range b {
+		set[id] = true
+	}
+	result := []uint{}
+	for _, id := range a {
+		if set[id] {
+			result = append(result, id)
+			delete(set, id)
+		}
+	}
+	return result
+}
+
+// uniqueUintIDs は重複を除いたIDを返す
+func uniqueUintIDs(ids []uint) []uint {
+	return intersectUintIDs(ids, ids)
+}
+
+// Merge は担当者への割り当てを紐付けの委任範囲に制限して委任権限に加える
+func (a *DelegatedAccess) Merge(relation *ProjectVendorRelation, assignment *VendorStaffAssignment) {
+	a.RelationIDs = append(a.RelationIDs, relation.ID)
+	for _, p := range assignment.Permissions.Intersect(relation.GrantedPermissions) {
+		if !a.Permissions.Has(p) {
+			a.Permissions = append(a.Permissions, p)
+		}
+	}
+	for _, id := range intersectUintIDs(assignment.CSPAccountIDs, relation.GrantedCSPAccountIDs) {
+		found := false
+		for _, existing := range a.CSPAccountIDs {
+			if existing == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			a.CSPAccountIDs = append(a.CSPAccountIDs, id)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package model
+
+import "time"
+
+// VendorPortfolio はベンダー担当者が紐付けを通じて関わる全ての依頼元プロジェクトの一覧
+type VendorPortfolio struct {
+	Engagements []VendorEngagement `json:"engagements"`
+	Total       int                `json:"total"`
+}
+
+// VendorEngagement はベンダー紐付け1件分の依頼元プロジェクトの状況
+type VendorEngagement struct {
+	RelationID      uint                 `json:"relation_id"`
+	RelationStatus  VendorRelationStatus `json:"relation_status"`
+	StartDate       *time.Time           `json:"start_date"`
+	EndDate         *time.Time           `json:"end_date"`
+	DaysUntilExpiry *int                 `json:"days_until_expiry"` // 契約終了日までの日数（終了日未指定の場合はnil）
+	VendorProject   PortfolioProject     `json:"vendor_project"`
+	ClientProject   PortfolioProject     `json:"client_project"`
+
+	CSPAccounts                   []PortfolioCSPAccount `json:"csp_accounts"`                               // 依頼元プロジェクトで自分がメンバーになっているCSPアカウント
+	PendingCSPRequests            []PendingCSPRequest   `json:"pending_csp_requests"`                       // 依頼元プロジェクトの未処理のCSP申請
+	PendingCSPRequestsUnavailable bool                  `json:"pending_csp_requests_unavailable,omitempty"` // プロビジョニングサービスから取得できなかった場合
+}
+
+// PortfolioProject はポートフォリオに表示するプロジェクトの概要
+type PortfolioProject struct {
+	ID          uint          `json:"id"`
+	Name        string        `json:"name"`
+	ProjectType ProjectType   `json:"project_type"`
+	Status      ProjectStatus `json:"status"`
+}
+
+// PortfolioCSPAccount はポートフォリオに表示するCSPアカウントのメンバー情報
+type PortfolioCSPAccount struct {
+	CSPAccountID uint        `json:"csp_account_id"`
+	Provider     CSPProvider `json:"provider"`
+	AccountName  string      `json:"account_name"`
+	Role         string      `json:"role"`
+	Status       string      `json:"status"`
+}
+
+// PendingCSPRequest はプロビジョニングサービスから取得する未処理のCSP申請
+type PendingCSPRequest struct {
+	ID              string      `json:"id"`
+	ProjectID       uint        `json:"project_id"`
+	RequestedBy     string      `json:"requested_by"`
+	Provider        CSPProvider `json:"provider"`
+	AccountName     string      `json:"account_name"`
+	Status          string      `json:"status"`
+	VendorProjectID *uint       `json:"vendor_project_id"`
+	SponsorEmail    *string     `json:"sponsor_email"`
+	CreatedAt       time.Time   `json:"created_at"`
+}
+
+// NewPortfolioProject はプロジェクトからポートフォリオ用の概要を作成
+func NewPortfolioProject(project *Project) PortfolioProject {
+	return PortfolioProject{
+		ID:          project.ID,
+		Name:        project.Name,
+		ProjectType: project.ProjectType,
+		Status:      project.Status,
+	}
+}
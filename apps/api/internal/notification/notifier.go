@@ -0,0 +1,88 @@
+package notification
+
+import (
+	"fmt"
+	"log"
+	"mime"
+	"net/smtp"
+	"os"
+	"strings"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+)
+
+// NewNotifier は環境変数に応じた通知送信実装を返す
+// SMTP_HOSTが設定されていればSMTPで送信し、未設定の場合はログ出力のみ行う（開発用）
+func NewNotifier() interfaces.Notifier {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return &logNotifier{}
+	}
+
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+
+	from := os.Getenv("SMTP_FROM")
+	if from == "" {
+		from = "no-reply@cgas.local"
+	}
+
+	return &smtpNotifier{
+		addr:     host + ":" + port,
+		host:     host,
+		from:     from,
+		username: os.Getenv("SMTP_USERNAME"),
+		password: os.Getenv("SMTP_PASSWORD"),
+	}
+}
+
+// logNotifier は通知内容をログに出力するだけの実装
+type logNotifier struct{}
+
+func (n *logNotifier) Send(notification *model.Notification) error {
+	log.Printf("[NOTIFY] to=%s subject=%q\n%s", strings.Join(notification.To, ","), notification.Subject, notification.Body)
+	return nil
+}
+
+// smtpNotifier はSMTPでメールを送信する実装
+type smtpNotifier struct {
+	addr     string
+	host     string
+	from     string
+	username string
+	password string
+}
+
+func (n *smtpNotifier) Send(notification *model.Notification) error {
+	if len(notification.To) == 0 {
+		return nil
+	}
+
+	var auth smtp.Auth
+	if n.username != "" {
+		auth = smtp.PlainAuth("", n.username, n.password, n.host)
+	}
+
+	message := fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s",
+		n.from,
+		strings.Join(notification.To, ", "),
+		encodeSubject(notification.Subject),
+		notification.Body,
+	)
+
+	if err := smtp.SendMail(n.addr, auth, n.from, notification.To, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	return nil
+}
+
+// encodeSubject は件名をヘッダーに書ける形にする
+// 改行を取り除いてヘッダーの挿入を防ぎ、日本語を含む件名はRFC 2047でエンコードする
+func encodeSubject(subject string) string {
+	subject = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(subject)
+	return mime.QEncoding.Encode("UTF-8", subject)
+}
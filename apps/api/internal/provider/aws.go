@@ -0,0 +1,767 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+)
+
+const (
+	awsOrganizationsEndpoint = "https://organizations.us-east-1.amazonaws.com/"
+	awsSTSEndpoint           = "https://sts.amazonaws.com/"
+	awsIAMEndpoint           = "https://iam.amazonaws.com/"
+	awsCostExplorerEndpoint  = "https://ce.us-east-1.amazonaws.com/"
+	awsGlobalRegion          = "us-east-1"
+
+	// awsCreateAccountPrefix はAWS Organizationsのアカウント作成リクエストIDのプレフィックス
+	awsCreateAccountPrefix = "car-"
+	// awsAccessUserName はメンバーアカウントに作成する、認証情報発行用のIAMユーザー名
+	awsAccessUserName = "cgas-access"
+	// awsReadOnlyPolicyARN はuserロールの一時的な認証情報に付けるセッションポリシー
+	awsReadOnlyPolicyARN = "arn:aws:iam::aws:policy/ReadOnlyAccess"
+)
+
+// awsSessionNameInvalidChars はRoleSessionNameに使えない文字
+var awsSessionNameInvalidChars = regexp.MustCompile(`[^\w+=,.@-]`)
+
+// awsCredentials はAWS APIの呼び出しに使う認証情報
+type awsCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// awsDriver はAWS Organizationsでメンバーアカウントを作成・管理するドライバー
+type awsDriver struct {
+	credentials   awsCredentials
+	emailDomain   string // メンバーアカウントのルートユーザーのメールアドレスに使うドメイン
+	roleName      string // メンバーアカウントに作成される管理ロール
+	defaultRegion string
+	sso           *awsSSOConfig // メンバーの管理に使うIAM Identity Center（未設定の場合はnil）
+	httpClient    *http.Client
+}
+
+// awsSSOConfig はメンバーアカウントへのユーザーの割り当てに使うIAM Identity Centerの設定
+type awsSSOConfig struct {
+	instanceARN     string
+	identityStoreID string
+	region          string
+	permissionSets  map[model.CSPAccountMemberRole]string // メンバーのロールごとの権限セットARN
+}
+
+// newAWSSSOConfigFromEnv は環境変数からIAM Identity Centerの設定を読み込む（未設定の場合はnil）
+func newAWSSSOConfigFromEnv() *awsSSOConfig {
+	instanceARN := os.Getenv("AWS_SSO_INSTANCE_ARN")
+	identityStoreID := os.Getenv("AWS_SSO_IDENTITY_STORE_ID")
+	adminPermissionSet := os.Getenv("AWS_SSO_ADMIN_PERMISSION_SET_ARN")
+	userPermissionSet := os.Getenv("AWS_SSO_USER_PERMISSION_SET_ARN")
+	if instanceARN == "" || identityStoreID == "" || adminPermissionSet == "" || userPermissionSet == "" {
+		return nil
+	}
+
+	region := os.Getenv("AWS_SSO_REGION")
+	if region == "" {
+		region = awsGlobalRegion
+	}
+	return &awsSSOConfig{
+		instanceARN:     instanceARN,
+		identityStoreID: identityStoreID,
+		region:          region,
+		permissionSets: map[model.CSPAccountMemberRole]string{
+			model.CSPAccountMemberRoleAdmin: adminPermissionSet,
+			model.CSPAccountMemberRoleUser:  userPermissionSet,
+		},
+	}
+}
+
+// newAWSDriverFromEnv は環境変数からAWSドライバーを作成（認証情報が未設定の場合はfalse）
+func newAWSDriverFromEnv() (interfaces.ProviderDriver, bool) {
+	accessKeyID := os.Getenv("AWS_ACCESS_KEY_ID")
+	secretAccessKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	emailDomain := os.Getenv("AWS_ACCOUNT_EMAIL_DOMAIN")
+	if accessKeyID == "" || secretAccessKey == "" || emailDomain == "" {
+		return nil, false
+	}
+
+	roleName := os.Getenv("AWS_MEMBER_ROLE_NAME")
+	if roleName == "" {
+		roleName = "OrganizationAccountAccessRole"
+	}
+	defaultRegion := os.Getenv("AWS_DEFAULT_REGION")
+	if defaultRegion == "" {
+		defaultRegion = registryDefaultRegion(model.CSPProviderAWS)
+	}
+
+	return &awsDriver{
+		credentials: awsCredentials{
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		},
+		emailDomain:   emailDomain,
+		roleName:      roleName,
+		defaultRegion: defaultRegion,
+		sso:           newAWSSSOConfigFromEnv(),
+		httpClient:    &http.Client{Timeout: 20 * time.Second},
+	}, true
+}
+
+func (d *awsDriver) Provider() model.CSPProvider {
+	return model.CSPProviderAWS
+}
+
+func (d *awsDriver) DefaultRegion() string {
+	return d.defaultRegion
+}
+
+type awsCreateAccountStatus struct {
+	ID            string `json:"Id"`
+	State         string `json:"State"`
+	AccountID     string `json:"AccountId"`
+	FailureReason string `json:"FailureReason"`
+}
+
+// CreateAccount はOrganizationsでメンバーアカウントを作成する
+// 作成は非同期のため、完了するまではアカウント作成リクエストIDをアカウントIDとして返す
+func (d *awsDriver) CreateAccount(input *model.ProviderAccountInput) (*model.ProviderAccount, error) {
+	// ルートユーザーのメールアドレスは申請で指定されていればそれを使い、なければ生成する
+	email := input.Attributes["root_email"]
+	if email == "" {
+		email = fmt.Sprintf("aws+%s-%s@%s", accountSlug(input.AccountName, 40), randomToken(3), d.emailDomain)
+	}
+
+	var resp struct {
+		CreateAccountStatus awsCreateAccountStatus `json:"CreateAccountStatus"`
+	}
+	if err := d.callOrganizations("CreateAccount", map[string]interface{}{
+		"Email":                  email,
+		"AccountName":            input.AccountName,
+		"RoleName":               d.roleName,
+		"IamUserAccessToBilling": "DENY",
+	}, &resp); err != nil {
+		return nil, err
+	}
+
+	region := input.Region
+	if region == "" {
+		region = d.defaultRegion
+	}
+	account, err := d.accountFromCreateStatus(&resp.CreateAccountStatus, region)
+	if err != nil {
+		return nil, err
+	}
+	account.Attributes = model.CSPAccountAttributes{"root_email": email}
+	return account, nil
+}
+
+func (d *awsDriver) GetAccount(accountID string) (*model.ProviderAccount, error) {
+	if strings.HasPrefix(accountID, awsCreateAccountPrefix) {
+		var resp struct {
+			CreateAccountStatus awsCreateAccountStatus `json:"CreateAccountStatus"`
+		}
+		if err := d.callOrganizations("DescribeCreateAccountStatus", map[string]interface{}{
+			"CreateAccountRequestId": accountID,
+		}, &resp); err != nil {
+			return nil, err
+		}
+		return d.accountFromCreateStatus(&resp.CreateAccountStatus, "")
+	}
+
+	var resp struct {
+		Account struct {
+			ID     string `json:"Id"`
+			Status string `json:"Status"`
+		} `json:"Account"`
+	}
+	if err := d.callOrganizations("DescribeAccount", map[string]interface{}{"AccountId": accountID}, &resp); err != nil {
+		return nil, err
+	}
+
+	status := model.ProviderAccountStatusActive
+	switch resp.Account.Status {
+	case "SUSPENDED":
+		status = model.ProviderAccountStatusSuspended
+	case "PENDING_CLOSURE":
+		status = model.ProviderAccountStatusClosed
+	}
+	return &model.ProviderAccount{AccountID: resp.Account.ID, Status: status}, nil
+}
+
+// accountFromCreateStatus はアカウント作成の状態をプロバイダー側のアカウント情報に変換
+func (d *awsDriver) accountFromCreateStatus(createStatus *awsCreateAccountStatus, region string) (*model.ProviderAccount, error) {
+	switch createStatus.State {
+	case "SUCCEEDED":
+		return &model.ProviderAccount{AccountID: createStatus.AccountID, Region: region, Status: model.ProviderAccountStatusActive}, nil
+	case "FAILED":
+		return &model.ProviderAccount{AccountID: createStatus.ID, Region: region, Status: model.ProviderAccountStatusFailed}, nil
+	default:
+		return &model.ProviderAccount{AccountID: createStatus.ID, Region: region, Status: model.ProviderAccountStatusProvisioning}, nil
+	}
+}
+
+func (d *awsDriver) CloseAccount(accountID string) error {
+	return d.callOrganizations("CloseAccount", map[string]interface{}{"AccountId": accountID}, nil)
+}
+
+// RotateCredentials はメンバーアカウントの管理ロールを引き受け、
+// 認証情報発行用のIAMユーザーに新しいアクセスキーを発行して使用中以外の古いアクセスキーを削除する
+func (d *awsDriver) RotateCredentials(accountID, keepKeyID string) (*model.ProviderCredentials, error) {
+	if strings.HasPrefix(accountID, awsCreateAccountPrefix) {
+		return nil, fmt.Errorf("AWS account %s is still being created", accountID)
+	}
+
+	memberCredentials, err := d.assumeMemberRole(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// IAMユーザーがなければ作成する
+	if err := d.callIAM(memberCredentials, url.Values{"Action": {"CreateUser"}, "UserName": {awsAccessUserName}}, nil); err != nil && !strings.Contains(err.Error(), "EntityAlreadyExists") {
+		return nil, err
+	}
+
+	var listResp struct {
+		Keys []string `xml:"ListAccessKeysResult>AccessKeyMetadata>member>AccessKeyId"`
+	}
+	if err := d.callIAM(memberCredentials, url.Values{"Action": {"ListAccessKeys"}, "UserName": {awsAccessUserName}}, &listResp); err != nil {
+		return nil, err
+	}
+
+	// IAMユーザーのアクセスキーは2つまでのため、使用中の鍵以外は発行前に削除する
+	for _, key := range listResp.Keys {
+		if key == keepKeyID {
+			continue
+		}
+		if err := d.deleteAccessKey(memberCredentials, key); err != nil {
+			return nil, err
+		}
+	}
+
+	var createResp struct {
+		AccessKeyID     string `xml:"CreateAccessKeyResult>AccessKey>AccessKeyId"`
+		SecretAccessKey string `xml:"CreateAccessKeyResult>AccessKey>SecretAccessKey"`
+	}
+	if err := d.callIAM(memberCredentials, url.Values{"Action": {"CreateAccessKey"}, "UserName": {awsAccessUserName}}, &createResp); err != nil {
+		return nil, err
+	}
+
+	return &model.ProviderCredentials{
+		AccessKey: createResp.AccessKeyID,
+		SecretKey: createResp.SecretAccessKey,
+		KeyID:     createResp.AccessKeyID,
+	}, nil
+}
+
+// RevokeCredentials は認証情報発行用のIAMユーザーからアクセスキーを削除する
+func (d *awsDriver) RevokeCredentials(accountID, keyID string) error {
+	memberCredentials, err := d.assumeMemberRole(accountID)
+	if err != nil {
+		return err
+	}
+	if err := d.deleteAccessKey(memberCredentials, keyID); err != nil && !strings.Contains(err.Error(), "NoSuchEntity") {
+		return err
+	}
+	return nil
+}
+
+func (d *awsDriver) deleteAccessKey(credentials awsCredentials, accessKeyID string) error {
+	return d.callIAM(credentials, url.Values{
+		"Action":      {"DeleteAccessKey"},
+		"UserName":    {awsAccessUserName},
+		"AccessKeyId": {accessKeyID},
+	}, nil)
+}
+
+// IssueTemporaryCredentials はメンバーアカウントの管理ロールを利用者のセッション名で引き受け、一時的な認証情報を返す
+// userロールにはReadOnlyAccessのセッションポリシーを付けて読み取り専用に絞り込む
+func (d *awsDriver) IssueTemporaryCredentials(accountID string, input *model.TemporaryCredentialInput) (*model.TemporaryCredentials, error) {
+	if strings.HasPrefix(accountID, awsCreateAccountPrefix) {
+		return nil, fmt.Errorf("AWS account %s is still being created", accountID)
+	}
+
+	params := url.Values{"DurationSeconds": {fmt.Sprintf("%d", int(input.Duration.Seconds()))}}
+	if input.Role != model.CSPAccountMemberRoleAdmin {
+		params.Set("PolicyArns.member.1.arn", awsReadOnlyPolicyARN)
+	}
+	credentials, expiresAt, err := d.assumeRole(accountID, awsSessionName(input.SessionName), params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TemporaryCredentials{
+		AccessKey:    credentials.AccessKeyID,
+		SecretKey:    credentials.SecretAccessKey,
+		SessionToken: credentials.SessionToken,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+// assumeMemberRole はメンバーアカウントの管理ロールの一時的な認証情報を取得
+func (d *awsDriver) assumeMemberRole(accountID string) (awsCredentials, error) {
+	credentials, _, err := d.assumeRole(accountID, "cgas-provisioning", nil)
+	return credentials, err
+}
+
+// assumeRole はメンバーアカウントの管理ロールを引き受け、一時的な認証情報と有効期限を返す
+func (d *awsDriver) assumeRole(accountID, sessionName string, extra url.Values) (awsCredentials, time.Time, error) {
+	var resp struct {
+		AccessKeyID     string    `xml:"AssumeRoleResult>Credentials>AccessKeyId"`
+		SecretAccessKey string    `xml:"AssumeRoleResult>Credentials>SecretAccessKey"`
+		SessionToken    string    `xml:"AssumeRoleResult>Credentials>SessionToken"`
+		Expiration      time.Time `xml:"AssumeRoleResult>Credentials>Expiration"`
+	}
+	params := url.Values{
+		"Action":          {"AssumeRole"},
+		"Version":         {"2011-06-15"},
+		"RoleArn":         {fmt.Sprintf("arn:aws:iam::%s:role/%s", accountID, d.roleName)},
+		"RoleSessionName": {sessionName},
+	}
+	for key, values := range extra {
+		params[key] = values
+	}
+	if err := d.callQuery(awsSTSEndpoint, "sts", d.credentials, params, &resp); err != nil {
+		return awsCredentials{}, time.Time{}, err
+	}
+	return awsCredentials{AccessKeyID: resp.AccessKeyID, SecretAccessKey: resp.SecretAccessKey, SessionToken: resp.SessionToken}, resp.Expiration, nil
+}
+
+// awsSessionName はRoleSessionNameに使えない文字を置き換え、64文字以内に切り詰める
+func awsSessionName(name string) string {
+	name = awsSessionNameInvalidChars.ReplaceAllString(name, "-")
+	if len(name) < 2 {
+		name = "cgas-" + name
+	}
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// GetCurrentMonthCost はCost Explorerで、管理アカウントから見たメンバーアカウントの当月の費用を取得する
+func (d *awsDriver) GetCurrentMonthCost(accountID string) (*model.ProviderCost, error) {
+	if strings.HasPrefix(accountID, awsCreateAccountPrefix) {
+		return nil, fmt.Errorf("AWS account %s is still being created", accountID)
+	}
+
+	start, end := currentMonthPeriod(time.Now())
+	var resp struct {
+		ResultsByTime []struct {
+			Total map[string]struct {
+				Amount string `json:"Amount"`
+				Unit   string `json:"Unit"`
+			} `json:"Total"`
+		} `json:"ResultsByTime"`
+	}
+	if err := d.callJSON(awsCostExplorerEndpoint, awsGlobalRegion, "ce", "AWSInsightsIndexService.GetCostAndUsage", map[string]interface{}{
+		"TimePeriod":  map[string]string{"Start": start.Format("2006-01-02"), "End": end.Format("2006-01-02")},
+		"Granularity": "MONTHLY",
+		"Metrics":     []string{"UnblendedCost"},
+		"Filter": map[string]interface{}{
+			"Dimensions": map[string]interface{}{"Key": "LINKED_ACCOUNT", "Values": []string{accountID}},
+		},
+	}, &resp); err != nil {
+		return nil, err
+	}
+
+	cost := &model.ProviderCost{Currency: "USD", PeriodStart: start, PeriodEnd: end}
+	for _, result := range resp.ResultsByTime {
+		total, ok := result.Total["UnblendedCost"]
+		if !ok {
+			continue
+		}
+		amount, err := strconv.ParseFloat(total.Amount, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected cost amount %q: %w", total.Amount, err)
+		}
+		cost.Amount += amount
+		if total.Unit != "" {
+			cost.Currency = total.Unit
+		}
+	}
+	return cost, nil
+}
+
+// ListAccounts は組織に所属するアカウントの一覧を返す
+func (d *awsDriver) ListAccounts() ([]model.ProviderAccount, error) {
+	var accounts []model.ProviderAccount
+	nextToken := ""
+	for {
+		params := map[string]interface{}{}
+		if nextToken != "" {
+			params["NextToken"] = nextToken
+		}
+
+		var resp struct {
+			Accounts []struct {
+				ID     string `json:"Id"`
+				Name   string `json:"Name"`
+				Status string `json:"Status"`
+			} `json:"Accounts"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := d.callOrganizations("ListAccounts", params, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, account := range resp.Accounts {
+			status := model.ProviderAccountStatusActive
+			switch account.Status {
+			case "SUSPENDED":
+				status = model.ProviderAccountStatusSuspended
+			case "PENDING_CLOSURE":
+				status = model.ProviderAccountStatusClosed
+			}
+			accounts = append(accounts, model.ProviderAccount{AccountID: account.ID, AccountName: account.Name, Status: status})
+		}
+
+		if resp.NextToken == "" {
+			return accounts, nil
+		}
+		nextToken = resp.NextToken
+	}
+}
+
+// listAccountAssignments はアカウントで権限セットを割り当てられたユーザーのIDを返す
+func (d *awsDriver) listAccountAssignments(accountID, permissionSetARN string) ([]string, error) {
+	var userIDs []string
+	nextToken := ""
+	for {
+		params := map[string]interface{}{
+			"InstanceArn":      d.sso.instanceARN,
+			"AccountId":        accountID,
+			"PermissionSetArn": permissionSetARN,
+		}
+		if nextToken != "" {
+			params["NextToken"] = nextToken
+		}
+
+		var resp struct {
+			AccountAssignments []struct {
+				PrincipalID   string `json:"PrincipalId"`
+				PrincipalType string `json:"PrincipalType"`
+			} `json:"AccountAssignments"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := d.callSSOAdmin("ListAccountAssignments", params, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, assignment := range resp.AccountAssignments {
+			if assignment.PrincipalType == "USER" {
+				userIDs = append(userIDs, assignment.PrincipalID)
+			}
+		}
+
+		if resp.NextToken == "" {
+			return userIDs, nil
+		}
+		nextToken = resp.NextToken
+	}
+}
+
+// ListAccountMembers はIAM Identity Centerでアカウントに権限セットを割り当てられたユーザーを返す
+// 両方の権限セットを割り当てられたユーザーは管理者として扱う
+func (d *awsDriver) ListAccountMembers(accountID string) ([]model.ProviderAccountMember, error) {
+	if d.sso == nil {
+		return nil, model.ErrProviderMembersNotSupported
+	}
+	if strings.HasPrefix(accountID, awsCreateAccountPrefix) {
+		return nil, fmt.Errorf("AWS account %s is still being created", accountID)
+	}
+
+	roles := make(map[string]model.CSPAccountMemberRole)
+	var userIDs []string
+	for _, role := range []model.CSPAccountMemberRole{model.CSPAccountMemberRoleUser, model.CSPAccountMemberRoleAdmin} {
+		assigned, err := d.listAccountAssignments(accountID, d.sso.permissionSets[role])
+		if err != nil {
+			return nil, err
+		}
+		for _, userID := range assigned {
+			if _, ok := roles[userID]; !ok {
+				userIDs = append(userIDs, userID)
+			}
+			roles[userID] = role
+		}
+	}
+
+	members := make([]model.ProviderAccountMember, 0, len(userIDs))
+	for _, userID := range userIDs {
+		var resp struct {
+			UserName string `json:"UserName"`
+			Emails   []struct {
+				Value   string `json:"Value"`
+				Primary bool   `json:"Primary"`
+			} `json:"Emails"`
+		}
+		if err := d.callIdentityStore("DescribeUser", map[string]interface{}{
+			"IdentityStoreId": d.sso.identityStoreID,
+			"UserId":          userID,
+		}, &resp); err != nil {
+			if errors.Is(err, model.ErrProviderUserNotFound) {
+				continue
+			}
+			return nil, err
+		}
+
+		email := resp.UserName
+		// プライマリのメールアドレス（なければ最初のもの）を使う
+		for i, e := range resp.Emails {
+			if i == 0 || e.Primary {
+				email = e.Value
+			}
+		}
+		members = append(members, model.ProviderAccountMember{Email: strings.ToLower(email), Role: roles[userID]})
+	}
+	return members, nil
+}
+
+// GrantAccountMember はユーザーにロールの権限セットを割り当て、もう一方の権限セットの割り当ては削除する
+func (d *awsDriver) GrantAccountMember(accountID string, member *model.ProviderAccountMember) error {
+	if d.sso == nil {
+		return model.ErrProviderMembersNotSupported
+	}
+	if _, ok := d.sso.permissionSets[member.Role]; !ok {
+		return model.ErrInvalidRole
+	}
+	userID, err := d.lookupSSOUser(member.Email)
+	if err != nil {
+		return err
+	}
+
+	for role, permissionSetARN := range d.sso.permissionSets {
+		assigned, err := d.listAccountAssignments(accountID, permissionSetARN)
+		if err != nil {
+			return err
+		}
+		has := false
+		for _, id := range assigned {
+			if id == userID {
+				has = true
+				break
+			}
+		}
+
+		switch {
+		case role == member.Role && !has:
+			err = d.callSSOAdmin("CreateAccountAssignment", d.accountAssignmentParams(accountID, permissionSetARN, userID), nil)
+		case role != member.Role && has:
+			err = d.callSSOAdmin("DeleteAccountAssignment", d.accountAssignmentParams(accountID, permissionSetARN, userID), nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RevokeAccountMember はユーザーへの権限セットの割り当てを削除する
+func (d *awsDriver) RevokeAccountMember(accountID, email string) error {
+	if d.sso == nil {
+		return model.ErrProviderMembersNotSupported
+	}
+	userID, err := d.lookupSSOUser(email)
+	if errors.Is(err, model.ErrProviderUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, permissionSetARN := range d.sso.permissionSets {
+		assigned, err := d.listAccountAssignments(accountID, permissionSetARN)
+		if err != nil {
+			return err
+		}
+		for _, id := range assigned {
+			if id != userID {
+				continue
+			}
+			if err := d.callSSOAdmin("DeleteAccountAssignment", d.accountAssignmentParams(accountID, permissionSetARN, userID), nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// lookupSSOUser はメールアドレスからIdentity StoreのユーザーIDを取得する
+func (d *awsDriver) lookupSSOUser(email string) (string, error) {
+	var resp struct {
+		UserID string `json:"UserId"`
+	}
+	if err := d.callIdentityStore("GetUserId", map[string]interface{}{
+		"IdentityStoreId": d.sso.identityStoreID,
+		"AlternateIdentifier": map[string]interface{}{
+			"UniqueAttribute": map[string]string{"AttributePath": "emails.value", "AttributeValue": email},
+		},
+	}, &resp); err != nil {
+		return "", err
+	}
+	return resp.UserID, nil
+}
+
+func (d *awsDriver) accountAssignmentParams(accountID, permissionSetARN, userID string) map[string]interface{} {
+	return map[string]interface{}{
+		"InstanceArn":      d.sso.instanceARN,
+		"TargetId":         accountID,
+		"TargetType":       "AWS_ACCOUNT",
+		"PermissionSetArn": permissionSetARN,
+		"PrincipalType":    "USER",
+		"PrincipalId":      userID,
+	}
+}
+
+// callSSOAdmin はIAM Identity CenterのJSON APIを呼び出す
+func (d *awsDriver) callSSOAdmin(action string, params map[string]interface{}, out interface{}) error {
+	return d.callJSON(fmt.Sprintf("https://sso.%s.amazonaws.com/", d.sso.region), d.sso.region, "sso", "SWBExternalService."+action, params, out)
+}
+
+// callIdentityStore はIdentity StoreのJSON APIを呼び出す
+func (d *awsDriver) callIdentityStore(action string, params map[string]interface{}, out interface{}) error {
+	err := d.callJSON(fmt.Sprintf("https://identitystore.%s.amazonaws.com/", d.sso.region), d.sso.region, "identitystore", "AWSIdentityStore."+action, params, out)
+	if err != nil && strings.Contains(err.Error(), "ResourceNotFoundException") {
+		return model.ErrProviderUserNotFound
+	}
+	return err
+}
+
+// callOrganizations はOrganizationsのJSON APIを呼び出す
+func (d *awsDriver) callOrganizations(action string, params map[string]interface{}, out interface{}) error {
+	err := d.callJSON(awsOrganizationsEndpoint, awsGlobalRegion, "organizations", "AWSOrganizationsV20161128."+action, params, out)
+	if err != nil && strings.Contains(err.Error(), "AccountNotFoundException") {
+		return model.ErrProviderAccountNotFound
+	}
+	return err
+}
+
+// callJSON は管理アカウントの認証情報でAWSのJSON APIを呼び出す
+func (d *awsDriver) callJSON(endpoint, region, service, target string, params map[string]interface{}, out interface{}) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", target)
+	signAWSRequest(req, body, d.credentials, region, service, time.Now())
+
+	return doJSON(d.httpClient, req, out)
+}
+
+// callIAM はIAMのクエリAPIを呼び出す
+func (d *awsDriver) callIAM(credentials awsCredentials, params url.Values, out interface{}) error {
+	params.Set("Version", "2010-05-08")
+	return d.callQuery(awsIAMEndpoint, "iam", credentials, params, out)
+}
+
+// callQuery はAWSのクエリAPI（STS・IAM）を呼び出し、XMLのレスポンスをoutにデコードする
+func (d *awsDriver) callQuery(endpoint, service string, credentials awsCredentials, params url.Values, out interface{}) error {
+	body := []byte(params.Encode())
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	signAWSRequest(req, body, credentials, awsGlobalRegion, service, time.Now())
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach provider API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	payload, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read provider API response: %w", err)
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return &apiError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(payload))}
+	}
+	if out != nil {
+		if err := xml.Unmarshal(payload, out); err != nil {
+			return fmt.Errorf("failed to decode provider API response: %w", err)
+		}
+	}
+	return nil
+}
+
+// signAWSRequest はリクエストにAWS署名バージョン4の署名を付与する
+func signAWSRequest(req *http.Request, body []byte, credentials awsCredentials, region, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	if credentials.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", credentials.SessionToken)
+	}
+
+	// 署名対象のヘッダー（小文字・ソート済み）
+	headerNames := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		headerNames = append(headerNames, strings.ToLower(name))
+	}
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(req.Header.Get(name)) + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(canonicalHash[:])}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+credentials.SecretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		credentials.AccessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
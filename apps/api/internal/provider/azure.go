@@ -0,0 +1,689 @@
+package provider
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+)
+
+const (
+	azureManagementEndpoint = "https://management.azure.com"
+	azureGraphEndpoint      = "https://graph.microsoft.com/v1.0"
+	azureLoginEndpoint      = "https://login.microsoftonline.com"
+
+	// azureAliasPrefix は作成中のサブスクリプションを表すアカウントIDのプレフィックス（サブスクリプションエイリアス名を続ける）
+	azureAliasPrefix = "alias/"
+	// azureContributorRoleID は組み込みの共同作成者ロールの定義ID
+	azureContributorRoleID = "b24988ac-6180-42a0-ab88-20f7382dd24c"
+	// azureReaderRoleID は組み込みの閲覧者ロールの定義ID
+	azureReaderRoleID = "acdd72a7-3385-48ef-bd42-f606fba81ae7"
+)
+
+// azureDriver はサブスクリプションエイリアスでサブスクリプションを作成・管理するドライバー
+type azureDriver struct {
+	tenantID       string
+	clientID       string
+	clientSecret   string
+	billingScope   string // サブスクリプションの請求先（課金アカウントの請求書セクションなど）
+	defaultRegion  string
+	managementAuth *cachedToken
+	graphAuth      *cachedToken
+	httpClient     *http.Client
+}
+
+// newAzureDriverFromEnv は環境変数からAzureドライバーを作成（サービスプリンシパルが未設定の場合はfalse）
+func newAzureDriverFromEnv() (interfaces.ProviderDriver, bool) {
+	tenantID := os.Getenv("AZURE_TENANT_ID")
+	clientID := os.Getenv("AZURE_CLIENT_ID")
+	clientSecret := os.Getenv("AZURE_CLIENT_SECRET")
+	billingScope := os.Getenv("AZURE_BILLING_SCOPE")
+	if tenantID == "" || clientID == "" || clientSecret == "" || billingScope == "" {
+		return nil, false
+	}
+
+	defaultRegion := os.Getenv("AZURE_DEFAULT_REGION")
+	if defaultRegion == "" {
+		defaultRegion = registryDefaultRegion(model.CSPProviderAzure)
+	}
+
+	d := &azureDriver{
+		tenantID:      tenantID,
+		clientID:      clientID,
+		clientSecret:  clientSecret,
+		billingScope:  billingScope,
+		defaultRegion: defaultRegion,
+		httpClient:    &http.Client{Timeout: 20 * time.Second},
+	}
+	d.managementAuth = &cachedToken{fetch: func() (string, time.Duration, error) {
+		return d.fetchToken(azureManagementEndpoint + "/.default")
+	}}
+	d.graphAuth = &cachedToken{fetch: func() (string, time.Duration, error) {
+		return d.fetchToken("https://graph.microsoft.com/.default")
+	}}
+	return d, true
+}
+
+// fetchToken はクライアントクレデンシャルフローでアクセストークンを取得
+func (d *azureDriver) fetchToken(scope string) (string, time.Duration, error) {
+	return d.requestToken(d.clientID, d.clientSecret, scope)
+}
+
+// requestToken は指定したアプリケーションのクライアントクレデンシャルでアクセストークンを取得
+func (d *azureDriver) requestToken(clientID, clientSecret, scope string) (string, time.Duration, error) {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+		"scope":         {scope},
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/%s/oauth2/v2.0/token", azureLoginEndpoint, d.tenantID), strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var resp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := doJSON(d.httpClient, req, &resp); err != nil {
+		return "", 0, err
+	}
+	return resp.AccessToken, time.Duration(resp.ExpiresIn) * time.Second, nil
+}
+
+func (d *azureDriver) Provider() model.CSPProvider {
+	return model.CSPProviderAzure
+}
+
+func (d *azureDriver) DefaultRegion() string {
+	return d.defaultRegion
+}
+
+type azureAlias struct {
+	Properties struct {
+		SubscriptionID    string `json:"subscriptionId"`
+		ProvisioningState string `json:"provisioningState"`
+	} `json:"properties"`
+}
+
+// CreateAccount はサブスクリプションエイリアスを作成してサブスクリプションを作成する
+// 作成が完了していない場合はエイリアス名をアカウントIDとして返す
+func (d *azureDriver) CreateAccount(input *model.ProviderAccountInput) (*model.ProviderAccount, error) {
+	alias := fmt.Sprintf("%s-%s", accountSlug(input.AccountName, 50), randomToken(3))
+	if input.CSPRequestID != "" {
+		// 同じ申請から再試行された場合に二重に作成しないよう、申請IDからエイリアス名を決める
+		alias = "cgas-" + accountSlug(input.CSPRequestID, 58)
+	}
+
+	properties := map[string]interface{}{
+		"displayName":  truncate(input.AccountName, 64),
+		"billingScope": d.billingScope,
+		"workload":     "Production",
+	}
+	attributes := model.CSPAccountAttributes{"tenant_id": d.tenantID}
+	if managementGroupID := input.Attributes["management_group_id"]; managementGroupID != "" {
+		properties["additionalProperties"] = map[string]string{
+			"managementGroupId": "/providers/Microsoft.Management/managementGroups/" + managementGroupID,
+		}
+		attributes["management_group_id"] = managementGroupID
+	}
+
+	var resp azureAlias
+	if err := d.callManagement(http.MethodPut, d.aliasURL(alias), map[string]interface{}{
+		"properties": properties,
+	}, &resp); err != nil {
+		return nil, err
+	}
+
+	region := input.Region
+	if region == "" {
+		region = d.defaultRegion
+	}
+	account := d.accountFromAlias(alias, &resp)
+	account.Region = region
+	account.Attributes = attributes
+	return account, nil
+}
+
+func (d *azureDriver) GetAccount(accountID string) (*model.ProviderAccount, error) {
+	if alias, ok := strings.CutPrefix(accountID, azureAliasPrefix); ok {
+		var resp azureAlias
+		if err := d.callManagement(http.MethodGet, d.aliasURL(alias), nil, &resp); err != nil {
+			if hasStatus(err, http.StatusNotFound) {
+				return nil, model.ErrProviderAccountNotFound
+			}
+			return nil, err
+		}
+		return d.accountFromAlias(alias, &resp), nil
+	}
+
+	var resp struct {
+		SubscriptionID string `json:"subscriptionId"`
+		State          string `json:"state"`
+	}
+	if err := d.callManagement(http.MethodGet, fmt.Sprintf("%s/subscriptions/%s?api-version=2020-01-01", azureManagementEndpoint, accountID), nil, &resp); err != nil {
+		if hasStatus(err, http.StatusNotFound) {
+			return nil, model.ErrProviderAccountNotFound
+		}
+		return nil, err
+	}
+
+	status := model.ProviderAccountStatusActive
+	switch resp.State {
+	case "Disabled", "PastDue", "Warned":
+		status = model.ProviderAccountStatusSuspended
+	case "Deleted":
+		status = model.ProviderAccountStatusClosed
+	}
+	return &model.ProviderAccount{AccountID: resp.SubscriptionID, Status: status}, nil
+}
+
+// accountFromAlias はサブスクリプションエイリアスの状態をプロバイダー側のアカウント情報に変換
+func (d *azureDriver) accountFromAlias(alias string, resp *azureAlias) *model.ProviderAccount {
+	switch resp.Properties.ProvisioningState {
+	case "Succeeded":
+		return &model.ProviderAccount{AccountID: resp.Properties.SubscriptionID, Status: model.ProviderAccountStatusActive}
+	case "Failed", "Canceled":
+		return &model.ProviderAccount{AccountID: azureAliasPrefix + alias, Status: model.ProviderAccountStatusFailed}
+	default:
+		return &model.ProviderAccount{AccountID: azureAliasPrefix + alias, Status: model.ProviderAccountStatusProvisioning}
+	}
+}
+
+func (d *azureDriver) aliasURL(alias string) string {
+	return fmt.Sprintf("%s/providers/Microsoft.Subscription/aliases/%s?api-version=2021-10-01", azureManagementEndpoint, alias)
+}
+
+func (d *azureDriver) CloseAccount(accountID string) error {
+	if strings.HasPrefix(accountID, azureAliasPrefix) {
+		return fmt.Errorf("Azure subscription %s is still being created", accountID)
+	}
+	err := d.callManagement(http.MethodPost, fmt.Sprintf("%s/subscriptions/%s/providers/Microsoft.Subscription/cancel?api-version=2021-10-01", azureManagementEndpoint, accountID), nil, nil)
+	if hasStatus(err, http.StatusNotFound) {
+		return model.ErrProviderAccountNotFound
+	}
+	return err
+}
+
+// GetCurrentMonthCost はCost Managementのクエリでサブスクリプションの当月の実績費用を取得する
+func (d *azureDriver) GetCurrentMonthCost(accountID string) (*model.ProviderCost, error) {
+	if strings.HasPrefix(accountID, azureAliasPrefix) {
+		return nil, fmt.Errorf("Azure subscription %s is still being created", accountID)
+	}
+
+	start, end := currentMonthPeriod(time.Now())
+	var resp struct {
+		Properties struct {
+			Columns []struct {
+				Name string `json:"name"`
+			} `json:"columns"`
+			Rows [][]interface{} `json:"rows"`
+		} `json:"properties"`
+	}
+	err := d.callManagement(http.MethodPost, fmt.Sprintf("%s/subscriptions/%s/providers/Microsoft.CostManagement/query?api-version=2023-03-01", azureManagementEndpoint, accountID), map[string]interface{}{
+		"type":      "ActualCost",
+		"timeframe": "MonthToDate",
+		"dataset": map[string]interface{}{
+			"granularity": "None",
+			"aggregation": map[string]interface{}{
+				"totalCost": map[string]string{"name": "Cost", "function": "Sum"},
+			},
+		},
+	}, &resp)
+	if err != nil {
+		if hasStatus(err, http.StatusNotFound) {
+			return nil, model.ErrProviderAccountNotFound
+		}
+		return nil, err
+	}
+
+	// 行は集計した費用と通貨の列からなる（列の順序はcolumnsで確認する）
+	cost := &model.ProviderCost{PeriodStart: start, PeriodEnd: end}
+	for _, row := range resp.Properties.Rows {
+		for i, column := range resp.Properties.Columns {
+			if i >= len(row) {
+				break
+			}
+			switch column.Name {
+			case "totalCost", "Cost":
+				if amount, ok := row[i].(float64); ok {
+					cost.Amount += amount
+				}
+			case "Currency":
+				if currency, ok := row[i].(string); ok {
+					cost.Currency = currency
+				}
+			}
+		}
+	}
+	return cost, nil
+}
+
+// ListAccounts はサービスプリンシパルがアクセスできるサブスクリプションの一覧を返す
+func (d *azureDriver) ListAccounts() ([]model.ProviderAccount, error) {
+	var accounts []model.ProviderAccount
+	next := azureManagementEndpoint + "/subscriptions?api-version=2020-01-01"
+	for next != "" {
+		var resp struct {
+			Value []struct {
+				SubscriptionID string `json:"subscriptionId"`
+				DisplayName    string `json:"displayName"`
+				State          string `json:"state"`
+			} `json:"value"`
+			NextLink string `json:"nextLink"`
+		}
+		if err := d.callManagement(http.MethodGet, next, nil, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, subscription := range resp.Value {
+			status := model.ProviderAccountStatusActive
+			switch subscription.State {
+			case "Disabled", "PastDue", "Warned":
+				status = model.ProviderAccountStatusSuspended
+			case "Deleted":
+				status = model.ProviderAccountStatusClosed
+			}
+			accounts = append(accounts, model.ProviderAccount{AccountID: subscription.SubscriptionID, AccountName: subscription.DisplayName, Status: status})
+		}
+		next = resp.NextLink
+	}
+	return accounts, nil
+}
+
+// azureMemberRoleIDs はメンバーのロールに対応する組み込みロールの定義ID
+var azureMemberRoleIDs = map[model.CSPAccountMemberRole]string{
+	model.CSPAccountMemberRoleAdmin: azureContributorRoleID,
+	model.CSPAccountMemberRoleUser:  azureReaderRoleID,
+}
+
+type azureRoleAssignment struct {
+	ID         string `json:"id"`
+	Properties struct {
+		RoleDefinitionID string `json:"roleDefinitionId"`
+		PrincipalID      string `json:"principalId"`
+		PrincipalType    string `json:"principalType"`
+		Scope            string `json:"scope"`
+	} `json:"properties"`
+}
+
+// memberRole はロール割り当てに対応するメンバーのロール（共同作成者・閲覧者以外の場合はfalse）
+func (a *azureRoleAssignment) memberRole() (model.CSPAccountMemberRole, bool) {
+	for role, roleDefinitionID := range azureMemberRoleIDs {
+		if strings.HasSuffix(strings.ToLower(a.Properties.RoleDefinitionID), "/"+roleDefinitionID) {
+			return role, true
+		}
+	}
+	return "", false
+}
+
+// listUserRoleAssignments はサブスクリプションに直接割り当てられた、ユーザーへの共同作成者・閲覧者ロールの一覧を返す
+func (d *azureDriver) listUserRoleAssignments(subscriptionID string) ([]azureRoleAssignment, error) {
+	if strings.HasPrefix(subscriptionID, azureAliasPrefix) {
+		return nil, fmt.Errorf("Azure subscription %s is still being created", subscriptionID)
+	}
+
+	scope := "/subscriptions/" + subscriptionID
+	var assignments []azureRoleAssignment
+	next := fmt.Sprintf("%s%s/providers/Microsoft.Authorization/roleAssignments?api-version=2022-04-01&$filter=%s", azureManagementEndpoint, scope, url.QueryEscape("atScope()"))
+	for next != "" {
+		var resp struct {
+			Value    []azureRoleAssignment `json:"value"`
+			NextLink string                `json:"nextLink"`
+		}
+		if err := d.callManagement(http.MethodGet, next, nil, &resp); err != nil {
+			if hasStatus(err, http.StatusNotFound) {
+				return nil, model.ErrProviderAccountNotFound
+			}
+			return nil, err
+		}
+
+		for _, assignment := range resp.Value {
+			if assignment.Properties.PrincipalType != "User" || !strings.EqualFold(assignment.Properties.Scope, scope) {
+				continue
+			}
+			if _, ok := assignment.memberRole(); ok {
+				assignments = append(assignments, assignment)
+			}
+		}
+		next = resp.NextLink
+	}
+	return assignments, nil
+}
+
+// lookupUser はメールアドレス（ユーザープリンシパル名）からAzure ADのユーザーを取得する
+func (d *azureDriver) lookupUser(idOrEmail string) (id, email string, err error) {
+	var resp struct {
+		ID                string `json:"id"`
+		Mail              string `json:"mail"`
+		UserPrincipalName string `json:"userPrincipalName"`
+	}
+	err = d.callGraph(http.MethodGet, fmt.Sprintf("%s/users/%s?$select=id,mail,userPrincipalName", azureGraphEndpoint, url.PathEscape(idOrEmail)), nil, &resp)
+	if hasStatus(err, http.StatusNotFound) {
+		return "", "", model.ErrProviderUserNotFound
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	email = resp.Mail
+	if email == "" {
+		email = resp.UserPrincipalName
+	}
+	return resp.ID, strings.ToLower(email), nil
+}
+
+// ListAccountMembers はサブスクリプションで共同作成者（admin）・閲覧者（user）のロールを持つユーザーを返す
+// 両方のロールを持つユーザーは管理者として扱う
+func (d *azureDriver) ListAccountMembers(accountID string) ([]model.ProviderAccountMember, error) {
+	assignments, err := d.listUserRoleAssignments(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make(map[string]model.CSPAccountMemberRole)
+	var principalIDs []string
+	for _, assignment := range assignments {
+		role, _ := assignment.memberRole()
+		principalID := assignment.Properties.PrincipalID
+		if current, ok := roles[principalID]; !ok {
+			principalIDs = append(principalIDs, principalID)
+		} else if current == model.CSPAccountMemberRoleAdmin {
+			continue
+		}
+		roles[principalID] = role
+	}
+
+	members := make([]model.ProviderAccountMember, 0, len(principalIDs))
+	for _, principalID := range principalIDs {
+		_, email, err := d.lookupUser(principalID)
+		if errors.Is(err, model.ErrProviderUserNotFound) {
+			// 削除済みのユーザーへの割り当ては残っていても対象外
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, model.ProviderAccountMember{Email: email, Role: roles[principalID]})
+	}
+	return members, nil
+}
+
+// GrantAccountMember はユーザーにロールを割り当て、もう一方のロールの割り当ては削除する
+func (d *azureDriver) GrantAccountMember(accountID string, member *model.ProviderAccountMember) error {
+	principalID, _, err := d.lookupUser(member.Email)
+	if err != nil {
+		return err
+	}
+	assignments, err := d.listUserRoleAssignments(accountID)
+	if err != nil {
+		return err
+	}
+
+	roleDefinitionID, ok := azureMemberRoleIDs[member.Role]
+	if !ok {
+		return model.ErrInvalidRole
+	}
+
+	granted := false
+	for _, assignment := range assignments {
+		if assignment.Properties.PrincipalID != principalID {
+			continue
+		}
+		if role, _ := assignment.memberRole(); role == member.Role {
+			granted = true
+			continue
+		}
+		if err := d.deleteRoleAssignment(assignment.ID); err != nil {
+			return err
+		}
+	}
+	if granted {
+		return nil
+	}
+
+	scope := "/subscriptions/" + accountID
+	return d.callManagement(http.MethodPut, fmt.Sprintf("%s%s/providers/Microsoft.Authorization/roleAssignments/%s?api-version=2022-04-01", azureManagementEndpoint, scope, newUUID()), map[string]interface{}{
+		"properties": map[string]string{
+			"roleDefinitionId": scope + "/providers/Microsoft.Authorization/roleDefinitions/" + roleDefinitionID,
+			"principalId":      principalID,
+			"principalType":    "User",
+		},
+	}, nil)
+}
+
+// RevokeAccountMember はユーザーへの共同作成者・閲覧者ロールの割り当てを削除する
+func (d *azureDriver) RevokeAccountMember(accountID, email string) error {
+	principalID, _, err := d.lookupUser(email)
+	if errors.Is(err, model.ErrProviderUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	assignments, err := d.listUserRoleAssignments(accountID)
+	if err != nil {
+		return err
+	}
+
+	for _, assignment := range assignments {
+		if assignment.Properties.PrincipalID != principalID {
+			continue
+		}
+		if err := d.deleteRoleAssignment(assignment.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *azureDriver) deleteRoleAssignment(assignmentID string) error {
+	err := d.callManagement(http.MethodDelete, azureManagementEndpoint+assignmentID+"?api-version=2022-04-01", nil, nil)
+	if err != nil && !hasStatus(err, http.StatusNotFound) {
+		return err
+	}
+	return nil
+}
+
+type azureApplication struct {
+	ID                  string `json:"id"`
+	AppID               string `json:"appId"`
+	PasswordCredentials []struct {
+		KeyID string `json:"keyId"`
+	} `json:"passwordCredentials"`
+}
+
+// RotateCredentials はサブスクリプション用のアプリケーションに新しいクライアントシークレットを発行し、使用中以外の古いシークレットを削除する
+// アプリケーションがなければ作成し、サブスクリプションの共同作成者ロールを割り当てる
+// アクセスキーにはアプリケーション（クライアント）ID、シークレットキーにはクライアントシークレット、鍵の識別子にはシークレットのキーIDを返す
+func (d *azureDriver) RotateCredentials(accountID, keepKeyID string) (*model.ProviderCredentials, error) {
+	if strings.HasPrefix(accountID, azureAliasPrefix) {
+		return nil, fmt.Errorf("Azure subscription %s is still being created", accountID)
+	}
+
+	app, err := d.ensureApplication(accountID, "", azureContributorRoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var created struct {
+		KeyID      string `json:"keyId"`
+		SecretText string `json:"secretText"`
+	}
+	if err := d.callGraph(http.MethodPost, fmt.Sprintf("%s/applications/%s/addPassword", azureGraphEndpoint, app.ID), map[string]interface{}{
+		"passwordCredential": map[string]string{"displayName": "cgas"},
+	}, &created); err != nil {
+		return nil, err
+	}
+
+	for _, credential := range app.PasswordCredentials {
+		if credential.KeyID == keepKeyID {
+			continue
+		}
+		if err := d.removePassword(app.ID, credential.KeyID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.ProviderCredentials{AccessKey: app.AppID, SecretKey: created.SecretText, KeyID: created.KeyID}, nil
+}
+
+// RevokeCredentials はサブスクリプション用のアプリケーションからクライアントシークレットを削除する
+func (d *azureDriver) RevokeCredentials(accountID, keyID string) error {
+	app, err := d.ensureApplication(accountID, "", azureContributorRoleID)
+	if err != nil {
+		return err
+	}
+	for _, credential := range app.PasswordCredentials {
+		if credential.KeyID == keyID {
+			return d.removePassword(app.ID, keyID)
+		}
+	}
+	return nil
+}
+
+// IssueTemporaryCredentials はメンバーのロールに対応するアプリケーション（adminは共同作成者、userは閲覧者）の
+// 短期のシークレットでAzure Resource Managerのアクセストークンを取得し、シークレットはすぐに削除する
+func (d *azureDriver) IssueTemporaryCredentials(accountID string, input *model.TemporaryCredentialInput) (*model.TemporaryCredentials, error) {
+	if strings.HasPrefix(accountID, azureAliasPrefix) {
+		return nil, fmt.Errorf("Azure subscription %s is still being created", accountID)
+	}
+
+	suffix, roleDefinitionID := "-reader", azureReaderRoleID
+	if input.Role == model.CSPAccountMemberRoleAdmin {
+		suffix, roleDefinitionID = "", azureContributorRoleID
+	}
+	app, err := d.ensureApplication(accountID, suffix, roleDefinitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var created struct {
+		KeyID      string `json:"keyId"`
+		SecretText string `json:"secretText"`
+	}
+	if err := d.callGraph(http.MethodPost, fmt.Sprintf("%s/applications/%s/addPassword", azureGraphEndpoint, app.ID), map[string]interface{}{
+		"passwordCredential": map[string]string{
+			"displayName": "cgas-session " + input.SessionName,
+			"endDateTime": time.Now().Add(input.Duration).UTC().Format(time.RFC3339),
+		},
+	}, &created); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := d.removePassword(app.ID, created.KeyID); err != nil {
+			log.Printf("[WARN] Failed to remove temporary secret of Azure application %s: %v", app.AppID, err)
+		}
+	}()
+
+	// 発行直後のシークレットはAzure ADに反映されるまで使えない場合があるため数回再試行する
+	var token string
+	var expiresIn time.Duration
+	for attempt := 0; attempt < 3; attempt++ {
+		if attempt > 0 {
+			time.Sleep(2 * time.Second)
+		}
+		token, expiresIn, err = d.requestToken(app.AppID, created.SecretText, azureManagementEndpoint+"/.default")
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TemporaryCredentials{SessionToken: token, ExpiresAt: time.Now().Add(expiresIn)}, nil
+}
+
+func (d *azureDriver) removePassword(applicationID, keyID string) error {
+	return d.callGraph(http.MethodPost, fmt.Sprintf("%s/applications/%s/removePassword", azureGraphEndpoint, applicationID), map[string]string{
+		"keyId": keyID,
+	}, nil)
+}
+
+// ensureApplication はサブスクリプション用のアプリケーションを取得（なければ作成してロールを割り当てる）
+// 表示名は cgas-<サブスクリプションID><suffix> とし、ロールごとに別のアプリケーションを使う
+func (d *azureDriver) ensureApplication(subscriptionID, suffix, roleDefinitionID string) (*azureApplication, error) {
+	displayName := "cgas-" + subscriptionID + suffix
+
+	var listResp struct {
+		Value []azureApplication `json:"value"`
+	}
+	filter := url.QueryEscape(fmt.Sprintf("displayName eq '%s'", displayName))
+	if err := d.callGraph(http.MethodGet, azureGraphEndpoint+"/applications?$filter="+filter, nil, &listResp); err != nil {
+		return nil, err
+	}
+	if len(listResp.Value) > 0 {
+		return &listResp.Value[0], nil
+	}
+
+	var app azureApplication
+	if err := d.callGraph(http.MethodPost, azureGraphEndpoint+"/applications", map[string]string{"displayName": displayName}, &app); err != nil {
+		return nil, err
+	}
+
+	var principal struct {
+		ID string `json:"id"`
+	}
+	if err := d.callGraph(http.MethodPost, azureGraphEndpoint+"/servicePrincipals", map[string]string{"appId": app.AppID}, &principal); err != nil {
+		return nil, err
+	}
+
+	scope := "/subscriptions/" + subscriptionID
+	assignmentURL := fmt.Sprintf("%s%s/providers/Microsoft.Authorization/roleAssignments/%s?api-version=2022-04-01", azureManagementEndpoint, scope, newUUID())
+	if err := d.callManagement(http.MethodPut, assignmentURL, map[string]interface{}{
+		"properties": map[string]string{
+			"roleDefinitionId": scope + "/providers/Microsoft.Authorization/roleDefinitions/" + roleDefinitionID,
+			"principalId":      principal.ID,
+			"principalType":    "ServicePrincipal",
+		},
+	}, nil); err != nil {
+		return nil, err
+	}
+
+	return &app, nil
+}
+
+func (d *azureDriver) callManagement(method, url string, body interface{}, out interface{}) error {
+	return d.call(d.managementAuth, method, url, body, out)
+}
+
+func (d *azureDriver) callGraph(method, url string, body interface{}, out interface{}) error {
+	return d.call(d.graphAuth, method, url, body, out)
+}
+
+// call はアクセストークンを付けてAzureのAPIを呼び出す
+func (d *azureDriver) call(auth *cachedToken, method, url string, body interface{}, out interface{}) error {
+	token, err := auth.get()
+	if err != nil {
+		return err
+	}
+
+	req, err := newJSONRequest(method, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return doJSON(d.httpClient, req, out)
+}
+
+// newUUID はランダムなUUID（バージョン4）を生成
+func newUUID() string {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		panic(err)
+	}
+	raw[6] = (raw[6] & 0x0f) | 0x40
+	raw[8] = (raw[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", raw[0:4], raw[4:6], raw[6:8], raw[8:10], raw[10:])
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+)
+
+// driverSet はプロバイダーごとのドライバーの集合
+type driverSet map[model.CSPProvider]interfaces.ProviderDriver
+
+// NewProviderDrivers は環境変数に応じたプロバイダードライバーの集合を返す
+// PROVIDER_DRIVER=fake の場合だけ、認証情報が設定されていないプロバイダーにインメモリのフェイクドライバーを使う（開発用）
+// それ以外で認証情報が設定されていないプロバイダーがある場合は、ダミーのアカウントを本番に記録しないよう起動を失敗させる
+// 使わないプロバイダーはDISABLED_PROVIDER_DRIVERS（例: gcp,azure）で明示的に無効にする
+// プロビジョニングに対応していないプロバイダー（OCI・さくら等）はドライバーを必要としない
+func NewProviderDrivers() (interfaces.ProviderDrivers, error) {
+	drivers := driverSet{}
+	useFake := os.Getenv("PROVIDER_DRIVER") == "fake"
+	disabled := make(map[model.CSPProvider]bool)
+	for _, name := range strings.Split(os.Getenv("DISABLED_PROVIDER_DRIVERS"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			disabled[model.CSPProvider(strings.ToLower(name))] = true
+		}
+	}
+
+	if driver, ok := newAWSDriverFromEnv(); ok {
+		drivers[model.CSPProviderAWS] = driver
+	}
+	if driver, ok := newGCPDriverFromEnv(); ok {
+		drivers[model.CSPProviderGCP] = driver
+	}
+	if driver, ok := newAzureDriverFromEnv(); ok {
+		drivers[model.CSPProviderAzure] = driver
+	}
+
+	var missing []string
+	for _, definition := range model.CSPProviders() {
+		if _, ok := drivers[definition.ID]; ok {
+			continue
+		}
+		if !definition.Supports(model.CSPProviderCapabilityProvisioning) {
+			continue
+		}
+		switch {
+		case disabled[definition.ID]:
+			log.Printf("[PROVIDER] %s driver is disabled", definition.ID)
+		case useFake:
+			log.Printf("[PROVIDER] %s driver is not configured, using fake driver", definition.ID)
+			drivers[definition.ID] = NewFakeDriver(definition.ID)
+		default:
+			missing = append(missing, string(definition.ID))
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("provider drivers are not configured for %s; set their credentials, list them in DISABLED_PROVIDER_DRIVERS, or set PROVIDER_DRIVER=fake for development", strings.Join(missing, ", "))
+	}
+
+	return drivers, nil
+}
+
+// NewDriverSet は指定したドライバーからなる集合を作成（フェイクドライバーとの組み合わせ用）
+func NewDriverSet(drivers ...interfaces.ProviderDriver) interfaces.ProviderDrivers {
+	set := driverSet{}
+	for _, driver := range drivers {
+		set[driver.Provider()] = driver
+	}
+	return set
+}
+
+func (d driverSet) Driver(provider model.CSPProvider) (interfaces.ProviderDriver, error) {
+	driver, ok := d[provider]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", model.ErrProviderDriverNotFound, provider)
+	}
+	return driver, nil
+}
+
+// currentMonthPeriod は当月（UTC）の月初と翌日の0時を返す（費用の集計期間。終了日は含まない）
+func currentMonthPeriod(now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	return start, end
+}
+
+// registryDefaultRegion はプロバイダーレジストリに定義されたデフォルトリージョンを返す
+func registryDefaultRegion(provider model.CSPProvider) string {
+	if definition, ok := model.LookupCSPProvider(provider); ok {
+		return definition.DefaultRegion
+	}
+	return "default"
+}
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+)
+
+// fakeDriver はプロバイダーを呼び出さずにメモリ上でアカウントを管理するドライバー（開発・テスト用）
+type fakeDriver struct {
+	provider model.CSPProvider
+	mu       sync.Mutex
+	accounts map[string]*model.ProviderAccount
+	members  map[string]map[string]model.CSPAccountMemberRole // アカウントIDごとのメールアドレスとロール
+}
+
+// NewFakeDriver はインメモリのフェイクドライバーを作成
+func NewFakeDriver(provider model.CSPProvider) interfaces.ProviderDriver {
+	return &fakeDriver{
+		provider: provider,
+		accounts: make(map[string]*model.ProviderAccount),
+		members:  make(map[string]map[string]model.CSPAccountMemberRole),
+	}
+}
+
+func (d *fakeDriver) Provider() model.CSPProvider {
+	return d.provider
+}
+
+func (d *fakeDriver) DefaultRegion() string {
+	return registryDefaultRegion(d.provider)
+}
+
+func (d *fakeDriver) CreateAccount(input *model.ProviderAccountInput) (*model.ProviderAccount, error) {
+	region := input.Region
+	if region == "" {
+		region = d.DefaultRegion()
+	}
+
+	account := &model.ProviderAccount{
+		AccountID:   fmt.Sprintf("%s-%s-%s", d.provider, accountSlug(input.AccountName, 20), randomToken(4)),
+		AccountName: input.AccountName,
+		Region:      region,
+		Status:      model.ProviderAccountStatusActive,
+		Attributes:  fakeRequiredAttributes(d.provider, input.Attributes),
+		Credentials: &model.ProviderCredentials{
+			AccessKey: "AK" + randomToken(10),
+			SecretKey: "SK" + randomToken(20),
+		},
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.accounts[account.AccountID] = account
+
+	created := *account
+	return &created, nil
+}
+
+func (d *fakeDriver) GetAccount(accountID string) (*model.ProviderAccount, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	account, ok := d.accounts[accountID]
+	if !ok {
+		// 再起動前に作成したアカウントや手動登録したアカウントは有効なものとして扱う
+		return &model.ProviderAccount{AccountID: accountID, Status: model.ProviderAccountStatusActive}, nil
+	}
+	return &model.ProviderAccount{AccountID: account.AccountID, Region: account.Region, Status: account.Status}, nil
+}
+
+func (d *fakeDriver) CloseAccount(accountID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if account, ok := d.accounts[accountID]; ok {
+		account.Status = model.ProviderAccountStatusClosed
+		return nil
+	}
+	d.accounts[accountID] = &model.ProviderAccount{AccountID: accountID, Status: model.ProviderAccountStatusClosed}
+	return nil
+}
+
+func (d *fakeDriver) RotateCredentials(accountID, keepKeyID string) (*model.ProviderCredentials, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if account, ok := d.accounts[accountID]; ok && account.Status == model.ProviderAccountStatusClosed {
+		return nil, model.ErrProviderAccountNotFound
+	}
+	accessKey := "AK" + randomToken(10)
+	return &model.ProviderCredentials{
+		AccessKey: accessKey,
+		SecretKey: "SK" + randomToken(20),
+		KeyID:     accessKey,
+	}, nil
+}
+
+func (d *fakeDriver) RevokeCredentials(accountID, keyID string) error {
+	return nil
+}
+
+func (d *fakeDriver) IssueTemporaryCredentials(accountID string, input *model.TemporaryCredentialInput) (*model.TemporaryCredentials, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if account, ok := d.accounts[accountID]; ok && account.Status == model.ProviderAccountStatusClosed {
+		return nil, model.ErrProviderAccountNotFound
+	}
+	return &model.TemporaryCredentials{
+		AccessKey:    "ASIA" + randomToken(8),
+		SecretKey:    "SK" + randomToken(20),
+		SessionToken: "session-" + string(input.Role) + "-" + randomToken(24),
+		ExpiresAt:    time.Now().Add(input.Duration),
+	}, nil
+}
+
+// GetCurrentMonthCost は費用が発生していないものとして返す
+func (d *fakeDriver) GetCurrentMonthCost(accountID string) (*model.ProviderCost, error) {
+	start, end := currentMonthPeriod(time.Now())
+	return &model.ProviderCost{Amount: 0, Currency: "USD", PeriodStart: start, PeriodEnd: end}, nil
+}
+
+// ListAccounts はこのドライバーで作成したアカウントの一覧を返す
+func (d *fakeDriver) ListAccounts() ([]model.ProviderAccount, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	accounts := make([]model.ProviderAccount, 0, len(d.accounts))
+	for _, account := range d.accounts {
+		accounts = append(accounts, model.ProviderAccount{
+			AccountID:   account.AccountID,
+			AccountName: account.AccountName,
+			Region:      account.Region,
+			Status:      account.Status,
+		})
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].AccountID < accounts[j].AccountID })
+	return accounts, nil
+}
+
+// ListAccountMembers は付与したロールの一覧を返す
+// 一度もロールを付与していないアカウントはプロバイダー側の状態が分からないため、対象外として扱う
+func (d *fakeDriver) ListAccountMembers(accountID string) ([]model.ProviderAccountMember, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.members[accountID]; !ok {
+		return nil, model.ErrProviderMembersNotSupported
+	}
+	members := make([]model.ProviderAccountMember, 0, len(d.members[accountID]))
+	for email, role := range d.members[accountID] {
+		members = append(members, model.ProviderAccountMember{Email: email, Role: role})
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Email < members[j].Email })
+	return members, nil
+}
+
+func (d *fakeDriver) GrantAccountMember(accountID string, member *model.ProviderAccountMember) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.members[accountID] == nil {
+		d.members[accountID] = make(map[string]model.CSPAccountMemberRole)
+	}
+	d.members[accountID][strings.ToLower(member.Email)] = member.Role
+	return nil
+}
+
+func (d *fakeDriver) RevokeAccountMember(accountID, email string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.members[accountID], strings.ToLower(email))
+	return nil
+}
+
+// fakeRequiredAttributes は申請で指定されていない必須属性をダミーの値で補完する
+func fakeRequiredAttributes(provider model.CSPProvider, attributes model.CSPAccountAttributes) model.CSPAccountAttributes {
+	definition, ok := model.LookupCSPProvider(provider)
+	if !ok {
+		return nil
+	}
+
+	filled := model.CSPAccountAttributes{}
+	for _, attribute := range definition.Attributes {
+		if !attribute.Required || attributes[attribute.Key] != "" {
+			continue
+		}
+		switch attribute.Key {
+		case "root_email":
+			filled[attribute.Key] = fmt.Sprintf("%s+%s@example.com", provider, randomToken(4))
+		case "tenant_id":
+			filled[attribute.Key] = newUUID()
+		case "tenancy_id":
+			filled[attribute.Key] = "ocid1.tenancy.oc1.." + randomToken(16)
+		}
+	}
+	return filled
+}
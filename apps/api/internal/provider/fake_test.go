@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+)
+
+// testDriverContract はドライバーがProviderDriverの約束事（アカウントの作成・取得・閉鎖、認証情報、メンバー管理）を守っているかを検証する
+func testDriverContract(t *testing.T, driver interfaces.ProviderDriver) {
+	t.Helper()
+
+	account, err := driver.CreateAccount(&model.ProviderAccountInput{AccountName: "Contract Test", ProjectID: 1, CSPRequestID: "req-1"})
+	if err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+	if account.AccountID == "" {
+		t.Fatal("CreateAccount returned an empty account ID")
+	}
+	if account.Region != driver.DefaultRegion() {
+		t.Errorf("CreateAccount region = %q, want default region %q", account.Region, driver.DefaultRegion())
+	}
+	if account.Status != model.ProviderAccountStatusActive {
+		t.Errorf("CreateAccount status = %q, want %q", account.Status, model.ProviderAccountStatusActive)
+	}
+	if account.Credentials == nil || account.Credentials.AccessKey == "" || account.Credentials.SecretKey == "" {
+		t.Error("CreateAccount did not return credentials")
+	}
+
+	fetched, err := driver.GetAccount(account.AccountID)
+	if err != nil {
+		t.Fatalf("GetAccount: %v", err)
+	}
+	if fetched.AccountID != account.AccountID || fetched.Status != model.ProviderAccountStatusActive {
+		t.Errorf("GetAccount = %+v, want active account %s", fetched, account.AccountID)
+	}
+
+	accounts, err := driver.ListAccounts()
+	if err != nil {
+		t.Fatalf("ListAccounts: %v", err)
+	}
+	if !containsAccount(accounts, account.AccountID, "Contract Test") {
+		t.Errorf("ListAccounts = %+v, want it to include %s named %q", accounts, account.AccountID, "Contract Test")
+	}
+
+	rotated, err := driver.RotateCredentials(account.AccountID, account.Credentials.KeyID)
+	if err != nil {
+		t.Fatalf("RotateCredentials: %v", err)
+	}
+	if rotated.AccessKey == "" || rotated.AccessKey == account.Credentials.AccessKey {
+		t.Errorf("RotateCredentials returned access key %q, want a new key", rotated.AccessKey)
+	}
+	if err := driver.RevokeCredentials(account.AccountID, "unknown-key"); err != nil {
+		t.Errorf("RevokeCredentials of an unknown key: %v", err)
+	}
+
+	temporary, err := driver.IssueTemporaryCredentials(account.AccountID, &model.TemporaryCredentialInput{
+		Role:        model.CSPAccountMemberRoleUser,
+		SessionName: "member@example.com",
+		Duration:    15 * time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("IssueTemporaryCredentials: %v", err)
+	}
+	if temporary.AccessKey == "" || temporary.SessionToken == "" || !temporary.ExpiresAt.After(time.Now()) {
+		t.Errorf("IssueTemporaryCredentials = %+v, want unexpired session credentials", temporary)
+	}
+
+	cost, err := driver.GetCurrentMonthCost(account.AccountID)
+	if err != nil && !errors.Is(err, model.ErrProviderCostNotAvailable) {
+		t.Fatalf("GetCurrentMonthCost: %v", err)
+	}
+	if err == nil && !cost.PeriodStart.Before(cost.PeriodEnd) {
+		t.Errorf("GetCurrentMonthCost period = %s - %s, want start before end", cost.PeriodStart, cost.PeriodEnd)
+	}
+
+	testMemberContract(t, driver, account.AccountID)
+
+	if err := driver.CloseAccount(account.AccountID); err != nil {
+		t.Fatalf("CloseAccount: %v", err)
+	}
+	closed, err := driver.GetAccount(account.AccountID)
+	if err != nil {
+		t.Fatalf("GetAccount after close: %v", err)
+	}
+	if closed.Status != model.ProviderAccountStatusClosed {
+		t.Errorf("GetAccount after close status = %q, want %q", closed.Status, model.ProviderAccountStatusClosed)
+	}
+	if _, err := driver.RotateCredentials(account.AccountID, ""); !errors.Is(err, model.ErrProviderAccountNotFound) {
+		t.Errorf("RotateCredentials after close error = %v, want %v", err, model.ErrProviderAccountNotFound)
+	}
+}
+
+// testMemberContract はロールの付与・置き換え・削除がメールアドレスの大文字小文字を区別せずに反映されるかを検証する
+func testMemberContract(t *testing.T, driver interfaces.ProviderDriver, accountID string) {
+	t.Helper()
+
+	if err := driver.GrantAccountMember(accountID, &model.ProviderAccountMember{Email: "Member@Example.com", Role: model.CSPAccountMemberRoleUser}); err != nil {
+		t.Fatalf("GrantAccountMember: %v", err)
+	}
+	if err := driver.GrantAccountMember(accountID, &model.ProviderAccountMember{Email: "member@example.com", Role: model.CSPAccountMemberRoleAdmin}); err != nil {
+		t.Fatalf("GrantAccountMember (replace role): %v", err)
+	}
+	members, err := driver.ListAccountMembers(accountID)
+	if err != nil {
+		t.Fatalf("ListAccountMembers: %v", err)
+	}
+	if len(members) != 1 || members[0].Email != "member@example.com" || members[0].Role != model.CSPAccountMemberRoleAdmin {
+		t.Errorf("ListAccountMembers = %+v, want only member@example.com as admin", members)
+	}
+
+	if err := driver.RevokeAccountMember(accountID, "MEMBER@example.com"); err != nil {
+		t.Fatalf("RevokeAccountMember: %v", err)
+	}
+	if err := driver.RevokeAccountMember(accountID, "member@example.com"); err != nil {
+		t.Errorf("RevokeAccountMember of a revoked member: %v", err)
+	}
+	members, err = driver.ListAccountMembers(accountID)
+	if err != nil {
+		t.Fatalf("ListAccountMembers after revoke: %v", err)
+	}
+	if len(members) != 0 {
+		t.Errorf("ListAccountMembers after revoke = %+v, want none", members)
+	}
+}
+
+func containsAccount(accounts []model.ProviderAccount, accountID, accountName string) bool {
+	for _, account := range accounts {
+		if account.AccountID == accountID && account.AccountName == accountName {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFakeDriverContract(t *testing.T) {
+	for _, definition := range model.CSPProviders() {
+		definition := definition
+		t.Run(string(definition.ID), func(t *testing.T) {
+			testDriverContract(t, NewFakeDriver(definition.ID))
+		})
+	}
+}
+
+func TestFakeDriverListAccountMembersBeforeGrant(t *testing.T) {
+	driver := NewFakeDriver(model.CSPProviderAWS)
+	if _, err := driver.ListAccountMembers("123456789012"); !errors.Is(err, model.ErrProviderMembersNotSupported) {
+		t.Errorf("ListAccountMembers error = %v, want %v", err, model.ErrProviderMembersNotSupported)
+	}
+}
+
+func TestNewProviderDrivers(t *testing.T) {
+	for _, key := range []string{
+		"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_ACCOUNT_EMAIL_DOMAIN",
+		"GCP_PROJECT_PARENT", "GCP_ACCESS_TOKEN",
+		"AZURE_TENANT_ID", "AZURE_CLIENT_ID", "AZURE_CLIENT_SECRET", "AZURE_BILLING_SCOPE",
+	} {
+		t.Setenv(key, "")
+	}
+
+	t.Run("fails without credentials or opt-in", func(t *testing.T) {
+		t.Setenv("PROVIDER_DRIVER", "")
+		t.Setenv("DISABLED_PROVIDER_DRIVERS", "")
+		if _, err := NewProviderDrivers(); err == nil {
+			t.Fatal("NewProviderDrivers succeeded without credentials, want an error")
+		}
+	})
+
+	t.Run("uses fake drivers when opted in", func(t *testing.T) {
+		t.Setenv("PROVIDER_DRIVER", "fake")
+		t.Setenv("DISABLED_PROVIDER_DRIVERS", "")
+		drivers, err := NewProviderDrivers()
+		if err != nil {
+			t.Fatalf("NewProviderDrivers: %v", err)
+		}
+		for _, definition := range model.CSPProviders() {
+			driver, err := drivers.Driver(definition.ID)
+			if !definition.Supports(model.CSPProviderCapabilityProvisioning) {
+				// プロビジョニング非対応のプロバイダーにはフェイクドライバーも用意しない
+				if !errors.Is(err, model.ErrProviderDriverNotFound) {
+					t.Errorf("Driver(%s) error = %v, want %v", definition.ID, err, model.ErrProviderDriverNotFound)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("Driver(%s): %v", definition.ID, err)
+			}
+			if _, ok := driver.(*fakeDriver); !ok {
+				t.Errorf("Driver(%s) = %T, want *fakeDriver", definition.ID, driver)
+			}
+		}
+	})
+
+	t.Run("leaves disabled providers without a driver", func(t *testing.T) {
+		t.Setenv("PROVIDER_DRIVER", "")
+		// プロビジョニング対応のプロバイダーだけ無効にすれば起動できる
+		var provisioning []string
+		for _, definition := range model.CSPProviders() {
+			if definition.Supports(model.CSPProviderCapabilityProvisioning) {
+				provisioning = append(provisioning, string(definition.ID))
+			}
+		}
+		t.Setenv("DISABLED_PROVIDER_DRIVERS", strings.Join(provisioning, ", "))
+		drivers, err := NewProviderDrivers()
+		if err != nil {
+			t.Fatalf("NewProviderDrivers: %v", err)
+		}
+		if _, err := drivers.Driver(model.CSPProviderAWS); !errors.Is(err, model.ErrProviderDriverNotFound) {
+			t.Errorf("Driver(aws) error = %v, want %v", err, model.ErrProviderDriverNotFound)
+		}
+	})
+}
@@ -0,0 +1,518 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+)
+
+const (
+	gcpResourceManagerEndpoint = "https://cloudresourcemanager.googleapis.com/v3"
+	gcpIAMEndpoint             = "https://iam.googleapis.com/v1"
+	gcpBillingEndpoint         = "https://cloudbilling.googleapis.com/v1"
+	gcpIAMCredentialsEndpoint  = "https://iamcredentials.googleapis.com/v1"
+	gcpMetadataTokenURL        = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+	// gcpBillingAccountLabel は作成時に指定された請求先アカウントを記録するラベル
+	gcpBillingAccountLabel = "cgas-billing-account"
+
+	// gcpCloudPlatformScope は一時的なアクセストークンに付けるスコープ
+	gcpCloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+	// gcpAccessServiceAccount はプロジェクトに作成する、認証情報発行用のサービスアカウント名
+	gcpAccessServiceAccount = "cgas-access"
+)
+
+// gcpDriver はResource Managerでプロジェクトを作成・管理するドライバー
+type gcpDriver struct {
+	parent         string // プロジェクトを作成するフォルダ・組織（folders/123 や organizations/456）
+	billingAccount string // プロジェクトに紐付ける請求先アカウント（billingAccounts/XXXX、省略可）
+	defaultRegion  string
+	token          *cachedToken
+	httpClient     *http.Client
+}
+
+// newGCPDriverFromEnv は環境変数からGCPドライバーを作成（作成先が未設定の場合はfalse）
+// アクセストークンはGCP_ACCESS_TOKENが設定されていればそれを使い、未設定の場合はメタデータサーバーから取得する
+func newGCPDriverFromEnv() (interfaces.ProviderDriver, bool) {
+	parent := os.Getenv("GCP_PROJECT_PARENT")
+	if parent == "" {
+		return nil, false
+	}
+
+	defaultRegion := os.Getenv("GCP_DEFAULT_REGION")
+	if defaultRegion == "" {
+		defaultRegion = registryDefaultRegion(model.CSPProviderGCP)
+	}
+
+	d := &gcpDriver{
+		parent:         parent,
+		billingAccount: os.Getenv("GCP_BILLING_ACCOUNT"),
+		defaultRegion:  defaultRegion,
+		httpClient:     &http.Client{Timeout: 20 * time.Second},
+	}
+
+	if staticToken := os.Getenv("GCP_ACCESS_TOKEN"); staticToken != "" {
+		d.token = &cachedToken{fetch: func() (string, time.Duration, error) {
+			return staticToken, time.Hour, nil
+		}}
+	} else {
+		d.token = &cachedToken{fetch: d.fetchMetadataToken}
+	}
+	return d, true
+}
+
+// fetchMetadataToken はメタデータサーバーからデフォルトのサービスアカウントのアクセストークンを取得
+func (d *gcpDriver) fetchMetadataToken() (string, time.Duration, error) {
+	req, err := http.NewRequest(http.MethodGet, gcpMetadataTokenURL, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	var resp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := doJSON(d.httpClient, req, &resp); err != nil {
+		return "", 0, err
+	}
+	return resp.AccessToken, time.Duration(resp.ExpiresIn) * time.Second, nil
+}
+
+func (d *gcpDriver) Provider() model.CSPProvider {
+	return model.CSPProviderGCP
+}
+
+func (d *gcpDriver) DefaultRegion() string {
+	return d.defaultRegion
+}
+
+// CreateAccount はプロジェクトを作成する
+// 作成は非同期のため、状態は作成中として返す（プロジェクトIDは作成前に確定する）
+func (d *gcpDriver) CreateAccount(input *model.ProviderAccountInput) (*model.ProviderAccount, error) {
+	// プロジェクトIDは6〜30文字のため、ランダムな接尾辞の分を空けておく
+	projectID := fmt.Sprintf("%s-%s", accountSlug(input.AccountName, 23), randomToken(3))
+
+	attributes := model.CSPAccountAttributes{}
+	parent := d.parent
+	if folderID := input.Attributes["folder_id"]; folderID != "" {
+		parent = "folders/" + folderID
+		attributes["folder_id"] = folderID
+	}
+
+	// 請求先アカウントはプロジェクトが有効になってから紐付けるため、ラベルに記録しておく
+	labels := map[string]string{"managed-by": "cgas"}
+	if input.ProjectID != 0 {
+		labels["cgas-project-id"] = fmt.Sprintf("%d", input.ProjectID)
+	}
+	billingAccountID := input.Attributes["billing_account_id"]
+	if billingAccountID == "" {
+		billingAccountID = strings.TrimPrefix(d.billingAccount, "billingAccounts/")
+	}
+	if billingAccountID != "" {
+		labels[gcpBillingAccountLabel] = strings.ToLower(billingAccountID)
+		attributes["billing_account_id"] = billingAccountID
+	}
+
+	if err := d.call(http.MethodPost, gcpResourceManagerEndpoint+"/projects", map[string]interface{}{
+		"projectId":   projectID,
+		"displayName": truncate(input.AccountName, 30),
+		"parent":      parent,
+		"labels":      labels,
+	}, nil); err != nil {
+		return nil, err
+	}
+
+	region := input.Region
+	if region == "" {
+		region = d.defaultRegion
+	}
+	return &model.ProviderAccount{AccountID: projectID, Region: region, Status: model.ProviderAccountStatusProvisioning, Attributes: attributes}, nil
+}
+
+// GetAccount はプロジェクトの状態を取得し、有効になったプロジェクトには請求先アカウントを紐付ける
+func (d *gcpDriver) GetAccount(accountID string) (*model.ProviderAccount, error) {
+	var resp struct {
+		ProjectID string            `json:"projectId"`
+		State     string            `json:"state"`
+		Labels    map[string]string `json:"labels"`
+	}
+	if err := d.call(http.MethodGet, gcpResourceManagerEndpoint+"/projects/"+accountID, nil, &resp); err != nil {
+		if hasStatus(err, http.StatusNotFound) || hasStatus(err, http.StatusForbidden) {
+			return nil, model.ErrProviderAccountNotFound
+		}
+		return nil, err
+	}
+
+	switch resp.State {
+	case "ACTIVE":
+		if err := d.linkBillingAccount(accountID, strings.ToUpper(resp.Labels[gcpBillingAccountLabel])); err != nil {
+			return nil, err
+		}
+		return &model.ProviderAccount{AccountID: resp.ProjectID, Status: model.ProviderAccountStatusActive}, nil
+	case "DELETE_REQUESTED":
+		return &model.ProviderAccount{AccountID: resp.ProjectID, Status: model.ProviderAccountStatusClosed}, nil
+	default:
+		return &model.ProviderAccount{AccountID: resp.ProjectID, Status: model.ProviderAccountStatusProvisioning}, nil
+	}
+}
+
+// linkBillingAccount はプロジェクトに請求先アカウントを紐付ける（作成時に指定されている場合のみ）
+func (d *gcpDriver) linkBillingAccount(projectID, billingAccountID string) error {
+	if billingAccountID == "" {
+		return nil
+	}
+	return d.call(http.MethodPut, gcpBillingEndpoint+"/projects/"+projectID+"/billingInfo", map[string]interface{}{
+		"billingAccountName": "billingAccounts/" + billingAccountID,
+	}, nil)
+}
+
+func (d *gcpDriver) CloseAccount(accountID string) error {
+	err := d.call(http.MethodDelete, gcpResourceManagerEndpoint+"/projects/"+accountID, nil, nil)
+	if hasStatus(err, http.StatusNotFound) {
+		return model.ErrProviderAccountNotFound
+	}
+	return err
+}
+
+// GetCurrentMonthCost はプロジェクト単位の費用を返すAPIがないため取得できない
+// （費用の確認には請求データのBigQueryエクスポートが必要）
+func (d *gcpDriver) GetCurrentMonthCost(accountID string) (*model.ProviderCost, error) {
+	return nil, model.ErrProviderCostNotAvailable
+}
+
+// RotateCredentials は認証情報発行用のサービスアカウントに新しい鍵を発行し、使用中以外の古い鍵を削除する
+// アクセスキーには鍵ID、シークレットキーには鍵ファイル（base64エンコードしたJSON）を返す
+func (d *gcpDriver) RotateCredentials(accountID, keepKeyID string) (*model.ProviderCredentials, error) {
+	serviceAccount := d.accessServiceAccountURL(accountID)
+
+	// サービスアカウントがなければ作成する
+	err := d.call(http.MethodPost, fmt.Sprintf("%s/projects/%s/serviceAccounts", gcpIAMEndpoint, accountID), map[string]interface{}{
+		"accountId":      gcpAccessServiceAccount,
+		"serviceAccount": map[string]string{"displayName": "CGAS access"},
+	}, nil)
+	if err != nil && !hasStatus(err, http.StatusConflict) {
+		return nil, err
+	}
+
+	var listResp struct {
+		Keys []struct {
+			Name string `json:"name"`
+		} `json:"keys"`
+	}
+	if err := d.call(http.MethodGet, serviceAccount+"/keys?keyTypes=USER_MANAGED", nil, &listResp); err != nil {
+		return nil, err
+	}
+
+	var createResp struct {
+		Name           string `json:"name"`
+		PrivateKeyData string `json:"privateKeyData"`
+	}
+	if err := d.call(http.MethodPost, serviceAccount+"/keys", map[string]interface{}{}, &createResp); err != nil {
+		return nil, err
+	}
+
+	for _, key := range listResp.Keys {
+		if path.Base(key.Name) == keepKeyID {
+			continue
+		}
+		if err := d.call(http.MethodDelete, gcpIAMEndpoint+"/"+key.Name, nil, nil); err != nil && !hasStatus(err, http.StatusNotFound) {
+			return nil, err
+		}
+	}
+
+	keyID := path.Base(createResp.Name)
+	return &model.ProviderCredentials{AccessKey: keyID, SecretKey: createResp.PrivateKeyData, KeyID: keyID}, nil
+}
+
+// RevokeCredentials は認証情報発行用のサービスアカウントから鍵を削除する
+func (d *gcpDriver) RevokeCredentials(accountID, keyID string) error {
+	err := d.call(http.MethodDelete, d.accessServiceAccountURL(accountID)+"/keys/"+keyID, nil, nil)
+	if err != nil && !hasStatus(err, http.StatusNotFound) {
+		return err
+	}
+	return nil
+}
+
+// gcpMemberServiceAccounts はメンバーのロールごとに一時的なアクセストークンを発行するサービスアカウントとプロジェクトでのロール
+var gcpMemberServiceAccounts = map[model.CSPAccountMemberRole]struct {
+	accountID string
+	role      string
+}{
+	model.CSPAccountMemberRoleAdmin: {accountID: "cgas-member-admin", role: "roles/editor"},
+	model.CSPAccountMemberRoleUser:  {accountID: "cgas-member-viewer", role: "roles/viewer"},
+}
+
+// IssueTemporaryCredentials はメンバーのロールに対応するサービスアカウントの短期アクセストークンを発行する
+// サービスアカウントがなければ作成し、プロジェクトのロール（編集者・閲覧者）を付与する
+func (d *gcpDriver) IssueTemporaryCredentials(accountID string, input *model.TemporaryCredentialInput) (*model.TemporaryCredentials, error) {
+	member, ok := gcpMemberServiceAccounts[input.Role]
+	if !ok {
+		member = gcpMemberServiceAccounts[model.CSPAccountMemberRoleUser]
+	}
+	email, err := d.ensureMemberServiceAccount(accountID, member.accountID, member.role)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		AccessToken string    `json:"accessToken"`
+		ExpireTime  time.Time `json:"expireTime"`
+	}
+	if err := d.call(http.MethodPost, fmt.Sprintf("%s/projects/-/serviceAccounts/%s:generateAccessToken", gcpIAMCredentialsEndpoint, email), map[string]interface{}{
+		"scope":    []string{gcpCloudPlatformScope},
+		"lifetime": fmt.Sprintf("%ds", int(input.Duration.Seconds())),
+	}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &model.TemporaryCredentials{SessionToken: resp.AccessToken, ExpiresAt: resp.ExpireTime}, nil
+}
+
+// ensureMemberServiceAccount はサービスアカウントを作成（既にあればそのまま）し、プロジェクトのロールを付与してメールアドレスを返す
+func (d *gcpDriver) ensureMemberServiceAccount(projectID, serviceAccountID, role string) (string, error) {
+	email := fmt.Sprintf("%s@%s.iam.gserviceaccount.com", serviceAccountID, projectID)
+	err := d.call(http.MethodPost, fmt.Sprintf("%s/projects/%s/serviceAccounts", gcpIAMEndpoint, projectID), map[string]interface{}{
+		"accountId":      serviceAccountID,
+		"serviceAccount": map[string]string{"displayName": "CGAS member (" + role + ")"},
+	}, nil)
+	if err != nil && !hasStatus(err, http.StatusConflict) {
+		return "", err
+	}
+
+	policy, err := d.getIamPolicy(projectID)
+	if err != nil {
+		return "", err
+	}
+	if !policy.addMember(role, "serviceAccount:"+email) {
+		return email, nil
+	}
+	if err := d.setIamPolicy(projectID, policy); err != nil {
+		return "", err
+	}
+	return email, nil
+}
+
+// gcpIAMPolicy はプロジェクトのIAMポリシー
+type gcpIAMPolicy struct {
+	Version  int                   `json:"version,omitempty"`
+	Etag     string                `json:"etag"`
+	Bindings []gcpIAMPolicyBinding `json:"bindings"`
+}
+
+type gcpIAMPolicyBinding struct {
+	Role    string   `json:"role"`
+	Members []string `json:"members"`
+}
+
+// addMember はロールにメンバーを追加する（既に付与されている場合はfalse）
+func (p *gcpIAMPolicy) addMember(role, member string) bool {
+	for i := range p.Bindings {
+		if p.Bindings[i].Role != role {
+			continue
+		}
+		for _, m := range p.Bindings[i].Members {
+			if m == member {
+				return false
+			}
+		}
+		p.Bindings[i].Members = append(p.Bindings[i].Members, member)
+		return true
+	}
+	p.Bindings = append(p.Bindings, gcpIAMPolicyBinding{Role: role, Members: []string{member}})
+	return true
+}
+
+// removeMember はロールからメンバーを外す（付与されていなかった場合はfalse）
+func (p *gcpIAMPolicy) removeMember(role, member string) bool {
+	for i := range p.Bindings {
+		if p.Bindings[i].Role != role {
+			continue
+		}
+		for j, m := range p.Bindings[i].Members {
+			if m == member {
+				p.Bindings[i].Members = append(p.Bindings[i].Members[:j], p.Bindings[i].Members[j+1:]...)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (d *gcpDriver) getIamPolicy(projectID string) (*gcpIAMPolicy, error) {
+	var policy gcpIAMPolicy
+	err := d.call(http.MethodPost, gcpResourceManagerEndpoint+"/projects/"+projectID+":getIamPolicy", map[string]interface{}{}, &policy)
+	if hasStatus(err, http.StatusNotFound) || hasStatus(err, http.StatusForbidden) {
+		return nil, model.ErrProviderAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (d *gcpDriver) setIamPolicy(projectID string, policy *gcpIAMPolicy) error {
+	return d.call(http.MethodPost, gcpResourceManagerEndpoint+"/projects/"+projectID+":setIamPolicy", map[string]interface{}{"policy": policy}, nil)
+}
+
+// gcpMemberRole はメンバーのロールに対応するプロジェクトのロール（一時的なアクセストークンと同じロールを使う）
+func gcpMemberRole(role model.CSPAccountMemberRole) string {
+	member, ok := gcpMemberServiceAccounts[role]
+	if !ok {
+		member = gcpMemberServiceAccounts[model.CSPAccountMemberRoleUser]
+	}
+	return member.role
+}
+
+// ListAccounts は作成先のフォルダ・組織にあるプロジェクトの一覧を返す
+func (d *gcpDriver) ListAccounts() ([]model.ProviderAccount, error) {
+	var accounts []model.ProviderAccount
+	pageToken := ""
+	for {
+		query := url.Values{"parent": {d.parent}}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+
+		var resp struct {
+			Projects []struct {
+				ProjectID   string `json:"projectId"`
+				DisplayName string `json:"displayName"`
+				State       string `json:"state"`
+			} `json:"projects"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := d.call(http.MethodGet, gcpResourceManagerEndpoint+"/projects?"+query.Encode(), nil, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, project := range resp.Projects {
+			status := model.ProviderAccountStatusProvisioning
+			switch project.State {
+			case "ACTIVE":
+				status = model.ProviderAccountStatusActive
+			case "DELETE_REQUESTED":
+				status = model.ProviderAccountStatusClosed
+			}
+			accounts = append(accounts, model.ProviderAccount{AccountID: project.ProjectID, AccountName: project.DisplayName, Status: status})
+		}
+
+		if resp.NextPageToken == "" {
+			return accounts, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// ListAccountMembers はプロジェクトのIAMポリシーで編集者・閲覧者のロールを付与されたユーザーを返す
+// 両方のロールを持つユーザーは管理者として扱う
+func (d *gcpDriver) ListAccountMembers(accountID string) ([]model.ProviderAccountMember, error) {
+	policy, err := d.getIamPolicy(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make(map[string]model.CSPAccountMemberRole)
+	var emails []string
+	for _, role := range []model.CSPAccountMemberRole{model.CSPAccountMemberRoleUser, model.CSPAccountMemberRoleAdmin} {
+		for _, binding := range policy.Bindings {
+			if binding.Role != gcpMemberRole(role) {
+				continue
+			}
+			for _, m := range binding.Members {
+				if !strings.HasPrefix(m, "user:") {
+					continue
+				}
+				email := strings.ToLower(strings.TrimPrefix(m, "user:"))
+				if _, ok := roles[email]; !ok {
+					emails = append(emails, email)
+				}
+				roles[email] = role
+			}
+		}
+	}
+
+	members := make([]model.ProviderAccountMember, 0, len(emails))
+	for _, email := range emails {
+		members = append(members, model.ProviderAccountMember{Email: email, Role: roles[email]})
+	}
+	return members, nil
+}
+
+// GrantAccountMember はユーザーにロールを付与し、もう一方のロールは外す
+func (d *gcpDriver) GrantAccountMember(accountID string, member *model.ProviderAccountMember) error {
+	policy, err := d.getIamPolicy(accountID)
+	if err != nil {
+		return err
+	}
+
+	principal := "user:" + strings.ToLower(member.Email)
+	changed := policy.addMember(gcpMemberRole(member.Role), principal)
+	for role := range gcpMemberServiceAccounts {
+		if role != member.Role && policy.removeMember(gcpMemberRole(role), principal) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return d.setIamPolicy(accountID, policy)
+}
+
+// RevokeAccountMember はユーザーから編集者・閲覧者のロールを外す
+func (d *gcpDriver) RevokeAccountMember(accountID, email string) error {
+	policy, err := d.getIamPolicy(accountID)
+	if err != nil {
+		return err
+	}
+
+	principal := "user:" + strings.ToLower(email)
+	changed := false
+	for role := range gcpMemberServiceAccounts {
+		if policy.removeMember(gcpMemberRole(role), principal) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return d.setIamPolicy(accountID, policy)
+}
+
+// accessServiceAccountURL は認証情報発行用のサービスアカウントのURL
+func (d *gcpDriver) accessServiceAccountURL(projectID string) string {
+	return fmt.Sprintf("%s/projects/%s/serviceAccounts/%s@%s.iam.gserviceaccount.com",
+		gcpIAMEndpoint, projectID, gcpAccessServiceAccount, projectID)
+}
+
+// call はアクセストークンを付けてGoogle Cloud APIを呼び出す
+func (d *gcpDriver) call(method, url string, body interface{}, out interface{}) error {
+	token, err := d.token.get()
+	if err != nil {
+		return err
+	}
+
+	req, err := newJSONRequest(method, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return doJSON(d.httpClient, req, out)
+}
+
+// truncate は文字列を最大文字数で切り詰める
+func truncate(value string, maxLen int) string {
+	runes := []rune(value)
+	if len(runes) > maxLen {
+		return string(runes[:maxLen])
+	}
+	return value
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// apiError はプロバイダーのAPIがエラーを返した場合のエラー
+type apiError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("provider API returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// hasStatus はエラーが指定したHTTPステータスのAPIエラーかどうかを判定
+func hasStatus(err error, statusCode int) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// newJSONRequest はJSONボディのリクエストを作成（bodyがnilの場合はボディなし）
+func newJSONRequest(method, url string, body interface{}) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// doJSON はリクエストを送信し、成功時はレスポンスのJSONをoutにデコードする
+func doJSON(client *http.Client, req *http.Request, out interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach provider API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	payload, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read provider API response: %w", err)
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return &apiError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(payload))}
+	}
+
+	if out != nil && len(payload) > 0 {
+		if err := json.Unmarshal(payload, out); err != nil {
+			return fmt.Errorf("failed to decode provider API response: %w", err)
+		}
+	}
+	return nil
+}
+
+// cachedToken は有効期限までアクセストークンを使い回す
+type cachedToken struct {
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+	fetch     func() (string, time.Duration, error)
+}
+
+func (t *cachedToken) get() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// 期限切れ直前のトークンは使わない
+	if t.token != "" && time.Now().Add(time.Minute).Before(t.expiresAt) {
+		return t.token, nil
+	}
+
+	token, expiresIn, err := t.fetch()
+	if err != nil {
+		return "", fmt.Errorf("failed to obtain provider access token: %w", err)
+	}
+	t.token = token
+	t.expiresAt = time.Now().Add(expiresIn)
+	return token, nil
+}
+
+// randomToken はnバイトのランダムな値を16進文字列で返す
+func randomToken(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// accountSlug はアカウント名をプロバイダーのID・名前に使える形式（英小文字・数字・ハイフン）に変換
+func accountSlug(name string, maxLen int) string {
+	var b strings.Builder
+	lastHyphen := true
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			lastHyphen = false
+		case !lastHyphen:
+			b.WriteRune('-')
+			lastHyphen = true
+		}
+	}
+
+	slug := strings.Trim(b.String(), "-")
+	if len(slug) > maxLen {
+		slug = strings.TrimRight(slug[:maxLen], "-")
+	}
+	if slug == "" || slug[0] < 'a' || slug[0] > 'z' {
+		slug = "cgas-" + slug
+		if len(slug) > maxLen {
+			slug = strings.TrimRight(slug[:maxLen], "-")
+		}
+	}
+	return slug
+}
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"fmt"
+	"time"
+
 	"go-nextjs-api/internal/interfaces"
 	"go-nextjs-api/internal/model"
 
@@ -77,22 +80,140 @@ func (r *cspRepository) UpdateCSPAccount(account *model.CSPAccount) error {
 	return r.db.Save(account).Error
 }
 
+// DeleteCSPAccount はCSPアカウントを削除（プロジェクトとの紐付け・メンバーを削除し、ベンダーへの委任から外す）
 func (r *cspRepository) DeleteCSPAccount(id uint) error {
-	return r.db.Delete(&model.CSPAccount{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("csp_account_id = ?", id).Delete(&model.CSPAccountMember{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("csp_account_id = ?", id).Delete(&model.ProjectCSPAccount{}).Error; err != nil {
+			return err
+		}
+
+		granted := fmt.Sprintf("[%d]", id)
+		var relations []model.ProjectVendorRelation
+		if err := tx.Where("granted_csp_account_ids @> CAST(? AS jsonb)", granted).Find(&relations).Error; err != nil {
+			return err
+		}
+		for i := range relations {
+			relations[i].GrantedCSPAccountIDs = removeCSPAccountID(relations[i].GrantedCSPAccountIDs, id)
+			if err := tx.Model(&relations[i]).Select("granted_csp_account_ids").Updates(&relations[i]).Error; err != nil {
+				return err
+			}
+		}
+		var assignments []model.VendorStaffAssignment
+		if err := tx.Where("csp_account_ids @> CAST(? AS jsonb)", granted).Find(&assignments).Error; err != nil {
+			return err
+		}
+		for i := range assignments {
+			assignments[i].CSPAccountIDs = removeCSPAccountID(assignments[i].CSPAccountIDs, id)
+			if err := tx.Model(&assignments[i]).Select("csp_account_ids").Updates(&assignments[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Delete(&model.CSPAccount{}, id).Error
+	})
+}
+
+// removeCSPAccountID はCSPアカウントIDの一覧から指定したIDを除く
+func removeCSPAccountID(ids []uint, id uint) []uint {
+	remaining := make([]uint, 0, len(ids))
+	for _, existing := range ids {
+		if existing != id {
+			remaining = append(remaining, existing)
+		}
+	}
+	return remaining
+}
+
+// SelectCSPAccountsForCredentialRotation はローテーションポリシーが設定されているか、猶予期間中の旧鍵があるCSPアカウントを取得
+func (r *cspRepository) SelectCSPAccountsForCredentialRotation() ([]model.CSPAccount, error) {
+	var accounts []model.CSPAccount
+	err := r.db.Preload("CreatedByUser").Preload("ProjectCSPAccounts").
+		Where("credential_max_age_days > 0 OR previous_credential_key_id <> ''").
+		Order("id").Find(&accounts).Error
+	return accounts, err
+}
+
+// SelectCSPAccountsByStatus は指定したステータスのCSPアカウントを取得
+func (r *cspRepository) SelectCSPAccountsByStatus(status model.CSPAccountStatus) ([]model.CSPAccount, error) {
+	var accounts []model.CSPAccount
+	err := r.db.Preload("CreatedByUser").Preload("ProjectCSPAccounts").
+		Where("status = ?", status).
+		Order("id").Find(&accounts).Error
+	return accounts, err
+}
+
+// CSPAccountLifecycleEvent related methods
+
+func (r *cspRepository) UpdateCSPAccountStatus(account *model.CSPAccount, event *model.CSPAccountLifecycleEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(account).Error; err != nil {
+			return err
+		}
+		return tx.Create(event).Error
+	})
+}
+
+// SelectLifecycleEventsByCSPAccountID はCSPアカウントのステータス遷移の履歴を新しい順に取得
+func (r *cspRepository) SelectLifecycleEventsByCSPAccountID(cspAccountID uint) ([]model.CSPAccountLifecycleEvent, error) {
+	var events []model.CSPAccountLifecycleEvent
+	err := r.db.Preload("ChangedByUser").
+		Where("csp_account_id = ?", cspAccountID).
+		Order("created_at DESC, id DESC").Find(&events).Error
+	return events, err
+}
+
+// CSPAccountCredentialRotation related methods
+
+// SelectCredentialRotationsByCSPAccountID はCSPアカウントのローテーション履歴を新しい順に取得
+func (r *cspRepository) SelectCredentialRotationsByCSPAccountID(cspAccountID uint) ([]model.CSPAccountCredentialRotation, error) {
+	var rotations []model.CSPAccountCredentialRotation
+	err := r.db.Preload("RotatedByUser").
+		Where("csp_account_id = ?", cspAccountID).
+		Order("created_at DESC, id DESC").Find(&rotations).Error
+	return rotations, err
+}
+
+func (r *cspRepository) InsertCredentialRotation(rotation *model.CSPAccountCredentialRotation) error {
+	return r.db.Create(rotation).Error
+}
+
+// MarkPreviousCredentialRevoked は旧鍵を置き換えたローテーション履歴に失効日時を記録
+func (r *cspRepository) MarkPreviousCredentialRevoked(cspAccountID uint, keyID string, revokedAt time.Time) error {
+	return r.db.Model(&model.CSPAccountCredentialRotation{}).
+		Where("csp_account_id = ? AND previous_key_id = ? AND previous_key_revoked_at IS NULL", cspAccountID, keyID).
+		Update("previous_key_revoked_at", revokedAt).Error
+}
+
+// CSPCredentialIssuance related methods
+
+// SelectCredentialIssuancesByCSPAccountID はCSPアカウントの一時的な認証情報の払い出し記録を新しい順に取得
+func (r *cspRepository) SelectCredentialIssuancesByCSPAccountID(cspAccountID uint) ([]model.CSPCredentialIssuance, error) {
+	var issuances []model.CSPCredentialIssuance
+	err := r.db.Preload("User").
+		Where("csp_account_id = ?", cspAccountID).
+		Order("created_at DESC, id DESC").Find(&issuances).Error
+	return issuances, err
+}
+
+func (r *cspRepository) InsertCredentialIssuance(issuance *model.CSPCredentialIssuance) error {
+	return r.db.Create(issuance).Error
 }
 
 // ProjectCSPAccount related methods
 
 func (r *cspRepository) SelectProjectCSPAccountAll() ([]model.ProjectCSPAccount, error) {
 	var relations []model.ProjectCSPAccount
-	err := r.db.Preload("Project").Preload("CSPAccount").Preload("CreatedByUser").
+	err := r.db.Preload("Project").Preload("CSPAccount").Preload("Environment").Preload("CreatedByUser").
 		Find(&relations).Error
 	return relations, err
 }
 
 func (r *cspRepository) SelectProjectCSPAccountByID(id uint) (*model.ProjectCSPAccount, error) {
 	var relation model.ProjectCSPAccount
-	err := r.db.Preload("Project").Preload("CSPAccount").Preload("CreatedByUser").
+	err := r.db.Preload("Project").Preload("CSPAccount").Preload("Environment").Preload("CreatedByUser").
 		First(&relation, id).Error
 	if err != nil {
 		return nil, err
@@ -102,21 +223,31 @@ func (r *cspRepository) SelectProjectCSPAccountByID(id uint) (*model.ProjectCSPA
 
 func (r *cspRepository) SelectProjectCSPAccountsByProjectID(projectID uint) ([]model.ProjectCSPAccount, error) {
 	var relations []model.ProjectCSPAccount
-	err := r.db.Preload("Project").Preload("CSPAccount").Preload("CreatedByUser").
+	err := r.db.Preload("Project").Preload("CSPAccount").Preload("Environment").Preload("CreatedByUser").
 		Where("project_id = ?", projectID).Order("created_at DESC").Find(&relations).Error
 	return relations, err
 }
 
 func (r *cspRepository) SelectProjectCSPAccountsByCSPAccountID(cspAccountID uint) ([]model.ProjectCSPAccount, error) {
 	var relations []model.ProjectCSPAccount
-	err := r.db.Preload("Project").Preload("CSPAccount").Preload("CreatedByUser").
+	err := r.db.Preload("Project").Preload("CSPAccount").Preload("Environment").Preload("CreatedByUser").
 		Where("csp_account_id = ?", cspAccountID).Order("created_at DESC").Find(&relations).Error
 	return relations, err
 }
 
+// SelectProjectCSPAccountsByProjectMetadata はプロジェクトのタグ・カスタム属性で絞り込んだ関連一覧を取得（レポート用）
+func (r *cspRepository) SelectProjectCSPAccountsByProjectMetadata(filter *model.ProjectMetadataFilter) ([]model.ProjectCSPAccount, error) {
+	var relations []model.ProjectCSPAccount
+	query := r.db.Preload("Project").Preload("CSPAccount").Preload("Environment").Preload("CreatedByUser").
+		Joins("JOIN projects p ON p.id = project_csp_accounts.project_id AND p.deleted_at IS NULL")
+	query = applyProjectMetadataFilter(query, "p.", filter)
+	err := query.Order("project_csp_accounts.project_id ASC, project_csp_accounts.created_at DESC").Find(&relations).Error
+	return relations, err
+}
+
 func (r *cspRepository) SelectProjectCSPAccountByProjectAndCSPAccount(projectID, cspAccountID uint) (*model.ProjectCSPAccount, error) {
 	var relation model.ProjectCSPAccount
-	err := r.db.Preload("Project").Preload("CSPAccount").Preload("CreatedByUser").
+	err := r.db.Preload("Project").Preload("CSPAccount").Preload("Environment").Preload("CreatedByUser").
 		Where("project_id = ? AND csp_account_id = ?", projectID, cspAccountID).First(&relation).Error
 	if err != nil {
 		return nil, err
@@ -210,10 +341,77 @@ func (r *cspRepository) UpdateCSPAccountMember(member *model.CSPAccountMember) e
 }
 
 func (r *cspRepository) DeleteCSPAccountMember(id uint) error {
-	return r.db.Delete(&model.CSPAccountMember{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return deleteCSPAccountMembers(tx, "id = ?", id)
+	})
+}
+
+func (r *cspRepository) UpdateCSPAccountMemberSyncStatus(ids []uint, status model.CSPAccountMemberSyncStatus, syncError string, attemptedAt time.Time) error {
+	updates := map[string]interface{}{
+		"sync_status":       status,
+		"sync_error":        syncError,
+		"sync_attempted_at": attemptedAt,
+	}
+	if status == model.CSPAccountMemberSyncStatusSynced {
+		updates["synced_at"] = attemptedAt
+	}
+	return r.db.Model(&model.CSPAccountMember{}).Where("id IN ?", ids).UpdateColumns(updates).Error
 }
 
 func (r *cspRepository) DeleteCSPAccountMemberByCSPAccountAndUser(cspAccountID, userID uint) error {
-	return r.db.Where("csp_account_id = ? AND user_id = ?", cspAccountID, userID).
-		Delete(&model.CSPAccountMember{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return deleteCSPAccountMembers(tx, "csp_account_id = ? AND user_id = ?", cspAccountID, userID)
+	})
+}
+
+func (r *cspRepository) SelectCSPMemberRevocationsByCSPAccountID(cspAccountID uint) ([]model.CSPMemberRevocation, error) {
+	var revocations []model.CSPMemberRevocation
+	err := r.db.Where("csp_account_id = ?", cspAccountID).Order("id ASC").Find(&revocations).Error
+	return revocations, err
+}
+
+func (r *cspRepository) InsertCSPMemberRevocation(cspAccountID uint, email string) error {
+	return insertCSPMemberRevocation(r.db, cspAccountID, email)
+}
+
+func (r *cspRepository) UpdateCSPMemberRevocationFailure(cspAccountID uint, email, lastError string, attemptedAt time.Time) error {
+	var revocation model.CSPMemberRevocation
+	return r.db.Where(model.CSPMemberRevocation{CSPAccountID: cspAccountID, Email: email}).
+		Assign(map[string]interface{}{"last_error": lastError, "attempted_at": attemptedAt}).
+		FirstOrCreate(&revocation).Error
+}
+
+func (r *cspRepository) DeleteCSPMemberRevocation(cspAccountID uint, email string) error {
+	return r.db.Where("csp_account_id = ? AND email = ?", cspAccountID, email).
+		Delete(&model.CSPMemberRevocation{}).Error
+}
+
+// deleteCSPAccountMembers は条件に合うCSPアカウントメンバーを削除し、メールアドレスをプロバイダー側のロールを外す対象として記録
+// 削除と同じトランザクションで記録するため、反映に失敗しても定期実行で再試行される
+func deleteCSPAccountMembers(tx *gorm.DB, query interface{}, args ...interface{}) error {
+	var members []model.CSPAccountMember
+	if err := tx.Preload("User").Where(query, args...).Find(&members).Error; err != nil {
+		return err
+	}
+	if len(members) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.ID)
+		if err := insertCSPMemberRevocation(tx, member.CSPAccountID, member.ProviderEmail()); err != nil {
+			return err
+		}
+	}
+	return tx.Delete(&model.CSPAccountMember{}, ids).Error
+}
+
+// insertCSPMemberRevocation はプロバイダー側のロールを外す対象を記録（記録済みの場合はそのまま）
+func insertCSPMemberRevocation(tx *gorm.DB, cspAccountID uint, email string) error {
+	if email == "" {
+		return nil
+	}
+	revocation := model.CSPMemberRevocation{CSPAccountID: cspAccountID, Email: email}
+	return tx.Where(&revocation).FirstOrCreate(&revocation).Error
 }
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type customAttributeRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomAttributeRepository(db *gorm.DB) interfaces.CustomAttributeRepository {
+	return &customAttributeRepository{db: db}
+}
+
+// SelectDefinitionsByOrganizationID は組織のカスタム属性定義一覧を取得
+func (r *customAttributeRepository) SelectDefinitionsByOrganizationID(organizationID uint) ([]model.CustomAttributeDefinition, error) {
+	var definitions []model.CustomAttributeDefinition
+	err := r.db.Where("organization_id = ?", organizationID).
+		Order("key ASC").
+		Find(&definitions).Error
+	return definitions, err
+}
+
+// SelectDefinitionByID はカスタム属性定義を取得
+func (r *customAttributeRepository) SelectDefinitionByID(id uint) (*model.CustomAttributeDefinition, error) {
+	var definition model.CustomAttributeDefinition
+	if err := r.db.First(&definition, id).Error; err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+// SelectDefinitionByKey は組織内のキーでカスタム属性定義を取得
+func (r *customAttributeRepository) SelectDefinitionByKey(organizationID uint, key string) (*model.CustomAttributeDefinition, error) {
+	var definition model.CustomAttributeDefinition
+	if err := r.db.Where("organization_id = ? AND key = ?", organizationID, key).First(&definition).Error; err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+// InsertDefinition はカスタム属性定義を作成
+func (r *customAttributeRepository) InsertDefinition(definition *model.CustomAttributeDefinition) error {
+	return r.db.Create(definition).Error
+}
+
+// UpdateDefinition はカスタム属性定義を更新
+func (r *customAttributeRepository) UpdateDefinition(definition *model.CustomAttributeDefinition) error {
+	return r.db.Save(definition).Error
+}
+
+// DeleteDefinition はカスタム属性定義を削除（ソフトデリート）
+// 同じトランザクションで組織のプロジェクト（ゴミ箱内を含む）から属性値を取り除く
+func (r *customAttributeRepository) DeleteDefinition(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var definition model.CustomAttributeDefinition
+		if err := tx.First(&definition, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&definition).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Model(&model.Project{}).
+			Where("organization_id = ? AND jsonb_exists(custom_attributes, ?)", definition.OrganizationID, definition.Key).
+			UpdateColumn("custom_attributes", gorm.Expr("custom_attributes - ?", definition.Key)).Error
+	})
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"fmt"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type deletionRepository struct {
+	db *gorm.DB
+}
+
+func NewDeletionRepository(db *gorm.DB) interfaces.DeletionRepository {
+	return &deletionRepository{db: db}
+}
+
+// openVendorRelationStatuses は継続中のベンダー紐付けステータス
+var openVendorRelationStatuses = []model.VendorRelationStatus{
+	model.VendorRelationStatusProposed,
+	model.VendorRelationStatusAccepted,
+	model.VendorRelationStatusActive,
+}
+
+// SelectProjectDependents はプロジェクトに依存するレコードを取得
+func (r *deletionRepository) SelectProjectDependents(projectID uint) (*model.ProjectDeletionDependents, error) {
+	dependents := &model.ProjectDeletionDependents{}
+
+	if err := r.db.Preload("User").Where("project_id = ?", projectID).
+		Order("id ASC").Find(&dependents.Members).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("project_id = ? AND status = ?", projectID, model.InvitationStatusPending).
+		Order("id ASC").Find(&dependents.Invitations).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Preload("Project").Preload("VendorProject").
+		Where("(project_id = ? OR vendor_project_id = ?) AND status IN ?", projectID, projectID, openVendorRelationStatuses).
+		Order("id ASC").Find(&dependents.VendorRelations).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Preload("User").
+		Where("relation_id IN (?)", r.db.Model(&model.ProjectVendorRelation{}).Select("id").
+			Where("project_id = ? OR vendor_project_id = ?", projectID, projectID)).
+		Order("id ASC").Find(&dependents.StaffAssignments).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Preload("CSPAccount").Where("project_id = ?", projectID).
+		Order("id ASC").Find(&dependents.ProjectCSPAccounts).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Preload("User").Preload("CSPAccount").Where("project_id = ?", projectID).
+		Order("id ASC").Find(&dependents.CSPAccountMembers).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("project_id = ?", projectID).
+		Order("id ASC").Find(&dependents.Environments).Error; err != nil {
+		return nil, err
+	}
+
+	return dependents, nil
+}
+
+// SelectUserDependents はユーザーに依存するレコードを取得
+func (r *deletionRepository) SelectUserDependents(userID uint) (*model.UserDeletionDependents, error) {
+	dependents := &model.UserDeletionDependents{}
+
+	if err := r.db.Preload("Project").Where("user_id = ?", userID).
+		Order("id ASC").Find(&dependents.Memberships).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Preload("CSPAccount").Where("user_id = ?", userID).
+		Order("id ASC").Find(&dependents.CSPAccountMembers).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Preload("Relation").Where("user_id = ?", userID).
+		Order("id ASC").Find(&dependents.StaffAssignments).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Preload("Environment").Where("user_id = ?", userID).
+		Order("id ASC").Find(&dependents.EnvironmentMemberships).Error; err != nil {
+		return nil, err
+	}
+
+	return dependents, nil
+}
+
+// SelectCSPAccountDependents はCSPアカウントに依存するレコードを取得
+func (r *deletionRepository) SelectCSPAccountDependents(cspAccountID uint) (*model.CSPAccountDeletionDependents, error) {
+	dependents := &model.CSPAccountDeletionDependents{}
+	granted := fmt.Sprintf("[%d]", cspAccountID)
+
+	if err := r.db.Preload("Project").Where("csp_account_id = ?", cspAccountID).
+		Order("id ASC").Find(&dependents.ProjectCSPAccounts).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Preload("User").Where("csp_account_id = ?", cspAccountID).
+		Order("id ASC").Find(&dependents.CSPAccountMembers).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("granted_csp_account_ids @> CAST(? AS jsonb)", granted).
+		Order("id ASC").Find(&dependents.VendorRelations).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("csp_account_ids @> CAST(? AS jsonb)", granted).
+		Order("id ASC").Find(&dependents.StaffAssignments).Error; err != nil {
+		return nil, err
+	}
+
+	return dependents, nil
+}
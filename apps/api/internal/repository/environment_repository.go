@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type environmentRepository struct {
+	db *gorm.DB
+}
+
+func NewEnvironmentRepository(db *gorm.DB) interfaces.EnvironmentRepository {
+	return &environmentRepository{db: db}
+}
+
+// SelectByProjectID はプロジェクトの環境一覧を取得
+func (r *environmentRepository) SelectByProjectID(projectID uint) ([]model.ProjectEnvironment, error) {
+	var environments []model.ProjectEnvironment
+	err := r.db.Where("project_id = ?", projectID).Order("id ASC").Find(&environments).Error
+	return environments, err
+}
+
+// SelectByID は環境を取得
+func (r *environmentRepository) SelectByID(id uint) (*model.ProjectEnvironment, error) {
+	var environment model.ProjectEnvironment
+	if err := r.db.First(&environment, id).Error; err != nil {
+		return nil, err
+	}
+	return &environment, nil
+}
+
+// SelectByProjectAndName はプロジェクト内の名前で環境を取得
+func (r *environmentRepository) SelectByProjectAndName(projectID uint, name string) (*model.ProjectEnvironment, error) {
+	var environment model.ProjectEnvironment
+	if err := r.db.Where("project_id = ? AND name = ?", projectID, name).First(&environment).Error; err != nil {
+		return nil, err
+	}
+	return &environment, nil
+}
+
+// Insert は環境を作成
+func (r *environmentRepository) Insert(environment *model.ProjectEnvironment) error {
+	return r.db.Create(environment).Error
+}
+
+// Update は環境の設定を更新
+func (r *environmentRepository) Update(environment *model.ProjectEnvironment) error {
+	return r.db.Save(environment).Error
+}
+
+// Delete は環境と環境のメンバー権限を削除（ソフトデリート）
+func (r *environmentRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("environment_id = ?", id).Delete(&model.ProjectEnvironmentMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.ProjectEnvironment{}, id).Error
+	})
+}
+
+// CountCSPAccounts は環境に属するCSPアカウント数をカウント
+func (r *environmentRepository) CountCSPAccounts(environmentID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.ProjectCSPAccount{}).Where("environment_id = ?", environmentID).Count(&count).Error
+	return count, err
+}
+
+// SelectCSPAccounts は環境に属するCSPアカウントとの紐付け一覧を取得
+func (r *environmentRepository) SelectCSPAccounts(environmentID uint) ([]model.ProjectCSPAccount, error) {
+	var links []model.ProjectCSPAccount
+	err := r.db.Preload("CSPAccount").Where("environment_id = ?", environmentID).
+		Order("id ASC").Find(&links).Error
+	return links, err
+}
+
+// SelectMembers は環境のメンバー一覧を取得
+func (r *environmentRepository) SelectMembers(environmentID uint) ([]model.ProjectEnvironmentMember, error) {
+	var members []model.ProjectEnvironmentMember
+	err := r.db.Preload("User").Where("environment_id = ?", environmentID).
+		Order("id ASC").Find(&members).Error
+	return members, err
+}
+
+// SelectMember は環境のメンバーを取得
+func (r *environmentRepository) SelectMember(environmentID, userID uint) (*model.ProjectEnvironmentMember, error) {
+	var member model.ProjectEnvironmentMember
+	if err := r.db.Preload("User").Where("environment_id = ? AND user_id = ?", environmentID, userID).
+		First(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// InsertMember は環境のメンバーと、環境に属するCSPアカウントのメンバーを同一トランザクションで作成
+func (r *environmentRepository) InsertMember(member *model.ProjectEnvironmentMember, cspAccountMembers []model.CSPAccountMember) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(member).Error; err != nil {
+			return err
+		}
+		if len(cspAccountMembers) == 0 {
+			return nil
+		}
+		return tx.Create(&cspAccountMembers).Error
+	})
+}
+
+// DeleteMember は環境のメンバーと、環境に属するCSPアカウントのメンバーを削除（ソフトデリート）
+func (r *environmentRepository) DeleteMember(member *model.ProjectEnvironmentMember) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		accountIDs := tx.Session(&gorm.Session{NewDB: true}).Model(&model.ProjectCSPAccount{}).Select("csp_account_id").
+			Where("project_id = ? AND environment_id = ?", member.ProjectID, member.EnvironmentID)
+		if err := deleteCSPAccountMembers(tx, "project_id = ? AND user_id = ? AND csp_account_id IN (?)", member.ProjectID, member.UserID, accountIDs); err != nil {
+			return err
+		}
+		return tx.Delete(member).Error
+	})
+}
+
+// UpdateCSPAccountEnvironment はCSPアカウントの所属環境を変更し、新しい環境のメンバーをCSPアカウントのメンバーとして追加
+func (r *environmentRepository) UpdateCSPAccountEnvironment(link *model.ProjectCSPAccount, environmentID *uint, cspAccountMembers []model.CSPAccountMember) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.ProjectCSPAccount{}).Where("id = ?", link.ID).
+			Update("environment_id", environmentID).Error; err != nil {
+			return err
+		}
+		if len(cspAccountMembers) == 0 {
+			return nil
+		}
+		return tx.Create(&cspAccountMembers).Error
+	})
+}
@@ -1,6 +1,11 @@
 package repository
 
 import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
 	"go-nextjs-api/internal/interfaces"
 	"go-nextjs-api/internal/model"
 
@@ -15,32 +20,90 @@ func NewProjectRepository(db *gorm.DB) interfaces.ProjectRepository {
 	return &projectRepository{db: db}
 }
 
-// SelectUserProjects はユーザーが所属するプロジェクト一覧を取得
-func (r *projectRepository) SelectUserProjects(userID uint) ([]model.UserProjectResponse, error) {
+// SelectUserProjects はユーザーが所属するプロジェクト一覧を参加日時の新しい順に全件取得（タグ・カスタム属性で絞り込み可能）
+func (r *projectRepository) SelectUserProjects(userID uint, filter *model.ProjectMetadataFilter) ([]model.UserProjectResponse, error) {
 	var userProjects []model.UserProjectResponse
 
-	query := `
-		SELECT 
-			p.id as project_id,
-			p.name,
-			p.description,
-			p.status,
-			p.project_type,
-			upr.role,
-			upr.created_at as joined_at
-		FROM projects p
-		JOIN user_project_roles upr ON p.id = upr.project_id
-		WHERE upr.user_id = ? AND upr.deleted_at IS NULL AND p.deleted_at IS NULL
-		ORDER BY upr.created_at DESC
-	`
+	query := r.db.Table("projects p").
+		Select("p.id as project_id, p.name, p.description, p.status, p.project_type, p.tags, upr.role, upr.created_at as joined_at").
+		Joins("JOIN user_project_roles upr ON p.id = upr.project_id").
+		Where("upr.user_id = ? AND upr.deleted_at IS NULL AND p.deleted_at IS NULL", userID)
+	query = applyProjectMetadataFilter(query, "p.", filter)
 
-	if err := r.db.Raw(query, userID).Scan(&userProjects).Error; err != nil {
+	if err := query.Order("upr.created_at DESC").Scan(&userProjects).Error; err != nil {
 		return nil, err
 	}
 
 	return userProjects, nil
 }
 
+// SearchProjects は条件に一致するプロジェクトを検索（ページング・ソート対応）
+// includeAllがfalseの場合、ユーザーが所属するプロジェクトとベンダープロジェクトのみを対象とする
+func (r *projectRepository) SearchProjects(userID uint, includeAll bool, query *model.ProjectQuery) ([]model.ProjectListItem, *model.PaginationInfo, error) {
+	base := r.db.Table("projects p").
+		Joins("LEFT JOIN user_project_roles upr ON upr.project_id = p.id AND upr.user_id = ? AND upr.deleted_at IS NULL", userID).
+		Where("p.deleted_at IS NULL")
+
+	if !includeAll {
+		base = base.Where("(upr.id IS NOT NULL OR p.project_type = ?)", model.ProjectTypeVendor)
+	}
+	if query.MemberOnly {
+		base = base.Where("upr.id IS NOT NULL")
+	}
+	if query.Keyword != "" {
+		pattern := "%" + escapeLikePattern(query.Keyword) + "%"
+		base = base.Where("(p.name ILIKE ? OR p.description ILIKE ?)", pattern, pattern)
+	}
+	if query.OrganizationID != 0 {
+		base = base.Where("p.organization_id = ?", query.OrganizationID)
+	}
+	if len(query.Statuses) > 0 {
+		base = base.Where("p.status IN ?", query.Statuses)
+	}
+	if len(query.ProjectTypes) > 0 {
+		base = base.Where("p.project_type IN ?", query.ProjectTypes)
+	}
+	if query.Role != "" {
+		base = base.Where("upr.role = ?", query.Role)
+	}
+	if query.CSPProvider != "" {
+		base = base.Where(`EXISTS (
+			SELECT 1 FROM project_csp_accounts pca
+			JOIN csp_accounts ca ON ca.id = pca.csp_account_id
+			WHERE pca.project_id = p.id AND pca.deleted_at IS NULL AND ca.deleted_at IS NULL AND ca.provider = ?
+		)`, query.CSPProvider)
+	}
+	base = applyProjectMetadataFilter(base, "p.", query.Metadata)
+
+	// 総数を取得
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var items []model.ProjectListItem
+	if err := base.Session(&gorm.Session{}).
+		Select("p.id, p.name, p.description, p.status, p.organization_id, p.project_type, p.tags, upr.role as user_role, upr.created_at as joined_at, p.created_at, p.updated_at").
+		Order(query.OrderClause()).
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Scan(&items).Error; err != nil {
+		return nil, nil, err
+	}
+
+	totalPages := int((total + int64(query.Limit) - 1) / int64(query.Limit))
+	pagination := &model.PaginationInfo{
+		Page:       query.Page,
+		Limit:      query.Limit,
+		Total:      int(total),
+		TotalPages: totalPages,
+		HasNext:    query.Page < totalPages,
+		HasPrev:    query.Page > 1,
+	}
+
+	return items, pagination, nil
+}
+
 // SelectByID はプロジェクト詳細を取得（ユーザのロール情報付き）
 func (r *projectRepository) SelectByID(id uint) (*model.ProjectDetails, error) {
 	var project model.Project
@@ -55,6 +118,8 @@ func (r *projectRepository) SelectByID(id uint) (*model.ProjectDetails, error) {
 		Status:         project.Status,
 		OrganizationID: project.OrganizationID,
 		ProjectType:    project.ProjectType,
+		Tags:           project.Tags,
+		CustomAttributes: project.CustomAttributes,
 		CreatedAt:      project.CreatedAt,
 		UpdatedAt:      project.UpdatedAt,
 	}
@@ -67,14 +132,139 @@ func (r *projectRepository) Insert(project *model.Project) error {
 	return r.db.Create(project).Error
 }
 
-// Update はプロジェクトを更新
+// InsertWithSetup はプロジェクトと初期メンバー・ベンダー紐付けを1トランザクションで作成
+// （いずれかの作成に失敗した場合はプロジェクトごとロールバック）
+func (r *projectRepository) InsertWithSetup(project *model.Project, members []model.UserProjectRole, vendorRelations []model.ProjectVendorRelation) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(project).Error; err != nil {
+			return err
+		}
+		for i := range members {
+			members[i].ProjectID = project.ID
+			if err := tx.Create(&members[i]).Error; err != nil {
+				return err
+			}
+		}
+		for i := range vendorRelations {
+			vendorRelations[i].ProjectID = project.ID
+			if err := tx.Omit("Project", "VendorProject").Create(&vendorRelations[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Update はプロジェクトを更新（ステータス・削除情報はUpdateStatus/Deleteで更新する）
 func (r *projectRepository) Update(project *model.Project) error {
-	return r.db.Save(project).Error
+	return r.db.Model(project).
+		Select("name", "description", "status", "organization_id", "project_type", "tags", "custom_attributes").
+		Updates(project).Error
+}
+
+// UpdateStatus はプロジェクトのステータスを更新
+func (r *projectRepository) UpdateStatus(id uint, status model.ProjectStatus, changedBy uint) error {
+	return r.db.Model(&model.Project{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":            status,
+		"status_changed_at": time.Now(),
+		"status_changed_by": changedBy,
+	}).Error
+}
+
+// Delete はプロジェクトを削除（ソフトデリート、削除実行者を記録）
+// 削除ポリシーでcascadeとした依存レコードはプロジェクトと同じ削除日時でソフトデリートし、復元時に一緒に復元できるようにする
+func (r *projectRepository) Delete(id uint, deletedBy uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return deleteProjectWithDependents(tx, id, deletedBy, time.Now())
+	})
+}
+
+// deleteProjectWithDependents はトランザクション内でプロジェクトと依存レコードを同じ削除日時でソフトデリート
+func deleteProjectWithDependents(tx *gorm.DB, id uint, deletedBy uint, deletedAt time.Time) error {
+	if err := tx.Model(&model.Project{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"deleted_by": deletedBy,
+		"deleted_at": deletedAt,
+	}).Error; err != nil {
+		return err
+	}
+
+	for _, dependent := range projectDependentScopes(tx, id) {
+		if err := dependent.UpdateColumn("deleted_at", deletedAt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// projectDependentScopes はプロジェクトと一緒に削除・復元する依存レコードの条件
+// （ベンダー担当者の割り当ては紐付けより先に処理する）
+func projectDependentScopes(tx *gorm.DB, projectID uint) []*gorm.DB {
+	relationIDs := tx.Session(&gorm.Session{NewDB: true}).Unscoped().Model(&model.ProjectVendorRelation{}).Select("id").
+		Where("project_id = ? OR vendor_project_id = ?", projectID, projectID)
+	return []*gorm.DB{
+		tx.Model(&model.VendorStaffAssignment{}).Where("relation_id IN (?)", relationIDs),
+		tx.Model(&model.ProjectVendorRelation{}).Where("project_id = ? OR vendor_project_id = ?", projectID, projectID),
+		tx.Model(&model.UserProjectRole{}).Where("project_id = ?", projectID),
+		tx.Model(&model.ProjectInvitation{}).Where("project_id = ? AND status = ?", projectID, model.InvitationStatusPending),
+		tx.Model(&model.CSPAccountMember{}).Where("project_id = ?", projectID),
+		tx.Model(&model.ProjectCSPAccount{}).Where("project_id = ?", projectID),
+		tx.Model(&model.ProjectEnvironmentMember{}).Where("project_id = ?", projectID),
+		tx.Model(&model.ProjectEnvironment{}).Where("project_id = ?", projectID),
+	}
+}
+
+// CountActiveCSPAccounts はプロジェクトに紐づく閉鎖されていないCSPアカウント数をカウント
+// 一時停止中・閉鎖処理中のアカウントもプロバイダー側に残っているため含める
+func (r *projectRepository) CountActiveCSPAccounts(projectID uint) (int64, error) {
+	var count int64
+	err := r.db.Table("project_csp_accounts pca").
+		Joins("JOIN csp_accounts ca ON ca.id = pca.csp_account_id").
+		Where("pca.project_id = ? AND pca.deleted_at IS NULL AND ca.deleted_at IS NULL AND ca.status NOT IN ?", projectID,
+			[]model.CSPAccountStatus{model.CSPAccountStatusClosed, model.CSPAccountStatusFailed}).
+		Count(&count).Error
+	return count, err
+}
+
+// SelectDeleted は指定日時以降にソフトデリートされたプロジェクト一覧を取得
+func (r *projectRepository) SelectDeleted(deletedSince time.Time) ([]model.Project, error) {
+	var projects []model.Project
+	err := r.db.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at >= ?", deletedSince).
+		Order("deleted_at DESC").
+		Find(&projects).Error
+	return projects, err
+}
+
+// SelectDeletedByID はソフトデリート済みのプロジェクトを取得
+func (r *projectRepository) SelectDeletedByID(id uint) (*model.Project, error) {
+	var project model.Project
+	if err := r.db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&project).Error; err != nil {
+		return nil, err
+	}
+	return &project, nil
 }
 
-// Delete はプロジェクトを削除
-func (r *projectRepository) Delete(id uint) error {
-	return r.db.Delete(&model.Project{}, id).Error
+// Restore はソフトデリート済みのプロジェクトを復元（プロジェクトと一緒に削除された依存レコードも復元）
+func (r *projectRepository) Restore(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var project model.Project
+		if err := tx.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&project).Error; err != nil {
+			return err
+		}
+
+		for _, dependent := range projectDependentScopes(tx, id) {
+			if err := dependent.Unscoped().Where("deleted_at = ?", project.DeletedAt.Time).
+				UpdateColumn("deleted_at", nil).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Unscoped().Model(&model.Project{}).Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"deleted_at": nil,
+				"deleted_by": nil,
+			}).Error
+	})
 }
 
 // SelectProjectMembers はプロジェクトメンバー一覧を取得（ページング対応）
@@ -118,6 +308,18 @@ func (r *projectRepository) SelectProjectMembers(projectID uint, page, limit int
 	return members, pagination, nil
 }
 
+// SelectAllProjectMembers はプロジェクトの全メンバーを取得（一括出力用）
+func (r *projectRepository) SelectAllProjectMembers(projectID uint) ([]model.ProjectMemberResponse, error) {
+	var members []model.ProjectMemberResponse
+	err := r.db.Table("users u").
+		Select("u.id as user_id, u.name, u.email, upr.role, upr.created_at as joined_at").
+		Joins("JOIN user_project_roles upr ON u.id = upr.user_id").
+		Where("upr.project_id = ? AND upr.deleted_at IS NULL AND u.deleted_at IS NULL", projectID).
+		Order("upr.created_at ASC").
+		Scan(&members).Error
+	return members, err
+}
+
 // SelectUserRole はプロジェクト内でのユーザーのロールを取得
 func (r *projectRepository) SelectUserRole(projectID, userID uint) (string, error) {
 	var role string
@@ -160,6 +362,161 @@ func (r *projectRepository) DeleteMember(projectID, userID uint) error {
 		Delete(&model.UserProjectRole{}).Error
 }
 
+// DeleteMemberWithCascade はメンバーを削除し、プロジェクト内の関連リソースを整理（トランザクション）
+// - 削除対象ユーザーのCSPアカウントメンバーシップを無効化
+// - 削除対象ユーザーが作成したリソースを reassignTo に引き継ぐ（nilの場合はフラグとして返す）
+// - 削除対象ユーザーが送信した承諾待ちの招待は引き継ぐか取り消す
+func (r *projectRepository) DeleteMemberWithCascade(projectID, userID uint, reassignTo *uint) (*model.MemberRemovalSummary, error) {
+	summary := &model.MemberRemovalSummary{
+		ProjectID:                      projectID,
+		UserID:                         userID,
+		ReassignedTo:                   reassignTo,
+		DeactivatedCSPAccountMemberIDs: []uint{},
+		ReassignedProjectCSPAccountIDs: []uint{},
+		ReassignedCSPAccountMemberIDs:  []uint{},
+		ReassignedInvitationIDs:        []uint{},
+		RevokedInvitationIDs:           []uint{},
+		FlaggedResources:               []model.FlaggedResource{},
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return removeMemberWithCascade(tx, summary)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// removeMemberWithCascade はトランザクション内でメンバーを削除し、summaryの対象プロジェクト・ユーザーの関連リソースを整理
+// 結果はsummaryに記録する（状態同期の削除からも同じ整理を行うため共通化）
+func removeMemberWithCascade(tx *gorm.DB, summary *model.MemberRemovalSummary) error {
+	if err := tx.Where("project_id = ? AND user_id = ?", summary.ProjectID, summary.UserID).
+		Delete(&model.UserProjectRole{}).Error; err != nil {
+		return err
+	}
+
+	// CSPアカウントメンバーシップを無効化（プロバイダー側のロールは定期実行で外す）
+	var memberIDs []uint
+	if err := tx.Model(&model.CSPAccountMember{}).
+		Where("project_id = ? AND user_id = ? AND status <> ?", summary.ProjectID, summary.UserID, model.CSPAccountMemberStatusInactive).
+		Pluck("id", &memberIDs).Error; err != nil {
+		return err
+	}
+	if len(memberIDs) > 0 {
+		if err := tx.Model(&model.CSPAccountMember{}).Where("id IN ?", memberIDs).Updates(map[string]interface{}{
+			"status":      model.CSPAccountMemberStatusInactive,
+			"sync_status": model.CSPAccountMemberSyncStatusPending,
+		}).Error; err != nil {
+			return err
+		}
+		summary.DeactivatedCSPAccountMemberIDs = memberIDs
+	}
+
+	// 作成したプロジェクトCSPアカウント関連
+	var relationIDs []uint
+	if err := tx.Model(&model.ProjectCSPAccount{}).
+		Where("project_id = ? AND created_by = ?", summary.ProjectID, summary.UserID).
+		Pluck("id", &relationIDs).Error; err != nil {
+		return err
+	}
+
+	// 他ユーザー向けに作成したCSPアカウントメンバー
+	var createdMemberIDs []uint
+	if err := tx.Model(&model.CSPAccountMember{}).
+		Where("project_id = ? AND created_by = ? AND user_id <> ?", summary.ProjectID, summary.UserID, summary.UserID).
+		Pluck("id", &createdMemberIDs).Error; err != nil {
+		return err
+	}
+
+	// 送信した承諾待ちの招待
+	var invitationIDs []uint
+	if err := tx.Model(&model.ProjectInvitation{}).
+		Where("project_id = ? AND invited_by = ? AND status = ?", summary.ProjectID, summary.UserID, model.InvitationStatusPending).
+		Pluck("id", &invitationIDs).Error; err != nil {
+		return err
+	}
+
+	if summary.ReassignedTo != nil {
+		if len(relationIDs) > 0 {
+			if err := tx.Model(&model.ProjectCSPAccount{}).Where("id IN ?", relationIDs).
+				Update("created_by", *summary.ReassignedTo).Error; err != nil {
+				return err
+			}
+			summary.ReassignedProjectCSPAccountIDs = relationIDs
+		}
+		if len(createdMemberIDs) > 0 {
+			if err := tx.Model(&model.CSPAccountMember{}).Where("id IN ?", createdMemberIDs).
+				Update("created_by", *summary.ReassignedTo).Error; err != nil {
+				return err
+			}
+			summary.ReassignedCSPAccountMemberIDs = createdMemberIDs
+		}
+		if len(invitationIDs) > 0 {
+			if err := tx.Model(&model.ProjectInvitation{}).Where("id IN ?", invitationIDs).
+				Update("invited_by", *summary.ReassignedTo).Error; err != nil {
+				return err
+			}
+			summary.ReassignedInvitationIDs = invitationIDs
+		}
+		return nil
+	}
+
+	for _, id := range relationIDs {
+		summary.FlaggedResources = append(summary.FlaggedResources, model.FlaggedResource{
+			Type:   "project_csp_account",
+			ID:     id,
+			Reason: "created by removed member",
+		})
+	}
+	for _, id := range createdMemberIDs {
+		summary.FlaggedResources = append(summary.FlaggedResources, model.FlaggedResource{
+			Type:   "csp_account_member",
+			ID:     id,
+			Reason: "created by removed member",
+		})
+	}
+	if len(invitationIDs) > 0 {
+		if err := tx.Model(&model.ProjectInvitation{}).Where("id IN ?", invitationIDs).
+			Update("status", model.InvitationStatusRevoked).Error; err != nil {
+			return err
+		}
+		summary.RevokedInvitationIDs = invitationIDs
+	}
+	return nil
+}
+
+// TransferOwnership はオーナー権限を別メンバーに移譲（トランザクション）
+func (r *projectRepository) TransferOwnership(projectID, fromUserID, toUserID uint, previousOwnerRole model.Role) error {
+	if !previousOwnerRole.IsValid() {
+		return model.ErrInvalidRole
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.UserProjectRole{}).
+			Where("project_id = ? AND user_id = ?", projectID, toUserID).
+			Update("role", model.RoleOwner)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return model.ErrUserNotProjectMember
+		}
+
+		result = tx.Model(&model.UserProjectRole{}).
+			Where("project_id = ? AND user_id = ? AND role = ?", projectID, fromUserID, model.RoleOwner).
+			Update("role", previousOwnerRole)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return model.ErrInsufficientPermissions
+		}
+		return nil
+	})
+}
+
 // CountProjectOwners はプロジェクトのオーナー数をカウント
 func (r *projectRepository) CountProjectOwners(projectID uint) (int64, error) {
 	var count int64
@@ -178,26 +535,81 @@ func (r *projectRepository) IsMember(projectID, userID uint) (bool, error) {
 	return count > 0, err
 }
 
-// SelectByType はプロジェクトタイプでプロジェクト一覧を取得
-func (r *projectRepository) SelectByType(projectType string) ([]model.Project, error) {
-	var projects []model.Project
-	err := r.db.Where("project_type = ?", projectType).Find(&projects).Error
-	return projects, err
-}
-
 // SelectVendorRelationsByProjectID はプロジェクトのベンダー紐付け一覧を取得
+// 紐付け元・ベンダー側のどちらとして参加している紐付けも含む
 func (r *projectRepository) SelectVendorRelationsByProjectID(projectID uint) ([]model.ProjectVendorRelation, error) {
 	var relations []model.ProjectVendorRelation
-	err := r.db.Preload("VendorProject").
-		Where("project_id = ?", projectID).
+	err := r.db.Preload("Project").Preload("VendorProject").
+		Where("project_id = ? OR vendor_project_id = ?", projectID, projectID).
 		Order("created_at DESC").
 		Find(&relations).Error
 	return relations, err
 }
 
+// SelectVendorRelationsForVendorMember はユーザーが所属するベンダープロジェクトの成立済み（承諾済み・有効）の紐付け一覧を取得
+func (r *projectRepository) SelectVendorRelationsForVendorMember(userID uint) ([]model.ProjectVendorRelation, error) {
+	var relations []model.ProjectVendorRelation
+	err := r.db.Preload("Project").Preload("VendorProject").
+		Joins("JOIN user_project_roles upr ON upr.project_id = project_vendor_relations.vendor_project_id AND upr.deleted_at IS NULL").
+		Where("upr.user_id = ? AND project_vendor_relations.status IN ?", userID, []model.VendorRelationStatus{model.VendorRelationStatusAccepted, model.VendorRelationStatusActive}).
+		Order("project_vendor_relations.end_date ASC NULLS LAST, project_vendor_relations.id ASC").
+		Find(&relations).Error
+	return relations, err
+}
+
+// SelectVendorRelationByID はベンダー紐付けを取得
+func (r *projectRepository) SelectVendorRelationByID(relationID uint) (*model.ProjectVendorRelation, error) {
+	var relation model.ProjectVendorRelation
+	if err := r.db.Preload("Project").Preload("VendorProject").First(&relation, relationID).Error; err != nil {
+		return nil, err
+	}
+	return &relation, nil
+}
+
+// SelectOpenVendorRelation はプロジェクトとベンダーの継続中（提案中・承諾済み・有効）の紐付けを取得
+func (r *projectRepository) SelectOpenVendorRelation(projectID, vendorProjectID uint) (*model.ProjectVendorRelation, error) {
+	var relation model.ProjectVendorRelation
+	err := r.db.Where("project_id = ? AND vendor_project_id = ? AND status IN ?", projectID, vendorProjectID, []model.VendorRelationStatus{
+		model.VendorRelationStatusProposed,
+		model.VendorRelationStatusAccepted,
+		model.VendorRelationStatusActive,
+	}).First(&relation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &relation, nil
+}
+
+// SelectScheduledVendorRelations は契約期間による自動遷移の対象となりうる紐付けを取得
+// （承諾済み、または終了日が設定された有効な紐付け）
+func (r *projectRepository) SelectScheduledVendorRelations() ([]model.ProjectVendorRelation, error) {
+	var relations []model.ProjectVendorRelation
+	err := r.db.Preload("Project").Preload("VendorProject").
+		Where("status = ? OR (status = ? AND end_date IS NOT NULL)", model.VendorRelationStatusAccepted, model.VendorRelationStatusActive).
+		Find(&relations).Error
+	return relations, err
+}
+
 // InsertVendorRelation はベンダープロジェクト紐付けを作成
 func (r *projectRepository) InsertVendorRelation(relation *model.ProjectVendorRelation) error {
-	return r.db.Create(relation).Error
+	return r.db.Omit("Project", "VendorProject").Create(relation).Error
+}
+
+// UpdateVendorRelation はベンダープロジェクト紐付けを更新
+// 終了・期限切れ・委任範囲の縮小で担当者の委任から外れたCSPアカウントメンバーは同じトランザクションで削除する
+func (r *projectRepository) UpdateVendorRelation(relation *model.ProjectVendorRelation) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Project", "VendorProject").Save(relation).Error; err != nil {
+			return err
+		}
+
+		var staffUserIDs []uint
+		if err := tx.Model(&model.VendorStaffAssignment{}).Where("relation_id = ?", relation.ID).
+			Pluck("user_id", &staffUserIDs).Error; err != nil {
+			return err
+		}
+		return revokeUndelegatedCSPAccountMembers(tx, relation.ProjectID, staffUserIDs)
+	})
 }
 
 // DeleteVendorRelation はベンダープロジェクト紐付けを削除
@@ -205,6 +617,255 @@ func (r *projectRepository) DeleteVendorRelation(relationID uint) error {
 	return r.db.Delete(&model.ProjectVendorRelation{}, relationID).Error
 }
 
+// SelectVendorStaffAssignments はベンダー紐付けの担当者割り当て一覧を取得
+func (r *projectRepository) SelectVendorStaffAssignments(relationID uint) ([]model.VendorStaffAssignment, error) {
+	var assignments []model.VendorStaffAssignment
+	err := r.db.Preload("User").
+		Where("relation_id = ?", relationID).
+		Order("created_at ASC").
+		Find(&assignments).Error
+	return assignments, err
+}
+
+// SelectVendorStaffAssignment はベンダー紐付けの担当者割り当てを取得
+func (r *projectRepository) SelectVendorStaffAssignment(relationID, userID uint) (*model.VendorStaffAssignment, error) {
+	var assignment model.VendorStaffAssignment
+	if err := r.db.Where("relation_id = ? AND user_id = ?", relationID, userID).First(&assignment).Error; err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+// SaveVendorStaffAssignment はベンダー紐付けの担当者割り当てを作成・更新
+// 割り当てを狭めた場合、委任から外れたCSPアカウントメンバーは同じトランザクションで削除する
+func (r *projectRepository) SaveVendorStaffAssignment(assignment *model.VendorStaffAssignment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Relation", "User").Save(assignment).Error; err != nil {
+			return err
+		}
+		return revokeUndelegatedStaffMembers(tx, assignment)
+	})
+}
+
+// DeleteVendorStaffAssignment はベンダー紐付けの担当者割り当てを削除
+// 委任から外れたCSPアカウントメンバーは同じトランザクションで削除する
+func (r *projectRepository) DeleteVendorStaffAssignment(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var assignment model.VendorStaffAssignment
+		if err := tx.First(&assignment, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&assignment).Error; err != nil {
+			return err
+		}
+		return revokeUndelegatedStaffMembers(tx, &assignment)
+	})
+}
+
+// revokeUndelegatedStaffMembers は担当者割り当ての紐付け元プロジェクトで、担当者の委任から外れたCSPアカウントメンバーを削除
+func revokeUndelegatedStaffMembers(tx *gorm.DB, assignment *model.VendorStaffAssignment) error {
+	var projectIDs []uint
+	if err := tx.Unscoped().Model(&model.ProjectVendorRelation{}).Where("id = ?", assignment.RelationID).
+		Pluck("project_id", &projectIDs).Error; err != nil {
+		return err
+	}
+	if len(projectIDs) == 0 {
+		return nil
+	}
+	return revokeUndelegatedCSPAccountMembers(tx, projectIDs[0], []uint{assignment.UserID})
+}
+
+// revokeUndelegatedCSPAccountMembers はベンダー担当者のCSPアカウントメンバーのうち、現在の委任範囲に含まれなくなったものを削除
+// 削除はプロバイダー側のロールを外す対象として記録する（定期実行で外す）。紐付け元プロジェクトのメンバーでもあるユーザーは対象外
+func revokeUndelegatedCSPAccountMembers(tx *gorm.DB, projectID uint, userIDs []uint) error {
+	for _, userID := range userIDs {
+		var memberCount int64
+		if err := tx.Model(&model.UserProjectRole{}).Where("project_id = ? AND user_id = ?", projectID, userID).
+			Count(&memberCount).Error; err != nil {
+			return err
+		}
+		if memberCount > 0 {
+			continue
+		}
+
+		assignments, err := selectActiveStaffAssignments(tx, projectID, userID)
+		if err != nil {
+			return err
+		}
+		access := &model.DelegatedAccess{}
+		for i := range assignments {
+			access.Merge(&assignments[i].Relation, &assignments[i])
+		}
+
+		if access.Has(model.VendorPermissionCSPAccountMember) && len(access.CSPAccountIDs) > 0 {
+			err = deleteCSPAccountMembers(tx, "project_id = ? AND user_id = ? AND csp_account_id NOT IN ?", projectID, userID, access.CSPAccountIDs)
+		} else {
+			err = deleteCSPAccountMembers(tx, "project_id = ? AND user_id = ?", projectID, userID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SelectActiveStaffAssignments はユーザーが紐付け元プロジェクトに対して持つ有効な担当者割り当てを取得
+// 紐付けが有効（契約終了日前）で、ユーザーがベンダープロジェクトのメンバーであるものに限る
+func (r *projectRepository) SelectActiveStaffAssignments(projectID, userID uint) ([]model.VendorStaffAssignment, error) {
+	return selectActiveStaffAssignments(r.db, projectID, userID)
+}
+
+func selectActiveStaffAssignments(db *gorm.DB, projectID, userID uint) ([]model.VendorStaffAssignment, error) {
+	var assignments []model.VendorStaffAssignment
+	err := db.Preload("Relation").
+		Joins("JOIN project_vendor_relations pvr ON pvr.id = vendor_staff_assignments.relation_id AND pvr.deleted_at IS NULL").
+		Joins("JOIN user_project_roles upr ON upr.project_id = pvr.vendor_project_id AND upr.user_id = vendor_staff_assignments.user_id AND upr.deleted_at IS NULL").
+		Where("vendor_staff_assignments.user_id = ? AND pvr.project_id = ? AND pvr.status = ?", userID, projectID, model.VendorRelationStatusActive).
+		Where("pvr.end_date IS NULL OR pvr.end_date >= CURRENT_DATE").
+		Find(&assignments).Error
+	return assignments, err
+}
+
+// SelectActiveVendorProjectIDsForMember はプロジェクトと有効な紐付けがあるベンダープロジェクトのうちユーザーが所属するもののIDを取得
+func (r *projectRepository) SelectActiveVendorProjectIDsForMember(projectID, userID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&model.ProjectVendorRelation{}).
+		Joins("JOIN user_project_roles upr ON upr.project_id = project_vendor_relations.vendor_project_id AND upr.deleted_at IS NULL").
+		Where("project_vendor_relations.project_id = ? AND project_vendor_relations.status = ? AND upr.user_id = ?", projectID, model.VendorRelationStatusActive, userID).
+		Where("project_vendor_relations.end_date IS NULL OR project_vendor_relations.end_date >= CURRENT_DATE").
+		Distinct().
+		Pluck("project_vendor_relations.vendor_project_id", &ids).Error
+	return ids, err
+}
+
+// SelectManagerEmails はプロジェクトの管理者（owner/admin）のメールアドレス一覧を取得
+func (r *projectRepository) SelectManagerEmails(projectID uint) ([]string, error) {
+	var emails []string
+	err := r.db.Table("user_project_roles upr").
+		Joins("JOIN users u ON u.id = upr.user_id AND u.deleted_at IS NULL").
+		Where("upr.project_id = ? AND upr.role IN ? AND upr.deleted_at IS NULL", projectID, []model.Role{model.RoleOwner, model.RoleAdmin}).
+		Pluck("u.email", &emails).Error
+	return emails, err
+}
+
+// SelectOwnerEmails はプロジェクトのオーナーのメールアドレス一覧を取得
+func (r *projectRepository) SelectOwnerEmails(projectID uint) ([]string, error) {
+	var emails []string
+	err := r.db.Table("user_project_roles upr").
+		Joins("JOIN users u ON u.id = upr.user_id AND u.deleted_at IS NULL").
+		Where("upr.project_id = ? AND upr.role = ? AND upr.deleted_at IS NULL", projectID, model.RoleOwner).
+		Pluck("u.email", &emails).Error
+	return emails, err
+}
+
+// SelectInvitationsByProjectID はプロジェクトの招待一覧を取得（statusが空の場合は全件）
+func (r *projectRepository) SelectInvitationsByProjectID(projectID uint, status model.InvitationStatus) ([]model.ProjectInvitation, error) {
+	var invitations []model.ProjectInvitation
+	query := r.db.Where("project_id = ?", projectID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("created_at DESC").Find(&invitations).Error
+	return invitations, err
+}
+
+// SelectInvitationByID は招待を取得
+func (r *projectRepository) SelectInvitationByID(id uint) (*model.ProjectInvitation, error) {
+	var invitation model.ProjectInvitation
+	if err := r.db.Preload("Project").First(&invitation, id).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// SelectPendingInvitationByEmail はメールアドレス宛ての承諾待ち招待を取得
+func (r *projectRepository) SelectPendingInvitationByEmail(projectID uint, email string) (*model.ProjectInvitation, error) {
+	var invitation model.ProjectInvitation
+	err := r.db.Where("project_id = ? AND LOWER(email) = LOWER(?) AND status = ?", projectID, email, model.InvitationStatusPending).
+		First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// InsertInvitation は招待を作成
+func (r *projectRepository) InsertInvitation(invitation *model.ProjectInvitation) error {
+	return r.db.Create(invitation).Error
+}
+
+// UpdateInvitation は招待を更新
+func (r *projectRepository) UpdateInvitation(invitation *model.ProjectInvitation) error {
+	return r.db.Omit("Project", "InvitedByUser").Save(invitation).Error
+}
+
+// AcceptInvitation は招待を承諾済みにし、ユーザーをプロジェクトメンバーとして追加（トランザクション）
+func (r *projectRepository) AcceptInvitation(invitation *model.ProjectInvitation, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return acceptInvitation(tx, invitation, userID)
+	})
+}
+
+// InsertUserWithInvitation は招待リンクから登録するユーザーを作成し、招待を承諾する（トランザクション）
+// 承諾に失敗した場合はユーザーも作成しない。新規ユーザーはデフォルトプロジェクト（システム）にもviewer権限で参加させる
+func (r *projectRepository) InsertUserWithInvitation(user *model.User, invitation *model.ProjectInvitation) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+
+		var systemProject model.Project
+		err := tx.Where("name = ?", "システム").First(&systemProject).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil && systemProject.ID != invitation.ProjectID {
+			if err := tx.Create(&model.UserProjectRole{
+				UserID:    user.ID,
+				ProjectID: systemProject.ID,
+				Role:      model.RoleViewer,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		return acceptInvitation(tx, invitation, user.ID)
+	})
+}
+
+// acceptInvitation はトランザクション内で招待を承諾済みにし、ユーザーをプロジェクトメンバーとして追加
+func acceptInvitation(tx *gorm.DB, invitation *model.ProjectInvitation, userID uint) error {
+	userProjectRole := model.UserProjectRole{
+		UserID:    userID,
+		ProjectID: invitation.ProjectID,
+		Role:      invitation.Role,
+	}
+	if err := tx.Create(&userProjectRole).Error; err != nil {
+		return err
+	}
+
+	now := time.Now()
+	result := tx.Model(&model.ProjectInvitation{}).
+		Where("id = ? AND status = ?", invitation.ID, model.InvitationStatusPending).
+		Updates(map[string]interface{}{
+			"status":      model.InvitationStatusAccepted,
+			"accepted_by": userID,
+			"accepted_at": now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	// 同時に承諾・取り消しされた場合はロールバック
+	if result.RowsAffected == 0 {
+		return model.ErrInvitationNotPending
+	}
+
+	invitation.Status = model.InvitationStatusAccepted
+	invitation.AcceptedBy = &userID
+	invitation.AcceptedAt = &now
+	return nil
+}
+
 // CheckAdminProjectPermission は管理者プロジェクトでの権限をチェック
 func (r *projectRepository) CheckAdminProjectPermission(userID uint) (*model.ProjectPermissionResponse, error) {
 	// 管理者プロジェクトを取得
@@ -248,4 +909,26 @@ func (r *projectRepository) CheckAdminProjectPermission(userID uint) (*model.Pro
 		CanEdit:   false,
 		CanManage: false,
 	}, nil
-}
\ No newline at end of file
+}
+
+// applyProjectMetadataFilter はタグ・カスタム属性の一致条件をクエリに追加
+// prefixにはprojectsテーブルのエイリアス（例: "p."）を指定する
+func applyProjectMetadataFilter(query *gorm.DB, prefix string, filter *model.ProjectMetadataFilter) *gorm.DB {
+	if filter.IsEmpty() {
+		return query
+	}
+	for key, value := range filter.Tags {
+		// 包含演算子を使うことでtagsのGINインデックスが利用される
+		condition, _ := json.Marshal(map[string]string{key: value})
+		query = query.Where(prefix+"tags @> ?::jsonb", string(condition))
+	}
+	for key, value := range filter.Attributes {
+		query = query.Where(prefix+"custom_attributes ->> ? = ?", key, value)
+	}
+	return query
+}
+
+// escapeLikePattern はLIKE/ILIKEのワイルドカード文字をエスケープ
+func escapeLikePattern(keyword string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(keyword)
+}
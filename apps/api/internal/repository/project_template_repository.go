@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type projectTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewProjectTemplateRepository(db *gorm.DB) interfaces.ProjectTemplateRepository {
+	return &projectTemplateRepository{db: db}
+}
+
+// SelectAll はプロジェクトテンプレート一覧を取得
+func (r *projectTemplateRepository) SelectAll() ([]model.ProjectTemplate, error) {
+	var templates []model.ProjectTemplate
+	err := r.db.Order("name ASC").Find(&templates).Error
+	return templates, err
+}
+
+// SelectByID はプロジェクトテンプレートを取得
+func (r *projectTemplateRepository) SelectByID(id uint) (*model.ProjectTemplate, error) {
+	var template model.ProjectTemplate
+	if err := r.db.First(&template, id).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// SelectByName は名前でプロジェクトテンプレートを取得
+func (r *projectTemplateRepository) SelectByName(name string) (*model.ProjectTemplate, error) {
+	var template model.ProjectTemplate
+	if err := r.db.Where("name = ?", name).First(&template).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// Insert はプロジェクトテンプレートを作成
+func (r *projectTemplateRepository) Insert(template *model.ProjectTemplate) error {
+	return r.db.Create(template).Error
+}
+
+// Update はプロジェクトテンプレートを更新
+func (r *projectTemplateRepository) Update(template *model.ProjectTemplate) error {
+	return r.db.Save(template).Error
+}
+
+// Delete はプロジェクトテンプレートを削除（ソフトデリート）
+func (r *projectTemplateRepository) Delete(id uint) error {
+	return r.db.Delete(&model.ProjectTemplate{}, id).Error
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type reconciliationRepository struct {
+	db *gorm.DB
+}
+
+func NewReconciliationRepository(db *gorm.DB) interfaces.ReconciliationRepository {
+	return &reconciliationRepository{db: db}
+}
+
+func (r *reconciliationRepository) InsertRun(run *model.CSPReconciliationRun) error {
+	return r.db.Create(run).Error
+}
+
+func (r *reconciliationRepository) UpdateRun(run *model.CSPReconciliationRun) error {
+	return r.db.Save(run).Error
+}
+
+// SelectRuns は棚卸しの実行履歴を新しい順に取得
+func (r *reconciliationRepository) SelectRuns(limit int) ([]model.CSPReconciliationRun, error) {
+	var runs []model.CSPReconciliationRun
+	err := r.db.Order("started_at DESC, id DESC").Limit(limit).Find(&runs).Error
+	return runs, err
+}
+
+// SelectDriftItems はドリフトを最終検出日時の新しい順に取得
+func (r *reconciliationRepository) SelectDriftItems(filter *model.CSPDriftFilter) ([]model.CSPDriftItem, error) {
+	query := r.db.Preload("ResolvedByUser")
+	if filter.Provider != "" {
+		query = query.Where("provider = ?", filter.Provider)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.CSPAccountID != nil {
+		query = query.Where("csp_account_id = ?", *filter.CSPAccountID)
+	}
+
+	var items []model.CSPDriftItem
+	err := query.Order("last_detected_at DESC, id DESC").Find(&items).Error
+	return items, err
+}
+
+func (r *reconciliationRepository) SelectDriftItemByID(id uint) (*model.CSPDriftItem, error) {
+	var item model.CSPDriftItem
+	err := r.db.Preload("ResolvedByUser").First(&item, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *reconciliationRepository) SelectDriftItemsByProvider(provider model.CSPProvider) ([]model.CSPDriftItem, error) {
+	var items []model.CSPDriftItem
+	err := r.db.Where("provider = ?", provider).Find(&items).Error
+	return items, err
+}
+
+func (r *reconciliationRepository) InsertDriftItem(item *model.CSPDriftItem) error {
+	return r.db.Create(item).Error
+}
+
+func (r *reconciliationRepository) UpdateDriftItem(item *model.CSPDriftItem) error {
+	return r.db.Omit("ResolvedByUser").Save(item).Error
+}
+
+func (r *reconciliationRepository) ResolveDriftItem(item *model.CSPDriftItem) error {
+	result := r.db.Model(&model.CSPDriftItem{}).
+		Where("id = ? AND status = ?", item.ID, model.CSPDriftStatusOpen).
+		Select("status", "csp_account_id", "csp_account_member_id", "resolution", "resolution_note", "resolved_by", "resolved_at", "updated_at").
+		Updates(item)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrDriftItemNotOpen
+	}
+	return nil
+}
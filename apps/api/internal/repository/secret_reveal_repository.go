@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type secretRevealRepository struct {
+	db *gorm.DB
+}
+
+func NewSecretRevealRepository(db *gorm.DB) interfaces.SecretRevealRepository {
+	return &secretRevealRepository{db: db}
+}
+
+func (r *secretRevealRepository) SelectByID(id uint) (*model.CSPSecretReveal, error) {
+	var reveal model.CSPSecretReveal
+	err := r.db.Preload("RequestedByUser").Preload("ApprovedByUser").First(&reveal, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &reveal, nil
+}
+
+// SelectByCSPAccountID はCSPアカウントの開示申請を新しい順に取得
+func (r *secretRevealRepository) SelectByCSPAccountID(cspAccountID uint) ([]model.CSPSecretReveal, error) {
+	var reveals []model.CSPSecretReveal
+	err := r.db.Preload("RequestedByUser").Preload("ApprovedByUser").
+		Where("csp_account_id = ?", cspAccountID).
+		Order("created_at DESC, id DESC").Find(&reveals).Error
+	return reveals, err
+}
+
+func (r *secretRevealRepository) Insert(reveal *model.CSPSecretReveal, entry *model.SecretRevealAuditEntry, auditKey []byte) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(reveal).Error; err != nil {
+			return err
+		}
+		entry.RevealID = reveal.ID
+		return appendSecretRevealAuditEntry(tx, entry, auditKey)
+	})
+}
+
+func (r *secretRevealRepository) Transition(reveal *model.CSPSecretReveal, from model.SecretRevealStatus, entry *model.SecretRevealAuditEntry, auditKey []byte) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.CSPSecretReveal{}).
+			Where("id = ? AND status = ?", reveal.ID, from).
+			Select("status", "approved_by", "approved_at", "reject_reason", "revealed_at", "expires_at", "updated_at").
+			Updates(reveal)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return model.ErrSecretRevealStateChanged
+		}
+		return appendSecretRevealAuditEntry(tx, entry, auditKey)
+	})
+}
+
+// SelectAuditEntries は監査記録を追記順に取得
+func (r *secretRevealRepository) SelectAuditEntries() ([]model.SecretRevealAuditEntry, error) {
+	var entries []model.SecretRevealAuditEntry
+	err := r.db.Order("id ASC").Find(&entries).Error
+	return entries, err
+}
+
+// SelectAuditHead は保存した監査記録の先頭を取得（まだ記録がない場合はnil）
+func (r *secretRevealRepository) SelectAuditHead() (*model.SecretRevealAuditHead, error) {
+	var head model.SecretRevealAuditHead
+	err := r.db.Where("id = ?", model.SecretRevealAuditHeadID).Limit(1).Find(&head).Error
+	if err != nil {
+		return nil, err
+	}
+	if head.ID == 0 {
+		return nil, nil
+	}
+	return &head, nil
+}
+
+// appendSecretRevealAuditEntry は直前の記録のハッシュをつないで監査記録を追記し、先頭を更新
+// 同時に追記されてチェーンが分岐しないよう、テーブルをロックしてから直前の記録を取得する
+func appendSecretRevealAuditEntry(tx *gorm.DB, entry *model.SecretRevealAuditEntry, auditKey []byte) error {
+	if err := tx.Exec("LOCK TABLE csp_secret_reveal_audit_entries IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+		return err
+	}
+
+	var last model.SecretRevealAuditEntry
+	err := tx.Order("id DESC").Limit(1).Find(&last).Error
+	if err != nil {
+		return err
+	}
+	entry.PrevHash = model.SecretRevealAuditGenesisHash
+	if last.ID != 0 {
+		entry.PrevHash = last.Hash
+	}
+
+	entry.CreatedAt = time.Now().Truncate(time.Microsecond)
+	entry.Hash = entry.ComputeHash(auditKey)
+	if err := tx.Create(entry).Error; err != nil {
+		return err
+	}
+
+	var count int64
+	if err := tx.Model(&model.SecretRevealAuditEntry{}).Count(&count).Error; err != nil {
+		return err
+	}
+	head := &model.SecretRevealAuditHead{
+		ID:          model.SecretRevealAuditHeadID,
+		LastEntryID: entry.ID,
+		Hash:        entry.Hash,
+		Entries:     int(count),
+	}
+	head.MAC = head.ComputeMAC(auditKey)
+	return tx.Save(head).Error
+}
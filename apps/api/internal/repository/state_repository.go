@@ -0,0 +1,367 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type stateRepository struct {
+	db *gorm.DB
+}
+
+func NewStateRepository(db *gorm.DB) interfaces.StateRepository {
+	return &stateRepository{db: db}
+}
+
+// LoadSnapshot は状態同期のプラン作成に必要な現在の状態を読み込む
+func (r *stateRepository) LoadSnapshot(emails []string) (*model.StateSnapshot, error) {
+	snapshot := &model.StateSnapshot{}
+
+	if err := r.db.Order("id ASC").Find(&snapshot.Organizations).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Order("id ASC").Find(&snapshot.Projects).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Preload("User").Order("id ASC").Find(&snapshot.Members).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("status IN ?", []model.VendorRelationStatus{
+		model.VendorRelationStatusProposed, model.VendorRelationStatusAccepted, model.VendorRelationStatusActive,
+	}).Order("id ASC").Find(&snapshot.VendorRelations).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Preload("CSPAccount").Order("id ASC").Find(&snapshot.ProjectCSPAccounts).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Preload("User").Preload("CSPAccount").Order("id ASC").Find(&snapshot.CSPAccountMembers).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Order("id ASC").Find(&snapshot.CSPAccounts).Error; err != nil {
+		return nil, err
+	}
+	if len(emails) > 0 {
+		if err := r.db.Where("LOWER(email) IN ?", emails).Find(&snapshot.Users).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return snapshot, nil
+}
+
+// ApplyPlan はプランの変更を順に適用（全体を1トランザクションで実行し、失敗時は全て取り消す）
+func (r *stateRepository) ApplyPlan(plan *model.StatePlan, actorID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		applier := &statePlanApplier{
+			tx:              tx,
+			actorID:         actorID,
+			now:             time.Now(),
+			organizationIDs: make(map[string]uint),
+			projectIDs:      make(map[string]uint),
+		}
+		for i := range plan.Changes {
+			change := &plan.Changes[i]
+			if err := applier.apply(change); err != nil {
+				return fmt.Errorf("%s %s %q: %w", change.Action, change.Kind, change.Key, err)
+			}
+		}
+		return nil
+	})
+}
+
+// statePlanApplier はトランザクション内でプランの変更を適用する
+// 新規作成した組織・プロジェクトのIDを保持し、後続の変更から識別キーで参照できるようにする
+type statePlanApplier struct {
+	tx              *gorm.DB
+	actorID         uint
+	now             time.Time
+	organizationIDs map[string]uint
+	projectIDs      map[string]uint
+}
+
+func (a *statePlanApplier) apply(change *model.StateChange) error {
+	switch change.Kind {
+	case model.StateResourceOrganization:
+		return a.applyOrganization(change)
+	case model.StateResourceProject:
+		return a.applyProject(change)
+	case model.StateResourceProjectMember:
+		return a.applyProjectMember(change)
+	case model.StateResourceVendorRelation:
+		return a.applyVendorRelation(change)
+	case model.StateResourceProjectCSPAccount:
+		return a.applyProjectCSPAccount(change)
+	case model.StateResourceCSPAccountMember:
+		return a.applyCSPAccountMember(change)
+	}
+	return fmt.Errorf("unknown resource kind %q", change.Kind)
+}
+
+func (a *statePlanApplier) organizationID(target *model.StateChangeTarget) (uint, error) {
+	if target.OrganizationID != 0 {
+		return target.OrganizationID, nil
+	}
+	if id, ok := a.organizationIDs[target.OrganizationKey]; ok {
+		return id, nil
+	}
+	return 0, fmt.Errorf("organization %q is not resolved", target.OrganizationKey)
+}
+
+func (a *statePlanApplier) projectID(id uint, key string) (uint, error) {
+	if id != 0 {
+		return id, nil
+	}
+	if created, ok := a.projectIDs[key]; ok {
+		return created, nil
+	}
+	return 0, fmt.Errorf("project %q is not resolved", key)
+}
+
+func (a *statePlanApplier) applyOrganization(change *model.StateChange) error {
+	target := &change.Target
+	switch change.Action {
+	case model.StateChangeCreate:
+		desired := target.Organization
+		organization := model.Organization{
+			Name:             desired.Name,
+			OrganizationType: desired.Type,
+			Status:           desired.Status,
+		}
+		if desired.Description != nil {
+			organization.Description = *desired.Description
+		}
+		if organization.Status == "" {
+			organization.Status = model.OrgStatusActive
+		}
+		if err := a.tx.Omit("Projects").Create(&organization).Error; err != nil {
+			return err
+		}
+		a.organizationIDs[target.OrganizationKey] = organization.ID
+		return nil
+
+	case model.StateChangeUpdate:
+		desired := target.Organization
+		var organization model.Organization
+		if err := a.tx.First(&organization, target.ID).Error; err != nil {
+			return err
+		}
+		if desired.Type != "" {
+			organization.OrganizationType = desired.Type
+		}
+		if desired.Description != nil {
+			organization.Description = *desired.Description
+		}
+		if desired.Status != "" {
+			organization.Status = desired.Status
+		}
+		return a.tx.Model(&organization).Select("organization_type", "description", "status").Updates(&organization).Error
+
+	case model.StateChangeDelete:
+		return a.tx.Delete(&model.Organization{}, target.ID).Error
+	}
+	return nil
+}
+
+func (a *statePlanApplier) applyProject(change *model.StateChange) error {
+	target := &change.Target
+	switch change.Action {
+	case model.StateChangeCreate:
+		desired := target.Project
+		organizationID, err := a.organizationID(target)
+		if err != nil {
+			return err
+		}
+		project := model.Project{
+			Name:           desired.Name,
+			OrganizationID: organizationID,
+			ProjectType:    desired.Type,
+			Status:         desired.Status,
+			Tags:           desired.Tags,
+		}
+		if desired.Description != nil {
+			project.Description = *desired.Description
+		}
+		if project.Status == "" {
+			project.Status = model.ProjectStatusActive
+		}
+		if err := a.tx.Omit("Organization", "UserProjects").Create(&project).Error; err != nil {
+			return err
+		}
+		a.projectIDs[target.ProjectKey] = project.ID
+		return nil
+
+	case model.StateChangeUpdate:
+		desired := target.Project
+		var project model.Project
+		if err := a.tx.First(&project, target.ID).Error; err != nil {
+			return err
+		}
+		columns := []string{"description", "tags"}
+		if desired.Description != nil {
+			project.Description = *desired.Description
+		}
+		if desired.Tags != nil {
+			project.Tags = desired.Tags
+		}
+		if desired.Status != "" && desired.Status != project.Status {
+			project.Status = desired.Status
+			project.StatusChangedAt = &a.now
+			project.StatusChangedBy = &a.actorID
+			columns = append(columns, "status", "status_changed_at", "status_changed_by")
+		}
+		return a.tx.Model(&project).Select(columns).Updates(&project).Error
+
+	case model.StateChangeDelete:
+		// 通常の削除と同じく依存レコードも一緒にソフトデリートする（削除可否はサービス層で確認済み）
+		return deleteProjectWithDependents(a.tx, target.ID, a.actorID, a.now)
+	}
+	return nil
+}
+
+func (a *statePlanApplier) applyProjectMember(change *model.StateChange) error {
+	target := &change.Target
+	projectID, err := a.projectID(target.ProjectID, target.ProjectKey)
+	if err != nil {
+		return err
+	}
+
+	switch change.Action {
+	case model.StateChangeCreate:
+		return a.tx.Create(&model.UserProjectRole{
+			UserID:    target.UserID,
+			ProjectID: projectID,
+			Role:      model.Role(target.Role),
+		}).Error
+	case model.StateChangeUpdate:
+		return a.tx.Model(&model.UserProjectRole{}).
+			Where("project_id = ? AND user_id = ?", projectID, target.UserID).
+			Update("role", target.Role).Error
+	case model.StateChangeDelete:
+		// 通常のメンバー削除と同じくCSPアカウントメンバーシップの無効化・招待の取り消しを行う
+		return removeMemberWithCascade(a.tx, &model.MemberRemovalSummary{ProjectID: projectID, UserID: target.UserID})
+	}
+	return nil
+}
+
+func (a *statePlanApplier) applyVendorRelation(change *model.StateChange) error {
+	target := &change.Target
+	switch change.Action {
+	case model.StateChangeCreate:
+		projectID, err := a.projectID(target.ProjectID, target.ProjectKey)
+		if err != nil {
+			return err
+		}
+		vendorProjectID, err := a.projectID(target.VendorProjectID, target.VendorProjectKey)
+		if err != nil {
+			return err
+		}
+		startDate, endDate, err := model.ParseContractPeriod(target.Vendor.StartDate, target.Vendor.EndDate)
+		if err != nil {
+			return err
+		}
+
+		// 定義による紐付けは提案・承諾の手続きを経ずに成立させる
+		relation := model.ProjectVendorRelation{
+			ProjectID:       projectID,
+			VendorProjectID: vendorProjectID,
+			Status:          model.VendorRelationStatusAccepted,
+			StartDate:       startDate,
+			EndDate:         endDate,
+			ProposedBy:      &a.actorID,
+			RespondedBy:     &a.actorID,
+			RespondedAt:     &a.now,
+		}
+		relation.Status = relation.ScheduledStatus(a.now)
+		return a.tx.Omit("Project", "VendorProject").Create(&relation).Error
+
+	case model.StateChangeUpdate:
+		var relation model.ProjectVendorRelation
+		if err := a.tx.First(&relation, target.ID).Error; err != nil {
+			return err
+		}
+		startDate, endDate, err := model.ParseContractPeriod(target.Vendor.StartDate, target.Vendor.EndDate)
+		if err != nil {
+			return err
+		}
+		relation.StartDate = startDate
+		relation.EndDate = endDate
+		if relation.Status == model.VendorRelationStatusProposed {
+			relation.Status = model.VendorRelationStatusAccepted
+			relation.RespondedBy = &a.actorID
+			relation.RespondedAt = &a.now
+		}
+		relation.Status = relation.ScheduledStatus(a.now)
+		return a.tx.Model(&relation).Select("start_date", "end_date", "status", "responded_by", "responded_at").Updates(&relation).Error
+
+	case model.StateChangeDelete:
+		var relation model.ProjectVendorRelation
+		if err := a.tx.First(&relation, target.ID).Error; err != nil {
+			return err
+		}
+		// 提案中のものは取り下げ（削除）、成立済みのものは終了として記録する
+		if relation.Status == model.VendorRelationStatusProposed {
+			return a.tx.Delete(&relation).Error
+		}
+		relation.Status = model.VendorRelationStatusTerminated
+		relation.TerminatedBy = &a.actorID
+		relation.TerminatedAt = &a.now
+		relation.StatusReason = "removed from declarative state"
+		return a.tx.Model(&relation).Select("status", "terminated_by", "terminated_at", "status_reason").Updates(&relation).Error
+	}
+	return nil
+}
+
+func (a *statePlanApplier) applyProjectCSPAccount(change *model.StateChange) error {
+	target := &change.Target
+	switch change.Action {
+	case model.StateChangeCreate:
+		projectID, err := a.projectID(target.ProjectID, target.ProjectKey)
+		if err != nil {
+			return err
+		}
+		return a.tx.Omit("Project", "CSPAccount", "CreatedByUser").Create(&model.ProjectCSPAccount{
+			ProjectID:    projectID,
+			CSPAccountID: target.CSPAccountID,
+			CreatedBy:    a.actorID,
+		}).Error
+	case model.StateChangeDelete:
+		return a.tx.Delete(&model.ProjectCSPAccount{}, target.ID).Error
+	}
+	return nil
+}
+
+func (a *statePlanApplier) applyCSPAccountMember(change *model.StateChange) error {
+	target := &change.Target
+	switch change.Action {
+	case model.StateChangeCreate:
+		projectID, err := a.projectID(target.ProjectID, target.ProjectKey)
+		if err != nil {
+			return err
+		}
+		return a.tx.Omit("CSPAccount", "Project", "User", "CreatedByUser").Create(&model.CSPAccountMember{
+			CSPAccountID: target.CSPAccountID,
+			ProjectID:    projectID,
+			UserID:       target.UserID,
+			SSOEnabled:   true,
+			SSOProvider:  "default",
+			SSOEmail:     target.Email,
+			Role:         target.Role,
+			Status:       string(model.CSPAccountMemberStatusActive),
+			CreatedBy:    a.actorID,
+		}).Error
+	case model.StateChangeUpdate:
+		return a.tx.Model(&model.CSPAccountMember{}).Where("id = ?", target.ID).Updates(map[string]interface{}{
+			"role":        target.Role,
+			"status":      string(model.CSPAccountMemberStatusActive),
+			"sync_status": model.CSPAccountMemberSyncStatusPending,
+		}).Error
+	case model.StateChangeDelete:
+		return deleteCSPAccountMembers(a.tx, "id = ?", target.ID)
+	}
+	return nil
+}
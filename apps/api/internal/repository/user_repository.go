@@ -2,6 +2,8 @@ package repository
 
 import (
 	"fmt"
+	"strings"
+
 	"go-nextjs-api/internal/interfaces"
 	"go-nextjs-api/internal/model"
 
@@ -31,9 +33,10 @@ func (r *userRepository) SelectByID(id uint) (*model.User, error) {
 	return &user, nil
 }
 
+// SelectByEmail はメールアドレスでユーザーを取得（大文字小文字は区別しない）
 func (r *userRepository) SelectByEmail(email string) (*model.User, error) {
 	var user model.User
-	err := r.db.Where("email = ?", email).First(&user).Error
+	err := r.db.Where("LOWER(email) = ?", strings.ToLower(email)).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -49,8 +52,23 @@ func (r *userRepository) Update(user *model.User) error {
 	return r.db.Save(user).Error
 }
 
+// Delete はユーザーを削除（プロジェクトメンバー・CSPアカウントメンバー・ベンダー担当者の割り当ても削除）
 func (r *userRepository) Delete(id uint) error {
-	return r.db.Delete(&model.User{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", id).Delete(&model.UserProjectRole{}).Error; err != nil {
+			return err
+		}
+		if err := deleteCSPAccountMembers(tx, "user_id = ?", id); err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", id).Delete(&model.VendorStaffAssignment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", id).Delete(&model.ProjectEnvironmentMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.User{}, id).Error
+	})
 }
 
 func (r *userRepository) SelectWithPagination(page, limit int) ([]model.User, *model.PaginationInfo, error) {
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"strings"
+
 	"go-nextjs-api/internal/database"
 	"go-nextjs-api/internal/interfaces"
 	"go-nextjs-api/internal/middleware"
@@ -16,6 +18,12 @@ func NewAuthService(userRepo interfaces.UserRepository) interfaces.AuthService {
 }
 
 func (s *authService) Register(req *model.RegisterRequest) (*model.AuthResponse, error) {
+	// メールアドレスは小文字で保存し、大文字小文字の違いで別のユーザーとして登録できないようにする
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if _, err := s.userRepo.SelectByEmail(email); err == nil {
+		return nil, model.ErrUserAlreadyExists
+	}
+
 	// パスワードをハッシュ化
 	hashedPassword, err := model.HashPassword(req.Password)
 	if err != nil {
@@ -25,7 +33,7 @@ func (s *authService) Register(req *model.RegisterRequest) (*model.AuthResponse,
 	// ユーザー作成
 	user := &model.User{
 		Name:     req.Name,
-		Email:    req.Email,
+		Email:    email,
 		Password: hashedPassword,
 	}
 
@@ -53,7 +61,7 @@ func (s *authService) Register(req *model.RegisterRequest) (*model.AuthResponse,
 
 func (s *authService) Login(req *model.LoginRequest) (*model.AuthResponse, error) {
 	// ユーザー検索
-	user, err := s.userRepo.SelectByEmail(req.Email)
+	user, err := s.userRepo.SelectByEmail(strings.TrimSpace(req.Email))
 	if err != nil {
 		return nil, err
 	}
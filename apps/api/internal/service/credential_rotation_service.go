@@ -0,0 +1,314 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// defaultCredentialGracePeriod はローテーション後に旧鍵を使える期間のデフォルト
+const defaultCredentialGracePeriod = 24 * time.Hour
+
+// defaultCredentialRetryInterval は定期実行で失敗したローテーション・旧鍵の失効を再試行するまでの間隔のデフォルト
+const defaultCredentialRetryInterval = 24 * time.Hour
+
+// maxCredentialMaxAgeDays はローテーションポリシーに設定できる最大有効日数の上限
+const maxCredentialMaxAgeDays = 365
+
+type credentialRotationService struct {
+	cspRepo         interfaces.CSPRepository
+	projectRepo     interfaces.ProjectRepository
+	providerDrivers interfaces.ProviderDrivers
+	notifier        interfaces.Notifier
+	gracePeriod     time.Duration
+	retryInterval   time.Duration
+}
+
+func NewCredentialRotationService(
+	cspRepo interfaces.CSPRepository,
+	projectRepo interfaces.ProjectRepository,
+	providerDrivers interfaces.ProviderDrivers,
+	notifier interfaces.Notifier,
+) interfaces.CredentialRotationService {
+	// 猶予期間はCSP_CREDENTIAL_GRACE_PERIOD（例: 12h、0で即時失効）で変更できる
+	gracePeriod := defaultCredentialGracePeriod
+	if v, err := time.ParseDuration(os.Getenv("CSP_CREDENTIAL_GRACE_PERIOD")); err == nil && v >= 0 {
+		gracePeriod = v
+	}
+
+	// 失敗後の再試行間隔はCSP_CREDENTIAL_RETRY_INTERVAL（例: 6h）で変更できる
+	retryInterval := defaultCredentialRetryInterval
+	if v, err := time.ParseDuration(os.Getenv("CSP_CREDENTIAL_RETRY_INTERVAL")); err == nil && v > 0 {
+		retryInterval = v
+	}
+
+	return &credentialRotationService{
+		cspRepo:         cspRepo,
+		projectRepo:     projectRepo,
+		providerDrivers: providerDrivers,
+		notifier:        notifier,
+		gracePeriod:     gracePeriod,
+		retryInterval:   retryInterval,
+	}
+}
+
+// GetRotationHistory はCSPアカウントの認証情報の発行・ローテーション履歴を取得
+func (s *credentialRotationService) GetRotationHistory(cspAccountID uint) ([]model.CSPAccountCredentialRotation, error) {
+	if _, err := s.getAccount(cspAccountID); err != nil {
+		return nil, err
+	}
+	return s.cspRepo.SelectCredentialRotationsByCSPAccountID(cspAccountID)
+}
+
+// UpdateRotationPolicy はCSPアカウントの認証情報の最大有効日数を設定（0またはnilで自動ローテーションを無効にする）
+func (s *credentialRotationService) UpdateRotationPolicy(cspAccountID, adminID uint, req *model.CredentialRotationPolicyRequest) (*model.CSPAccount, error) {
+	account, err := s.getAccount(cspAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	maxAgeDays := req.MaxAgeDays
+	if maxAgeDays != nil {
+		if *maxAgeDays < 0 || *maxAgeDays > maxCredentialMaxAgeDays {
+			return nil, model.ErrInvalidCredentialRotationPolicy
+		}
+		if *maxAgeDays == 0 {
+			maxAgeDays = nil
+		}
+	}
+	if maxAgeDays != nil && !supportsCredentialRotation(account.Provider) {
+		return nil, model.ErrCredentialRotationNotSupported
+	}
+
+	account.CredentialMaxAgeDays = maxAgeDays
+	if err := s.cspRepo.UpdateCSPAccount(account); err != nil {
+		return nil, err
+	}
+	return s.cspRepo.SelectCSPAccountByID(cspAccountID)
+}
+
+// RotateCredentials は管理者の操作で認証情報をローテーションする
+func (s *credentialRotationService) RotateCredentials(cspAccountID, adminID uint, req *model.CredentialRotationRequest) (*model.CSPAccountCredentialRotation, error) {
+	account, err := s.getAccount(cspAccountID)
+	if err != nil {
+		return nil, err
+	}
+	return s.rotate(account, model.CredentialRotationTriggerManual, &adminID, req.Reason)
+}
+
+// ProcessScheduledRotations は最大有効日数を過ぎた認証情報をローテーションし、猶予期間を過ぎた旧鍵を失効させる
+// 失敗したアカウントは再試行間隔を空けて再試行し（通知は失敗が続いている間は最初の1回のみ）、ローテーションした件数を返す
+func (s *credentialRotationService) ProcessScheduledRotations() (int, error) {
+	accounts, err := s.cspRepo.SelectCSPAccountsForCredentialRotation()
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	rotated := 0
+	for i := range accounts {
+		account := &accounts[i]
+
+		if account.PreviousCredentialExpired(now) && !s.retryPending(account.PreviousCredentialRevokeFailedAt, now) {
+			if err := s.revokePrevious(account); err != nil {
+				log.Printf("Failed to revoke previous credentials of CSP account %d: %v", account.ID, err)
+				s.recordFailure(account, &account.PreviousCredentialRevokeFailedAt, "旧認証情報の失効", err)
+			}
+		}
+
+		if account.Status != model.CSPAccountStatusActive || !account.CredentialRotationDue(now) {
+			continue
+		}
+		if s.retryPending(account.CredentialRotationFailedAt, now) {
+			continue
+		}
+		reason := fmt.Sprintf("認証情報が最大有効日数（%d日）を過ぎたため", *account.CredentialMaxAgeDays)
+		if _, err := s.rotate(account, model.CredentialRotationTriggerScheduled, nil, reason); err != nil {
+			log.Printf("Failed to rotate credentials of CSP account %d: %v", account.ID, err)
+			continue
+		}
+		rotated++
+	}
+
+	return rotated, nil
+}
+
+// rotate はプロバイダードライバーで新しい認証情報を発行し、使用中だった鍵を猶予期間の間だけ残す
+// 猶予期間中の別の旧鍵はドライバーが削除する。結果（成功・失敗）は履歴に記録し、失敗した場合は通知する
+func (s *credentialRotationService) rotate(account *model.CSPAccount, trigger model.CredentialRotationTrigger, rotatedBy *uint, reason string) (*model.CSPAccountCredentialRotation, error) {
+	if !supportsCredentialRotation(account.Provider) {
+		return nil, model.ErrCredentialRotationNotSupported
+	}
+	if account.Status != model.CSPAccountStatusActive {
+		return nil, model.ErrCSPAccountNotActive
+	}
+
+	rotation := &model.CSPAccountCredentialRotation{
+		CSPAccountID:  account.ID,
+		Trigger:       trigger,
+		Reason:        reason,
+		RotatedBy:     rotatedBy,
+		PreviousKeyID: account.CurrentCredentialKeyID(),
+	}
+
+	credentials, err := s.issueCredentials(account, rotation.PreviousKeyID)
+	if err != nil {
+		rotation.Status = model.CredentialRotationStatusFailed
+		rotation.Error = err.Error()
+		if insertErr := s.cspRepo.InsertCredentialRotation(rotation); insertErr != nil {
+			log.Printf("Failed to record credential rotation of CSP account %d: %v", account.ID, insertErr)
+		}
+		s.recordFailure(account, &account.CredentialRotationFailedAt, "認証情報のローテーション", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	// ドライバーが削除した猶予期間中の旧鍵は失効済みとして記録する
+	if account.PreviousCredentialKeyID != "" && account.PreviousCredentialKeyID != rotation.PreviousKeyID {
+		if err := s.cspRepo.MarkPreviousCredentialRevoked(account.ID, account.PreviousCredentialKeyID, now); err != nil {
+			log.Printf("Failed to record revocation of previous credentials of CSP account %d: %v", account.ID, err)
+		}
+	}
+
+	account.AccessKey = credentials.AccessKey
+	account.SecretKey = credentials.SecretKey
+	account.CredentialKeyID = credentials.KeyID
+	account.CredentialIssuedAt = &now
+	account.CredentialRotationFailedAt = nil
+	account.PreviousCredentialKeyID = ""
+	account.PreviousCredentialExpiresAt = nil
+	account.PreviousCredentialRevokeFailedAt = nil
+	if rotation.PreviousKeyID != "" {
+		expiresAt := now.Add(s.gracePeriod)
+		account.PreviousCredentialKeyID = rotation.PreviousKeyID
+		account.PreviousCredentialExpiresAt = &expiresAt
+		rotation.PreviousKeyExpiresAt = &expiresAt
+	}
+	if err := s.cspRepo.UpdateCSPAccount(account); err != nil {
+		return nil, err
+	}
+
+	rotation.Status = model.CredentialRotationStatusSucceeded
+	rotation.KeyID = credentials.KeyID
+	if err := s.cspRepo.InsertCredentialRotation(rotation); err != nil {
+		return nil, err
+	}
+
+	// 猶予期間を設けない場合は旧鍵をすぐに失効させる
+	if s.gracePeriod == 0 && account.PreviousCredentialKeyID != "" {
+		if err := s.revokePrevious(account); err != nil {
+			log.Printf("Failed to revoke previous credentials of CSP account %d: %v", account.ID, err)
+			s.recordFailure(account, &account.PreviousCredentialRevokeFailedAt, "旧認証情報の失効", err)
+		}
+	}
+
+	return rotation, nil
+}
+
+// issueCredentials はプロバイダードライバーで新しい認証情報を発行する
+func (s *credentialRotationService) issueCredentials(account *model.CSPAccount, keepKeyID string) (*model.ProviderCredentials, error) {
+	driver, err := s.providerDrivers.Driver(account.Provider)
+	if err != nil {
+		return nil, err
+	}
+	return driver.RotateCredentials(account.AccountID, keepKeyID)
+}
+
+// revokePrevious は猶予期間中の旧鍵をプロバイダー側で失効させ、CSPアカウントと履歴に反映する
+func (s *credentialRotationService) revokePrevious(account *model.CSPAccount) error {
+	driver, err := s.providerDrivers.Driver(account.Provider)
+	if err != nil {
+		return err
+	}
+	keyID := account.PreviousCredentialKeyID
+	if err := driver.RevokeCredentials(account.AccountID, keyID); err != nil {
+		return err
+	}
+
+	account.PreviousCredentialKeyID = ""
+	account.PreviousCredentialExpiresAt = nil
+	account.PreviousCredentialRevokeFailedAt = nil
+	if err := s.cspRepo.UpdateCSPAccount(account); err != nil {
+		return err
+	}
+	return s.cspRepo.MarkPreviousCredentialRevoked(account.ID, keyID, time.Now())
+}
+
+// retryPending は直近の失敗から再試行間隔が経っていないかどうかを判定
+func (s *credentialRotationService) retryPending(failedAt *time.Time, now time.Time) bool {
+	return failedAt != nil && now.Before(failedAt.Add(s.retryInterval))
+}
+
+// recordFailure は失敗日時を記録し、失敗が続いていない場合（最初の失敗）のみ通知する
+func (s *credentialRotationService) recordFailure(account *model.CSPAccount, failedAt **time.Time, operation string, cause error) {
+	firstFailure := *failedAt == nil
+	now := time.Now()
+	*failedAt = &now
+	if err := s.cspRepo.UpdateCSPAccount(account); err != nil {
+		log.Printf("Failed to record credential failure of CSP account %d: %v", account.ID, err)
+	}
+	if firstFailure {
+		s.notifyFailure(account, operation, cause)
+	}
+}
+
+// notifyFailure はローテーションの失敗をCSPアカウントの作成者と紐付くプロジェクトの管理者に通知する
+func (s *credentialRotationService) notifyFailure(account *model.CSPAccount, operation string, cause error) {
+	seen := make(map[string]bool)
+	var recipients []string
+	addRecipient := func(email string) {
+		if email != "" && !seen[email] {
+			seen[email] = true
+			recipients = append(recipients, email)
+		}
+	}
+
+	addRecipient(account.CreatedByUser.Email)
+	for _, relation := range account.ProjectCSPAccounts {
+		emails, err := s.projectRepo.SelectManagerEmails(relation.ProjectID)
+		if err != nil {
+			log.Printf("Failed to get managers of project %d: %v", relation.ProjectID, err)
+			continue
+		}
+		for _, email := range emails {
+			addRecipient(email)
+		}
+	}
+	if len(recipients) == 0 {
+		return
+	}
+
+	body := fmt.Sprintf("CSPアカウント「%s」（%s %s）の%sに失敗しました。\n\nエラー: %v\n\n現在の認証情報は引き続き有効です。原因を確認し、必要に応じて手動でローテーションしてください。\n成功するまで%sごとに再試行しますが、この通知は失敗が続いている間は再送しません。",
+		account.AccountName, account.Provider, account.AccountID, operation, cause, s.retryInterval)
+	if err := s.notifier.Send(&model.Notification{
+		To:      recipients,
+		Subject: fmt.Sprintf("[CGAS] %sに失敗しました（%s）", operation, account.AccountName),
+		Body:    body,
+	}); err != nil {
+		log.Printf("Failed to send credential rotation alert (CSP account %d): %v", account.ID, err)
+	}
+}
+
+func (s *credentialRotationService) getAccount(cspAccountID uint) (*model.CSPAccount, error) {
+	account, err := s.cspRepo.SelectCSPAccountByID(cspAccountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrCSPAccountNotFound
+		}
+		return nil, err
+	}
+	return account, nil
+}
+
+// supportsCredentialRotation はプロバイダーが認証情報のローテーションに対応しているかをレジストリの定義でチェック
+func supportsCredentialRotation(provider model.CSPProvider) bool {
+	definition, ok := model.LookupCSPProvider(provider)
+	return ok && definition.Supports(model.CSPProviderCapabilityCredentialRotation)
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// 一時的な認証情報の有効期間（AWSのロールの最大セッション時間の既定値に合わせて最大1時間）
+const (
+	defaultTemporaryCredentialDuration = time.Hour
+	minTemporaryCredentialDuration     = 15 * time.Minute
+	maxTemporaryCredentialDuration     = time.Hour
+)
+
+type credentialVendingService struct {
+	cspRepo         interfaces.CSPRepository
+	projectRepo     interfaces.ProjectRepository
+	providerDrivers interfaces.ProviderDrivers
+}
+
+func NewCredentialVendingService(
+	cspRepo interfaces.CSPRepository,
+	projectRepo interfaces.ProjectRepository,
+	providerDrivers interfaces.ProviderDrivers,
+) interfaces.CredentialVendingService {
+	return &credentialVendingService{
+		cspRepo:         cspRepo,
+		projectRepo:     projectRepo,
+		providerDrivers: providerDrivers,
+	}
+}
+
+// IssueTemporaryCredentials はCSPアカウントの有効なメンバーに、ロールに応じた権限の短期の認証情報を払い出す
+// 長期の認証情報は返さず、払い出しごとに記録を残す
+func (s *credentialVendingService) IssueTemporaryCredentials(userID, cspAccountID uint, req *model.TemporaryCredentialRequest, sourceIP string) (*model.TemporaryCredentials, error) {
+	duration := defaultTemporaryCredentialDuration
+	if req.DurationSeconds != 0 {
+		duration = time.Duration(req.DurationSeconds) * time.Second
+		if duration < minTemporaryCredentialDuration || duration > maxTemporaryCredentialDuration {
+			return nil, model.ErrInvalidCredentialDuration
+		}
+	}
+
+	member, err := s.cspRepo.SelectCSPAccountMemberByCSPAccountProjectAndUser(cspAccountID, req.ProjectID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotCSPAccountMember
+		}
+		return nil, err
+	}
+	if member.Status != string(model.CSPAccountMemberStatusActive) {
+		return nil, model.ErrNotCSPAccountMember
+	}
+
+	// プロジェクトとの紐付けが残っていて、プロジェクトが利用中であること
+	if _, err := s.cspRepo.SelectProjectCSPAccountByProjectAndCSPAccount(req.ProjectID, cspAccountID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrCSPAccountNotInProject
+		}
+		return nil, err
+	}
+	project, err := s.projectRepo.SelectByID(req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.Status.IsReadOnly() {
+		return nil, model.ErrProjectArchived
+	}
+
+	account := &member.CSPAccount
+	if account.Status != model.CSPAccountStatusActive {
+		return nil, model.ErrCSPAccountNotActive
+	}
+	definition, ok := model.LookupCSPProvider(account.Provider)
+	if !ok || !definition.Supports(model.CSPProviderCapabilityTemporaryCredentials) {
+		return nil, model.ErrTemporaryCredentialsNotSupported
+	}
+	driver, err := s.providerDrivers.Driver(account.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	role := model.CSPAccountMemberRole(member.Role)
+	credentials, err := driver.IssueTemporaryCredentials(account.AccountID, &model.TemporaryCredentialInput{
+		Role:        role,
+		SessionName: member.User.Email,
+		Duration:    duration,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue temporary credentials for %s account %s: %w", account.Provider, account.AccountID, err)
+	}
+	credentials.CSPAccountID = account.ID
+	credentials.Provider = account.Provider
+	credentials.AccountID = account.AccountID
+	credentials.Role = role
+
+	if err := s.cspRepo.InsertCredentialIssuance(&model.CSPCredentialIssuance{
+		CSPAccountID: account.ID,
+		ProjectID:    req.ProjectID,
+		UserID:       userID,
+		Role:         role,
+		ExpiresAt:    credentials.ExpiresAt,
+		SourceIP:     sourceIP,
+	}); err != nil {
+		// 記録できない払い出しは行わない
+		return nil, err
+	}
+
+	return credentials, nil
+}
+
+// GetIssuances はCSPアカウントの一時的な認証情報の払い出し記録を取得
+func (s *credentialVendingService) GetIssuances(cspAccountID uint) ([]model.CSPCredentialIssuance, error) {
+	if _, err := s.cspRepo.SelectCSPAccountByID(cspAccountID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrCSPAccountNotFound
+		}
+		return nil, err
+	}
+	return s.cspRepo.SelectCredentialIssuancesByCSPAccountID(cspAccountID)
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type cspLifecycleService struct {
+	cspRepo         interfaces.CSPRepository
+	cspService      interfaces.CSPService
+	providerDrivers interfaces.ProviderDrivers
+}
+
+func NewCSPLifecycleService(
+	cspRepo interfaces.CSPRepository,
+	cspService interfaces.CSPService,
+	providerDrivers interfaces.ProviderDrivers,
+) interfaces.CSPLifecycleService {
+	return &cspLifecycleService{
+		cspRepo:         cspRepo,
+		cspService:      cspService,
+		providerDrivers: providerDrivers,
+	}
+}
+
+// SuspendCSPAccount は利用中のCSPアカウントを一時停止する
+// 一時停止中は認証情報の払い出し・ローテーションを行わない
+func (s *cspLifecycleService) SuspendCSPAccount(cspAccountID, adminID uint, req *model.CSPAccountStatusChangeRequest) (*model.CSPAccount, error) {
+	return s.changeStatus(cspAccountID, adminID, model.CSPAccountStatusSuspended, req.Reason)
+}
+
+// ResumeCSPAccount は一時停止中のCSPアカウントの利用を再開する
+func (s *cspLifecycleService) ResumeCSPAccount(cspAccountID, adminID uint, req *model.CSPAccountStatusChangeRequest) (*model.CSPAccount, error) {
+	return s.changeStatus(cspAccountID, adminID, model.CSPAccountStatusActive, req.Reason)
+}
+
+func (s *cspLifecycleService) changeStatus(cspAccountID, adminID uint, next model.CSPAccountStatus, reason string) (*model.CSPAccount, error) {
+	account, err := s.getAccount(cspAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if !account.Status.CanTransitionTo(next) {
+		return nil, model.ErrInvalidCSPAccountStatusTransition
+	}
+
+	event := transitionCSPAccountStatus(account, next, strings.TrimSpace(reason), &adminID, time.Now())
+	if err := s.cspRepo.UpdateCSPAccountStatus(account, event); err != nil {
+		return nil, err
+	}
+	log.Printf("CSP account %d: %s -> %s, by userID=%d", account.ID, event.FromStatus, event.ToStatus, adminID)
+	return s.cspRepo.SelectCSPAccountByID(account.ID)
+}
+
+// GetClosureCheck はCSPアカウントを閉鎖できるかどうか（残っているメンバー・当月の費用）を確認する
+func (s *cspLifecycleService) GetClosureCheck(cspAccountID uint) (*model.CSPAccountClosureCheck, error) {
+	account, err := s.getAccount(cspAccountID)
+	if err != nil {
+		return nil, err
+	}
+	return s.closureCheck(account)
+}
+
+func (s *cspLifecycleService) closureCheck(account *model.CSPAccount) (*model.CSPAccountClosureCheck, error) {
+	check := &model.CSPAccountClosureCheck{
+		CSPAccountID:     account.ID,
+		Status:           account.Status,
+		ActiveMembers:    []model.CSPAccountMember{},
+		LinkedProjectIDs: []uint{},
+		Blockers:         []string{},
+	}
+	if !account.Status.CanTransitionTo(model.CSPAccountStatusClosing) {
+		check.Blockers = append(check.Blockers, model.ErrInvalidCSPAccountStatusTransition.Error())
+	}
+
+	members, err := s.cspRepo.SelectCSPAccountMembersByCSPAccountID(account.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		if member.Status == string(model.CSPAccountMemberStatusActive) {
+			check.ActiveMembers = append(check.ActiveMembers, member)
+		}
+	}
+	if len(check.ActiveMembers) > 0 {
+		check.Blockers = append(check.Blockers, model.ErrCSPAccountHasActiveMembers.Error())
+	}
+	for _, relation := range account.ProjectCSPAccounts {
+		check.LinkedProjectIDs = append(check.LinkedProjectIDs, relation.ProjectID)
+	}
+
+	// 当月の費用が発生している、または確認できない場合は、閉鎖時に費用の確認（acknowledge_cost）を求める
+	cost, err := s.currentMonthCost(account)
+	if err != nil {
+		check.CostError = err.Error()
+		check.RequiresCostAck = true
+	} else {
+		check.Cost = cost
+		check.RequiresCostAck = cost.Amount > 0
+	}
+
+	check.Closable = len(check.Blockers) == 0
+	return check, nil
+}
+
+func (s *cspLifecycleService) currentMonthCost(account *model.CSPAccount) (*model.ProviderCost, error) {
+	driver, err := s.providerDrivers.Driver(account.Provider)
+	if err != nil {
+		return nil, err
+	}
+	return driver.GetCurrentMonthCost(account.AccountID)
+}
+
+// CloseCSPAccount はメンバーが残っていないことと当月の費用を確認した上で、プロバイダー側のアカウントを閉鎖する
+// プロバイダーでの閉鎖が非同期の場合は閉鎖処理中として記録し、完了は状態の反映（定期実行・手動）で確定する
+// 閉鎖後もCSPアカウントとステータス遷移の履歴は記録として保持する
+func (s *cspLifecycleService) CloseCSPAccount(cspAccountID, adminID uint, req *model.CSPAccountClosureRequest) (*model.CSPAccount, error) {
+	account, err := s.getAccount(cspAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if !account.Status.CanTransitionTo(model.CSPAccountStatusClosing) {
+		return nil, model.ErrInvalidCSPAccountStatusTransition
+	}
+
+	check, err := s.closureCheck(account)
+	if err != nil {
+		return nil, err
+	}
+	if len(check.ActiveMembers) > 0 {
+		return nil, model.ErrCSPAccountHasActiveMembers
+	}
+	if check.RequiresCostAck && !req.AcknowledgeCost {
+		return nil, model.ErrCSPAccountCostNotAcknowledged
+	}
+
+	// プロビジョニングに対応していないプロバイダーのアカウントはドライバーで閉鎖できない
+	definition, ok := model.LookupCSPProvider(account.Provider)
+	if !ok || !definition.Supports(model.CSPProviderCapabilityProvisioning) {
+		return nil, model.ErrProvisioningNotSupported
+	}
+	driver, err := s.providerDrivers.Driver(account.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// プロバイダーに閉鎖を依頼する前に閉鎖処理中として記録し、その間の認証情報の払い出しやメンバーの追加を止める
+	reason := strings.TrimSpace(req.Reason)
+	if check.Cost != nil {
+		reason = fmt.Sprintf("%s（当月の費用: %.2f %s）", reason, check.Cost.Amount, check.Cost.Currency)
+	}
+	previousStatus := account.Status
+	account.ClosedBy = &adminID
+	event := transitionCSPAccountStatus(account, model.CSPAccountStatusClosing, reason, &adminID, time.Now())
+	if err := s.cspRepo.UpdateCSPAccountStatus(account, event); err != nil {
+		return nil, err
+	}
+
+	// プロバイダー側に既に存在しない場合はそのまま閉鎖済みにする
+	if err := driver.CloseAccount(account.AccountID); err != nil && !errors.Is(err, model.ErrProviderAccountNotFound) {
+		closeErr := fmt.Errorf("failed to close %s account %s: %w", account.Provider, account.AccountID, err)
+		// 閉鎖を依頼できなかったため元のステータスに戻し、失敗を履歴に残す
+		account.ClosedBy = nil
+		revert := transitionCSPAccountStatus(account, previousStatus, fmt.Sprintf("プロバイダーでの閉鎖に失敗したため元のステータスに戻しました: %v", err), &adminID, time.Now())
+		if revertErr := s.cspRepo.UpdateCSPAccountStatus(account, revert); revertErr != nil {
+			log.Printf("Failed to revert CSP account %d after closure failure: %v", account.ID, revertErr)
+		}
+		return nil, closeErr
+	}
+	log.Printf("CSP account %d: closure requested by userID=%d", account.ID, adminID)
+
+	// 即時に閉鎖されるプロバイダーではその場で閉鎖済みになる
+	closed, err := s.cspService.SyncCSPAccountStatus(account.ID)
+	if err != nil {
+		log.Printf("Failed to confirm closure of CSP account %d: %v", account.ID, err)
+		return s.cspRepo.SelectCSPAccountByID(account.ID)
+	}
+	return closed, nil
+}
+
+// GetLifecycleEvents はCSPアカウントのステータス遷移の履歴を取得
+func (s *cspLifecycleService) GetLifecycleEvents(cspAccountID uint) ([]model.CSPAccountLifecycleEvent, error) {
+	if _, err := s.getAccount(cspAccountID); err != nil {
+		return nil, err
+	}
+	return s.cspRepo.SelectLifecycleEventsByCSPAccountID(cspAccountID)
+}
+
+// ProcessClosingAccounts は閉鎖処理中のアカウントの状態をプロバイダーから反映し、閉鎖済みになった件数を返す
+func (s *cspLifecycleService) ProcessClosingAccounts() (int, error) {
+	accounts, err := s.cspRepo.SelectCSPAccountsByStatus(model.CSPAccountStatusClosing)
+	if err != nil {
+		return 0, err
+	}
+
+	closed := 0
+	for _, account := range accounts {
+		synced, err := s.cspService.SyncCSPAccountStatus(account.ID)
+		if err != nil {
+			log.Printf("Failed to sync closing CSP account %d: %v", account.ID, err)
+			continue
+		}
+		if synced.Status == model.CSPAccountStatusClosed {
+			closed++
+		}
+	}
+	return closed, nil
+}
+
+func (s *cspLifecycleService) getAccount(cspAccountID uint) (*model.CSPAccount, error) {
+	account, err := s.cspRepo.SelectCSPAccountByID(cspAccountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrCSPAccountNotFound
+		}
+		return nil, err
+	}
+	return account, nil
+}
+
+// transitionCSPAccountStatus はCSPアカウントのステータスを更新し、履歴に記録する遷移を返す
+// 閉鎖済みになったアカウントは保管していた認証情報を破棄し、ローテーションを止める
+func transitionCSPAccountStatus(account *model.CSPAccount, next model.CSPAccountStatus, reason string, changedBy *uint, now time.Time) *model.CSPAccountLifecycleEvent {
+	event := &model.CSPAccountLifecycleEvent{
+		CSPAccountID: account.ID,
+		FromStatus:   account.Status,
+		ToStatus:     next,
+		Reason:       reason,
+		ChangedBy:    changedBy,
+	}
+
+	account.Status = next
+	account.StatusReason = reason
+	account.StatusChangedAt = &now
+	if next == model.CSPAccountStatusClosed {
+		account.ClosedAt = &now
+		account.AccessKey = ""
+		account.SecretKey = ""
+		account.CredentialKeyID = ""
+		account.CredentialMaxAgeDays = nil
+		account.PreviousCredentialKeyID = ""
+		account.PreviousCredentialExpiresAt = nil
+	}
+	return event
+}
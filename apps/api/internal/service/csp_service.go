@@ -5,6 +5,9 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"log"
+	"time"
+
 	"go-nextjs-api/internal/interfaces"
 	"go-nextjs-api/internal/model"
 
@@ -12,20 +15,32 @@ import (
 )
 
 type cspService struct {
-	cspRepo     interfaces.CSPRepository
-	projectRepo interfaces.ProjectRepository
-	userRepo    interfaces.UserRepository
+	cspRepo         interfaces.CSPRepository
+	projectRepo     interfaces.ProjectRepository
+	userRepo        interfaces.UserRepository
+	environmentRepo interfaces.EnvironmentRepository
+	deletionService interfaces.DeletionService
+	providerDrivers interfaces.ProviderDrivers
+	memberSync      interfaces.MemberSyncService
 }
 
 func NewCSPService(
 	cspRepo interfaces.CSPRepository,
 	projectRepo interfaces.ProjectRepository,
 	userRepo interfaces.UserRepository,
+	environmentRepo interfaces.EnvironmentRepository,
+	deletionService interfaces.DeletionService,
+	providerDrivers interfaces.ProviderDrivers,
+	memberSync interfaces.MemberSyncService,
 ) interfaces.CSPService {
 	return &cspService{
-		cspRepo:     cspRepo,
-		projectRepo: projectRepo,
-		userRepo:    userRepo,
+		cspRepo:         cspRepo,
+		projectRepo:     projectRepo,
+		userRepo:        userRepo,
+		environmentRepo: environmentRepo,
+		deletionService: deletionService,
+		providerDrivers: providerDrivers,
+		memberSync:      memberSync,
 	}
 }
 
@@ -51,10 +66,20 @@ func (s *cspService) CreateCSPAccount(adminID uint, req *model.CSPAccountCreateR
 	// 管理者権限をチェック（簡易版）
 	// 実際の実装では適切な権限チェックを行う
 
-	// プロバイダーの有効性をチェック
-	if !req.Provider.IsValid() {
+	// プロバイダーの有効性とアカウントID・リージョンの形式をレジストリの定義でチェック
+	definition, ok := model.LookupCSPProvider(req.Provider)
+	if !ok {
 		return nil, model.ErrInvalidCSPProvider
 	}
+	if !definition.ValidateAccountID(req.AccountID) {
+		return nil, model.ErrInvalidCSPAccountID
+	}
+	if req.Region != "" && !definition.HasRegion(req.Region) {
+		return nil, model.ErrRegionNotOffered
+	}
+	if err := definition.ValidateAttributes(req.Attributes, true); err != nil {
+		return nil, err
+	}
 
 	cspAccount := &model.CSPAccount{
 		Provider:     req.Provider,
@@ -63,9 +88,14 @@ func (s *cspService) CreateCSPAccount(adminID uint, req *model.CSPAccountCreateR
 		AccessKey:    req.AccessKey,
 		SecretKey:    req.SecretKey,
 		Region:       req.Region,
-		Status:       "active",
+		Attributes:   req.Attributes,
+		Status:       model.CSPAccountStatusActive,
 		CreatedBy:    adminID,
 	}
+	if cspAccount.AccessKey != "" {
+		issuedAt := time.Now()
+		cspAccount.CredentialIssuedAt = &issuedAt
+	}
 
 	err := s.cspRepo.InsertCSPAccount(cspAccount)
 	if err != nil {
@@ -75,12 +105,196 @@ func (s *cspService) CreateCSPAccount(adminID uint, req *model.CSPAccountCreateR
 	return s.cspRepo.SelectCSPAccountByID(cspAccount.ID)
 }
 
+// ProvisionCSPAccount はプロバイダードライバーでアカウントを作成し、CSPアカウントとして登録する
+// 非同期で作成されるプロバイダーでは作成中（provisioning）として登録し、SyncCSPAccountStatusで完了を反映する
+func (s *cspService) ProvisionCSPAccount(creatorID uint, req *model.CSPAccountProvisionRequest) (*model.CSPAccount, error) {
+	definition, ok := model.LookupCSPProvider(req.Provider)
+	if !ok {
+		return nil, model.ErrInvalidCSPProvider
+	}
+	if !definition.Supports(model.CSPProviderCapabilityProvisioning) {
+		return nil, model.ErrProvisioningNotSupported
+	}
+	if req.Region != "" && !definition.HasRegion(req.Region) {
+		return nil, model.ErrRegionNotOffered
+	}
+	// 申請時点では未確定の属性（テナントIDなど）はドライバーが補完するため、必須属性の欠落は許容する
+	if err := definition.ValidateAttributes(req.Attributes, false); err != nil {
+		return nil, err
+	}
+
+	driver, err := s.providerDrivers.Driver(req.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	region := req.Region
+	if region == "" {
+		region = driver.DefaultRegion()
+	}
+
+	providerAccount, err := driver.CreateAccount(&model.ProviderAccountInput{
+		AccountName:  req.AccountName,
+		Region:       region,
+		ProjectID:    req.ProjectID,
+		CSPRequestID: req.CSPRequestID,
+		Attributes:   req.Attributes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s account: %w", req.Provider, err)
+	}
+
+	attributes := model.CSPAccountAttributes{}
+	for key, value := range req.Attributes {
+		attributes[key] = value
+	}
+	for key, value := range providerAccount.Attributes {
+		attributes[key] = value
+	}
+
+	cspAccount := &model.CSPAccount{
+		Provider:    req.Provider,
+		AccountName: req.AccountName,
+		AccountID:   providerAccount.AccountID,
+		Region:      region,
+		Attributes:  attributes,
+		Status:      model.CSPAccountStatusProvisioning.StatusFromProvider(providerAccount.Status),
+		CreatedBy:   creatorID,
+	}
+	if providerAccount.Credentials != nil {
+		issuedAt := time.Now()
+		cspAccount.AccessKey = providerAccount.Credentials.AccessKey
+		cspAccount.SecretKey = providerAccount.Credentials.SecretKey
+		cspAccount.CredentialKeyID = providerAccount.Credentials.KeyID
+		cspAccount.CredentialIssuedAt = &issuedAt
+	}
+
+	if err := s.cspRepo.InsertCSPAccount(cspAccount); err != nil {
+		// 登録できなかったアカウントがプロバイダー側に残らないよう閉鎖する
+		if providerAccount.Status == model.ProviderAccountStatusActive {
+			if closeErr := driver.CloseAccount(providerAccount.AccountID); closeErr != nil {
+				log.Printf("[WARN] Failed to close orphaned %s account %s: %v", req.Provider, providerAccount.AccountID, closeErr)
+			}
+		}
+		return nil, err
+	}
+
+	return s.cspRepo.SelectCSPAccountByID(cspAccount.ID)
+}
+
+// SyncCSPAccountStatus はプロバイダー側のアカウントの状態をCSPアカウントに反映する
+// 作成が完了したアカウントはアカウントIDを確定し、認証情報が未発行であれば発行する
+func (s *cspService) SyncCSPAccountStatus(id uint) (*model.CSPAccount, error) {
+	account, err := s.cspRepo.SelectCSPAccountByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrCSPAccountNotFound
+		}
+		return nil, err
+	}
+
+	// 閉鎖済み・作成に失敗したアカウントはプロバイダー側に存在しないため反映するものがない
+	if account.Status.IsTerminal() {
+		return account, nil
+	}
+
+	driver, err := s.providerDrivers.Driver(account.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	providerAccount, err := driver.GetAccount(account.AccountID)
+	if err != nil {
+		// 閉鎖処理中にプロバイダー側から削除されたアカウントは閉鎖済みとして扱う
+		if account.Status != model.CSPAccountStatusClosing || !errors.Is(err, model.ErrProviderAccountNotFound) {
+			return nil, err
+		}
+		providerAccount = &model.ProviderAccount{AccountID: account.AccountID, Status: model.ProviderAccountStatusClosed}
+	}
+
+	account.AccountID = providerAccount.AccountID
+	var transition *model.CSPAccountLifecycleEvent
+	if next := account.Status.StatusFromProvider(providerAccount.Status); next != account.Status {
+		transition = transitionCSPAccountStatus(account, next, fmt.Sprintf("プロバイダー側の状態（%s）を反映", providerAccount.Status), nil, time.Now())
+	}
+	var issued *model.CSPAccountCredentialRotation
+	if account.Status == model.CSPAccountStatusActive && account.AccessKey == "" {
+		credentials, err := driver.RotateCredentials(account.AccountID, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to issue credentials for %s account %s: %w", account.Provider, account.AccountID, err)
+		}
+		issuedAt := time.Now()
+		account.AccessKey = credentials.AccessKey
+		account.SecretKey = credentials.SecretKey
+		account.CredentialKeyID = credentials.KeyID
+		account.CredentialIssuedAt = &issuedAt
+		issued = &model.CSPAccountCredentialRotation{
+			CSPAccountID: account.ID,
+			Trigger:      model.CredentialRotationTriggerInitial,
+			Reason:       "アカウントの作成完了",
+			Status:       model.CredentialRotationStatusSucceeded,
+			KeyID:        credentials.KeyID,
+		}
+	}
+
+	if transition != nil {
+		err = s.cspRepo.UpdateCSPAccountStatus(account, transition)
+	} else {
+		err = s.cspRepo.UpdateCSPAccount(account)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if issued != nil {
+		if err := s.cspRepo.InsertCredentialRotation(issued); err != nil {
+			log.Printf("Failed to record credential issuance of CSP account %d: %v", account.ID, err)
+		}
+	}
+	return s.cspRepo.SelectCSPAccountByID(id)
+}
+
 func (s *cspService) UpdateCSPAccount(id uint, adminID uint, account *model.CSPAccount) (*model.CSPAccount, error) {
 	// 既存のアカウントを取得
 	existingAccount, err := s.cspRepo.SelectCSPAccountByID(id)
 	if err != nil {
 		return nil, err
 	}
+	// 閉鎖済みのアカウントは記録として保持し、変更しない
+	if existingAccount.Status == model.CSPAccountStatusClosed {
+		return nil, model.ErrCSPAccountClosed
+	}
+
+	// プロバイダー固有の属性をレジストリのスキーマで検証（未指定の場合は既存の属性を維持）
+	provider := account.Provider
+	if provider == "" {
+		provider = existingAccount.Provider
+	}
+	definition, ok := model.LookupCSPProvider(provider)
+	if !ok {
+		return nil, model.ErrInvalidCSPProvider
+	}
+	if account.Attributes == nil {
+		account.Attributes = existingAccount.Attributes
+	}
+	if err := definition.ValidateAttributes(account.Attributes, true); err != nil {
+		return nil, err
+	}
+
+	// 認証情報とローテーション状態はローテーションでのみ更新する
+	account.AccessKey = existingAccount.AccessKey
+	account.SecretKey = existingAccount.SecretKey
+	account.CredentialMaxAgeDays = existingAccount.CredentialMaxAgeDays
+	account.CredentialKeyID = existingAccount.CredentialKeyID
+	account.CredentialIssuedAt = existingAccount.CredentialIssuedAt
+	account.PreviousCredentialKeyID = existingAccount.PreviousCredentialKeyID
+	account.PreviousCredentialExpiresAt = existingAccount.PreviousCredentialExpiresAt
+
+	// ステータスはライフサイクルの遷移（一時停止・再開・閉鎖・状態の反映）でのみ更新する
+	account.Status = existingAccount.Status
+	account.StatusReason = existingAccount.StatusReason
+	account.StatusChangedAt = existingAccount.StatusChangedAt
+	account.ClosedAt = existingAccount.ClosedAt
+	account.ClosedBy = existingAccount.ClosedBy
 
 	// 管理者権限をチェック（簡易版）
 	// IDを設定して更新
@@ -95,6 +309,27 @@ func (s *cspService) UpdateCSPAccount(id uint, adminID uint, account *model.CSPA
 
 func (s *cspService) DeleteCSPAccount(id uint, adminID uint) error {
 	// 管理者権限をチェック（簡易版）
+	// 削除できるのはプロバイダーでの作成に失敗したアカウントのみ
+	// プロバイダー側に存在するアカウントは閉鎖の手続きを経て、閉鎖後も記録として保持する
+	account, err := s.cspRepo.SelectCSPAccountByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrCSPAccountNotFound
+		}
+		return err
+	}
+	switch account.Status {
+	case model.CSPAccountStatusFailed:
+	case model.CSPAccountStatusClosed:
+		return model.ErrCSPAccountRetained
+	default:
+		return model.ErrCSPAccountMustBeClosed
+	}
+
+	// 紐付け・メンバー・ベンダーへの委任は削除ポリシーに従って一緒に削除
+	if err := s.deletionService.EnsureCSPAccountDeletable(id); err != nil {
+		return err
+	}
 	return s.cspRepo.DeleteCSPAccount(id)
 }
 
@@ -116,20 +351,31 @@ func (s *cspService) GetProjectCSPAccountsByCSPAccountID(cspAccountID uint) ([]m
 	return s.cspRepo.SelectProjectCSPAccountsByCSPAccountID(cspAccountID)
 }
 
+// GetProjectCSPAccountReport はプロジェクトのタグ・カスタム属性で絞り込んだCSPアカウント関連一覧を取得
+func (s *cspService) GetProjectCSPAccountReport(filter *model.ProjectMetadataFilter) ([]model.ProjectCSPAccount, error) {
+	return s.cspRepo.SelectProjectCSPAccountsByProjectMetadata(filter)
+}
+
 func (s *cspService) CreateProjectCSPAccount(adminID uint, projectID, cspAccountID uint) (*model.ProjectCSPAccount, error) {
 	// 管理者権限をチェック（簡易版）
 
-	// プロジェクトの存在をチェック
-	_, err := s.projectRepo.SelectByID(projectID)
+	// プロジェクトの存在をチェック（アーカイブ済みは読み取り専用）
+	project, err := s.projectRepo.SelectByID(projectID)
 	if err != nil {
 		return nil, err
 	}
+	if project.Status.IsReadOnly() {
+		return nil, model.ErrProjectArchived
+	}
 
-	// CSPアカウントの存在をチェック
-	_, err = s.cspRepo.SelectCSPAccountByID(cspAccountID)
+	// CSPアカウントの存在をチェック（閉鎖済み・閉鎖処理中のアカウントは紐付けられない）
+	account, err := s.cspRepo.SelectCSPAccountByID(cspAccountID)
 	if err != nil {
 		return nil, err
 	}
+	if !account.Status.AcceptsChanges() {
+		return nil, model.ErrCSPAccountClosed
+	}
 
 	// 既に関連付けが存在していないかチェック
 	_, err = s.cspRepo.SelectProjectCSPAccountByProjectAndCSPAccount(projectID, cspAccountID)
@@ -190,11 +436,14 @@ func (s *cspService) CanUserManageProjectCSPAccount(userID, projectID uint) (boo
 	return true, nil
 }
 
-func (s *cspService) CanUserManageCSPAccountMember(userID, cspAccountID uint) (bool, error) {
-	// CSPアカウントメンバーを管理できる権限があるかチェック
-	// 実際の実装では適切な権限チェックを行う
-	// 今回は簡易版としてtrue
-	return true, nil
+// CanUserManageCSPAccountMember はメンバーが所属するプロジェクトの管理権限（オーナー・管理者）を持つかを返す
+// プロジェクトメンバーでない場合やベンダー委任のみの場合は管理不可とする
+func (s *cspService) CanUserManageCSPAccountMember(userID, projectID uint) (bool, error) {
+	role, err := s.projectRepo.SelectUserRole(projectID, userID)
+	if err != nil {
+		return false, err
+	}
+	return model.Role(role).CanManageProject(), nil
 }
 
 // CSPAccountMember related methods
@@ -220,17 +469,23 @@ func (s *cspService) GetCSPAccountMembersByUserID(userID uint) ([]model.CSPAccou
 }
 
 func (s *cspService) CreateCSPAccountMember(creatorID uint, req *model.CSPAccountMemberCreateRequest) (*model.CSPAccountMember, error) {
-	// CSPアカウントの存在をチェック
-	_, err := s.cspRepo.SelectCSPAccountByID(req.CSPAccountID)
+	// CSPアカウントの存在をチェック（閉鎖済み・閉鎖処理中のアカウントにはメンバーを追加できない）
+	account, err := s.cspRepo.SelectCSPAccountByID(req.CSPAccountID)
 	if err != nil {
 		return nil, err
 	}
+	if !account.Status.AcceptsChanges() {
+		return nil, model.ErrCSPAccountClosed
+	}
 
-	// プロジェクトの存在をチェック
-	_, err = s.projectRepo.SelectByID(req.ProjectID)
+	// プロジェクトの存在をチェック（アーカイブ済みは読み取り専用）
+	project, err := s.projectRepo.SelectByID(req.ProjectID)
 	if err != nil {
 		return nil, err
 	}
+	if project.Status.IsReadOnly() {
+		return nil, model.ErrProjectArchived
+	}
 
 	// ユーザーの存在をチェック
 	_, err = s.userRepo.SelectByID(req.UserID)
@@ -239,7 +494,7 @@ func (s *cspService) CreateCSPAccountMember(creatorID uint, req *model.CSPAccoun
 	}
 
 	// CSPアカウントとプロジェクトの関連があるかチェック
-	_, err = s.cspRepo.SelectProjectCSPAccountByProjectAndCSPAccount(req.ProjectID, req.CSPAccountID)
+	link, err := s.cspRepo.SelectProjectCSPAccountByProjectAndCSPAccount(req.ProjectID, req.CSPAccountID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("CSP account is not associated with this project")
@@ -247,6 +502,24 @@ func (s *cspService) CreateCSPAccountMember(creatorID uint, req *model.CSPAccoun
 		return nil, err
 	}
 
+	// 割り当て対象はプロジェクトメンバー、またはこのCSPアカウントへの割り当てを委任されたベンダー担当者
+	if err := s.ensureAssignableMember(req.ProjectID, req.CSPAccountID, req.UserID); err != nil {
+		return nil, err
+	}
+
+	// 環境に所属するCSPアカウントの場合、プロジェクトメンバーは環境のメンバーである必要がある
+	defaultSSOProvider, defaultRole := "default", "user"
+	if link.EnvironmentID != nil {
+		environment, err := s.environmentRepo.SelectByID(*link.EnvironmentID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.ensureEnvironmentMember(environment, req.ProjectID, req.UserID); err != nil {
+			return nil, err
+		}
+		defaultSSOProvider, defaultRole = environment.DefaultSSOProvider, string(environment.DefaultMemberRole)
+	}
+
 	// 既に同じユーザーが登録されていないかチェック
 	_, err = s.cspRepo.SelectCSPAccountMemberByCSPAccountProjectAndUser(req.CSPAccountID, req.ProjectID, req.UserID)
 	if err == nil {
@@ -262,12 +535,12 @@ func (s *cspService) CreateCSPAccountMember(creatorID uint, req *model.CSPAccoun
 		ssoEnabled = *req.SSOEnabled
 	}
 
-	ssoProvider := "default"
+	ssoProvider := defaultSSOProvider
 	if req.SSOProvider != "" {
 		ssoProvider = req.SSOProvider
 	}
 
-	role := "user"
+	role := defaultRole
 	if req.Role != "" {
 		role = req.Role
 	}
@@ -299,6 +572,7 @@ func (s *cspService) CreateCSPAccountMember(creatorID uint, req *model.CSPAccoun
 	if err != nil {
 		return nil, err
 	}
+	s.syncMemberEmail(member.CSPAccountID, member.SSOEmail)
 
 	// 作成されたメンバー情報を返す
 	return s.cspRepo.SelectCSPAccountMemberByID(member.ID)
@@ -311,8 +585,7 @@ func (s *cspService) UpdateCSPAccountMember(id uint, userID uint, req *model.CSP
 		return nil, err
 	}
 
-	// 権限チェック（簡易版）
-	hasAccess, err := s.CanUserManageCSPAccountMember(userID, existingMember.CSPAccountID)
+	hasAccess, err := s.CanUserManageCSPAccountMember(userID, existingMember.ProjectID)
 	if err != nil {
 		return nil, err
 	}
@@ -322,6 +595,17 @@ func (s *cspService) UpdateCSPAccountMember(id uint, userID uint, req *model.CSP
 			return nil, model.ErrInsufficientPermissions
 		}
 	}
+	// 自分自身のロール・ステータスは管理者であっても変更できない
+	roleChanged := req.Role != "" && req.Role != existingMember.Role
+	statusChanged := req.Status != "" && req.Status != existingMember.Status
+	if existingMember.UserID == userID && (roleChanged || statusChanged) {
+		return nil, model.ErrCannotChangeOwnCSPAccountMember
+	}
+
+	if err := ensureProjectWritable(s.projectRepo, existingMember.ProjectID); err != nil {
+		return nil, err
+	}
+	previousEmail := existingMember.ProviderEmail()
 
 	// フィールドを更新
 	if req.SSOEnabled != nil {
@@ -350,12 +634,31 @@ func (s *cspService) UpdateCSPAccountMember(id uint, userID uint, req *model.CSP
 		}
 	}
 
+	// メールアドレスを変更した場合は、変更前のメールアドレスのロールも外す（失敗しても定期実行で再試行できるよう先に記録）
+	emailChanged := existingMember.ProviderEmail() != previousEmail
+	if emailChanged {
+		if err := s.cspRepo.InsertCSPMemberRevocation(existingMember.CSPAccountID, previousEmail); err != nil {
+			return nil, err
+		}
+	}
+
+	existingMember.SyncStatus = model.CSPAccountMemberSyncStatusPending
 	err = s.cspRepo.UpdateCSPAccountMember(existingMember)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.cspRepo.SelectCSPAccountMemberByID(id)
+	s.syncMemberEmail(existingMember.CSPAccountID, existingMember.ProviderEmail())
+	var revokeErr error
+	if emailChanged {
+		revokeErr = s.syncMemberEmail(existingMember.CSPAccountID, previousEmail)
+	}
+
+	updated, err := s.cspRepo.SelectCSPAccountMemberByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return updated, revokeErr
 }
 
 func (s *cspService) DeleteCSPAccountMember(id uint, userID uint) error {
@@ -365,8 +668,7 @@ func (s *cspService) DeleteCSPAccountMember(id uint, userID uint) error {
 		return err
 	}
 
-	// 権限チェック（簡易版）
-	hasAccess, err := s.CanUserManageCSPAccountMember(userID, existingMember.CSPAccountID)
+	hasAccess, err := s.CanUserManageCSPAccountMember(userID, existingMember.ProjectID)
 	if err != nil {
 		return err
 	}
@@ -377,11 +679,79 @@ func (s *cspService) DeleteCSPAccountMember(id uint, userID uint) error {
 		}
 	}
 
-	return s.cspRepo.DeleteCSPAccountMember(id)
+	if err := ensureProjectWritable(s.projectRepo, existingMember.ProjectID); err != nil {
+		return err
+	}
+
+	if err := s.cspRepo.DeleteCSPAccountMember(id); err != nil {
+		return err
+	}
+	// 同じメールアドレスの有効なメンバーが残っていなければ、プロバイダー側のロールを外す
+	return s.syncMemberEmail(existingMember.CSPAccountID, existingMember.ProviderEmail())
+}
+
+// syncMemberEmail はメンバーの変更をプロバイダー側に反映する
+// 反映できなかった場合は反映状況を記録して定期実行で再試行するため、変更自体は失敗にしない
+// メンバーが残っていないメールアドレスのロールを外せなかった場合は、呼び出し元に知らせるためErrCSPMemberRevocationPendingを返す
+func (s *cspService) syncMemberEmail(cspAccountID uint, email string) error {
+	err := s.memberSync.SyncMemberEmail(cspAccountID, email)
+	if errors.Is(err, model.ErrCSPMemberRevocationPending) {
+		return err
+	}
+	if err != nil && !errors.Is(err, model.ErrCSPAccountNotActive) && !errors.Is(err, model.ErrProviderMembersNotSupported) {
+		log.Printf("Failed to sync CSP account %d member %s to the provider: %v", cspAccountID, email, err)
+	}
+	return nil
 }
 
 // Helper methods
 
+// ensureAssignableMember はユーザーをCSPアカウントメンバーとして割り当て可能かチェック
+func (s *cspService) ensureAssignableMember(projectID, cspAccountID, userID uint) error {
+	return ensureAssignableCSPAccountMember(s.projectRepo, projectID, cspAccountID, userID)
+}
+
+// ensureAssignableCSPAccountMember はユーザーをCSPアカウントのメンバーに割り当て可能かチェック
+func ensureAssignableCSPAccountMember(projectRepo interfaces.ProjectRepository, projectID, cspAccountID, userID uint) error {
+	isMember, err := projectRepo.IsMember(projectID, userID)
+	if err != nil {
+		return err
+	}
+	if isMember {
+		return nil
+	}
+
+	delegated, err := resolveDelegatedAccess(projectRepo, userID, projectID)
+	if err != nil {
+		return err
+	}
+	if !delegated.AllowsCSPAccountMember(cspAccountID) {
+		return model.ErrVendorPermissionNotGranted
+	}
+	return nil
+}
+
+// ensureEnvironmentMember はプロジェクトメンバーが環境のメンバーかどうかチェック
+// ベンダー担当者はCSPアカウント単位で委任されているため対象外
+func (s *cspService) ensureEnvironmentMember(environment *model.ProjectEnvironment, projectID, userID uint) error {
+	isMember, err := s.projectRepo.IsMember(projectID, userID)
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return nil
+	}
+
+	_, err = s.environmentRepo.SelectMember(environment.ID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrNotEnvironmentMember
+		}
+		return err
+	}
+	return nil
+}
+
 // generateRandomString はランダムな文字列を生成
 func (s *cspService) generateRandomString(length int) string {
 	bytes := make([]byte, length/2)
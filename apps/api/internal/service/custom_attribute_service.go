@@ -0,0 +1,103 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type customAttributeService struct {
+	customAttributeRepo interfaces.CustomAttributeRepository
+}
+
+func NewCustomAttributeService(customAttributeRepo interfaces.CustomAttributeRepository) interfaces.CustomAttributeService {
+	return &customAttributeService{
+		customAttributeRepo: customAttributeRepo,
+	}
+}
+
+// GetDefinitions は組織のカスタム属性定義一覧を取得
+func (s *customAttributeService) GetDefinitions(organizationID uint) ([]model.CustomAttributeDefinition, error) {
+	return s.customAttributeRepo.SelectDefinitionsByOrganizationID(organizationID)
+}
+
+// CreateDefinition は組織にカスタム属性定義を追加（管理者用）
+func (s *customAttributeService) CreateDefinition(organizationID uint, req *model.CustomAttributeDefinitionRequest) (*model.CustomAttributeDefinition, error) {
+	definition := &model.CustomAttributeDefinition{
+		OrganizationID: organizationID,
+		Key:            strings.TrimSpace(req.Key),
+		Label:          req.Label,
+		Description:    req.Description,
+		Type:           req.Type,
+		Required:       req.Required,
+		Options:        req.Options,
+	}
+	if err := definition.Validate(); err != nil {
+		return nil, err
+	}
+
+	_, err := s.customAttributeRepo.SelectDefinitionByKey(organizationID, definition.Key)
+	if err == nil {
+		return nil, model.ErrCustomAttributeDefinitionAlreadyExists
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if err := s.customAttributeRepo.InsertDefinition(definition); err != nil {
+		return nil, err
+	}
+
+	return definition, nil
+}
+
+// UpdateDefinition はカスタム属性定義を更新（管理者用）
+// 既存の属性値との整合性を保つため、キーと型は変更できない
+func (s *customAttributeService) UpdateDefinition(organizationID, definitionID uint, req *model.CustomAttributeDefinitionRequest) (*model.CustomAttributeDefinition, error) {
+	definition, err := s.getDefinition(organizationID, definitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.Key) != definition.Key || req.Type != definition.Type {
+		return nil, fmt.Errorf("%w: key and type cannot be changed", model.ErrInvalidCustomAttributeDefinition)
+	}
+
+	definition.Label = req.Label
+	definition.Description = req.Description
+	definition.Required = req.Required
+	definition.Options = req.Options
+	if err := definition.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.customAttributeRepo.UpdateDefinition(definition); err != nil {
+		return nil, err
+	}
+
+	return definition, nil
+}
+
+// DeleteDefinition はカスタム属性定義を削除（管理者用）
+// 既存プロジェクトの属性値も一緒に取り除く
+func (s *customAttributeService) DeleteDefinition(organizationID, definitionID uint) error {
+	if _, err := s.getDefinition(organizationID, definitionID); err != nil {
+		return err
+	}
+
+	return s.customAttributeRepo.DeleteDefinition(definitionID)
+}
+
+// getDefinition は組織に属するカスタム属性定義を取得
+func (s *customAttributeService) getDefinition(organizationID, definitionID uint) (*model.CustomAttributeDefinition, error) {
+	definition, err := s.customAttributeRepo.SelectDefinitionByID(definitionID)
+	if err != nil || definition.OrganizationID != organizationID {
+		return nil, model.ErrCustomAttributeDefinitionNotFound
+	}
+	return definition, nil
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+)
+
+type deletionService struct {
+	userRepo           interfaces.UserRepository
+	projectRepo        interfaces.ProjectRepository
+	cspRepo            interfaces.CSPRepository
+	deletionRepo       interfaces.DeletionRepository
+	provisioningClient interfaces.ProvisioningClient
+}
+
+func NewDeletionService(
+	userRepo interfaces.UserRepository,
+	projectRepo interfaces.ProjectRepository,
+	cspRepo interfaces.CSPRepository,
+	deletionRepo interfaces.DeletionRepository,
+	provisioningClient interfaces.ProvisioningClient,
+) interfaces.DeletionService {
+	return &deletionService{
+		userRepo:           userRepo,
+		projectRepo:        projectRepo,
+		cspRepo:            cspRepo,
+		deletionRepo:       deletionRepo,
+		provisioningClient: provisioningClient,
+	}
+}
+
+// PreviewProjectDeletion はプロジェクトを削除した場合の影響を取得（削除と同じくオーナーのみ）
+func (s *deletionService) PreviewProjectDeletion(userID, projectID uint) (*model.DeletionImpact, error) {
+	role, err := s.projectRepo.SelectUserRole(projectID, userID)
+	if err != nil || model.Role(role) != model.RoleOwner {
+		return nil, model.ErrInsufficientPermissions
+	}
+	return s.projectDeletionImpact(projectID)
+}
+
+// PreviewUserDeletion はユーザーを削除した場合の影響を取得（管理者用）
+func (s *deletionService) PreviewUserDeletion(userID uint) (*model.DeletionImpact, error) {
+	return s.userDeletionImpact(userID)
+}
+
+// PreviewCSPAccountDeletion はCSPアカウントを削除した場合の影響を取得（管理者用）
+func (s *deletionService) PreviewCSPAccountDeletion(cspAccountID uint) (*model.DeletionImpact, error) {
+	return s.cspAccountDeletionImpact(cspAccountID)
+}
+
+// EnsureProjectDeletable はブロックする依存レコードがなければnilを返す
+func (s *deletionService) EnsureProjectDeletable(projectID uint) error {
+	return ensureDeletable(s.projectDeletionImpact(projectID))
+}
+
+// EnsureUserDeletable はブロックする依存レコードがなければnilを返す
+func (s *deletionService) EnsureUserDeletable(userID uint) error {
+	return ensureDeletable(s.userDeletionImpact(userID))
+}
+
+// EnsureCSPAccountDeletable はブロックする依存レコードがなければnilを返す
+func (s *deletionService) EnsureCSPAccountDeletable(cspAccountID uint) error {
+	return ensureDeletable(s.cspAccountDeletionImpact(cspAccountID))
+}
+
+func ensureDeletable(impact *model.DeletionImpact, err error) error {
+	if err != nil {
+		return err
+	}
+	if !impact.Deletable {
+		return &model.DeletionBlockedError{Impact: impact}
+	}
+	return nil
+}
+
+func (s *deletionService) projectDeletionImpact(projectID uint) (*model.DeletionImpact, error) {
+	project, err := s.projectRepo.SelectByID(projectID)
+	if err != nil {
+		return nil, model.ErrProjectNotFound
+	}
+	dependents, err := s.deletionRepo.SelectProjectDependents(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	impact := model.NewDeletionImpact(model.DeletionTargetProject, project.ID, project.Name)
+
+	var members []model.DeletionDependencyItem
+	for _, member := range dependents.Members {
+		members = append(members, deletionItem(member.UserID, fmt.Sprintf("%s (%s)", member.User.Email, member.Role)))
+	}
+	impact.Add(model.DeletionServiceAPI, model.DeletionResourceProjectMembers, members)
+
+	var invitations []model.DeletionDependencyItem
+	for _, invitation := range dependents.Invitations {
+		invitations = append(invitations, deletionItem(invitation.ID, fmt.Sprintf("%s (%s)", invitation.Email, invitation.Role)))
+	}
+	impact.Add(model.DeletionServiceAPI, model.DeletionResourceProjectInvitations, invitations)
+
+	var relations []model.DeletionDependencyItem
+	for _, relation := range dependents.VendorRelations {
+		relations = append(relations, deletionItem(relation.ID, fmt.Sprintf("%s -> %s (%s)", relation.Project.Name, relation.VendorProject.Name, relation.Status)))
+	}
+	impact.Add(model.DeletionServiceAPI, model.DeletionResourceVendorRelations, relations)
+
+	var assignments []model.DeletionDependencyItem
+	for _, assignment := range dependents.StaffAssignments {
+		assignments = append(assignments, deletionItem(assignment.ID, fmt.Sprintf("%s (relation %d)", assignment.User.Email, assignment.RelationID)))
+	}
+	impact.Add(model.DeletionServiceAPI, model.DeletionResourceVendorStaffAssignments, assignments)
+
+	// 閉鎖されていないCSPアカウントが紐付いている間はアーカイブと同様に削除できない
+	var activeAccounts, links []model.DeletionDependencyItem
+	for _, link := range dependents.ProjectCSPAccounts {
+		item := deletionItem(link.ID, fmt.Sprintf("%s/%s (%s)", link.CSPAccount.Provider, link.CSPAccount.AccountName, link.CSPAccount.Status))
+		if !link.CSPAccount.Status.IsTerminal() {
+			activeAccounts = append(activeAccounts, item)
+		} else {
+			links = append(links, item)
+		}
+	}
+	impact.Add(model.DeletionServiceAPI, model.DeletionResourceActiveCSPAccounts, activeAccounts)
+	impact.Add(model.DeletionServiceAPI, model.DeletionResourceProjectCSPAccounts, links)
+
+	var cspMembers []model.DeletionDependencyItem
+	for _, member := range dependents.CSPAccountMembers {
+		cspMembers = append(cspMembers, deletionItem(member.ID, fmt.Sprintf("%s on %s/%s", member.User.Email, member.CSPAccount.Provider, member.CSPAccount.AccountName)))
+	}
+	impact.Add(model.DeletionServiceAPI, model.DeletionResourceCSPAccountMembers, cspMembers)
+
+	var environments []model.DeletionDependencyItem
+	for _, environment := range dependents.Environments {
+		environments = append(environments, deletionItem(environment.ID, environment.Name))
+	}
+	impact.Add(model.DeletionServiceAPI, model.DeletionResourceProjectEnvironments, environments)
+
+	s.addCSPRequestReferences(impact, projectID, "")
+	return impact, nil
+}
+
+func (s *deletionService) userDeletionImpact(userID uint) (*model.DeletionImpact, error) {
+	user, err := s.userRepo.SelectByID(userID)
+	if err != nil {
+		return nil, model.ErrUserNotFound
+	}
+	dependents, err := s.deletionRepo.SelectUserDependents(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	impact := model.NewDeletionImpact(model.DeletionTargetUser, user.ID, user.Email)
+
+	// オーナーのプロジェクトはオーナー権限を移譲するまで削除できない
+	var ownerships, memberships []model.DeletionDependencyItem
+	for _, membership := range dependents.Memberships {
+		item := deletionItem(membership.ProjectID, fmt.Sprintf("%s (%s)", membership.Project.Name, membership.Role))
+		if membership.Role == model.RoleOwner {
+			ownerships = append(ownerships, item)
+		} else {
+			memberships = append(memberships, item)
+		}
+	}
+	impact.Add(model.DeletionServiceAPI, model.DeletionResourceProjectOwnerships, ownerships)
+	impact.Add(model.DeletionServiceAPI, model.DeletionResourceProjectMembers, memberships)
+
+	var cspMembers []model.DeletionDependencyItem
+	for _, member := range dependents.CSPAccountMembers {
+		cspMembers = append(cspMembers, deletionItem(member.ID, fmt.Sprintf("%s/%s (project %d)", member.CSPAccount.Provider, member.CSPAccount.AccountName, member.ProjectID)))
+	}
+	impact.Add(model.DeletionServiceAPI, model.DeletionResourceCSPAccountMembers, cspMembers)
+
+	var assignments []model.DeletionDependencyItem
+	for _, assignment := range dependents.StaffAssignments {
+		assignments = append(assignments, deletionItem(assignment.ID, fmt.Sprintf("relation %d (project %d -> %d)", assignment.RelationID, assignment.Relation.ProjectID, assignment.Relation.VendorProjectID)))
+	}
+	impact.Add(model.DeletionServiceAPI, model.DeletionResourceVendorStaffAssignments, assignments)
+
+	var environmentMemberships []model.DeletionDependencyItem
+	for _, membership := range dependents.EnvironmentMemberships {
+		environmentMemberships = append(environmentMemberships, deletionItem(membership.ID, fmt.Sprintf("%s (project %d, %s)", membership.Environment.Name, membership.ProjectID, membership.Role)))
+	}
+	impact.Add(model.DeletionServiceAPI, model.DeletionResourceEnvironmentMemberships, environmentMemberships)
+
+	s.addCSPRequestReferences(impact, 0, user.Email)
+	return impact, nil
+}
+
+func (s *deletionService) cspAccountDeletionImpact(cspAccountID uint) (*model.DeletionImpact, error) {
+	account, err := s.cspRepo.SelectCSPAccountByID(cspAccountID)
+	if err != nil {
+		return nil, model.ErrCSPAccountNotFound
+	}
+	dependents, err := s.deletionRepo.SelectCSPAccountDependents(cspAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	impact := model.NewDeletionImpact(model.DeletionTargetCSPAccount, account.ID, string(account.Provider)+"/"+account.AccountName)
+
+	var links []model.DeletionDependencyItem
+	for _, link := range dependents.ProjectCSPAccounts {
+		links = append(links, deletionItem(link.ID, link.Project.Name))
+	}
+	impact.Add(model.DeletionServiceAPI, model.DeletionResourceProjectCSPAccounts, links)
+
+	var members []model.DeletionDependencyItem
+	for _, member := range dependents.CSPAccountMembers {
+		members = append(members, deletionItem(member.ID, fmt.Sprintf("%s (project %d)", member.User.Email, member.ProjectID)))
+	}
+	impact.Add(model.DeletionServiceAPI, model.DeletionResourceCSPAccountMembers, members)
+
+	// ベンダー紐付け・担当者への委任からは対象のアカウントだけを外す
+	var grants []model.DeletionDependencyItem
+	for _, relation := range dependents.VendorRelations {
+		grants = append(grants, model.DeletionDependencyItem{
+			ID:    "relation:" + strconv.FormatUint(uint64(relation.ID), 10),
+			Label: fmt.Sprintf("vendor relation %d (project %d -> %d)", relation.ID, relation.ProjectID, relation.VendorProjectID),
+		})
+	}
+	for _, assignment := range dependents.StaffAssignments {
+		grants = append(grants, model.DeletionDependencyItem{
+			ID:    "staff:" + strconv.FormatUint(uint64(assignment.ID), 10),
+			Label: fmt.Sprintf("staff assignment of user %d (relation %d)", assignment.UserID, assignment.RelationID),
+		})
+	}
+	impact.Add(model.DeletionServiceAPI, model.DeletionResourceVendorAccessGrants, grants)
+
+	return impact, nil
+}
+
+// addCSPRequestReferences はCSPプロビジョニングサービスの申請を未処理・処理済みに分けて追加する
+// 申請を確認できない場合は削除できないものとして扱う
+func (s *deletionService) addCSPRequestReferences(impact *model.DeletionImpact, projectID uint, email string) {
+	references, err := s.provisioningClient.ListCSPRequestReferences(projectID, email)
+	if err != nil {
+		log.Printf("Failed to load CSP request references for %s %d: %v", impact.TargetType, impact.TargetID, err)
+		impact.MarkProvisioningUnavailable()
+		return
+	}
+
+	var open, closed []model.DeletionDependencyItem
+	for i := range references {
+		reference := &references[i]
+		item := model.DeletionDependencyItem{
+			ID:    reference.ID,
+			Label: fmt.Sprintf("%s/%s (project %d, %s, %s)", reference.Provider, reference.AccountName, reference.ProjectID, reference.Status, reference.Relation),
+		}
+		if reference.IsOpen() {
+			open = append(open, item)
+		} else {
+			closed = append(closed, item)
+		}
+	}
+	impact.Add(model.DeletionServiceProvisioning, model.DeletionResourceOpenCSPRequests, open)
+	impact.Add(model.DeletionServiceProvisioning, model.DeletionResourceClosedCSPRequests, closed)
+}
+
+func deletionItem(id uint, label string) model.DeletionDependencyItem {
+	return model.DeletionDependencyItem{ID: strconv.FormatUint(uint64(id), 10), Label: label}
+}
@@ -0,0 +1,363 @@
+package service
+
+import (
+	"errors"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type environmentService struct {
+	userRepo        interfaces.UserRepository
+	projectRepo     interfaces.ProjectRepository
+	cspRepo         interfaces.CSPRepository
+	environmentRepo interfaces.EnvironmentRepository
+	projectService  interfaces.ProjectService
+}
+
+func NewEnvironmentService(
+	userRepo interfaces.UserRepository,
+	projectRepo interfaces.ProjectRepository,
+	cspRepo interfaces.CSPRepository,
+	environmentRepo interfaces.EnvironmentRepository,
+	projectService interfaces.ProjectService,
+) interfaces.EnvironmentService {
+	return &environmentService{
+		userRepo:        userRepo,
+		projectRepo:     projectRepo,
+		cspRepo:         cspRepo,
+		environmentRepo: environmentRepo,
+		projectService:  projectService,
+	}
+}
+
+// GetEnvironments はプロジェクトの環境一覧を取得（閲覧権限が必要）
+func (s *environmentService) GetEnvironments(userID, projectID uint) ([]model.ProjectEnvironment, error) {
+	if err := s.checkPermission(userID, projectID, false); err != nil {
+		return nil, err
+	}
+	return s.environmentRepo.SelectByProjectID(projectID)
+}
+
+// GetEnvironment は環境の設定と所属するCSPアカウント・メンバーを取得（閲覧権限が必要）
+func (s *environmentService) GetEnvironment(userID, projectID, environmentID uint) (*model.ProjectEnvironmentDetail, error) {
+	if err := s.checkPermission(userID, projectID, false); err != nil {
+		return nil, err
+	}
+	environment, err := s.loadEnvironment(projectID, environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.environmentRepo.SelectCSPAccounts(environment.ID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.environmentRepo.SelectMembers(environment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ProjectEnvironmentDetail{
+		ProjectEnvironment: *environment,
+		CSPAccounts:        links,
+		Members:            members,
+	}, nil
+}
+
+// GetEnvironmentSettings は環境の設定を取得（内部API用。CSPプロビジョニングサービスが申請の検証に使う）
+func (s *environmentService) GetEnvironmentSettings(projectID, environmentID uint) (*model.ProjectEnvironment, error) {
+	return s.loadEnvironment(projectID, environmentID)
+}
+
+// CreateEnvironment はプロジェクトに環境を追加（管理権限が必要）
+func (s *environmentService) CreateEnvironment(userID, projectID uint, req *model.ProjectEnvironmentRequest) (*model.ProjectEnvironment, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkPermission(userID, projectID, true); err != nil {
+		return nil, err
+	}
+	if err := s.ensureNameAvailable(projectID, req.Name, 0); err != nil {
+		return nil, err
+	}
+
+	environment := &model.ProjectEnvironment{ProjectID: projectID, CreatedBy: userID}
+	req.ApplyTo(environment)
+	if err := s.environmentRepo.Insert(environment); err != nil {
+		return nil, err
+	}
+	return environment, nil
+}
+
+// UpdateEnvironment は環境の設定を更新（管理権限が必要）
+// 許可リージョンを変更する場合、既に所属しているCSPアカウントのリージョンは許可されたままでなければならない
+func (s *environmentService) UpdateEnvironment(userID, projectID, environmentID uint, req *model.ProjectEnvironmentRequest) (*model.ProjectEnvironment, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkPermission(userID, projectID, true); err != nil {
+		return nil, err
+	}
+	environment, err := s.loadEnvironment(projectID, environmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureNameAvailable(projectID, req.Name, environment.ID); err != nil {
+		return nil, err
+	}
+
+	req.ApplyTo(environment)
+
+	links, err := s.environmentRepo.SelectCSPAccounts(environment.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		if !environment.AllowsRegion(link.CSPAccount.Region) {
+			return nil, model.ErrRegionNotAllowed
+		}
+	}
+
+	if err := s.environmentRepo.Update(environment); err != nil {
+		return nil, err
+	}
+	return environment, nil
+}
+
+// DeleteEnvironment は環境を削除（管理権限が必要。CSPアカウントが所属している間は削除できない）
+func (s *environmentService) DeleteEnvironment(userID, projectID, environmentID uint) error {
+	if err := s.checkPermission(userID, projectID, true); err != nil {
+		return err
+	}
+	environment, err := s.loadEnvironment(projectID, environmentID)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.environmentRepo.CountCSPAccounts(environment.ID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return model.ErrEnvironmentInUse
+	}
+
+	return s.environmentRepo.Delete(environment.ID)
+}
+
+// AddEnvironmentMember はプロジェクトメンバーに環境へのアクセスを付与（管理権限が必要）
+// 環境に所属するCSPアカウントのメンバーにも環境のデフォルト設定で追加する
+func (s *environmentService) AddEnvironmentMember(userID, projectID, environmentID uint, req *model.ProjectEnvironmentMemberRequest) (*model.ProjectEnvironmentMember, error) {
+	if err := s.checkPermission(userID, projectID, true); err != nil {
+		return nil, err
+	}
+	environment, err := s.loadEnvironment(projectID, environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.SelectByID(req.UserID)
+	if err != nil {
+		return nil, model.ErrUserNotFound
+	}
+	isMember, err := s.projectRepo.IsMember(projectID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, model.ErrUserNotProjectMember
+	}
+
+	_, err = s.environmentRepo.SelectMember(environment.ID, user.ID)
+	if err == nil {
+		return nil, model.ErrEnvironmentMemberAlreadyExists
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	role := req.Role
+	if role == "" {
+		role = environment.DefaultMemberRole
+	}
+	if !role.IsValid() {
+		return nil, model.ErrInvalidRole
+	}
+
+	member := &model.ProjectEnvironmentMember{
+		EnvironmentID: environment.ID,
+		ProjectID:     projectID,
+		UserID:        user.ID,
+		Role:          role,
+		CreatedBy:     userID,
+	}
+	member.User = *user
+
+	links, err := s.environmentRepo.SelectCSPAccounts(environment.ID)
+	if err != nil {
+		return nil, err
+	}
+	cspAccountMembers, err := s.cspAccountMembersFor(environment, links, []model.ProjectEnvironmentMember{*member}, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.environmentRepo.InsertMember(member, cspAccountMembers); err != nil {
+		return nil, err
+	}
+	return s.environmentRepo.SelectMember(environment.ID, user.ID)
+}
+
+// RemoveEnvironmentMember は環境へのアクセスを取り消す（管理権限が必要）
+// 環境に所属するCSPアカウントのメンバーからも外す
+func (s *environmentService) RemoveEnvironmentMember(userID, projectID, environmentID, memberUserID uint) error {
+	if err := s.checkPermission(userID, projectID, true); err != nil {
+		return err
+	}
+	environment, err := s.loadEnvironment(projectID, environmentID)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.environmentRepo.SelectMember(environment.ID, memberUserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrEnvironmentMemberNotFound
+		}
+		return err
+	}
+
+	return s.environmentRepo.DeleteMember(member)
+}
+
+// AssignCSPAccount はプロジェクトに紐付くCSPアカウントの所属環境を変更（管理権限が必要）
+// 環境に移す場合は環境の許可リージョンを満たす必要があり、環境のメンバーがCSPアカウントのメンバーに追加される
+// 環境から外しても既存のCSPアカウントメンバーはそのまま残る
+func (s *environmentService) AssignCSPAccount(userID, projectID, cspAccountID uint, req *model.ProjectCSPAccountEnvironmentRequest) (*model.ProjectCSPAccount, error) {
+	if err := s.checkPermission(userID, projectID, true); err != nil {
+		return nil, err
+	}
+
+	link, err := s.cspRepo.SelectProjectCSPAccountByProjectAndCSPAccount(projectID, cspAccountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrCSPAccountNotInProject
+		}
+		return nil, err
+	}
+
+	if err := s.moveCSPAccount(link, req.EnvironmentID, userID); err != nil {
+		return nil, err
+	}
+	return s.cspRepo.SelectProjectCSPAccountByID(link.ID)
+}
+
+// AttachProvisionedCSPAccount はCSP申請の承認で作成されたCSPアカウントを申請で指定された環境に所属させる（内部API用）
+func (s *environmentService) AttachProvisionedCSPAccount(creatorID, projectID, cspAccountID, environmentID uint) error {
+	link, err := s.cspRepo.SelectProjectCSPAccountByProjectAndCSPAccount(projectID, cspAccountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrCSPAccountNotInProject
+		}
+		return err
+	}
+	return s.moveCSPAccount(link, &environmentID, creatorID)
+}
+
+// moveCSPAccount はCSPアカウントを環境に移し、環境のメンバーをCSPアカウントのメンバーとして追加
+func (s *environmentService) moveCSPAccount(link *model.ProjectCSPAccount, environmentID *uint, actorID uint) error {
+	if environmentID == nil {
+		return s.environmentRepo.UpdateCSPAccountEnvironment(link, nil, nil)
+	}
+
+	environment, err := s.loadEnvironment(link.ProjectID, *environmentID)
+	if err != nil {
+		return err
+	}
+	if !environment.AllowsRegion(link.CSPAccount.Region) {
+		return model.ErrRegionNotAllowed
+	}
+
+	members, err := s.environmentRepo.SelectMembers(environment.ID)
+	if err != nil {
+		return err
+	}
+	cspAccountMembers, err := s.cspAccountMembersFor(environment, []model.ProjectCSPAccount{*link}, members, actorID)
+	if err != nil {
+		return err
+	}
+
+	return s.environmentRepo.UpdateCSPAccountEnvironment(link, &environment.ID, cspAccountMembers)
+}
+
+// cspAccountMembersFor は環境のメンバーを環境のCSPアカウントのメンバーとして追加する内容を作成（既に登録済みの組み合わせは除く）
+func (s *environmentService) cspAccountMembersFor(environment *model.ProjectEnvironment, links []model.ProjectCSPAccount, members []model.ProjectEnvironmentMember, createdBy uint) ([]model.CSPAccountMember, error) {
+	var cspAccountMembers []model.CSPAccountMember
+	for _, link := range links {
+		for _, member := range members {
+			_, err := s.cspRepo.SelectCSPAccountMemberByCSPAccountProjectAndUser(link.CSPAccountID, link.ProjectID, member.UserID)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err
+			}
+
+			cspAccountMembers = append(cspAccountMembers, model.CSPAccountMember{
+				CSPAccountID: link.CSPAccountID,
+				ProjectID:    link.ProjectID,
+				UserID:       member.UserID,
+				SSOEnabled:   true,
+				SSOProvider:  environment.DefaultSSOProvider,
+				SSOEmail:     member.User.Email,
+				Role:         string(member.Role),
+				Status:       string(model.CSPAccountMemberStatusActive),
+				CreatedBy:    createdBy,
+			})
+		}
+	}
+	return cspAccountMembers, nil
+}
+
+// checkPermission はプロジェクトの閲覧権限（manage=trueの場合は管理権限）を確認
+// 変更操作の場合はアーカイブ済みでないことも確認する
+func (s *environmentService) checkPermission(userID, projectID uint, manage bool) error {
+	permission, err := s.projectService.CheckProjectPermission(userID, projectID)
+	if err != nil {
+		return err
+	}
+	if !manage {
+		if !permission.CanView {
+			return model.ErrInsufficientPermissions
+		}
+		return nil
+	}
+	if !permission.CanManage {
+		return model.ErrInsufficientPermissions
+	}
+	return ensureProjectWritable(s.projectRepo, projectID)
+}
+
+// loadEnvironment はプロジェクトに属する環境を取得
+func (s *environmentService) loadEnvironment(projectID, environmentID uint) (*model.ProjectEnvironment, error) {
+	environment, err := s.environmentRepo.SelectByID(environmentID)
+	if err != nil || environment.ProjectID != projectID {
+		return nil, model.ErrEnvironmentNotFound
+	}
+	return environment, nil
+}
+
+// ensureNameAvailable はプロジェクト内で環境名が重複しないことを確認（excludeIDは更新対象の環境）
+func (s *environmentService) ensureNameAvailable(projectID uint, name string, excludeID uint) error {
+	existing, err := s.environmentRepo.SelectByProjectAndName(projectID, name)
+	if err == nil && existing.ID != excludeID {
+		return model.ErrEnvironmentAlreadyExists
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
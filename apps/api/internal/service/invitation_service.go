@@ -0,0 +1,407 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/middleware"
+	"go-nextjs-api/internal/model"
+
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+// invitationTTL は招待リンクの有効期間
+const invitationTTL = 7 * 24 * time.Hour
+
+type invitationService struct {
+	userRepo       interfaces.UserRepository
+	projectRepo    interfaces.ProjectRepository
+	projectService interfaces.ProjectService
+	authService    interfaces.AuthService
+	notifier       interfaces.Notifier
+	webAppURL      string
+}
+
+func NewInvitationService(
+	userRepo interfaces.UserRepository,
+	projectRepo interfaces.ProjectRepository,
+	projectService interfaces.ProjectService,
+	authService interfaces.AuthService,
+	notifier interfaces.Notifier,
+) interfaces.InvitationService {
+	webAppURL := os.Getenv("WEB_APP_URL")
+	if webAppURL == "" {
+		webAppURL = "http://localhost:3000"
+	}
+
+	return &invitationService{
+		userRepo:       userRepo,
+		projectRepo:    projectRepo,
+		projectService: projectService,
+		authService:    authService,
+		notifier:       notifier,
+		webAppURL:      strings.TrimRight(webAppURL, "/"),
+	}
+}
+
+// GetProjectInvitations はプロジェクトの招待一覧を取得（管理者以上）
+func (s *invitationService) GetProjectInvitations(userID, projectID uint, status model.InvitationStatus) ([]model.ProjectInvitationResponse, error) {
+	if _, err := s.requireManagePermission(userID, projectID); err != nil {
+		return nil, err
+	}
+
+	if status != "" && !status.IsValid() {
+		return nil, model.ErrInvalidInvitationStatus
+	}
+
+	invitations, err := s.projectRepo.SelectInvitationsByProjectID(projectID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	responses := make([]model.ProjectInvitationResponse, 0, len(invitations))
+	for i := range invitations {
+		responses = append(responses, model.NewProjectInvitationResponse(&invitations[i], now))
+	}
+	return responses, nil
+}
+
+// CreateInvitation はメールアドレス宛てにプロジェクト招待を作成して送信
+func (s *invitationService) CreateInvitation(inviterID, projectID uint, req *model.ProjectInvitationCreateRequest) (*model.ProjectInvitationResponse, error) {
+	permission, err := s.requireManagePermission(inviterID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ensureProjectWritable(s.projectRepo, projectID); err != nil {
+		return nil, err
+	}
+
+	if !req.Role.IsValid() {
+		return nil, model.ErrInvalidRole
+	}
+	// オーナー権限での招待はオーナーのみ可能
+	if req.Role == model.RoleOwner && permission.Role != model.RoleOwner {
+		return nil, model.ErrInsufficientPermissions
+	}
+
+	// 小文字で保存し、大文字小文字の違いで別の招待として扱わないようにする（ユーザーの検索も大文字小文字を区別しない）
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+
+	// 既にメンバーの場合は招待不要
+	if user, err := s.userRepo.SelectByEmail(email); err == nil {
+		isMember, err := s.projectRepo.IsMember(projectID, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if isMember {
+			return nil, model.ErrUserProjectRoleAlreadyExists
+		}
+	}
+
+	// 承諾待ちの招待が既にある場合は再送を促す
+	_, err = s.projectRepo.SelectPendingInvitationByEmail(projectID, email)
+	if err == nil {
+		return nil, model.ErrInvitationAlreadyExists
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	invitation := &model.ProjectInvitation{
+		ProjectID: projectID,
+		Email:     email,
+		Role:      req.Role,
+		Status:    model.InvitationStatusPending,
+		TokenID:   generateTokenID(),
+		ExpiresAt: time.Now().Add(invitationTTL),
+		InvitedBy: inviterID,
+	}
+
+	if err := s.projectRepo.InsertInvitation(invitation); err != nil {
+		return nil, err
+	}
+
+	// 送信に失敗しても招待自体は残し、再送で対応できるようにする
+	if err := s.sendInvitation(invitation); err != nil {
+		log.Printf("[ERROR] Failed to send invitation %d to %s: %v", invitation.ID, invitation.Email, err)
+	}
+
+	response := model.NewProjectInvitationResponse(invitation, time.Now())
+	return &response, nil
+}
+
+// ResendInvitation は招待トークンを再発行して再送（古いリンクは無効化）
+func (s *invitationService) ResendInvitation(userID, projectID, invitationID uint) (*model.ProjectInvitationResponse, error) {
+	if _, err := s.requireManagePermission(userID, projectID); err != nil {
+		return nil, err
+	}
+
+	invitation, err := s.getProjectInvitation(projectID, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if invitation.Status != model.InvitationStatusPending {
+		return nil, model.ErrInvitationNotPending
+	}
+
+	if err := ensureProjectWritable(s.projectRepo, projectID); err != nil {
+		return nil, err
+	}
+
+	invitation.TokenID = generateTokenID()
+	invitation.ExpiresAt = time.Now().Add(invitationTTL)
+	if err := s.projectRepo.UpdateInvitation(invitation); err != nil {
+		return nil, err
+	}
+
+	if err := s.sendInvitation(invitation); err != nil {
+		return nil, err
+	}
+
+	response := model.NewProjectInvitationResponse(invitation, time.Now())
+	return &response, nil
+}
+
+// RevokeInvitation は承諾待ちの招待を取り消す
+func (s *invitationService) RevokeInvitation(userID, projectID, invitationID uint) error {
+	if _, err := s.requireManagePermission(userID, projectID); err != nil {
+		return err
+	}
+
+	invitation, err := s.getProjectInvitation(projectID, invitationID)
+	if err != nil {
+		return err
+	}
+
+	if invitation.Status != model.InvitationStatusPending {
+		return model.ErrInvitationNotPending
+	}
+
+	invitation.Status = model.InvitationStatusRevoked
+	return s.projectRepo.UpdateInvitation(invitation)
+}
+
+// PreviewInvitation は招待トークンから招待内容を取得（認証不要）
+func (s *invitationService) PreviewInvitation(token string) (*model.InvitationPreviewResponse, error) {
+	invitation, err := s.resolveInvitation(token)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.userRepo.SelectByEmail(invitation.Email)
+	userRegistered := err == nil
+
+	return &model.InvitationPreviewResponse{
+		ProjectID:      invitation.ProjectID,
+		ProjectName:    invitation.Project.Name,
+		Email:          invitation.Email,
+		Role:           invitation.Role,
+		ExpiresAt:      invitation.ExpiresAt,
+		UserRegistered: userRegistered,
+	}, nil
+}
+
+// AcceptInvitation はログイン中のユーザーとして招待を承諾
+func (s *invitationService) AcceptInvitation(userID uint, token string) (*model.ProjectInvitationResponse, error) {
+	invitation, err := s.resolveInvitation(token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.SelectByID(userID)
+	if err != nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, model.ErrInvitationEmailMismatch
+	}
+
+	if err := ensureProjectWritable(s.projectRepo, invitation.ProjectID); err != nil {
+		return nil, err
+	}
+
+	isMember, err := s.projectRepo.IsMember(invitation.ProjectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if isMember {
+		return nil, model.ErrUserProjectRoleAlreadyExists
+	}
+
+	if err := s.projectRepo.AcceptInvitation(invitation, userID); err != nil {
+		return nil, err
+	}
+
+	response := model.NewProjectInvitationResponse(invitation, time.Now())
+	return &response, nil
+}
+
+// RegisterWithInvitation は招待リンクから未登録ユーザーを登録し、そのまま招待を承諾
+func (s *invitationService) RegisterWithInvitation(req *model.InvitationRegisterRequest) (*model.AuthResponse, error) {
+	invitation, err := s.resolveInvitation(req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userRepo.SelectByEmail(invitation.Email); err == nil {
+		return nil, model.ErrUserAlreadyExists
+	}
+
+	if err := ensureProjectWritable(s.projectRepo, invitation.ProjectID); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := model.HashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	// 招待先のメールアドレスで登録する（メールアドレスの所有確認を兼ねる）
+	// ユーザーの作成と招待の承諾は同一トランザクションで行い、承諾できなければ登録もしない
+	user := &model.User{
+		Name:     req.Name,
+		Email:    invitation.Email,
+		Password: hashedPassword,
+	}
+	if err := s.projectRepo.InsertUserWithInvitation(user, invitation); err != nil {
+		return nil, err
+	}
+
+	token, err := s.authService.RefreshToken(user)
+	if err != nil {
+		return nil, err
+	}
+	authResponse := &model.AuthResponse{
+		Token: token,
+		User:  *user,
+	}
+
+	projects, err := s.projectRepo.SelectUserProjects(user.ID, nil)
+	if err == nil {
+		authResponse.Projects = projects
+	}
+
+	return authResponse, nil
+}
+
+// requireManagePermission はプロジェクトの管理権限（owner/admin）を要求
+func (s *invitationService) requireManagePermission(userID, projectID uint) (*model.ProjectPermissionResponse, error) {
+	if _, err := s.projectRepo.SelectByID(projectID); err != nil {
+		return nil, model.ErrProjectNotFound
+	}
+
+	permission, err := s.projectService.CheckProjectPermission(userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if !permission.CanManage {
+		return nil, model.ErrInsufficientPermissions
+	}
+	return permission, nil
+}
+
+// getProjectInvitation はプロジェクトに属する招待を取得
+func (s *invitationService) getProjectInvitation(projectID, invitationID uint) (*model.ProjectInvitation, error) {
+	invitation, err := s.projectRepo.SelectInvitationByID(invitationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrInvitationNotFound
+		}
+		return nil, err
+	}
+	if invitation.ProjectID != projectID {
+		return nil, model.ErrInvitationNotFound
+	}
+	return invitation, nil
+}
+
+// resolveInvitation は招待トークンを検証し、承諾可能な招待を返す
+func (s *invitationService) resolveInvitation(token string) (*model.ProjectInvitation, error) {
+	claims, err := middleware.ValidateInvitationToken(token)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, model.ErrInvitationExpired
+		}
+		return nil, model.ErrInvalidInvitationToken
+	}
+
+	invitation, err := s.projectRepo.SelectInvitationByID(claims.InvitationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrInvitationNotFound
+		}
+		return nil, err
+	}
+
+	// 再送により置き換えられた古いトークンは受け付けない
+	if invitation.TokenID != claims.ID {
+		return nil, model.ErrInvalidInvitationToken
+	}
+	if invitation.Status != model.InvitationStatusPending {
+		return nil, model.ErrInvitationNotPending
+	}
+	if invitation.IsExpired(time.Now()) {
+		return nil, model.ErrInvitationExpired
+	}
+
+	return invitation, nil
+}
+
+// sendInvitation は招待メールを送信し、送信記録を更新
+func (s *invitationService) sendInvitation(invitation *model.ProjectInvitation) error {
+	token, err := middleware.GenerateInvitationToken(invitation.ID, invitation.Email, invitation.TokenID, invitation.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	projectName := invitation.Project.Name
+	if projectName == "" {
+		if details, err := s.projectRepo.SelectByID(invitation.ProjectID); err == nil {
+			projectName = details.Name
+		}
+	}
+
+	link := fmt.Sprintf("%s/invitations/accept?token=%s", s.webAppURL, url.QueryEscape(token))
+	body := fmt.Sprintf(
+		"プロジェクト「%s」に%sとして招待されました。\n\n以下のリンクから招待を承諾してください（有効期限: %s）。\n%s\n\nアカウントをお持ちでない場合は、リンク先から登録できます。",
+		projectName,
+		invitation.Role,
+		invitation.ExpiresAt.Format("2006-01-02 15:04"),
+		link,
+	)
+
+	if err := s.notifier.Send(&model.Notification{
+		To:      []string{invitation.Email},
+		Subject: fmt.Sprintf("[CGAS] プロジェクト「%s」への招待", projectName),
+		Body:    body,
+	}); err != nil {
+		return err
+	}
+
+	invitation.LastSentAt = time.Now()
+	invitation.SendCount++
+	return s.projectRepo.UpdateInvitation(invitation)
+}
+
+// generateTokenID はトークン識別子用のランダム文字列を生成
+func generateTokenID() string {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		// crypto/randが失敗するのは致命的な環境異常のみ
+		panic(fmt.Sprintf("failed to generate random token id: %v", err))
+	}
+	return hex.EncodeToString(bytes)
+}
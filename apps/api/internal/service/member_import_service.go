@@ -0,0 +1,331 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type memberImportService struct {
+	userRepo       interfaces.UserRepository
+	projectRepo    interfaces.ProjectRepository
+	cspRepo        interfaces.CSPRepository
+	projectService interfaces.ProjectService
+}
+
+func NewMemberImportService(
+	userRepo interfaces.UserRepository,
+	projectRepo interfaces.ProjectRepository,
+	cspRepo interfaces.CSPRepository,
+	projectService interfaces.ProjectService,
+) interfaces.MemberImportService {
+	return &memberImportService{
+		userRepo:       userRepo,
+		projectRepo:    projectRepo,
+		cspRepo:        cspRepo,
+		projectService: projectService,
+	}
+}
+
+// ImportProjectMembers はファイルの各行（メールアドレス・ロール）でプロジェクトメンバーを追加・ロール変更
+// ドライランの場合は変更せずに各行の処理内容とエラーを返す
+// オーナーの付与・変更はオーナー権限の移譲で行うため取り込み対象外とする
+func (s *memberImportService) ImportProjectMembers(userID, projectID uint, rows [][]string, dryRun bool) (*model.MemberImportResult, error) {
+	if err := s.requireManagePermission(userID, projectID); err != nil {
+		return nil, err
+	}
+	if err := ensureProjectWritable(s.projectRepo, projectID); err != nil {
+		return nil, err
+	}
+
+	importRows, err := model.ParseMemberImportRows(rows, model.ProjectMemberImportColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.MemberImportResult{DryRun: dryRun, Rows: []model.MemberImportRowResult{}}
+	seen := make(map[string]int)
+	for _, row := range importRows {
+		rowResult := model.MemberImportRowResult{
+			Row:   row.Number,
+			Email: row.Get(model.MemberImportColumnEmail),
+			Role:  strings.ToLower(row.Get(model.MemberImportColumnRole)),
+		}
+
+		if err := s.planProjectMemberRow(projectID, &rowResult, seen); err != nil {
+			rowResult.Action = model.MemberImportActionError
+			rowResult.Error = err.Error()
+		} else if !dryRun {
+			if err := s.applyProjectMemberRow(projectID, &rowResult); err != nil {
+				rowResult.Action = model.MemberImportActionError
+				rowResult.Error = err.Error()
+			}
+		}
+
+		result.AddRow(rowResult)
+	}
+
+	return result, nil
+}
+
+// planProjectMemberRow は1行分の処理内容を決定（エラーの場合は取り込まない）
+func (s *memberImportService) planProjectMemberRow(projectID uint, row *model.MemberImportRowResult, seen map[string]int) error {
+	if row.Email == "" {
+		return errors.New("email is required")
+	}
+	key := strings.ToLower(row.Email)
+	if previous, ok := seen[key]; ok {
+		return errors.New("duplicate of row " + strconv.Itoa(previous))
+	}
+	seen[key] = row.Row
+
+	role := model.Role(row.Role)
+	if !role.IsValid() {
+		return model.ErrInvalidRole
+	}
+	if role == model.RoleOwner {
+		return errors.New("owner role cannot be assigned by import; use ownership transfer")
+	}
+
+	user, err := s.userRepo.SelectByEmail(row.Email)
+	if err != nil {
+		return model.ErrUserNotFound
+	}
+
+	currentRole, err := s.projectRepo.SelectUserRole(projectID, user.ID)
+	if err != nil {
+		row.Action = model.MemberImportActionCreate
+		return nil
+	}
+	switch model.Role(currentRole) {
+	case role:
+		row.Action = model.MemberImportActionUnchanged
+	case model.RoleOwner:
+		return errors.New("owner's role cannot be changed by import; use ownership transfer")
+	default:
+		row.Action = model.MemberImportActionUpdate
+	}
+	return nil
+}
+
+// applyProjectMemberRow は決定した処理内容を反映
+func (s *memberImportService) applyProjectMemberRow(projectID uint, row *model.MemberImportRowResult) error {
+	user, err := s.userRepo.SelectByEmail(row.Email)
+	if err != nil {
+		return model.ErrUserNotFound
+	}
+
+	switch row.Action {
+	case model.MemberImportActionCreate:
+		return s.projectRepo.InsertMember(projectID, user.ID, row.Role)
+	case model.MemberImportActionUpdate:
+		return s.projectRepo.UpdateMemberRole(projectID, user.ID, row.Role)
+	}
+	return nil
+}
+
+// ExportProjectMembers はプロジェクトメンバーを取り込みと同じ形式（メールアドレス・ロール）で出力
+func (s *memberImportService) ExportProjectMembers(userID, projectID uint) ([][]string, error) {
+	if err := s.requireViewPermission(userID, projectID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.projectRepo.SelectAllProjectMembers(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{model.ProjectMemberImportColumns}
+	for _, member := range members {
+		rows = append(rows, []string{member.Email, string(member.Role)})
+	}
+	return rows, nil
+}
+
+// ImportCSPAccountMembers はファイルの各行（メールアドレス・CSPアカウントID・ロール）でCSPアカウントメンバーを追加・ロール変更
+// 対象はプロジェクトメンバー、または該当CSPアカウントへの割り当てを委任されたベンダー担当者に限る
+func (s *memberImportService) ImportCSPAccountMembers(userID, projectID uint, rows [][]string, dryRun bool) (*model.MemberImportResult, error) {
+	if err := s.requireManagePermission(userID, projectID); err != nil {
+		return nil, err
+	}
+	if err := ensureProjectWritable(s.projectRepo, projectID); err != nil {
+		return nil, err
+	}
+
+	importRows, err := model.ParseMemberImportRows(rows, model.CSPAccountMemberImportColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	// プロジェクトに紐付いたCSPアカウントのみ対象とする
+	projectAccounts, err := s.cspRepo.SelectProjectCSPAccountsByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	linkedAccounts := make(map[uint]bool, len(projectAccounts))
+	for _, projectAccount := range projectAccounts {
+		linkedAccounts[projectAccount.CSPAccountID] = true
+	}
+
+	result := &model.MemberImportResult{DryRun: dryRun, Rows: []model.MemberImportRowResult{}}
+	seen := make(map[string]int)
+	for _, row := range importRows {
+		rowResult := model.MemberImportRowResult{
+			Row:   row.Number,
+			Email: row.Get(model.MemberImportColumnEmail),
+			Role:  strings.ToLower(row.Get(model.MemberImportColumnRole)),
+		}
+		if rowResult.Role == "" {
+			rowResult.Role = string(model.CSPAccountMemberRoleUser)
+		}
+
+		plan, err := s.planCSPAccountMemberRow(projectID, row, &rowResult, linkedAccounts, seen)
+		if err != nil {
+			rowResult.Action = model.MemberImportActionError
+			rowResult.Error = err.Error()
+		} else if !dryRun {
+			if err := s.applyCSPAccountMemberRow(userID, projectID, plan, &rowResult); err != nil {
+				rowResult.Action = model.MemberImportActionError
+				rowResult.Error = err.Error()
+			}
+		}
+
+		result.AddRow(rowResult)
+	}
+
+	return result, nil
+}
+
+// cspAccountMemberImportPlan はCSPアカウントメンバー1行分の反映内容
+type cspAccountMemberImportPlan struct {
+	user     *model.User
+	existing *model.CSPAccountMember
+}
+
+// planCSPAccountMemberRow は1行分の処理内容を決定（エラーの場合は取り込まない）
+func (s *memberImportService) planCSPAccountMemberRow(projectID uint, row model.MemberImportRow, rowResult *model.MemberImportRowResult, linkedAccounts map[uint]bool, seen map[string]int) (*cspAccountMemberImportPlan, error) {
+	if rowResult.Email == "" {
+		return nil, errors.New("email is required")
+	}
+
+	cspAccountID, err := strconv.ParseUint(row.Get(model.MemberImportColumnCSPAccountID), 10, 32)
+	if err != nil {
+		return nil, errors.New("invalid csp_account_id")
+	}
+	id := uint(cspAccountID)
+	rowResult.CSPAccountID = &id
+
+	key := strings.ToLower(rowResult.Email) + "/" + strconv.FormatUint(cspAccountID, 10)
+	if previous, ok := seen[key]; ok {
+		return nil, errors.New("duplicate of row " + strconv.Itoa(previous))
+	}
+	seen[key] = rowResult.Row
+
+	if !model.CSPAccountMemberRole(rowResult.Role).IsValid() {
+		return nil, errors.New("invalid role")
+	}
+	if !linkedAccounts[id] {
+		return nil, model.ErrCSPAccountNotInProject
+	}
+
+	user, err := s.userRepo.SelectByEmail(rowResult.Email)
+	if err != nil {
+		return nil, model.ErrUserNotFound
+	}
+	if err := ensureAssignableCSPAccountMember(s.projectRepo, projectID, id, user.ID); err != nil {
+		return nil, err
+	}
+
+	plan := &cspAccountMemberImportPlan{user: user}
+	existing, err := s.cspRepo.SelectCSPAccountMemberByCSPAccountProjectAndUser(id, projectID, user.ID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		rowResult.Action = model.MemberImportActionCreate
+		return plan, nil
+	}
+
+	plan.existing = existing
+	if existing.Role == rowResult.Role {
+		rowResult.Action = model.MemberImportActionUnchanged
+	} else {
+		rowResult.Action = model.MemberImportActionUpdate
+	}
+	return plan, nil
+}
+
+// applyCSPAccountMemberRow は決定した処理内容を反映（新規追加時の既定値は個別追加と同じ）
+func (s *memberImportService) applyCSPAccountMemberRow(creatorID, projectID uint, plan *cspAccountMemberImportPlan, row *model.MemberImportRowResult) error {
+	switch row.Action {
+	case model.MemberImportActionCreate:
+		return s.cspRepo.InsertCSPAccountMember(&model.CSPAccountMember{
+			CSPAccountID: *row.CSPAccountID,
+			ProjectID:    projectID,
+			UserID:       plan.user.ID,
+			SSOEnabled:   true,
+			SSOProvider:  "default",
+			SSOEmail:     plan.user.Email,
+			Role:         row.Role,
+			Status:       "active",
+			CreatedBy:    creatorID,
+		})
+	case model.MemberImportActionUpdate:
+		// プロバイダー側へは定期実行で反映する
+		plan.existing.Role = row.Role
+		plan.existing.SyncStatus = model.CSPAccountMemberSyncStatusPending
+		return s.cspRepo.UpdateCSPAccountMember(plan.existing)
+	}
+	return nil
+}
+
+// ExportCSPAccountMembers はCSPアカウントメンバーを取り込みと同じ形式（メールアドレス・CSPアカウントID・ロール）で出力
+func (s *memberImportService) ExportCSPAccountMembers(userID, projectID uint) ([][]string, error) {
+	if err := s.requireViewPermission(userID, projectID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.cspRepo.SelectCSPAccountMembersByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{model.CSPAccountMemberImportColumns}
+	for _, member := range members {
+		// 削除済みユーザーのメンバー情報は出力しない
+		if member.User.Email == "" {
+			continue
+		}
+		rows = append(rows, []string{member.User.Email, strconv.FormatUint(uint64(member.CSPAccountID), 10), member.Role})
+	}
+	return rows, nil
+}
+
+// requireManagePermission はプロジェクトの管理権限をチェック
+func (s *memberImportService) requireManagePermission(userID, projectID uint) error {
+	permission, err := s.projectService.CheckProjectPermission(userID, projectID)
+	if err != nil {
+		return err
+	}
+	if !permission.CanManage {
+		return model.ErrInsufficientPermission
+	}
+	return nil
+}
+
+// requireViewPermission はプロジェクトの閲覧権限（メンバーであること）をチェック
+func (s *memberImportService) requireViewPermission(userID, projectID uint) error {
+	permission, err := s.projectService.CheckProjectPermission(userID, projectID)
+	if err != nil {
+		return err
+	}
+	if permission.Role == "" {
+		return model.ErrInsufficientPermission
+	}
+	return nil
+}
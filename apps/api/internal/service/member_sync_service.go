@@ -0,0 +1,254 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type memberSyncService struct {
+	cspRepo         interfaces.CSPRepository
+	providerDrivers interfaces.ProviderDrivers
+}
+
+func NewMemberSyncService(
+	cspRepo interfaces.CSPRepository,
+	providerDrivers interfaces.ProviderDrivers,
+) interfaces.MemberSyncService {
+	return &memberSyncService{
+		cspRepo:         cspRepo,
+		providerDrivers: providerDrivers,
+	}
+}
+
+// SyncCSPAccountMember はメンバーのメールアドレスについて、プロバイダー側のロールをプラットフォームの状態に合わせる
+// 反映に失敗した場合も反映状況を記録した上でエラーを返す
+func (s *memberSyncService) SyncCSPAccountMember(memberID uint) (*model.CSPAccountMember, error) {
+	member, err := s.cspRepo.SelectCSPAccountMemberByID(memberID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrCSPAccountMemberNotFound
+		}
+		return nil, err
+	}
+
+	syncErr := s.SyncMemberEmail(member.CSPAccountID, member.ProviderEmail())
+	updated, err := s.cspRepo.SelectCSPAccountMemberByID(memberID)
+	if err != nil {
+		return nil, err
+	}
+	return updated, syncErr
+}
+
+// SyncMemberEmail はCSPアカウントでメールアドレスに付与するロールを、同じメールアドレスのメンバー全体から決めて反映する
+// 有効なメンバーが残っていない場合（削除・無効化・メールアドレスの変更）はプロバイダー側のロールを外す
+func (s *memberSyncService) SyncMemberEmail(cspAccountID uint, email string) error {
+	account, err := s.cspRepo.SelectCSPAccountByID(cspAccountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrCSPAccountNotFound
+		}
+		return err
+	}
+	if account.Status != model.CSPAccountStatusActive {
+		return model.ErrCSPAccountNotActive
+	}
+	members, err := s.cspRepo.SelectCSPAccountMembersByCSPAccountID(cspAccountID)
+	if err != nil {
+		return err
+	}
+	driver, err := s.providerDrivers.Driver(account.Provider)
+	if err != nil {
+		return err
+	}
+
+	email = strings.ToLower(email)
+	var sameEmail []model.CSPAccountMember
+	for _, member := range members {
+		if member.ProviderEmail() == email {
+			sameEmail = append(sameEmail, member)
+		}
+	}
+	return s.syncEmail(driver, account, email, sameEmail)
+}
+
+// SyncCSPAccountMembers はCSPアカウントのすべてのメンバーを反映し直す
+func (s *memberSyncService) SyncCSPAccountMembers(cspAccountID uint) ([]model.CSPAccountMember, error) {
+	account, err := s.cspRepo.SelectCSPAccountByID(cspAccountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrCSPAccountNotFound
+		}
+		return nil, err
+	}
+	if account.Status != model.CSPAccountStatusActive {
+		return nil, model.ErrCSPAccountNotActive
+	}
+
+	if _, err := s.syncAccount(account, true); err != nil {
+		return nil, err
+	}
+	return s.cspRepo.SelectCSPAccountMembersByCSPAccountID(cspAccountID)
+}
+
+// ProcessPendingSync は利用中のCSPアカウントで未反映・反映に失敗したメンバーを反映し、反映できたメンバーの件数を返す
+// 利用中になったアカウント（作成完了・一時停止からの再開）のメンバーもここで反映される
+func (s *memberSyncService) ProcessPendingSync() (int, error) {
+	accounts, err := s.cspRepo.SelectCSPAccountsByStatus(model.CSPAccountStatusActive)
+	if err != nil {
+		return 0, err
+	}
+
+	synced := 0
+	for i := range accounts {
+		count, err := s.syncAccount(&accounts[i], false)
+		if err != nil {
+			log.Printf("Failed to sync members of CSP account %d: %v", accounts[i].ID, err)
+		}
+		synced += count
+	}
+	return synced, nil
+}
+
+// syncAccount はアカウントのメンバーをメールアドレスごとに反映し、反映できたメンバーの件数を返す
+// allがfalseの場合は反映済み・メンバーの管理に対応していないメールアドレスは反映し直さない
+func (s *memberSyncService) syncAccount(account *model.CSPAccount, all bool) (int, error) {
+	members, err := s.cspRepo.SelectCSPAccountMembersByCSPAccountID(account.ID)
+	if err != nil {
+		return 0, err
+	}
+	revocations, err := s.cspRepo.SelectCSPMemberRevocationsByCSPAccountID(account.ID)
+	if err != nil {
+		return 0, err
+	}
+	driver, err := s.providerDrivers.Driver(account.Provider)
+	if err != nil {
+		return 0, err
+	}
+
+	byEmail := make(map[string][]model.CSPAccountMember)
+	var emails []string
+	for _, member := range members {
+		email := member.ProviderEmail()
+		if email == "" {
+			continue
+		}
+		if _, ok := byEmail[email]; !ok {
+			emails = append(emails, email)
+		}
+		byEmail[email] = append(byEmail[email], member)
+	}
+	// 削除・メールアドレスの変更でロールを外す必要があるメールアドレスは、反映できるまで毎回反映する
+	revoking := make(map[string]bool)
+	for _, revocation := range revocations {
+		if _, ok := byEmail[revocation.Email]; !ok {
+			emails = append(emails, revocation.Email)
+		}
+		revoking[revocation.Email] = true
+	}
+
+	synced := 0
+	var lastErr error
+	for _, email := range emails {
+		sameEmail := byEmail[email]
+		if !all && !revoking[email] && !needsSync(sameEmail) {
+			continue
+		}
+		err := s.syncEmail(driver, account, email, sameEmail)
+		if errors.Is(err, model.ErrProviderMembersNotSupported) {
+			// プロバイダーの設定が変わるまでは、他のメールアドレスも同じ結果になる
+			return synced, nil
+		}
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		synced += len(sameEmail)
+	}
+	return synced, lastErr
+}
+
+// syncEmail はメールアドレスに付与するロールをプロバイダー側に反映し、同じメールアドレスのメンバーに反映状況を記録する
+// メンバーが残っていないメールアドレスの反映に失敗した場合は、ロールを外す対象として記録して再試行する
+func (s *memberSyncService) syncEmail(driver interfaces.ProviderDriver, account *model.CSPAccount, email string, members []model.CSPAccountMember) error {
+	if email == "" {
+		return nil
+	}
+
+	var err error
+	if granted := desiredProviderMember(members); granted != nil {
+		err = driver.GrantAccountMember(account.AccountID, &model.ProviderAccountMember{
+			Email: email,
+			Role:  model.CSPAccountMemberRole(granted.Role),
+		})
+	} else {
+		err = driver.RevokeAccountMember(account.AccountID, email)
+	}
+
+	status, syncError := model.CSPAccountMemberSyncStatusSynced, ""
+	switch {
+	case errors.Is(err, model.ErrProviderMembersNotSupported):
+		status = model.CSPAccountMemberSyncStatusNotSupported
+	case err != nil:
+		status, syncError = model.CSPAccountMemberSyncStatusFailed, err.Error()
+	}
+
+	now := time.Now()
+	if len(members) > 0 {
+		ids := make([]uint, 0, len(members))
+		for _, member := range members {
+			ids = append(ids, member.ID)
+		}
+		if updateErr := s.cspRepo.UpdateCSPAccountMemberSyncStatus(ids, status, syncError, now); updateErr != nil {
+			return updateErr
+		}
+	}
+
+	var recordErr error
+	switch {
+	case status != model.CSPAccountMemberSyncStatusFailed:
+		recordErr = s.cspRepo.DeleteCSPMemberRevocation(account.ID, email)
+	case len(members) == 0:
+		recordErr = s.cspRepo.UpdateCSPMemberRevocationFailure(account.ID, email, syncError, now)
+	}
+	if recordErr != nil {
+		return recordErr
+	}
+	if err != nil && len(members) == 0 && !errors.Is(err, model.ErrProviderMembersNotSupported) {
+		return fmt.Errorf("%w: %v", model.ErrCSPMemberRevocationPending, err)
+	}
+	return err
+}
+
+// needsSync は同じメールアドレスのメンバーに未反映・反映に失敗したメンバーがいるかどうかを判定
+func needsSync(members []model.CSPAccountMember) bool {
+	for _, member := range members {
+		if member.SyncStatus != model.CSPAccountMemberSyncStatusSynced && member.SyncStatus != model.CSPAccountMemberSyncStatusNotSupported {
+			return true
+		}
+	}
+	return false
+}
+
+// desiredProviderMember は同じメールアドレスのメンバーのうち、プロバイダー側のロールを決めるメンバーを返す
+// 有効でSSOが有効なメンバーが対象で、複数のプロジェクトにいる場合はadminのロールを優先する（対象がいない場合はnil）
+func desiredProviderMember(members []model.CSPAccountMember) *model.CSPAccountMember {
+	var granted *model.CSPAccountMember
+	for i := range members {
+		member := &members[i]
+		if member.Status != string(model.CSPAccountMemberStatusActive) || !member.SSOEnabled {
+			continue
+		}
+		if granted == nil || (granted.Role != string(model.CSPAccountMemberRoleAdmin) && member.Role == string(model.CSPAccountMemberRoleAdmin)) {
+			granted = member
+		}
+	}
+	return granted
+}
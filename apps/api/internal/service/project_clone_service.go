@@ -0,0 +1,258 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+)
+
+type projectCloneService struct {
+	userRepo            interfaces.UserRepository
+	projectRepo         interfaces.ProjectRepository
+	cspRepo             interfaces.CSPRepository
+	customAttributeRepo interfaces.CustomAttributeRepository
+	projectService      interfaces.ProjectService
+	provisioningClient  interfaces.ProvisioningClient
+	notifier            interfaces.Notifier
+}
+
+func NewProjectCloneService(
+	userRepo interfaces.UserRepository,
+	projectRepo interfaces.ProjectRepository,
+	cspRepo interfaces.CSPRepository,
+	customAttributeRepo interfaces.CustomAttributeRepository,
+	projectService interfaces.ProjectService,
+	provisioningClient interfaces.ProvisioningClient,
+	notifier interfaces.Notifier,
+) interfaces.ProjectCloneService {
+	return &projectCloneService{
+		userRepo:            userRepo,
+		projectRepo:         projectRepo,
+		cspRepo:             cspRepo,
+		customAttributeRepo: customAttributeRepo,
+		projectService:      projectService,
+		provisioningClient:  provisioningClient,
+		notifier:            notifier,
+	}
+}
+
+// CloneProject は既存プロジェクトを複製して新しいプロジェクトを作成する
+// 複製を実行したユーザーがオーナーとなり、メンバー・ベンダー紐付けはプロジェクトと同一トランザクションで作成する
+// CSP申請はプロジェクト作成後に申請する（失敗してもプロジェクト作成は取り消さず、各項目の結果をレスポンスに含める）
+func (s *projectCloneService) CloneProject(userID, sourceProjectID uint, req *model.ProjectCloneRequest) (*model.ProjectResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.SelectByID(userID)
+	if err != nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	permission, err := s.projectService.CheckProjectPermission(userID, sourceProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if !permission.CanManage {
+		return nil, model.ErrInsufficientPermissions
+	}
+
+	source, err := s.projectRepo.SelectByID(sourceProjectID)
+	if err != nil {
+		return nil, model.ErrProjectNotFound
+	}
+	if source.ProjectType == model.ProjectTypeAdmin {
+		return nil, model.ErrProjectNotCloneable
+	}
+
+	include := req.Include
+	if len(include) == 0 {
+		include = model.DefaultProjectCloneItems
+	}
+	result := &model.ProjectCloneResult{SourceProjectID: source.ID, Include: include}
+
+	organizationID := source.OrganizationID
+	if req.OrganizationID != nil {
+		organizationID = *req.OrganizationID
+	}
+
+	// 複製元がアーカイブ済みでも、複製したプロジェクトは有効な状態で作成する
+	project := model.Project{
+		Name:             req.Name,
+		Status:           model.ProjectStatusActive,
+		OrganizationID:   organizationID,
+		ProjectType:      source.ProjectType,
+		Tags:             model.ProjectTags{},
+		CustomAttributes: model.CustomAttributeValues{},
+	}
+	if req.Includes(model.ProjectCloneMetadata) {
+		project.Description = source.Description
+		for key, value := range source.Tags {
+			project.Tags[key] = value
+		}
+		for key, value := range source.CustomAttributes {
+			project.CustomAttributes[key] = value
+		}
+	}
+	if req.Description != nil {
+		project.Description = *req.Description
+	}
+
+	// 組織を変更した場合は複製先の組織のカスタム属性定義で検証する
+	definitions, err := s.customAttributeRepo.SelectDefinitionsByOrganizationID(organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := model.ValidateCustomAttributes(definitions, project.CustomAttributes); err != nil {
+		return nil, err
+	}
+
+	members := []model.UserProjectRole{{UserID: userID, Role: model.RoleOwner}}
+	if req.Includes(model.ProjectCloneMembers) {
+		cloned, err := s.cloneMembers(source.ID, userID, result)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, cloned...)
+	}
+
+	var vendorRelations []model.ProjectVendorRelation
+	if req.Includes(model.ProjectCloneVendorRelations) {
+		if source.ProjectType == model.ProjectTypeVendor {
+			result.AddStep(model.TemplateStepVendorRelation, source.Name, model.TemplateStepSkipped, "vendor projects cannot have vendor relations")
+		} else {
+			vendorRelations, err = s.cloneVendorRelations(source.ID, userID, result)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.projectRepo.InsertWithSetup(&project, members, vendorRelations); err != nil {
+		return nil, err
+	}
+
+	// 複製したベンダー紐付けは提案として作成されるため、双方に通知する
+	for i := range vendorRelations {
+		notifyVendorRelationEvent(s.projectRepo, s.notifier, &vendorRelations[i])
+	}
+
+	if req.Includes(model.ProjectCloneCSPRequests) {
+		s.fileCSPRequests(source.ID, project.ID, user.Email, req, result)
+	}
+
+	return &model.ProjectResponse{
+		ID:               project.ID,
+		Name:             project.Name,
+		Description:      project.Description,
+		Status:           project.Status,
+		OrganizationID:   project.OrganizationID,
+		ProjectType:      project.ProjectType,
+		Tags:             project.Tags,
+		CustomAttributes: project.CustomAttributes,
+		CreatedAt:        project.CreatedAt,
+		UpdatedAt:        project.UpdatedAt,
+		UserRole:         model.RoleOwner,
+		CloneResult:      result,
+	}, nil
+}
+
+// cloneMembers は複製元のメンバーとロールを複製する
+// オーナーは複製を実行したユーザーのみとするため、複製元のオーナーは管理者として追加する
+func (s *projectCloneService) cloneMembers(sourceProjectID, ownerID uint, result *model.ProjectCloneResult) ([]model.UserProjectRole, error) {
+	sourceMembers, err := s.projectRepo.SelectAllProjectMembers(sourceProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []model.UserProjectRole
+	for _, member := range sourceMembers {
+		if member.UserID == ownerID {
+			continue
+		}
+		role := member.Role
+		message := "added as " + string(role)
+		if role == model.RoleOwner {
+			role = model.RoleAdmin
+			message = "added as admin (owner of the source project)"
+		}
+		members = append(members, model.UserProjectRole{UserID: member.UserID, Role: role})
+		result.AddStep(model.TemplateStepMember, member.Email, model.TemplateStepApplied, message)
+	}
+	return members, nil
+}
+
+// cloneVendorRelations は複製元の継続中のベンダー紐付けを、契約期間・委任権限を引き継いだ提案として複製する
+// CSPアカウントの委任は複製元のアカウントに対するものなので引き継がない
+func (s *projectCloneService) cloneVendorRelations(sourceProjectID, proposedBy uint, result *model.ProjectCloneResult) ([]model.ProjectVendorRelation, error) {
+	sourceRelations, err := s.projectRepo.SelectVendorRelationsByProjectID(sourceProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+	var relations []model.ProjectVendorRelation
+	for _, relation := range sourceRelations {
+		if !relation.Status.IsOpen() {
+			continue
+		}
+		target := "project:" + strconv.FormatUint(uint64(relation.VendorProjectID), 10)
+		vendorProject, err := s.projectRepo.SelectByID(relation.VendorProjectID)
+		if err != nil {
+			result.AddStep(model.TemplateStepVendorRelation, target, model.TemplateStepSkipped, "vendor project not found")
+			continue
+		}
+		if vendorProject.Status.IsReadOnly() {
+			result.AddStep(model.TemplateStepVendorRelation, vendorProject.Name, model.TemplateStepSkipped, "vendor project is archived")
+			continue
+		}
+		if relation.EndDate != nil && relation.EndDate.Before(today) {
+			result.AddStep(model.TemplateStepVendorRelation, vendorProject.Name, model.TemplateStepSkipped, "contract period has ended")
+			continue
+		}
+
+		relations = append(relations, model.ProjectVendorRelation{
+			VendorProjectID:    relation.VendorProjectID,
+			Status:             model.VendorRelationStatusProposed,
+			StartDate:          relation.StartDate,
+			EndDate:            relation.EndDate,
+			Note:               relation.Note,
+			ProposedBy:         &proposedBy,
+			GrantedPermissions: relation.GrantedPermissions,
+		})
+		result.AddStep(model.TemplateStepVendorRelation, vendorProject.Name, model.TemplateStepApplied, "proposed (awaiting vendor acceptance)")
+	}
+	return relations, nil
+}
+
+// fileCSPRequests は複製元に紐付くCSPアカウントと同じプロバイダーのアカウントを新しいプロジェクトで申請する
+func (s *projectCloneService) fileCSPRequests(sourceProjectID, projectID uint, requestedBy string, req *model.ProjectCloneRequest, result *model.ProjectCloneResult) {
+	links, err := s.cspRepo.SelectProjectCSPAccountsByProjectID(sourceProjectID)
+	if err != nil {
+		result.AddStep(model.TemplateStepCSPRequest, "project:"+strconv.FormatUint(uint64(sourceProjectID), 10), model.TemplateStepFailed, "failed to load CSP accounts of the source project")
+		return
+	}
+
+	for _, link := range links {
+		account := link.CSPAccount
+		if account.Status != model.CSPAccountStatusActive {
+			result.AddStep(model.TemplateStepCSPRequest, string(account.Provider)+"/"+account.AccountName, model.TemplateStepSkipped, "source CSP account is not active")
+			continue
+		}
+
+		request := model.TemplateCSPRequest{
+			Provider:    string(account.Provider),
+			AccountName: req.CSPAccountNameFor(account.AccountName),
+			Reason:      fmt.Sprintf("Cloned from project %d (CSP account %s)", sourceProjectID, account.AccountName),
+		}
+		target := request.Provider + "/" + request.AccountName
+		if err := s.provisioningClient.CreateCSPRequest(projectID, requestedBy, &request); err != nil {
+			result.AddStep(model.TemplateStepCSPRequest, target, model.TemplateStepFailed, err.Error())
+			continue
+		}
+		result.AddStep(model.TemplateStepCSPRequest, target, model.TemplateStepApplied, "")
+	}
+}
@@ -2,26 +2,112 @@ package service
 
 import (
 	"log"
+	"os"
+	"strconv"
+	"time"
 
 	"go-nextjs-api/internal/interfaces"
 	"go-nextjs-api/internal/model"
 )
 
+// defaultProjectRetentionDays は削除済みプロジェクトを復元可能な日数のデフォルト値
+const defaultProjectRetentionDays = 30
+
 type projectService struct {
-	userRepo    interfaces.UserRepository
-	projectRepo interfaces.ProjectRepository
+	userRepo            interfaces.UserRepository
+	projectRepo         interfaces.ProjectRepository
+	customAttributeRepo interfaces.CustomAttributeRepository
+	templateRepo        interfaces.ProjectTemplateRepository
+	provisioningClient  interfaces.ProvisioningClient
+	notifier            interfaces.Notifier
+	deletionService     interfaces.DeletionService
+	retention           time.Duration
 }
 
-func NewProjectService(userRepo interfaces.UserRepository, projectRepo interfaces.ProjectRepository) interfaces.ProjectService {
+func NewProjectService(
+	userRepo interfaces.UserRepository,
+	projectRepo interfaces.ProjectRepository,
+	customAttributeRepo interfaces.CustomAttributeRepository,
+	templateRepo interfaces.ProjectTemplateRepository,
+	provisioningClient interfaces.ProvisioningClient,
+	notifier interfaces.Notifier,
+	deletionService interfaces.DeletionService,
+) interfaces.ProjectService {
+	retentionDays := defaultProjectRetentionDays
+	if v, err := strconv.Atoi(os.Getenv("PROJECT_RETENTION_DAYS")); err == nil && v > 0 {
+		retentionDays = v
+	}
+
 	return &projectService{
-		userRepo:    userRepo,
-		projectRepo: projectRepo,
+		userRepo:            userRepo,
+		projectRepo:         projectRepo,
+		customAttributeRepo: customAttributeRepo,
+		templateRepo:        templateRepo,
+		provisioningClient:  provisioningClient,
+		notifier:            notifier,
+		deletionService:     deletionService,
+		retention:           time.Duration(retentionDays) * 24 * time.Hour,
 	}
 }
 
-// GetUserProjects はユーザーが所属するプロジェクト一覧を取得
-func (s *projectService) GetUserProjects(userID uint) ([]model.UserProjectResponse, error) {
-	return s.projectRepo.SelectUserProjects(userID)
+// GetAllUserProjects はユーザーが所属するプロジェクトを参加日時の新しい順に全件取得
+func (s *projectService) GetAllUserProjects(userID uint, filter *model.ProjectMetadataFilter) ([]model.UserProjectResponse, error) {
+	return s.projectRepo.SelectUserProjects(userID, filter)
+}
+
+// GetUserProjects はユーザーが所属するプロジェクト一覧を取得（ページング・絞り込み対応）
+func (s *projectService) GetUserProjects(userID uint, query *model.ProjectQuery) ([]model.UserProjectResponse, *model.PaginationInfo, error) {
+	query.MemberOnly = true
+	query.Normalize()
+
+	items, pagination, err := s.projectRepo.SearchProjects(userID, false, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	projects := make([]model.UserProjectResponse, 0, len(items))
+	for _, item := range items {
+		project := model.UserProjectResponse{
+			ProjectID:   item.ID,
+			Name:        item.Name,
+			Description: item.Description,
+			Status:      item.Status,
+			Tags:        item.Tags,
+		}
+		if item.UserRole != nil {
+			project.Role = *item.UserRole
+		}
+		if item.JoinedAt != nil {
+			project.JoinedAt = *item.JoinedAt
+		}
+		projects = append(projects, project)
+	}
+
+	return projects, pagination, nil
+}
+
+// SearchProjects はプロジェクトを検索
+// 管理者プロジェクトのメンバーは全プロジェクト、それ以外は所属プロジェクトとベンダープロジェクトが対象
+func (s *projectService) SearchProjects(userID uint, query *model.ProjectQuery) ([]model.ProjectListItem, *model.PaginationInfo, error) {
+	if query.Role != "" && !query.Role.IsValid() {
+		return nil, nil, model.ErrInvalidRole
+	}
+	for _, status := range query.Statuses {
+		if !status.IsValid() {
+			return nil, nil, model.ErrInvalidProjectStatus
+		}
+	}
+	if query.CSPProvider != "" && !query.CSPProvider.IsValid() {
+		return nil, nil, model.ErrInvalidCSPProvider
+	}
+
+	adminPermission, err := s.projectRepo.CheckAdminProjectPermission(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query.Normalize()
+	return s.projectRepo.SearchProjects(userID, adminPermission.CanView, query)
 }
 
 // GetProjectByID はプロジェクト詳細を取得
@@ -38,6 +124,8 @@ func (s *projectService) GetProjectByID(projectID uint) (*model.ProjectResponse,
 		Status:         details.Status,
 		OrganizationID: details.OrganizationID,
 		ProjectType:    details.ProjectType,
+		Tags:           details.Tags,
+		CustomAttributes: details.CustomAttributes,
 		CreatedAt:      details.CreatedAt,
 		UpdatedAt:      details.UpdatedAt,
 	}
@@ -64,37 +152,99 @@ func (s *projectService) GetProjectMembers(projectID uint, page, limit int) ([]m
 }
 
 // CreateProject はプロジェクトを作成
+// テンプレートが指定された場合は、メンバー・ベンダー紐付けをプロジェクトと同一トランザクションで作成し、
+// 初期CSP申請はプロジェクト作成後に申請する（各ステップの結果をレスポンスに含める）
 func (s *projectService) CreateProject(userID uint, req *model.ProjectCreateRequest) (*model.ProjectResponse, error) {
 	// ユーザーの存在確認
-	_, err := s.userRepo.SelectByID(userID)
+	user, err := s.userRepo.SelectByID(userID)
 	if err != nil {
 		return nil, model.ErrUserNotFound
 	}
 
+	var template *model.ProjectTemplate
+	if req.TemplateID != nil {
+		template, err = s.templateRepo.SelectByID(*req.TemplateID)
+		if err != nil {
+			return nil, model.ErrProjectTemplateNotFound
+		}
+	}
+
 	// デフォルトステータス設定
 	status := req.Status
 	if status == "" {
 		status = model.ProjectStatusActive
 	}
 
+	projectType := req.ProjectType
+	if projectType == "" && template != nil {
+		projectType = template.ProjectType
+	}
+
+	// タグ・カスタム属性の検証（必須属性があるため未指定でも検証する）
+	// テンプレートのタグはリクエストで同じキーが指定された場合に上書きされる
+	tags := model.ProjectTags{}
+	if template != nil {
+		for key, value := range template.Tags {
+			tags[key] = value
+		}
+	}
+	for key, value := range req.Tags {
+		tags[key] = value
+	}
+	customAttributes := req.CustomAttributes
+	if customAttributes == nil {
+		customAttributes = model.CustomAttributeValues{}
+	}
+	if err := tags.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.validateCustomAttributes(req.OrganizationID, customAttributes); err != nil {
+		return nil, err
+	}
+
 	// プロジェクト作成
 	project := model.Project{
 		Name:           req.Name,
 		Description:    req.Description,
 		Status:         status,
 		OrganizationID: req.OrganizationID,
-		ProjectType: req.ProjectType,
+		ProjectType: projectType,
+		Tags:             tags,
+		CustomAttributes: customAttributes,
 	}
 
-	if err := s.projectRepo.Insert(&project); err != nil {
-		return nil, err
+	// 作成者をオーナーとして追加
+	members := []model.UserProjectRole{{UserID: userID, Role: model.RoleOwner}}
+	var vendorRelations []model.ProjectVendorRelation
+	var result *model.ProjectTemplateApplyResult
+	if template != nil {
+		result = &model.ProjectTemplateApplyResult{TemplateID: template.ID}
+		members = append(members, s.resolveTemplateMembers(template, userID, result)...)
+		vendorRelations = s.resolveTemplateVendorRelations(template, userID, result)
 	}
 
-	// 作成者をオーナーとして追加
-	if err := s.projectRepo.InsertMember(project.ID, userID, string(model.RoleOwner)); err != nil {
+	if err := s.projectRepo.InsertWithSetup(&project, members, vendorRelations); err != nil {
 		return nil, err
 	}
 
+	// テンプレートによるベンダー紐付けは提案として作成されるため、双方に通知する
+	for i := range vendorRelations {
+		notifyVendorRelationEvent(s.projectRepo, s.notifier, &vendorRelations[i])
+	}
+
+	// 初期CSP申請（プロビジョニングサービス側の処理のため、失敗してもプロジェクト作成は取り消さない）
+	if template != nil {
+		for _, request := range template.CSPRequests {
+			request.AccountName = request.AccountNameFor(project.Name)
+			target := request.Provider + "/" + request.AccountName
+			if err := s.provisioningClient.CreateCSPRequest(project.ID, user.Email, &request); err != nil {
+				result.AddStep(model.TemplateStepCSPRequest, target, model.TemplateStepFailed, err.Error())
+				continue
+			}
+			result.AddStep(model.TemplateStepCSPRequest, target, model.TemplateStepApplied, "")
+		}
+	}
+
 	response := &model.ProjectResponse{
 		ID:             project.ID,
 		Name:           project.Name,
@@ -102,14 +252,87 @@ func (s *projectService) CreateProject(userID uint, req *model.ProjectCreateRequ
 		Status:         project.Status,
 		OrganizationID: project.OrganizationID,
 		ProjectType:    project.ProjectType,
+		Tags:           project.Tags,
+		CustomAttributes: project.CustomAttributes,
 		CreatedAt:      project.CreatedAt,
 		UpdatedAt:      project.UpdatedAt,
 		UserRole:       model.RoleOwner,
+		TemplateResult: result,
 	}
 
 	return response, nil
 }
 
+// resolveTemplateMembers はテンプレートのメンバーグループから追加するメンバーを解決
+// 存在しないユーザーや重複するユーザーはスキップとして結果に記録する
+func (s *projectService) resolveTemplateMembers(template *model.ProjectTemplate, ownerID uint, result *model.ProjectTemplateApplyResult) []model.UserProjectRole {
+	added := map[uint]bool{ownerID: true}
+	var members []model.UserProjectRole
+
+	addMember := func(user *model.User, target string, role model.Role) {
+		if user == nil {
+			result.AddStep(model.TemplateStepMember, target, model.TemplateStepSkipped, "user not found")
+			return
+		}
+		if added[user.ID] {
+			result.AddStep(model.TemplateStepMember, user.Email, model.TemplateStepSkipped, "already a member")
+			return
+		}
+		added[user.ID] = true
+		members = append(members, model.UserProjectRole{UserID: user.ID, Role: role})
+		result.AddStep(model.TemplateStepMember, user.Email, model.TemplateStepApplied, "added as "+string(role))
+	}
+
+	for _, group := range template.MemberGroups {
+		for _, memberID := range group.UserIDs {
+			user, err := s.userRepo.SelectByID(memberID)
+			if err != nil {
+				user = nil
+			}
+			addMember(user, "user:"+strconv.FormatUint(uint64(memberID), 10), group.Role)
+		}
+		for _, email := range group.Emails {
+			user, err := s.userRepo.SelectByEmail(email)
+			if err != nil {
+				user = nil
+			}
+			addMember(user, email, group.Role)
+		}
+	}
+
+	return members
+}
+
+// resolveTemplateVendorRelations はテンプレートのベンダー紐付けを解決
+// 紐付けはベンダー側の承諾待ち（提案中）として作成し、
+// 削除済み・アーカイブ済み・ベンダー以外のプロジェクトはスキップとして結果に記録する
+func (s *projectService) resolveTemplateVendorRelations(template *model.ProjectTemplate, proposedBy uint, result *model.ProjectTemplateApplyResult) []model.ProjectVendorRelation {
+	var relations []model.ProjectVendorRelation
+	for _, vendorProjectID := range template.VendorProjectIDs {
+		target := "project:" + strconv.FormatUint(uint64(vendorProjectID), 10)
+		vendorProject, err := s.projectRepo.SelectByID(vendorProjectID)
+		if err != nil {
+			result.AddStep(model.TemplateStepVendorRelation, target, model.TemplateStepSkipped, "vendor project not found")
+			continue
+		}
+		if vendorProject.ProjectType != model.ProjectTypeVendor {
+			result.AddStep(model.TemplateStepVendorRelation, vendorProject.Name, model.TemplateStepSkipped, "not a vendor project")
+			continue
+		}
+		if vendorProject.Status.IsReadOnly() {
+			result.AddStep(model.TemplateStepVendorRelation, vendorProject.Name, model.TemplateStepSkipped, "vendor project is archived")
+			continue
+		}
+		relations = append(relations, model.ProjectVendorRelation{
+			VendorProjectID: vendorProjectID,
+			Status:          model.VendorRelationStatusProposed,
+			ProposedBy:      &proposedBy,
+		})
+		result.AddStep(model.TemplateStepVendorRelation, vendorProject.Name, model.TemplateStepApplied, "proposed (awaiting vendor acceptance)")
+	}
+	return relations
+}
+
 // UpdateProject はプロジェクトを更新
 func (s *projectService) UpdateProject(userID, projectID uint, req *model.ProjectUpdateRequest) (*model.ProjectResponse, error) {
 	// 権限チェック
@@ -128,6 +351,26 @@ func (s *projectService) UpdateProject(userID, projectID uint, req *model.Projec
 		return nil, model.ErrProjectNotFound
 	}
 
+	statusChanged := req.Status != "" && req.Status != details.Status
+	hasFieldChanges := req.Name != "" || req.Description != nil || req.OrganizationID != 0 ||
+		req.Tags != nil || req.CustomAttributes != nil
+
+	// アーカイブ解除を伴う場合は、先にステータスを戻してから他の項目を更新する
+	if statusChanged && details.Status.IsReadOnly() {
+		if err := s.transitionStatus(userID, details, req.Status); err != nil {
+			return nil, err
+		}
+		details.Status = req.Status
+		statusChanged = false
+	}
+
+	// アーカイブ等に遷移できない場合は、他の項目も保存せずにエラーを返す
+	if statusChanged {
+		if err := checkProjectStatusTransition(s.projectRepo, s.provisioningClient, details.ID, details.Status, req.Status); err != nil {
+			return nil, err
+		}
+	}
+
 	// 更新用のProjectオブジェクトを作成
 	project := &model.Project{
 		ID:             details.ID,
@@ -136,27 +379,57 @@ func (s *projectService) UpdateProject(userID, projectID uint, req *model.Projec
 		Status:         details.Status,
 		OrganizationID: details.OrganizationID,
 		ProjectType:    details.ProjectType,
+		Tags:           details.Tags,
+		CustomAttributes: details.CustomAttributes,
 		CreatedAt:      details.CreatedAt,
 		UpdatedAt:      details.UpdatedAt,
 	}
 
-	// 更新処理
-	if req.Name != "" {
-		project.Name = req.Name
-	}
-	if req.Description != nil {
-		project.Description = *req.Description
-	}
-	if req.Status != "" {
+	if hasFieldChanges {
+		// アーカイブ済みプロジェクトは読み取り専用
+		if details.Status.IsReadOnly() {
+			return nil, model.ErrProjectArchived
+		}
+
+		// 更新処理
+		if req.Name != "" {
+			project.Name = req.Name
+		}
+		if req.Description != nil {
+			project.Description = *req.Description
+		}
+		if req.OrganizationID != 0 {
+			project.OrganizationID = req.OrganizationID
+		}
+		if req.Tags != nil {
+			if err := req.Tags.Validate(); err != nil {
+				return nil, err
+			}
+			project.Tags = req.Tags
+		}
+		if req.CustomAttributes != nil {
+			project.CustomAttributes = req.CustomAttributes
+		}
+
+		// 属性値の変更時、または組織が変わる場合は（移動先の）属性定義で検証する
+		if req.CustomAttributes != nil || project.OrganizationID != details.OrganizationID {
+			if err := s.validateCustomAttributes(project.OrganizationID, project.CustomAttributes); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := s.projectRepo.Update(project); err != nil {
+			return nil, err
+		}
+	}
+
+	// ステータス変更は遷移ルールに従って最後に適用する
+	if statusChanged {
+		if err := s.transitionStatus(userID, details, req.Status); err != nil {
+			return nil, err
+		}
 		project.Status = req.Status
 	}
-	if req.OrganizationID != 0 {
-		project.OrganizationID = req.OrganizationID
-	}
-
-	if err := s.projectRepo.Update(project); err != nil {
-		return nil, err
-	}
 
 	response := &model.ProjectResponse{
 		ID:             project.ID,
@@ -165,6 +438,8 @@ func (s *projectService) UpdateProject(userID, projectID uint, req *model.Projec
 		Status:         project.Status,
 		OrganizationID: project.OrganizationID,
 		ProjectType:    project.ProjectType,
+		Tags:           project.Tags,
+		CustomAttributes: project.CustomAttributes,
 		CreatedAt:      project.CreatedAt,
 		UpdatedAt:      project.UpdatedAt,
 		UserRole:       permission.Role,
@@ -185,8 +460,135 @@ func (s *projectService) DeleteProject(userID, projectID uint) error {
 		return model.ErrInsufficientPermissions
 	}
 
-	// プロジェクト削除（ソフトデリート）
-	return s.projectRepo.Delete(projectID)
+	// アクティブなCSPアカウントや未処理のCSP申請が残っている場合は削除できない
+	if err := s.deletionService.EnsureProjectDeletable(projectID); err != nil {
+		return err
+	}
+
+	// プロジェクト削除（ソフトデリート。依存レコードも一緒に削除し、保持期間内は管理者が復元可能）
+	return s.projectRepo.Delete(projectID, userID)
+}
+
+// validateCustomAttributes は組織のカスタム属性定義に基づいて属性値を検証
+func (s *projectService) validateCustomAttributes(organizationID uint, customAttributes model.CustomAttributeValues) error {
+	definitions, err := s.customAttributeRepo.SelectDefinitionsByOrganizationID(organizationID)
+	if err != nil {
+		return err
+	}
+
+	return model.ValidateCustomAttributes(definitions, customAttributes)
+}
+
+// TransitionProjectStatus はプロジェクトのステータスを遷移ルールに従って変更
+func (s *projectService) TransitionProjectStatus(userID, projectID uint, status model.ProjectStatus) (*model.ProjectResponse, error) {
+	permission, err := s.CheckProjectPermission(userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if !permission.CanManage {
+		return nil, model.ErrInsufficientPermissions
+	}
+
+	details, err := s.projectRepo.SelectByID(projectID)
+	if err != nil {
+		return nil, model.ErrProjectNotFound
+	}
+
+	if err := s.transitionStatus(userID, details, status); err != nil {
+		return nil, err
+	}
+
+	response, err := s.GetProjectByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	response.UserRole = permission.Role
+
+	return response, nil
+}
+
+// transitionStatus は遷移可否とアーカイブ前提条件をチェックしてステータスを更新
+func (s *projectService) transitionStatus(userID uint, details *model.ProjectDetails, next model.ProjectStatus) error {
+	if err := checkProjectStatusTransition(s.projectRepo, s.provisioningClient, details.ID, details.Status, next); err != nil {
+		return err
+	}
+
+	log.Printf("TransitionProjectStatus: projectID=%d, %s -> %s, by userID=%d", details.ID, details.Status, next, userID)
+	return s.projectRepo.UpdateStatus(details.ID, next, userID)
+}
+
+// checkProjectStatusTransition はステータスの遷移可否とアーカイブの前提条件をチェック
+// アーカイブはアクティブなCSPアカウントと承認待ち申請がない場合のみ許可
+func checkProjectStatusTransition(projectRepo interfaces.ProjectRepository, provisioningClient interfaces.ProvisioningClient, projectID uint, current, next model.ProjectStatus) error {
+	if !next.IsValid() {
+		return model.ErrInvalidProjectStatus
+	}
+	if !current.CanTransitionTo(next) {
+		return model.ErrInvalidProjectStatusTransition
+	}
+
+	if next == model.ProjectStatusArchived {
+		activeCount, err := projectRepo.CountActiveCSPAccounts(projectID)
+		if err != nil {
+			return err
+		}
+		if activeCount > 0 {
+			return model.ErrProjectHasActiveCSPAccounts
+		}
+
+		pendingCount, err := provisioningClient.CountPendingCSPRequests(projectID)
+		if err != nil {
+			return err
+		}
+		if pendingCount > 0 {
+			return model.ErrProjectHasPendingCSPRequests
+		}
+	}
+	return nil
+}
+
+// GetTrashedProjects は保持期間内の削除済みプロジェクト一覧を取得（管理者用）
+func (s *projectService) GetTrashedProjects() ([]model.TrashedProjectResponse, error) {
+	projects, err := s.projectRepo.SelectDeleted(time.Now().Add(-s.retention))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.TrashedProjectResponse, 0, len(projects))
+	for _, project := range projects {
+		responses = append(responses, model.TrashedProjectResponse{
+			ID:              project.ID,
+			Name:            project.Name,
+			Description:     project.Description,
+			Status:          project.Status,
+			OrganizationID:  project.OrganizationID,
+			ProjectType:     project.ProjectType,
+			DeletedAt:       project.DeletedAt.Time,
+			DeletedBy:       project.DeletedBy,
+			RestoreDeadline: project.DeletedAt.Time.Add(s.retention),
+		})
+	}
+
+	return responses, nil
+}
+
+// RestoreProject は削除済みプロジェクトを保持期間内であれば復元（管理者用）
+func (s *projectService) RestoreProject(adminID, projectID uint) (*model.ProjectResponse, error) {
+	project, err := s.projectRepo.SelectDeletedByID(projectID)
+	if err != nil {
+		return nil, model.ErrProjectNotInTrash
+	}
+
+	if time.Now().After(project.DeletedAt.Time.Add(s.retention)) {
+		return nil, model.ErrProjectRestoreWindowExpired
+	}
+
+	if err := s.projectRepo.Restore(projectID); err != nil {
+		return nil, err
+	}
+
+	log.Printf("RestoreProject: projectID=%d restored by adminID=%d", projectID, adminID)
+	return s.GetProjectByID(projectID)
 }
 
 // AddUserToProject はプロジェクトにユーザーを追加
@@ -197,10 +599,9 @@ func (s *projectService) AddUserToProject(projectID, userID uint, role model.Rol
 		return model.ErrUserNotFound
 	}
 
-	// プロジェクトの存在確認
-	_, err = s.projectRepo.SelectByID(projectID)
-	if err != nil {
-		return model.ErrProjectNotFound
+	// プロジェクトの存在確認（アーカイブ済みは追加不可）
+	if err := ensureProjectWritable(s.projectRepo, projectID); err != nil {
+		return err
 	}
 
 	// 既に参加していないかチェック
@@ -220,6 +621,10 @@ func (s *projectService) AddUserToProject(projectID, userID uint, role model.Rol
 func (s *projectService) UpdateUserProjectRole(projectID, userID uint, role model.Role) error {
 	log.Printf("UpdateUserProjectRole: Start - projectID=%d, userID=%d, role=%s", projectID, userID, role)
 	
+	if err := ensureProjectWritable(s.projectRepo, projectID); err != nil {
+		return err
+	}
+
 	// メンバーシップの確認
 	isMember, err := s.projectRepo.IsMember(projectID, userID)
 	if err != nil {
@@ -243,103 +648,140 @@ func (s *projectService) UpdateUserProjectRole(projectID, userID uint, role mode
 }
 
 // RemoveUserFromProject はプロジェクトからユーザーを削除
-func (s *projectService) RemoveUserFromProject(projectID, userID uint) error {
+// CSPアカウントメンバーシップの無効化と、作成リソースの引き継ぎ（またはフラグ付け）も併せて行う
+func (s *projectService) RemoveUserFromProject(projectID, userID uint, req *model.MemberRemovalRequest) (*model.MemberRemovalSummary, error) {
+	if err := ensureProjectWritable(s.projectRepo, projectID); err != nil {
+		return nil, err
+	}
+
 	// オーナーが最後の一人の場合は削除を禁止
 	ownerCount, err := s.projectRepo.CountProjectOwners(projectID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// ユーザーのロールを確認
 	userRole, err := s.projectRepo.SelectUserRole(projectID, userID)
-	if err != nil {
-		return model.ErrUserProjectRoleNotFound
+	if err != nil || userRole == "" {
+		return nil, model.ErrUserProjectRoleNotFound
 	}
 
 	if userRole == string(model.RoleOwner) && ownerCount <= 1 {
-		return model.ErrCannotRemoveLastOwner
+		return nil, model.ErrCannotRemoveLastOwner
+	}
+
+	// 引き継ぎ先は残留する管理権限を持つメンバーに限る
+	var reassignTo *uint
+	if req != nil && req.ReassignTo != nil {
+		if *req.ReassignTo == userID {
+			return nil, model.ErrInvalidReassignTarget
+		}
+		permission, err := s.CheckProjectPermission(*req.ReassignTo, projectID)
+		if err != nil {
+			return nil, err
+		}
+		if !permission.CanManage {
+			return nil, model.ErrInvalidReassignTarget
+		}
+		reassignTo = req.ReassignTo
+	}
+
+	summary, err := s.projectRepo.DeleteMemberWithCascade(projectID, userID, reassignTo)
+	if err != nil {
+		return nil, err
 	}
+	summary.PreviousRole = model.Role(userRole)
 
-	return s.projectRepo.DeleteMember(projectID, userID)
+	log.Printf("RemoveUserFromProject: projectID=%d, userID=%d, deactivated_csp_members=%d, reassigned=%d, flagged=%d",
+		projectID, userID,
+		len(summary.DeactivatedCSPAccountMemberIDs),
+		len(summary.ReassignedProjectCSPAccountIDs)+len(summary.ReassignedCSPAccountMemberIDs)+len(summary.ReassignedInvitationIDs),
+		len(summary.FlaggedResources))
+
+	return summary, nil
 }
 
-// CheckProjectPermission はプロジェクト権限をチェック
-func (s *projectService) CheckProjectPermission(userID, projectID uint) (*model.ProjectPermissionResponse, error) {
-	// ユーザーのロールを取得
-	userRole, err := s.projectRepo.SelectUserRole(projectID, userID)
+// TransferOwnership はプロジェクトのオーナー権限を別メンバーに移譲（現オーナーのみ）
+func (s *projectService) TransferOwnership(currentOwnerID, projectID uint, req *model.OwnershipTransferRequest) error {
+	permission, err := s.CheckProjectPermission(currentOwnerID, projectID)
 	if err != nil {
-		// メンバーでない場合
-		return &model.ProjectPermissionResponse{
-			HasAccess: false,
-			CanView:   false,
-			CanEdit:   false,
-			CanManage: false,
-		}, nil
+		return err
+	}
+	if permission.Role != model.RoleOwner {
+		return model.ErrInsufficientPermissions
 	}
 
-	role := model.Role(userRole)
-	response := &model.ProjectPermissionResponse{
-		HasAccess: true,
-		Role:      role,
-		CanView:   role.CanViewProject(),
-		CanEdit:   role.CanEditProject(),
-		CanManage: role.CanManageProject(),
+	if req.NewOwnerID == currentOwnerID {
+		return model.ErrInvalidOwnershipTransfer
 	}
 
-	return response, nil
-}
+	if err := ensureProjectWritable(s.projectRepo, projectID); err != nil {
+		return err
+	}
 
-// GetProjectsByType はプロジェクトタイプでプロジェクト一覧を取得
-func (s *projectService) GetProjectsByType(projectType string) ([]model.Project, error) {
-	return s.projectRepo.SelectByType(projectType)
-}
+	previousOwnerRole := req.PreviousOwnerRole
+	if previousOwnerRole == "" {
+		previousOwnerRole = model.RoleAdmin
+	}
+	if !previousOwnerRole.IsValid() {
+		return model.ErrInvalidRole
+	}
 
-// GetVendorRelations はプロジェクトのベンダー紐付け一覧を取得
-func (s *projectService) GetVendorRelations(userID, projectID uint) ([]model.ProjectVendorRelation, error) {
-	// プロジェクトメンバーかどうかを確認
-	isMember, err := s.projectRepo.IsMember(projectID, userID)
+	isMember, err := s.projectRepo.IsMember(projectID, req.NewOwnerID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if !isMember {
-		return nil, model.ErrUserNotProjectMember
+		return model.ErrUserNotProjectMember
 	}
 
-	return s.projectRepo.SelectVendorRelationsByProjectID(projectID)
+	return s.projectRepo.TransferOwnership(projectID, currentOwnerID, req.NewOwnerID, previousOwnerRole)
 }
 
-// CreateVendorRelation はベンダープロジェクト紐付けを作成
-func (s *projectService) CreateVendorRelation(userID, projectID, vendorProjectID uint) error {
-	// プロジェクトの管理権限があるかどうかを確認
-	permission, err := s.CheckProjectPermission(userID, projectID)
+// CheckProjectPermission はプロジェクト権限をチェック
+func (s *projectService) CheckProjectPermission(userID, projectID uint) (*model.ProjectPermissionResponse, error) {
+	// ユーザーのロールを取得
+	userRole, err := s.projectRepo.SelectUserRole(projectID, userID)
 	if err != nil {
-		return err
-	}
-	if !permission.CanManage {
-		return model.ErrInsufficientPermission
+		// メンバーでない場合はベンダー紐付け経由の委任権限を確認
+		delegated, err := resolveDelegatedAccess(s.projectRepo, userID, projectID)
+		if err != nil {
+			return nil, err
+		}
+		vendorProjectIDs, err := s.projectRepo.SelectActiveVendorProjectIDsForMember(projectID, userID)
+		if err != nil {
+			return nil, err
+		}
+		canView := delegated.Has(model.VendorPermissionViewProject)
+		return &model.ProjectPermissionResponse{
+			HasAccess:        canView,
+			CanView:          canView,
+			CanEdit:          false,
+			CanManage:        false,
+			Delegated:        delegated,
+			VendorProjectIDs: vendorProjectIDs,
+		}, nil
 	}
 
-	// 紐付けを作成
-	relation := &model.ProjectVendorRelation{
-		ProjectID:       projectID,
-		VendorProjectID: vendorProjectID,
+	role := model.Role(userRole)
+	response := &model.ProjectPermissionResponse{
+		HasAccess: true,
+		Role:      role,
+		CanView:   role.CanViewProject(),
+		CanEdit:   role.CanEditProject(),
+		CanManage: role.CanManageProject(),
 	}
 
-	return s.projectRepo.InsertVendorRelation(relation)
+	return response, nil
 }
 
-// DeleteVendorRelation はベンダープロジェクト紐付けを削除
-func (s *projectService) DeleteVendorRelation(userID, projectID, relationID uint) error {
-	// プロジェクトの管理権限があるかどうかを確認
-	permission, err := s.CheckProjectPermission(userID, projectID)
+// CheckProjectPermissionByEmail はメールアドレスで指定したユーザーのプロジェクト権限をチェック（内部API用）
+func (s *projectService) CheckProjectPermissionByEmail(email string, projectID uint) (*model.ProjectPermissionResponse, error) {
+	user, err := s.userRepo.SelectByEmail(email)
 	if err != nil {
-		return err
-	}
-	if !permission.CanManage {
-		return model.ErrInsufficientPermission
+		return nil, model.ErrUserNotFound
 	}
-
-	return s.projectRepo.DeleteVendorRelation(relationID)
+	return s.CheckProjectPermission(user.ID, projectID)
 }
 
 // CheckAdminProjectPermission は管理者プロジェクトでの権限をチェック
@@ -365,4 +807,16 @@ func (s *projectService) CanUserManageProject(userID, projectID uint) (bool, err
 		return false, err
 	}
 	return permission.CanManage, nil
-}
\ No newline at end of file
+}
+
+// ensureProjectWritable はプロジェクトが存在し、アーカイブ済み（読み取り専用）でないことを確認
+func ensureProjectWritable(projectRepo interfaces.ProjectRepository, projectID uint) error {
+	details, err := projectRepo.SelectByID(projectID)
+	if err != nil {
+		return model.ErrProjectNotFound
+	}
+	if details.Status.IsReadOnly() {
+		return model.ErrProjectArchived
+	}
+	return nil
+}
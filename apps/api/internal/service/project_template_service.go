@@ -0,0 +1,120 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type projectTemplateService struct {
+	templateRepo interfaces.ProjectTemplateRepository
+	projectRepo  interfaces.ProjectRepository
+}
+
+func NewProjectTemplateService(
+	templateRepo interfaces.ProjectTemplateRepository,
+	projectRepo interfaces.ProjectRepository,
+) interfaces.ProjectTemplateService {
+	return &projectTemplateService{
+		templateRepo: templateRepo,
+		projectRepo:  projectRepo,
+	}
+}
+
+// GetTemplates はプロジェクトテンプレート一覧を取得
+func (s *projectTemplateService) GetTemplates() ([]model.ProjectTemplate, error) {
+	return s.templateRepo.SelectAll()
+}
+
+// GetTemplate はプロジェクトテンプレートを取得
+func (s *projectTemplateService) GetTemplate(templateID uint) (*model.ProjectTemplate, error) {
+	template, err := s.templateRepo.SelectByID(templateID)
+	if err != nil {
+		return nil, model.ErrProjectTemplateNotFound
+	}
+	return template, nil
+}
+
+// CreateTemplate はプロジェクトテンプレートを作成（管理者用）
+func (s *projectTemplateService) CreateTemplate(adminID uint, req *model.ProjectTemplateRequest) (*model.ProjectTemplate, error) {
+	template := &model.ProjectTemplate{CreatedBy: adminID}
+	applyTemplateRequest(template, req)
+	if err := s.validateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Insert(template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// UpdateTemplate はプロジェクトテンプレートを更新（管理者用）
+// 既に作成済みのプロジェクトには影響しない
+func (s *projectTemplateService) UpdateTemplate(templateID uint, req *model.ProjectTemplateRequest) (*model.ProjectTemplate, error) {
+	template, err := s.GetTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	applyTemplateRequest(template, req)
+	if err := s.validateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Update(template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// DeleteTemplate はプロジェクトテンプレートを削除（管理者用）
+func (s *projectTemplateService) DeleteTemplate(templateID uint) error {
+	if _, err := s.GetTemplate(templateID); err != nil {
+		return err
+	}
+
+	return s.templateRepo.Delete(templateID)
+}
+
+// validateTemplate はテンプレート定義と名前の重複、ベンダープロジェクトの種類をチェック
+func (s *projectTemplateService) validateTemplate(template *model.ProjectTemplate) error {
+	if err := template.Validate(); err != nil {
+		return err
+	}
+
+	existing, err := s.templateRepo.SelectByName(template.Name)
+	if err == nil && existing.ID != template.ID {
+		return model.ErrProjectTemplateAlreadyExists
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	for _, vendorProjectID := range template.VendorProjectIDs {
+		vendorProject, err := s.projectRepo.SelectByID(vendorProjectID)
+		if err != nil || vendorProject.ProjectType != model.ProjectTypeVendor {
+			return fmt.Errorf("%w: project %d is not a vendor project", model.ErrInvalidProjectTemplate, vendorProjectID)
+		}
+	}
+
+	return nil
+}
+
+// applyTemplateRequest はリクエストの内容をテンプレートに反映
+func applyTemplateRequest(template *model.ProjectTemplate, req *model.ProjectTemplateRequest) {
+	template.Name = strings.TrimSpace(req.Name)
+	template.Description = req.Description
+	template.ProjectType = req.ProjectType
+	template.Tags = req.Tags
+	template.MemberGroups = req.MemberGroups
+	template.VendorProjectIDs = req.VendorProjectIDs
+	template.CSPRequests = req.CSPRequests
+}
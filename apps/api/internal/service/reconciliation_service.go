@@ -0,0 +1,558 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// reconciliationRunHistoryLimit は棚卸しの実行履歴として返す件数
+const reconciliationRunHistoryLimit = 50
+
+type reconciliationService struct {
+	reconciliationRepo interfaces.ReconciliationRepository
+	cspRepo            interfaces.CSPRepository
+	userRepo           interfaces.UserRepository
+	cspService         interfaces.CSPService
+	memberSync         interfaces.MemberSyncService
+	providerDrivers    interfaces.ProviderDrivers
+
+	// 定期実行と手動実行が重ならないよう、棚卸しは同時に1つだけ実行する
+	running sync.Mutex
+}
+
+func NewReconciliationService(
+	reconciliationRepo interfaces.ReconciliationRepository,
+	cspRepo interfaces.CSPRepository,
+	userRepo interfaces.UserRepository,
+	cspService interfaces.CSPService,
+	memberSync interfaces.MemberSyncService,
+	providerDrivers interfaces.ProviderDrivers,
+) interfaces.ReconciliationService {
+	return &reconciliationService{
+		reconciliationRepo: reconciliationRepo,
+		cspRepo:            cspRepo,
+		userRepo:           userRepo,
+		cspService:         cspService,
+		memberSync:         memberSync,
+		providerDrivers:    providerDrivers,
+	}
+}
+
+// RunReconciliation はプロバイダーのアカウント・メンバーの一覧をプラットフォームの記録と突き合わせ、ドリフトを記録する
+// プロバイダーごとに実行履歴を残し、一部のプロバイダーで失敗しても残りのプロバイダーは続けて実行する
+func (s *reconciliationService) RunReconciliation(provider model.CSPProvider, triggeredBy *uint) ([]model.CSPReconciliationRun, error) {
+	var providers []model.CSPProvider
+	if provider != "" {
+		if !provider.IsValid() {
+			return nil, model.ErrInvalidCSPProvider
+		}
+		providers = append(providers, provider)
+	} else {
+		for _, definition := range model.CSPProviders() {
+			providers = append(providers, definition.ID)
+		}
+	}
+
+	if !s.running.TryLock() {
+		return nil, model.ErrReconciliationInProgress
+	}
+	defer s.running.Unlock()
+
+	runs := make([]model.CSPReconciliationRun, 0, len(providers))
+	for _, p := range providers {
+		run := &model.CSPReconciliationRun{
+			Provider:    p,
+			Status:      model.CSPReconciliationRunStatusRunning,
+			TriggeredBy: triggeredBy,
+			Warnings:    []string{},
+			StartedAt:   time.Now(),
+		}
+		if err := s.reconciliationRepo.InsertRun(run); err != nil {
+			return nil, err
+		}
+
+		run.Status = model.CSPReconciliationRunStatusSucceeded
+		if err := s.reconcileProvider(run); err != nil {
+			log.Printf("Reconciliation of %s failed: %v", p, err)
+			run.Status = model.CSPReconciliationRunStatusFailed
+			run.Error = err.Error()
+		}
+		finishedAt := time.Now()
+		run.FinishedAt = &finishedAt
+		if err := s.reconciliationRepo.UpdateRun(run); err != nil {
+			return nil, err
+		}
+		runs = append(runs, *run)
+	}
+	return runs, nil
+}
+
+// reconcileProvider はプロバイダーの棚卸しを行い、検出結果を実行履歴に記録する
+func (s *reconciliationService) reconcileProvider(run *model.CSPReconciliationRun) error {
+	driver, err := s.providerDrivers.Driver(run.Provider)
+	if err != nil {
+		return err
+	}
+	providerAccounts, err := driver.ListAccounts()
+	if err != nil {
+		return fmt.Errorf("failed to list accounts: %w", err)
+	}
+	accounts, err := s.cspRepo.SelectCSPAccountsByProvider(run.Provider)
+	if err != nil {
+		return err
+	}
+
+	listed := make(map[string]model.ProviderAccount, len(providerAccounts))
+	for _, providerAccount := range providerAccounts {
+		listed[providerAccount.AccountID] = providerAccount
+	}
+	registered := make(map[string]bool, len(accounts))
+	for _, account := range accounts {
+		registered[account.AccountID] = true
+	}
+
+	var detected []*model.CSPDriftItem
+	// 確認できなかったアカウントのドリフトは、差分がなくなったとみなして自動で解消しない
+	unchecked := make(map[string]bool)
+
+	for _, providerAccount := range providerAccounts {
+		if registered[providerAccount.AccountID] || providerAccount.Status == model.ProviderAccountStatusClosed {
+			continue
+		}
+		detected = append(detected, &model.CSPDriftItem{
+			Kind:              model.CSPDriftKindUnknownAccount,
+			ProviderAccountID: providerAccount.AccountID,
+			AccountName:       providerAccount.AccountName,
+			Actual:            string(providerAccount.Status),
+			Detail:            fmt.Sprintf("%sのアカウント %s がプラットフォームに登録されていない", run.Provider, providerAccount.AccountID),
+		})
+	}
+
+	for i := range accounts {
+		account := &accounts[i]
+		// 作成中・閉鎖処理中の状態はステータスの反映（定期実行）で確定するため対象外
+		if account.Status.IsTerminal() || account.Status == model.CSPAccountStatusProvisioning || account.Status == model.CSPAccountStatusClosing {
+			continue
+		}
+		run.AccountsScanned++
+
+		providerAccount, ok := listed[account.AccountID]
+		if !ok {
+			// 一覧の範囲外（別のフォルダなど）にある場合もあるため、個別に存在を確認する
+			found, err := driver.GetAccount(account.AccountID)
+			if errors.Is(err, model.ErrProviderAccountNotFound) {
+				detected = append(detected, &model.CSPDriftItem{
+					Kind:              model.CSPDriftKindMissingAccount,
+					CSPAccountID:      &account.ID,
+					ProviderAccountID: account.AccountID,
+					AccountName:       account.AccountName,
+					Expected:          string(account.Status),
+					Detail:            fmt.Sprintf("CSPアカウント「%s」が%sに存在しない", account.AccountName, run.Provider),
+				})
+				continue
+			}
+			if err != nil {
+				run.Warnings = append(run.Warnings, fmt.Sprintf("account %s: %v", account.AccountID, err))
+				unchecked[account.AccountID] = true
+				continue
+			}
+			providerAccount = *found
+		}
+
+		if next := account.Status.StatusFromProvider(providerAccount.Status); next != account.Status {
+			detected = append(detected, &model.CSPDriftItem{
+				Kind:              model.CSPDriftKindStatusMismatch,
+				CSPAccountID:      &account.ID,
+				ProviderAccountID: account.AccountID,
+				AccountName:       account.AccountName,
+				Expected:          string(account.Status),
+				Actual:            string(providerAccount.Status),
+				Detail:            fmt.Sprintf("CSPアカウント「%s」のステータスがプラットフォームでは%s、%sでは%s", account.AccountName, account.Status, run.Provider, providerAccount.Status),
+			})
+		}
+
+		// メンバーは利用中のアカウントのみ突き合わせる（一時停止中はプロバイダー側でも利用できない）
+		if account.Status != model.CSPAccountStatusActive || providerAccount.Status != model.ProviderAccountStatusActive {
+			continue
+		}
+		memberDrift, err := s.detectMemberDrift(driver, account)
+		if errors.Is(err, model.ErrProviderMembersNotSupported) {
+			continue
+		}
+		if err != nil {
+			run.Warnings = append(run.Warnings, fmt.Sprintf("members of account %s: %v", account.AccountID, err))
+			unchecked[account.AccountID] = true
+			continue
+		}
+		detected = append(detected, memberDrift...)
+	}
+
+	return s.recordDrift(run, detected, unchecked)
+}
+
+// detectMemberDrift はアカウントの有効なメンバーとプロバイダー側でロールを付与されたユーザーを突き合わせる
+func (s *reconciliationService) detectMemberDrift(driver interfaces.ProviderDriver, account *model.CSPAccount) ([]*model.CSPDriftItem, error) {
+	actualMembers, err := driver.ListAccountMembers(account.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.cspRepo.SelectCSPAccountMembersByCSPAccountID(account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	byEmail := make(map[string][]model.CSPAccountMember)
+	var emails []string
+	for _, member := range members {
+		email := member.ProviderEmail()
+		if email == "" {
+			continue
+		}
+		if _, ok := byEmail[email]; !ok {
+			emails = append(emails, email)
+		}
+		byEmail[email] = append(byEmail[email], member)
+	}
+	// プロバイダー側でロールを付与されているべきメールアドレス（メンバーの反映と同じ規則で決める）
+	expected := make(map[string]*model.CSPAccountMember)
+	for _, email := range emails {
+		if member := desiredProviderMember(byEmail[email]); member != nil {
+			expected[email] = member
+		}
+	}
+
+	actual := make(map[string]model.CSPAccountMemberRole, len(actualMembers))
+	for _, member := range actualMembers {
+		actual[strings.ToLower(member.Email)] = member.Role
+	}
+
+	var items []*model.CSPDriftItem
+	for _, email := range emails {
+		member, ok := expected[email]
+		if !ok {
+			continue
+		}
+		role, granted := actual[email]
+		switch {
+		case !granted:
+			items = append(items, &model.CSPDriftItem{
+				Kind:               model.CSPDriftKindMissingMember,
+				CSPAccountID:       &account.ID,
+				ProviderAccountID:  account.AccountID,
+				AccountName:        account.AccountName,
+				CSPAccountMemberID: &member.ID,
+				Email:              email,
+				Expected:           member.Role,
+				Detail:             fmt.Sprintf("%s はプラットフォームの%sメンバーだが、プロバイダー側でロールが付与されていない", email, member.Role),
+			})
+		case string(role) != member.Role:
+			items = append(items, &model.CSPDriftItem{
+				Kind:               model.CSPDriftKindRoleMismatch,
+				CSPAccountID:       &account.ID,
+				ProviderAccountID:  account.AccountID,
+				AccountName:        account.AccountName,
+				CSPAccountMemberID: &member.ID,
+				Email:              email,
+				Expected:           member.Role,
+				Actual:             string(role),
+				Detail:             fmt.Sprintf("%s のロールがプラットフォームでは%s、プロバイダー側では%s", email, member.Role, role),
+			})
+		}
+	}
+	for _, member := range actualMembers {
+		email := strings.ToLower(member.Email)
+		if _, ok := expected[email]; ok {
+			continue
+		}
+		items = append(items, &model.CSPDriftItem{
+			Kind:              model.CSPDriftKindExtraMember,
+			CSPAccountID:      &account.ID,
+			ProviderAccountID: account.AccountID,
+			AccountName:       account.AccountName,
+			Email:             email,
+			Actual:            string(member.Role),
+			Detail:            fmt.Sprintf("%s はプロバイダー側で%sのロールを付与されているが、プラットフォームの有効なメンバーではない", email, member.Role),
+		})
+	}
+	return items, nil
+}
+
+// recordDrift は検出したドリフトを指紋で既存の記録と突き合わせて保存する
+// 解消済みのドリフトが再び検出された場合は未対応に戻し、無視したドリフトは無視のまま最終検出日時だけ更新する
+// 未対応のドリフトが検出されなくなった場合は自動で解消済みにする
+func (s *reconciliationService) recordDrift(run *model.CSPReconciliationRun, detected []*model.CSPDriftItem, unchecked map[string]bool) error {
+	existingItems, err := s.reconciliationRepo.SelectDriftItemsByProvider(run.Provider)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]*model.CSPDriftItem, len(existingItems))
+	for i := range existingItems {
+		existing[existingItems[i].Fingerprint] = &existingItems[i]
+	}
+
+	now := time.Now()
+	seen := make(map[string]bool, len(detected))
+	for _, item := range detected {
+		fingerprint := model.DriftFingerprint(run.Provider, item.Kind, item.ProviderAccountID, item.Email)
+		if seen[fingerprint] {
+			continue
+		}
+		seen[fingerprint] = true
+
+		current, ok := existing[fingerprint]
+		if !ok {
+			item.Provider = run.Provider
+			item.Status = model.CSPDriftStatusOpen
+			item.Fingerprint = fingerprint
+			item.FirstDetectedAt = now
+			item.LastDetectedAt = now
+			if err := s.reconciliationRepo.InsertDriftItem(item); err != nil {
+				return err
+			}
+			run.DriftDetected++
+			continue
+		}
+
+		current.CSPAccountID = item.CSPAccountID
+		current.AccountName = item.AccountName
+		current.CSPAccountMemberID = item.CSPAccountMemberID
+		current.Expected = item.Expected
+		current.Actual = item.Actual
+		current.Detail = item.Detail
+		current.LastDetectedAt = now
+		if current.Status == model.CSPDriftStatusResolved {
+			current.Status = model.CSPDriftStatusOpen
+			current.Resolution = ""
+			current.ResolutionNote = ""
+			current.ResolvedBy = nil
+			current.ResolvedAt = nil
+		}
+		if err := s.reconciliationRepo.UpdateDriftItem(current); err != nil {
+			return err
+		}
+		if current.Status == model.CSPDriftStatusOpen {
+			run.DriftDetected++
+		}
+	}
+
+	for _, current := range existingItems {
+		if current.Status != model.CSPDriftStatusOpen || seen[current.Fingerprint] || unchecked[current.ProviderAccountID] {
+			continue
+		}
+		current.Status = model.CSPDriftStatusResolved
+		current.ResolvedAt = &now
+		if err := s.reconciliationRepo.UpdateDriftItem(&current); err != nil {
+			return err
+		}
+		run.DriftResolved++
+	}
+	return nil
+}
+
+// GetRuns は棚卸しの実行履歴を新しい順に取得
+func (s *reconciliationService) GetRuns() ([]model.CSPReconciliationRun, error) {
+	return s.reconciliationRepo.SelectRuns(reconciliationRunHistoryLimit)
+}
+
+// GetDriftItems はドリフトの一覧を取得
+func (s *reconciliationService) GetDriftItems(filter *model.CSPDriftFilter) ([]model.CSPDriftItem, error) {
+	return s.reconciliationRepo.SelectDriftItems(filter)
+}
+
+// ResolveDriftItem は未対応のドリフトに対応する
+// import はプロバイダー側の状態をプラットフォームに取り込み、fix はプラットフォームの状態をプロバイダー側に反映する
+// ignore は差分を許容し、以後の棚卸しでも未対応に戻さない
+func (s *reconciliationService) ResolveDriftItem(id, adminID uint, req *model.CSPDriftResolveRequest) (*model.CSPDriftItem, error) {
+	item, err := s.reconciliationRepo.SelectDriftItemByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrDriftItemNotFound
+		}
+		return nil, err
+	}
+	if item.Status != model.CSPDriftStatusOpen {
+		return nil, model.ErrDriftItemNotOpen
+	}
+	if !item.Kind.AllowsAction(req.Action) {
+		return nil, model.ErrInvalidDriftAction
+	}
+
+	status := model.CSPDriftStatusIgnored
+	if req.Action != model.CSPDriftActionIgnore {
+		if err := s.remediate(item, adminID, req); err != nil {
+			return nil, err
+		}
+		status = model.CSPDriftStatusResolved
+	}
+
+	now := time.Now()
+	item.Status = status
+	item.Resolution = req.Action
+	item.ResolutionNote = strings.TrimSpace(req.Note)
+	item.ResolvedBy = &adminID
+	item.ResolvedAt = &now
+	if err := s.reconciliationRepo.ResolveDriftItem(item); err != nil {
+		return nil, err
+	}
+	log.Printf("Drift item %d (%s %s): %s by userID=%d", item.ID, item.Provider, item.Kind, req.Action, adminID)
+	return s.reconciliationRepo.SelectDriftItemByID(item.ID)
+}
+
+// remediate はドリフトの種類と対応方法に応じて、プラットフォームまたはプロバイダー側を更新する
+func (s *reconciliationService) remediate(item *model.CSPDriftItem, adminID uint, req *model.CSPDriftResolveRequest) error {
+	switch item.Kind {
+	case model.CSPDriftKindUnknownAccount:
+		return s.importAccount(item, adminID, req.ProjectID)
+	case model.CSPDriftKindMissingAccount:
+		return s.recordAccountClosed(item, adminID, req.Note)
+	case model.CSPDriftKindStatusMismatch:
+		_, err := s.cspService.SyncCSPAccountStatus(*item.CSPAccountID)
+		return err
+	}
+
+	account, err := s.cspRepo.SelectCSPAccountByID(*item.CSPAccountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrCSPAccountNotFound
+		}
+		return err
+	}
+
+	// メンバーの反映と同じ規則でプロバイダー側を更新し、メンバーの反映状況も記録する
+	if req.Action == model.CSPDriftActionFix {
+		return s.memberSync.SyncMemberEmail(account.ID, item.Email)
+	}
+
+	switch item.Kind {
+	case model.CSPDriftKindMissingMember:
+		_, err = s.cspService.UpdateCSPAccountMember(*item.CSPAccountMemberID, adminID, &model.CSPAccountMemberUpdateRequest{
+			Status: string(model.CSPAccountMemberStatusInactive),
+		})
+	case model.CSPDriftKindRoleMismatch:
+		_, err = s.cspService.UpdateCSPAccountMember(*item.CSPAccountMemberID, adminID, &model.CSPAccountMemberUpdateRequest{
+			Role: item.Actual,
+		})
+	case model.CSPDriftKindExtraMember:
+		err = s.importMember(account, item, adminID, req.ProjectID)
+	}
+	return err
+}
+
+// importAccount はプラットフォームに登録されていないアカウントをCSPアカウントとして登録する
+// 認証情報は保管せず、プロジェクトが指定されていれば紐付ける
+func (s *reconciliationService) importAccount(item *model.CSPDriftItem, adminID uint, projectID *uint) error {
+	definition, ok := model.LookupCSPProvider(item.Provider)
+	if !ok {
+		return model.ErrInvalidCSPProvider
+	}
+	if !definition.ValidateAccountID(item.ProviderAccountID) {
+		return model.ErrInvalidCSPAccountID
+	}
+	driver, err := s.providerDrivers.Driver(item.Provider)
+	if err != nil {
+		return err
+	}
+	providerAccount, err := driver.GetAccount(item.ProviderAccountID)
+	if err != nil {
+		return err
+	}
+
+	status := model.CSPAccountStatusProvisioning
+	switch providerAccount.Status {
+	case model.ProviderAccountStatusActive:
+		status = model.CSPAccountStatusActive
+	case model.ProviderAccountStatusSuspended:
+		status = model.CSPAccountStatusSuspended
+	case model.ProviderAccountStatusClosed, model.ProviderAccountStatusFailed:
+		return model.ErrCSPAccountClosed
+	}
+
+	accountName := item.AccountName
+	if accountName == "" {
+		accountName = item.ProviderAccountID
+	}
+	now := time.Now()
+	account := &model.CSPAccount{
+		Provider:        item.Provider,
+		AccountName:     accountName,
+		AccountID:       item.ProviderAccountID,
+		Region:          driver.DefaultRegion(),
+		Status:          status,
+		StatusReason:    "棚卸しでプロバイダーから取り込み",
+		StatusChangedAt: &now,
+		CreatedBy:       adminID,
+	}
+	if err := s.cspRepo.InsertCSPAccount(account); err != nil {
+		return err
+	}
+	item.CSPAccountID = &account.ID
+
+	if projectID != nil {
+		if _, err := s.cspService.CreateProjectCSPAccount(adminID, *projectID, account.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordAccountClosed はプロバイダーに存在しないアカウントを閉鎖済みとして記録する
+func (s *reconciliationService) recordAccountClosed(item *model.CSPDriftItem, adminID uint, note string) error {
+	account, err := s.cspRepo.SelectCSPAccountByID(*item.CSPAccountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrCSPAccountNotFound
+		}
+		return err
+	}
+	if account.Status.IsTerminal() {
+		return nil
+	}
+
+	reason := "棚卸しでプロバイダーに存在しないことを確認"
+	if note = strings.TrimSpace(note); note != "" {
+		reason = fmt.Sprintf("%s（%s）", reason, note)
+	}
+	event := transitionCSPAccountStatus(account, model.CSPAccountStatusClosed, reason, &adminID, time.Now())
+	return s.cspRepo.UpdateCSPAccountStatus(account, event)
+}
+
+// importMember はプロバイダー側でロールを付与されたユーザーをCSPアカウントのメンバーとして登録する
+// プロジェクトが指定されていない場合は、アカウントが紐付いている唯一のプロジェクトを使う
+func (s *reconciliationService) importMember(account *model.CSPAccount, item *model.CSPDriftItem, adminID uint, projectID *uint) error {
+	if projectID == nil {
+		if len(account.ProjectCSPAccounts) != 1 {
+			return model.ErrDriftProjectRequired
+		}
+		projectID = &account.ProjectCSPAccounts[0].ProjectID
+	}
+
+	user, err := s.userRepo.SelectByEmail(item.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrUserNotFound
+		}
+		return err
+	}
+
+	member, err := s.cspService.CreateCSPAccountMember(adminID, &model.CSPAccountMemberCreateRequest{
+		CSPAccountID: account.ID,
+		ProjectID:    *projectID,
+		UserID:       user.ID,
+		SSOEmail:     item.Email,
+		Role:         item.Actual,
+	})
+	if err != nil {
+		return err
+	}
+	item.CSPAccountMemberID = &member.ID
+	return nil
+}
@@ -0,0 +1,356 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// defaultSecretRevealWindow は開示申請を承認・開示できる期間のデフォルト
+const defaultSecretRevealWindow = time.Hour
+
+// minRevealJustificationLength は開示申請の理由に求める最小文字数
+const minRevealJustificationLength = 20
+
+type secretRevealService struct {
+	revealRepo      interfaces.SecretRevealRepository
+	cspRepo         interfaces.CSPRepository
+	projectRepo     interfaces.ProjectRepository
+	notifier        interfaces.Notifier
+	requireApproval bool
+	window          time.Duration
+	auditKey        []byte
+}
+
+func NewSecretRevealService(
+	revealRepo interfaces.SecretRevealRepository,
+	cspRepo interfaces.CSPRepository,
+	projectRepo interfaces.ProjectRepository,
+	notifier interfaces.Notifier,
+) (interfaces.SecretRevealService, error) {
+	// 監査記録のハッシュチェーンはCSP_SECRET_REVEAL_AUDIT_KEYの鍵でHMACを計算する（DBを書き換えられる者でも鍵なしでは改ざんできない）
+	auditKey := os.Getenv("CSP_SECRET_REVEAL_AUDIT_KEY")
+	if auditKey == "" {
+		return nil, fmt.Errorf("CSP_SECRET_REVEAL_AUDIT_KEY is not set")
+	}
+
+	// CSP_SECRET_REVEAL_REQUIRE_APPROVAL=trueの場合は、すべての開示申請に別の管理者の承認を必須にする
+	requireApproval := strings.EqualFold(os.Getenv("CSP_SECRET_REVEAL_REQUIRE_APPROVAL"), "true")
+
+	// 承認・開示できる期間はCSP_SECRET_REVEAL_WINDOW（例: 30m）で変更できる
+	window := defaultSecretRevealWindow
+	if v, err := time.ParseDuration(os.Getenv("CSP_SECRET_REVEAL_WINDOW")); err == nil && v > 0 {
+		window = v
+	}
+
+	return &secretRevealService{
+		revealRepo:      revealRepo,
+		cspRepo:         cspRepo,
+		projectRepo:     projectRepo,
+		notifier:        notifier,
+		requireApproval: requireApproval,
+		window:          window,
+		auditKey:        []byte(auditKey),
+	}, nil
+}
+
+// RequestReveal はCSPアカウントの長期の認証情報の開示を申請する
+// 承認が不要な場合は申請と同時に承認済みになり、申請者が期限内に一度だけ開示できる
+func (s *secretRevealService) RequestReveal(cspAccountID, requesterID uint, req *model.SecretRevealCreateRequest, sourceIP string) (*model.CSPSecretReveal, error) {
+	justification := strings.TrimSpace(req.Justification)
+	if len([]rune(justification)) < minRevealJustificationLength {
+		return nil, model.ErrInvalidRevealJustification
+	}
+
+	account, err := s.getAccount(cspAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.SecretKey == "" {
+		return nil, model.ErrCSPAccountHasNoSecret
+	}
+
+	now := time.Now()
+	reveal := &model.CSPSecretReveal{
+		CSPAccountID:     account.ID,
+		RequestedBy:      requesterID,
+		Justification:    justification,
+		RequiresApproval: s.requireApproval || req.RequireApproval,
+		Status:           model.SecretRevealStatusApproved,
+		ExpiresAt:        now.Add(s.window),
+	}
+	if reveal.RequiresApproval {
+		reveal.Status = model.SecretRevealStatusPendingApproval
+	}
+
+	if err := s.insertReveal(reveal, &model.SecretRevealAuditEntry{
+		CSPAccountID: account.ID,
+		Event:        model.SecretRevealEventRequested,
+		ActorID:      requesterID,
+		Detail:       justification,
+		SourceIP:     sourceIP,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.revealRepo.SelectByID(reveal.ID)
+}
+
+// ApproveReveal は申請者以外の管理者が開示申請を承認する
+// 承認時点から改めて期限を設定する
+func (s *secretRevealService) ApproveReveal(revealID, approverID uint, sourceIP string) (*model.CSPSecretReveal, error) {
+	reveal, err := s.getPendingReveal(revealID, approverID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	reveal.Status = model.SecretRevealStatusApproved
+	reveal.ApprovedBy = &approverID
+	reveal.ApprovedAt = &now
+	reveal.ExpiresAt = now.Add(s.window)
+
+	if err := s.transitionReveal(reveal, model.SecretRevealStatusPendingApproval, &model.SecretRevealAuditEntry{
+		CSPAccountID: reveal.CSPAccountID,
+		Event:        model.SecretRevealEventApproved,
+		ActorID:      approverID,
+		SourceIP:     sourceIP,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.revealRepo.SelectByID(reveal.ID)
+}
+
+// RejectReveal は申請者以外の管理者が開示申請を却下する
+func (s *secretRevealService) RejectReveal(revealID, approverID uint, req *model.SecretRevealRejectRequest, sourceIP string) (*model.CSPSecretReveal, error) {
+	reveal, err := s.getPendingReveal(revealID, approverID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	reveal.Status = model.SecretRevealStatusRejected
+	reveal.ApprovedBy = &approverID
+	reveal.ApprovedAt = &now
+	reveal.RejectReason = strings.TrimSpace(req.Reason)
+
+	if err := s.transitionReveal(reveal, model.SecretRevealStatusPendingApproval, &model.SecretRevealAuditEntry{
+		CSPAccountID: reveal.CSPAccountID,
+		Event:        model.SecretRevealEventRejected,
+		ActorID:      approverID,
+		Detail:       reveal.RejectReason,
+		SourceIP:     sourceIP,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.revealRepo.SelectByID(reveal.ID)
+}
+
+// Reveal は承認済みの開示申請について、申請者に長期の認証情報を一度だけ返す
+// 開示の記録を残してからプロジェクトのオーナーに通知する
+func (s *secretRevealService) Reveal(revealID, requesterID uint, sourceIP string) (*model.RevealedSecret, error) {
+	reveal, err := s.getReveal(revealID)
+	if err != nil {
+		return nil, err
+	}
+	if reveal.RequestedBy != requesterID {
+		return nil, model.ErrSecretRevealNotRequester
+	}
+	if reveal.Status != model.SecretRevealStatusApproved {
+		return nil, model.ErrSecretRevealNotApproved
+	}
+	now := time.Now()
+	if reveal.IsExpired(now) {
+		return nil, model.ErrSecretRevealExpired
+	}
+
+	account, err := s.getAccount(reveal.CSPAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.SecretKey == "" {
+		return nil, model.ErrCSPAccountHasNoSecret
+	}
+
+	// ステータスを開示済みに更新できた場合のみ返す（同時に開示しても一度しか返さない）
+	reveal.Status = model.SecretRevealStatusRevealed
+	reveal.RevealedAt = &now
+	if err := s.transitionReveal(reveal, model.SecretRevealStatusApproved, &model.SecretRevealAuditEntry{
+		CSPAccountID: reveal.CSPAccountID,
+		Event:        model.SecretRevealEventRevealed,
+		ActorID:      requesterID,
+		SourceIP:     sourceIP,
+	}); err != nil {
+		return nil, err
+	}
+
+	s.notifyOwners(account, reveal)
+
+	return &model.RevealedSecret{
+		RevealID:     reveal.ID,
+		CSPAccountID: account.ID,
+		Provider:     account.Provider,
+		AccountID:    account.AccountID,
+		AccessKey:    account.AccessKey,
+		SecretKey:    account.SecretKey,
+		RevealedAt:   now,
+	}, nil
+}
+
+// GetReveals はCSPアカウントの開示申請の一覧を取得
+func (s *secretRevealService) GetReveals(cspAccountID uint) ([]model.CSPSecretReveal, error) {
+	if _, err := s.getAccount(cspAccountID); err != nil {
+		return nil, err
+	}
+	return s.revealRepo.SelectByCSPAccountID(cspAccountID)
+}
+
+// VerifyAuditLog は監査記録のハッシュチェーンをたどり、改ざん・欠落がないかを検証する
+// 保存した先頭とチェーンの末尾を照合し、末尾の記録の削除（切り詰め）も検出する
+func (s *secretRevealService) VerifyAuditLog() (*model.SecretRevealAuditVerification, error) {
+	entries, err := s.revealRepo.SelectAuditEntries()
+	if err != nil {
+		return nil, err
+	}
+	head, err := s.revealRepo.SelectAuditHead()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.SecretRevealAuditVerification{Valid: true, Entries: len(entries)}
+	prevHash := model.SecretRevealAuditGenesisHash
+	var lastEntryID uint
+	for i := range entries {
+		entry := &entries[i]
+		if entry.PrevHash != prevHash || entry.ComputeHash(s.auditKey) != entry.Hash {
+			result.Valid = false
+			result.BrokenEntryID = &entry.ID
+			return result, nil
+		}
+		prevHash = entry.Hash
+		lastEntryID = entry.ID
+	}
+
+	if head == nil {
+		// 先頭がない場合は記録も1件もないはず
+		result.HeadMismatch = len(entries) > 0
+	} else {
+		result.HeadEntryID = head.LastEntryID
+		result.HeadHash = head.Hash
+		result.HeadMismatch = head.ComputeMAC(s.auditKey) != head.MAC ||
+			head.LastEntryID != lastEntryID || head.Hash != prevHash || head.Entries != len(entries)
+	}
+	if result.HeadMismatch {
+		result.Valid = false
+	}
+	return result, nil
+}
+
+// insertReveal は開示申請を作成して監査記録を追記し、新しい先頭をログに出力する
+func (s *secretRevealService) insertReveal(reveal *model.CSPSecretReveal, entry *model.SecretRevealAuditEntry) error {
+	if err := s.revealRepo.Insert(reveal, entry, s.auditKey); err != nil {
+		return err
+	}
+	logAuditHead(entry)
+	return nil
+}
+
+// transitionReveal は開示申請のステータスを更新して監査記録を追記し、新しい先頭をログに出力する
+func (s *secretRevealService) transitionReveal(reveal *model.CSPSecretReveal, from model.SecretRevealStatus, entry *model.SecretRevealAuditEntry) error {
+	if err := s.revealRepo.Transition(reveal, from, entry, s.auditKey); err != nil {
+		return err
+	}
+	logAuditHead(entry)
+	return nil
+}
+
+// logAuditHead は監査記録の先頭をログに出力する（DBの外に控えた値と照合して切り詰めを検出できるようにする）
+func logAuditHead(entry *model.SecretRevealAuditEntry) {
+	log.Printf("Secret reveal audit head: entry=%d hash=%s", entry.ID, entry.Hash)
+}
+
+// notifyOwners は認証情報が開示されたことをCSPアカウントに紐づくプロジェクトのオーナーに通知する
+func (s *secretRevealService) notifyOwners(account *model.CSPAccount, reveal *model.CSPSecretReveal) {
+	seen := make(map[string]bool)
+	var recipients []string
+	for _, relation := range account.ProjectCSPAccounts {
+		emails, err := s.projectRepo.SelectOwnerEmails(relation.ProjectID)
+		if err != nil {
+			log.Printf("Failed to get owners of project %d: %v", relation.ProjectID, err)
+			continue
+		}
+		for _, email := range emails {
+			if email != "" && !seen[email] {
+				seen[email] = true
+				recipients = append(recipients, email)
+			}
+		}
+	}
+	if len(recipients) == 0 {
+		return
+	}
+
+	requester := reveal.RequestedByUser.Email
+	if requester == "" {
+		requester = fmt.Sprintf("user #%d", reveal.RequestedBy)
+	}
+	body := fmt.Sprintf("CSPアカウント「%s」（%s %s）の長期の認証情報が開示されました。\n\n開示者: %s\n理由: %s\n\n心当たりがない場合は管理者に連絡してください。開示後は認証情報のローテーションを推奨します。",
+		account.AccountName, account.Provider, account.AccountID, requester, reveal.Justification)
+	if err := s.notifier.Send(&model.Notification{
+		To:      recipients,
+		Subject: fmt.Sprintf("[CGAS] 認証情報が開示されました（%s）", account.AccountName),
+		Body:    body,
+	}); err != nil {
+		log.Printf("Failed to send secret reveal notification (CSP account %d): %v", account.ID, err)
+	}
+}
+
+// getPendingReveal は承認待ちで期限内の開示申請を取得し、承認者が申請者本人でないことを確認する
+func (s *secretRevealService) getPendingReveal(revealID, approverID uint) (*model.CSPSecretReveal, error) {
+	reveal, err := s.getReveal(revealID)
+	if err != nil {
+		return nil, err
+	}
+	if reveal.Status != model.SecretRevealStatusPendingApproval {
+		return nil, model.ErrSecretRevealNotPending
+	}
+	if reveal.RequestedBy == approverID {
+		return nil, model.ErrSecretRevealSelfApproval
+	}
+	if reveal.IsExpired(time.Now()) {
+		return nil, model.ErrSecretRevealExpired
+	}
+	return reveal, nil
+}
+
+func (s *secretRevealService) getReveal(revealID uint) (*model.CSPSecretReveal, error) {
+	reveal, err := s.revealRepo.SelectByID(revealID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrSecretRevealNotFound
+		}
+		return nil, err
+	}
+	return reveal, nil
+}
+
+func (s *secretRevealService) getAccount(cspAccountID uint) (*model.CSPAccount, error) {
+	account, err := s.cspRepo.SelectCSPAccountByID(cspAccountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrCSPAccountNotFound
+		}
+		return nil, err
+	}
+	return account, nil
+}
@@ -0,0 +1,889 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+)
+
+type stateSyncService struct {
+	stateRepo          interfaces.StateRepository
+	projectRepo        interfaces.ProjectRepository
+	deletionService    interfaces.DeletionService
+	provisioningClient interfaces.ProvisioningClient
+}
+
+func NewStateSyncService(
+	stateRepo interfaces.StateRepository,
+	projectRepo interfaces.ProjectRepository,
+	deletionService interfaces.DeletionService,
+	provisioningClient interfaces.ProvisioningClient,
+) interfaces.StateSyncService {
+	return &stateSyncService{
+		stateRepo:          stateRepo,
+		projectRepo:        projectRepo,
+		deletionService:    deletionService,
+		provisioningClient: provisioningClient,
+	}
+}
+
+// Plan は定義（YAML）と現在のDBの状態の差分から変更計画を作成する
+// pruneを指定した場合は定義に含まれないリソースの削除も計画に含める（管理者組織・管理者プロジェクトは対象外）
+func (s *stateSyncService) Plan(data []byte, prune bool) (*model.StatePlan, error) {
+	state, err := model.ParsePlatformState(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := state.Validate(); err != nil {
+		return nil, err
+	}
+
+	snapshot, err := s.stateRepo.LoadSnapshot(state.Emails())
+	if err != nil {
+		return nil, err
+	}
+
+	builder := newStatePlanBuilder(state, snapshot, prune)
+	plan := builder.build()
+	problems, err := s.deletionProblems(plan)
+	if err != nil {
+		return nil, err
+	}
+	statusProblems, err := s.statusTransitionProblems(plan)
+	if err != nil {
+		return nil, err
+	}
+	problems = append(append(builder.problems, problems...), statusProblems...)
+	if len(problems) > 0 {
+		return nil, &model.StateValidationError{Problems: problems}
+	}
+
+	plan.Fingerprint, err = stateFingerprint(plan)
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// Apply は変更計画を作成し、1トランザクションで適用する
+// expectedFingerprintを指定した場合は確認済みのプランと内容が一致するときのみ適用する
+func (s *stateSyncService) Apply(actorID uint, data []byte, prune bool, expectedFingerprint string) (*model.StatePlan, error) {
+	plan, err := s.Plan(data, prune)
+	if err != nil {
+		return nil, err
+	}
+	if expectedFingerprint != "" && expectedFingerprint != plan.Fingerprint {
+		return nil, model.ErrStatePlanOutdated
+	}
+	if !plan.HasChanges() {
+		return plan, nil
+	}
+
+	if err := s.stateRepo.ApplyPlan(plan, actorID); err != nil {
+		return nil, err
+	}
+	plan.Applied = true
+	return plan, nil
+}
+
+// deletionProblems は削除するプロジェクトを通常の削除と同じ削除ポリシーで確認し、ブロックされるものを問題点として返す
+func (s *stateSyncService) deletionProblems(plan *model.StatePlan) ([]string, error) {
+	var problems []string
+	for _, change := range plan.Changes {
+		if change.Kind != model.StateResourceProject || change.Action != model.StateChangeDelete {
+			continue
+		}
+		err := s.deletionService.EnsureProjectDeletable(change.Target.ID)
+		var blocked *model.DeletionBlockedError
+		if errors.As(err, &blocked) {
+			problems = append(problems, fmt.Sprintf("project %q cannot be deleted: %s", change.Key, blockingResources(blocked.Impact)))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return problems, nil
+}
+
+// statusTransitionProblems はプロジェクトのステータス変更を通常の更新と同じ前提条件で確認し、満たさないものを問題点として返す
+// 遷移ルール自体に反する変更はプラン作成時に問題点として報告済みのため対象外
+func (s *stateSyncService) statusTransitionProblems(plan *model.StatePlan) ([]string, error) {
+	var problems []string
+	for _, change := range plan.Changes {
+		if change.Kind != model.StateResourceProject || change.Action != model.StateChangeUpdate {
+			continue
+		}
+		for _, field := range change.Changes {
+			from, to := model.ProjectStatus(field.From), model.ProjectStatus(field.To)
+			if field.Field != "status" || !from.CanTransitionTo(to) {
+				continue
+			}
+			err := checkProjectStatusTransition(s.projectRepo, s.provisioningClient, change.Target.ID, from, to)
+			if errors.Is(err, model.ErrProjectHasActiveCSPAccounts) || errors.Is(err, model.ErrProjectHasPendingCSPRequests) {
+				problems = append(problems, fmt.Sprintf("project %q cannot change status to %q: %v", change.Key, to, err))
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return problems, nil
+}
+
+// blockingResources は削除をブロックしている依存リソースの一覧を文字列にする
+func blockingResources(impact *model.DeletionImpact) string {
+	var resources []string
+	for _, dependency := range impact.Dependencies {
+		if dependency.Policy == model.DeletionPolicyBlock {
+			resources = append(resources, fmt.Sprintf("%s (%d)", dependency.Resource, dependency.Count))
+		}
+	}
+	if impact.ProvisioningUnavailable {
+		resources = append(resources, "csp requests could not be checked")
+	}
+	return strings.Join(resources, ", ")
+}
+
+// stateFingerprint は変更内容とprune指定から計画の同一性を判定するハッシュを計算
+func stateFingerprint(plan *model.StatePlan) (string, error) {
+	payload, err := json.Marshal(struct {
+		Prune   bool                `json:"prune"`
+		Changes []model.StateChange `json:"changes"`
+	}{plan.Prune, plan.Changes})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// statePlanBuilder は定義とスナップショットを突き合わせて変更計画を組み立てる
+type statePlanBuilder struct {
+	state    *model.PlatformState
+	snapshot *model.StateSnapshot
+	prune    bool
+	plan     *model.StatePlan
+	problems []string
+
+	organizationsByName map[string][]model.Organization
+	organizationNames   map[uint]string
+	projectsByKey       map[string][]model.Project
+	members             map[uint][]model.UserProjectRole
+	vendorRelations     map[uint][]model.ProjectVendorRelation
+	projectCSPAccounts  map[uint][]model.ProjectCSPAccount
+	cspAccountMembers   map[[2]uint][]model.CSPAccountMember
+	cspAccountsByKey    map[string][]model.CSPAccount
+	usersByEmail        map[string]model.User
+
+	declaredOrganizations map[string]*stateOrganizationRef
+	declaredProjects      map[string]*stateProjectRef
+}
+
+// stateOrganizationRef は計画内での組織の参照（新規作成の場合IDは0）
+type stateOrganizationRef struct {
+	key string
+	id  uint
+}
+
+// stateProjectRef は計画内でのプロジェクトの参照（新規作成の場合existingはnil）
+type stateProjectRef struct {
+	key          string
+	organization *stateOrganizationRef
+	existing     *model.Project
+	kind         model.ProjectType
+	declared     *model.StateProject
+}
+
+func (r *stateProjectRef) id() uint {
+	if r.existing == nil {
+		return 0
+	}
+	return r.existing.ID
+}
+
+func newStatePlanBuilder(state *model.PlatformState, snapshot *model.StateSnapshot, prune bool) *statePlanBuilder {
+	b := &statePlanBuilder{
+		state:                 state,
+		snapshot:              snapshot,
+		prune:                 prune,
+		plan:                  &model.StatePlan{Prune: prune, Changes: []model.StateChange{}},
+		organizationsByName:   make(map[string][]model.Organization),
+		organizationNames:     make(map[uint]string),
+		projectsByKey:         make(map[string][]model.Project),
+		members:               make(map[uint][]model.UserProjectRole),
+		vendorRelations:       make(map[uint][]model.ProjectVendorRelation),
+		projectCSPAccounts:    make(map[uint][]model.ProjectCSPAccount),
+		cspAccountMembers:     make(map[[2]uint][]model.CSPAccountMember),
+		cspAccountsByKey:      make(map[string][]model.CSPAccount),
+		usersByEmail:          make(map[string]model.User),
+		declaredOrganizations: make(map[string]*stateOrganizationRef),
+		declaredProjects:      make(map[string]*stateProjectRef),
+	}
+
+	for _, org := range snapshot.Organizations {
+		b.organizationsByName[org.Name] = append(b.organizationsByName[org.Name], org)
+		b.organizationNames[org.ID] = org.Name
+	}
+	for _, project := range snapshot.Projects {
+		key := model.StateProjectKey(b.organizationNames[project.OrganizationID], project.Name)
+		b.projectsByKey[key] = append(b.projectsByKey[key], project)
+	}
+	for _, member := range snapshot.Members {
+		b.members[member.ProjectID] = append(b.members[member.ProjectID], member)
+	}
+	for _, relation := range snapshot.VendorRelations {
+		b.vendorRelations[relation.ProjectID] = append(b.vendorRelations[relation.ProjectID], relation)
+	}
+	for _, link := range snapshot.ProjectCSPAccounts {
+		b.projectCSPAccounts[link.ProjectID] = append(b.projectCSPAccounts[link.ProjectID], link)
+	}
+	for _, member := range snapshot.CSPAccountMembers {
+		key := [2]uint{member.ProjectID, member.CSPAccountID}
+		b.cspAccountMembers[key] = append(b.cspAccountMembers[key], member)
+	}
+	for _, account := range snapshot.CSPAccounts {
+		key := model.StateCSPAccountKey(account.Provider, account.AccountID)
+		b.cspAccountsByKey[key] = append(b.cspAccountsByKey[key], account)
+	}
+	for _, user := range snapshot.Users {
+		b.usersByEmail[strings.ToLower(user.Email)] = user
+	}
+	return b
+}
+
+func (b *statePlanBuilder) addProblem(format string, args ...interface{}) {
+	b.problems = append(b.problems, fmt.Sprintf(format, args...))
+}
+
+// build は組織→プロジェクト→プロジェクト配下のリソースの順に作成・更新し、最後に不要なプロジェクト・組織を削除する計画を作る
+func (b *statePlanBuilder) build() *model.StatePlan {
+	for i := range b.state.Organizations {
+		b.planOrganization(&b.state.Organizations[i])
+	}
+	for i := range b.state.Projects {
+		b.planProject(&b.state.Projects[i])
+	}
+	for i := range b.state.Projects {
+		ref := b.declaredProjects[model.StateProjectKey(b.state.Projects[i].Organization, b.state.Projects[i].Name)]
+		if ref == nil {
+			continue
+		}
+		before := len(b.plan.Changes)
+		b.planMembers(ref)
+		b.planVendorRelations(ref)
+		b.planCSPAccounts(ref)
+		if len(b.plan.Changes) > before && ref.existing != nil && ref.existing.Status.IsReadOnly() &&
+			(ref.declared.Status == "" || ref.declared.Status.IsReadOnly()) {
+			b.addProblem("project %q is archived and its members or assignments cannot be changed", ref.key)
+		}
+	}
+	if b.prune {
+		b.pruneProjects()
+		b.pruneOrganizations()
+	}
+	return b.plan
+}
+
+func (b *statePlanBuilder) planOrganization(declared *model.StateOrganization) {
+	key := model.StateOrganizationKey(declared.Name)
+	existing := b.organizationsByName[declared.Name]
+	if len(existing) > 1 {
+		b.addProblem("organization %q is ambiguous: %d organizations have this name", key, len(existing))
+		return
+	}
+
+	if len(existing) == 0 {
+		b.declaredOrganizations[key] = &stateOrganizationRef{key: key}
+		b.plan.Add(model.StateChange{
+			Action: model.StateChangeCreate,
+			Kind:   model.StateResourceOrganization,
+			Key:    key,
+			Target: model.StateChangeTarget{OrganizationKey: key, Organization: declared},
+		})
+		return
+	}
+
+	org := existing[0]
+	b.declaredOrganizations[key] = &stateOrganizationRef{key: key, id: org.ID}
+
+	var changes []model.StateFieldChange
+	if declared.Type != "" && declared.Type != org.OrganizationType {
+		changes = append(changes, model.StateFieldChange{Field: "type", From: string(org.OrganizationType), To: string(declared.Type)})
+	}
+	if declared.Description != nil && *declared.Description != org.Description {
+		changes = append(changes, model.StateFieldChange{Field: "description", From: org.Description, To: *declared.Description})
+	}
+	if declared.Status != "" && declared.Status != org.Status {
+		changes = append(changes, model.StateFieldChange{Field: "status", From: string(org.Status), To: string(declared.Status)})
+	}
+	if len(changes) > 0 {
+		b.plan.Add(model.StateChange{
+			Action:  model.StateChangeUpdate,
+			Kind:    model.StateResourceOrganization,
+			Key:     key,
+			Changes: changes,
+			Target:  model.StateChangeTarget{ID: org.ID, OrganizationID: org.ID, OrganizationKey: key, Organization: declared},
+		})
+	}
+}
+
+// resolveOrganization は定義またはDBから組織を解決する（prune時は定義に含まれない組織は削除されるため参照できない）
+func (b *statePlanBuilder) resolveOrganization(name string) *stateOrganizationRef {
+	if ref, ok := b.declaredOrganizations[name]; ok {
+		return ref
+	}
+	existing := b.organizationsByName[name]
+	switch {
+	case len(existing) > 1:
+		b.addProblem("organization %q is ambiguous: %d organizations have this name", name, len(existing))
+		return nil
+	case len(existing) == 0:
+		b.addProblem("organization %q is not declared and does not exist", name)
+		return nil
+	case b.prune && existing[0].OrganizationType != model.OrganizationTypeAdmin:
+		b.addProblem("organization %q must be declared when pruning", name)
+		return nil
+	}
+	return &stateOrganizationRef{key: name, id: existing[0].ID}
+}
+
+func (b *statePlanBuilder) planProject(declared *model.StateProject) {
+	key := model.StateProjectKey(declared.Organization, declared.Name)
+	organization := b.resolveOrganization(declared.Organization)
+	if organization == nil {
+		return
+	}
+
+	var existing []model.Project
+	if organization.id != 0 {
+		existing = b.projectsByKey[key]
+	}
+	if len(existing) > 1 {
+		b.addProblem("project %q is ambiguous: %d projects have this name", key, len(existing))
+		return
+	}
+
+	if len(existing) == 0 {
+		if len(declared.Members) == 0 {
+			b.addProblem("project %q: members with an owner are required to create a project", key)
+		}
+		if declared.Status != "" && declared.Status != model.ProjectStatusActive {
+			b.addProblem("project %q: new projects must start as %q", key, model.ProjectStatusActive)
+		}
+		kind := declared.Type
+		if kind == "" {
+			kind = model.ProjectType("normal")
+		}
+		b.declaredProjects[key] = &stateProjectRef{key: key, organization: organization, kind: kind, declared: declared}
+		b.plan.Add(model.StateChange{
+			Action: model.StateChangeCreate,
+			Kind:   model.StateResourceProject,
+			Key:    key,
+			Target: model.StateChangeTarget{
+				OrganizationID:  organization.id,
+				OrganizationKey: organization.key,
+				ProjectKey:      key,
+				Project:         declared,
+			},
+		})
+		return
+	}
+
+	project := existing[0]
+	b.declaredProjects[key] = &stateProjectRef{
+		key: key, organization: organization, existing: &project, kind: project.ProjectType, declared: declared,
+	}
+
+	if declared.Type != "" && declared.Type != project.ProjectType {
+		b.addProblem("project %q: type cannot be changed from %q to %q", key, project.ProjectType, declared.Type)
+	}
+
+	var changes []model.StateFieldChange
+	if declared.Description != nil && *declared.Description != project.Description {
+		changes = append(changes, model.StateFieldChange{Field: "description", From: project.Description, To: *declared.Description})
+	}
+	if declared.Tags != nil && !equalProjectTags(declared.Tags, project.Tags) {
+		changes = append(changes, model.StateFieldChange{Field: "tags", From: formatProjectTags(project.Tags), To: formatProjectTags(declared.Tags)})
+	}
+	if project.Status.IsReadOnly() && len(changes) > 0 && (declared.Status == "" || declared.Status.IsReadOnly()) {
+		b.addProblem("project %q is archived and cannot be modified", key)
+	}
+	if declared.Status != "" && declared.Status != project.Status {
+		if !project.Status.CanTransitionTo(declared.Status) {
+			b.addProblem("project %q: status cannot change from %q to %q", key, project.Status, declared.Status)
+		}
+		changes = append(changes, model.StateFieldChange{Field: "status", From: string(project.Status), To: string(declared.Status)})
+	}
+	if len(changes) > 0 {
+		b.plan.Add(model.StateChange{
+			Action:  model.StateChangeUpdate,
+			Kind:    model.StateResourceProject,
+			Key:     key,
+			Changes: changes,
+			Target: model.StateChangeTarget{
+				ID:              project.ID,
+				OrganizationID:  organization.id,
+				OrganizationKey: organization.key,
+				ProjectID:       project.ID,
+				ProjectKey:      key,
+				Project:         declared,
+			},
+		})
+	}
+}
+
+// resolveUser は定義で参照されたメールアドレスのユーザーを解決する（ユーザーは事前に登録されている必要がある）
+func (b *statePlanBuilder) resolveUser(projectKey, email string) (model.User, bool) {
+	user, ok := b.usersByEmail[strings.ToLower(email)]
+	if !ok {
+		b.addProblem("project %q: user %q does not exist", projectKey, email)
+	}
+	return user, ok
+}
+
+func (b *statePlanBuilder) planMembers(ref *stateProjectRef) {
+	declared := ref.declared.Members
+	if declared == nil {
+		return
+	}
+
+	existing := make(map[uint]model.UserProjectRole)
+	if ref.existing != nil {
+		for _, member := range b.members[ref.existing.ID] {
+			existing[member.UserID] = member
+		}
+	}
+
+	owners := 0
+	declaredUsers := make(map[uint]bool, len(declared))
+	for _, member := range declared {
+		user, ok := b.resolveUser(ref.key, member.Email)
+		if !ok {
+			continue
+		}
+		declaredUsers[user.ID] = true
+		if member.Role == model.RoleOwner {
+			owners++
+		}
+
+		email := strings.ToLower(member.Email)
+		target := model.StateChangeTarget{
+			ProjectID:  ref.id(),
+			ProjectKey: ref.key,
+			UserID:     user.ID,
+			Email:      email,
+			Role:       string(member.Role),
+		}
+		current, ok := existing[user.ID]
+		switch {
+		case !ok:
+			b.plan.Add(model.StateChange{
+				Action:  model.StateChangeCreate,
+				Kind:    model.StateResourceProjectMember,
+				Key:     ref.key + "/members/" + email,
+				Changes: []model.StateFieldChange{{Field: "role", To: string(member.Role)}},
+				Target:  target,
+			})
+		case current.Role != member.Role:
+			b.plan.Add(model.StateChange{
+				Action:  model.StateChangeUpdate,
+				Kind:    model.StateResourceProjectMember,
+				Key:     ref.key + "/members/" + email,
+				Changes: []model.StateFieldChange{{Field: "role", From: string(current.Role), To: string(member.Role)}},
+				Target:  target,
+			})
+		}
+	}
+
+	for _, member := range b.members[ref.id()] {
+		if ref.existing == nil || declaredUsers[member.UserID] {
+			continue
+		}
+		if !b.prune {
+			// 削除しないメンバーがオーナーのままだとオーナーが複数になる
+			if member.Role == model.RoleOwner && owners > 0 {
+				b.addProblem("project %q: current owner %q is not declared; declare them with another role or use prune", ref.key, member.User.Email)
+			}
+			continue
+		}
+		email := strings.ToLower(member.User.Email)
+		b.plan.Add(model.StateChange{
+			Action: model.StateChangeDelete,
+			Kind:   model.StateResourceProjectMember,
+			Key:    ref.key + "/members/" + email,
+			Target: model.StateChangeTarget{ProjectID: ref.existing.ID, ProjectKey: ref.key, UserID: member.UserID, Email: email},
+		})
+	}
+}
+
+// projectMemberIDs は計画適用後にプロジェクトメンバーとなるユーザーIDを返す
+func (b *statePlanBuilder) projectMemberIDs(ref *stateProjectRef) map[uint]bool {
+	ids := make(map[uint]bool)
+	if ref.declared.Members == nil || !b.prune {
+		for _, member := range b.members[ref.id()] {
+			ids[member.UserID] = true
+		}
+	}
+	for _, member := range ref.declared.Members {
+		if user, ok := b.usersByEmail[strings.ToLower(member.Email)]; ok {
+			ids[user.ID] = true
+		}
+	}
+	return ids
+}
+
+// resolveVendorProject は紐付け先のベンダープロジェクトを定義またはDBから解決する
+func (b *statePlanBuilder) resolveVendorProject(projectKey, vendorKey, organization string) *stateProjectRef {
+	if ref, ok := b.declaredProjects[vendorKey]; ok {
+		return ref
+	}
+	if _, declared := b.declaredOrganizations[organization]; !declared && b.organizationsByName[organization] == nil {
+		b.addProblem("project %q: vendor project %q does not exist", projectKey, vendorKey)
+		return nil
+	}
+	existing := b.projectsByKey[vendorKey]
+	switch {
+	case len(existing) > 1:
+		b.addProblem("project %q: vendor project %q is ambiguous", projectKey, vendorKey)
+		return nil
+	case len(existing) == 0:
+		b.addProblem("project %q: vendor project %q does not exist", projectKey, vendorKey)
+		return nil
+	case b.prune:
+		b.addProblem("project %q: vendor project %q must be declared when pruning", projectKey, vendorKey)
+		return nil
+	}
+	return &stateProjectRef{key: vendorKey, existing: &existing[0], kind: existing[0].ProjectType}
+}
+
+func (b *statePlanBuilder) planVendorRelations(ref *stateProjectRef) {
+	declared := ref.declared.Vendors
+	if declared == nil {
+		return
+	}
+	if len(declared) > 0 && (ref.kind == model.ProjectTypeVendor || ref.kind == model.ProjectTypeAdmin) {
+		b.addProblem("project %q: %s projects cannot have vendors", ref.key, ref.kind)
+		return
+	}
+
+	existing := make(map[uint]model.ProjectVendorRelation)
+	if ref.existing != nil {
+		for _, relation := range b.vendorRelations[ref.existing.ID] {
+			existing[relation.VendorProjectID] = relation
+		}
+	}
+
+	declaredVendors := make(map[uint]bool, len(declared))
+	for i := range declared {
+		vendor := &declared[i]
+		vendorKey := model.StateProjectKey(vendor.Organization, vendor.Project)
+		vendorRef := b.resolveVendorProject(ref.key, vendorKey, vendor.Organization)
+		if vendorRef == nil {
+			continue
+		}
+		if vendorRef.kind != model.ProjectTypeVendor {
+			b.addProblem("project %q: %q is not a vendor project", ref.key, vendorKey)
+			continue
+		}
+
+		key := ref.key + "/vendors/" + vendorKey
+		target := model.StateChangeTarget{
+			ProjectID:        ref.id(),
+			ProjectKey:       ref.key,
+			VendorProjectID:  vendorRef.id(),
+			VendorProjectKey: vendorKey,
+			Vendor:           vendor,
+		}
+		relation, ok := existing[vendorRef.id()]
+		if !ok || vendorRef.existing == nil {
+			b.plan.Add(model.StateChange{
+				Action:  model.StateChangeCreate,
+				Kind:    model.StateResourceVendorRelation,
+				Key:     key,
+				Changes: contractPeriodChanges("", "", vendor.StartDate, vendor.EndDate),
+				Target:  target,
+			})
+			continue
+		}
+		declaredVendors[relation.VendorProjectID] = true
+
+		currentStart, currentEnd := formatContractDate(relation.StartDate), formatContractDate(relation.EndDate)
+		changes := contractPeriodChanges(currentStart, currentEnd, vendor.StartDate, vendor.EndDate)
+		if relation.Status == model.VendorRelationStatusProposed {
+			changes = append(changes, model.StateFieldChange{Field: "status", From: string(relation.Status), To: string(model.VendorRelationStatusAccepted)})
+		}
+		if len(changes) > 0 {
+			target.ID = relation.ID
+			b.plan.Add(model.StateChange{
+				Action:  model.StateChangeUpdate,
+				Kind:    model.StateResourceVendorRelation,
+				Key:     key,
+				Changes: changes,
+				Target:  target,
+			})
+		}
+	}
+
+	if !b.prune || ref.existing == nil {
+		return
+	}
+	for _, relation := range b.vendorRelations[ref.existing.ID] {
+		if declaredVendors[relation.VendorProjectID] {
+			continue
+		}
+		b.plan.Add(model.StateChange{
+			Action: model.StateChangeDelete,
+			Kind:   model.StateResourceVendorRelation,
+			Key:    ref.key + "/vendors/" + b.projectKeyByID(relation.VendorProjectID),
+			Target: model.StateChangeTarget{ID: relation.ID, ProjectID: ref.existing.ID, ProjectKey: ref.key, VendorProjectID: relation.VendorProjectID},
+		})
+	}
+}
+
+func (b *statePlanBuilder) planCSPAccounts(ref *stateProjectRef) {
+	declared := ref.declared.CSPAccounts
+	if declared == nil {
+		return
+	}
+
+	existing := make(map[uint]model.ProjectCSPAccount)
+	if ref.existing != nil {
+		for _, link := range b.projectCSPAccounts[ref.existing.ID] {
+			existing[link.CSPAccountID] = link
+		}
+	}
+	memberIDs := b.projectMemberIDs(ref)
+
+	declaredAccounts := make(map[uint]bool, len(declared))
+	for i := range declared {
+		account := &declared[i]
+		accountKey := model.StateCSPAccountKey(account.Provider, account.AccountID)
+		candidates := b.cspAccountsByKey[accountKey]
+		if len(candidates) != 1 {
+			if len(candidates) == 0 {
+				b.addProblem("project %q: CSP account %q does not exist", ref.key, accountKey)
+			} else {
+				b.addProblem("project %q: CSP account %q is ambiguous", ref.key, accountKey)
+			}
+			continue
+		}
+		cspAccount := candidates[0]
+		declaredAccounts[cspAccount.ID] = true
+
+		key := ref.key + "/csp_accounts/" + accountKey
+		if _, ok := existing[cspAccount.ID]; !ok {
+			b.plan.Add(model.StateChange{
+				Action: model.StateChangeCreate,
+				Kind:   model.StateResourceProjectCSPAccount,
+				Key:    key,
+				Target: model.StateChangeTarget{ProjectID: ref.id(), ProjectKey: ref.key, CSPAccountID: cspAccount.ID},
+			})
+		}
+		b.planCSPAccountMembers(ref, key, cspAccount.ID, account.Members, memberIDs)
+	}
+
+	if !b.prune || ref.existing == nil {
+		return
+	}
+	for _, link := range b.projectCSPAccounts[ref.existing.ID] {
+		if declaredAccounts[link.CSPAccountID] {
+			continue
+		}
+		// 紐付けを解除するCSPアカウントのメンバーも合わせて削除する
+		key := ref.key + "/csp_accounts/" + model.StateCSPAccountKey(link.CSPAccount.Provider, link.CSPAccount.AccountID)
+		b.planCSPAccountMembers(ref, key, link.CSPAccountID, []model.StateCSPAccountMember{}, memberIDs)
+		b.plan.Add(model.StateChange{
+			Action: model.StateChangeDelete,
+			Kind:   model.StateResourceProjectCSPAccount,
+			Key:    key,
+			Target: model.StateChangeTarget{ID: link.ID, ProjectID: ref.existing.ID, ProjectKey: ref.key, CSPAccountID: link.CSPAccountID},
+		})
+	}
+}
+
+func (b *statePlanBuilder) planCSPAccountMembers(ref *stateProjectRef, accountKey string, cspAccountID uint, declared []model.StateCSPAccountMember, memberIDs map[uint]bool) {
+	if declared == nil {
+		return
+	}
+
+	existing := make(map[uint]model.CSPAccountMember)
+	for _, member := range b.cspAccountMembers[[2]uint{ref.id(), cspAccountID}] {
+		existing[member.UserID] = member
+	}
+
+	declaredUsers := make(map[uint]bool, len(declared))
+	for _, member := range declared {
+		user, ok := b.resolveUser(ref.key, member.Email)
+		if !ok {
+			continue
+		}
+		if !memberIDs[user.ID] {
+			b.addProblem("project %q: CSP account member %q must be a project member", ref.key, member.Email)
+			continue
+		}
+		declaredUsers[user.ID] = true
+
+		role := member.Role
+		if role == "" {
+			role = string(model.CSPAccountMemberRoleUser)
+		}
+		email := strings.ToLower(member.Email)
+		key := accountKey + "/members/" + email
+		target := model.StateChangeTarget{
+			ProjectID:    ref.id(),
+			ProjectKey:   ref.key,
+			UserID:       user.ID,
+			Email:        email,
+			CSPAccountID: cspAccountID,
+			Role:         role,
+		}
+
+		current, ok := existing[user.ID]
+		if !ok || ref.existing == nil {
+			b.plan.Add(model.StateChange{
+				Action:  model.StateChangeCreate,
+				Kind:    model.StateResourceCSPAccountMember,
+				Key:     key,
+				Changes: []model.StateFieldChange{{Field: "role", To: role}},
+				Target:  target,
+			})
+			continue
+		}
+
+		var changes []model.StateFieldChange
+		if current.Role != role {
+			changes = append(changes, model.StateFieldChange{Field: "role", From: current.Role, To: role})
+		}
+		if current.Status != string(model.CSPAccountMemberStatusActive) {
+			changes = append(changes, model.StateFieldChange{Field: "status", From: current.Status, To: string(model.CSPAccountMemberStatusActive)})
+		}
+		if len(changes) > 0 {
+			target.ID = current.ID
+			b.plan.Add(model.StateChange{
+				Action:  model.StateChangeUpdate,
+				Kind:    model.StateResourceCSPAccountMember,
+				Key:     key,
+				Changes: changes,
+				Target:  target,
+			})
+		}
+	}
+
+	if !b.prune || ref.existing == nil {
+		return
+	}
+	for _, member := range b.cspAccountMembers[[2]uint{ref.existing.ID, cspAccountID}] {
+		if declaredUsers[member.UserID] {
+			continue
+		}
+		email := strings.ToLower(member.User.Email)
+		b.plan.Add(model.StateChange{
+			Action: model.StateChangeDelete,
+			Kind:   model.StateResourceCSPAccountMember,
+			Key:    accountKey + "/members/" + email,
+			Target: model.StateChangeTarget{ID: member.ID, ProjectID: ref.existing.ID, ProjectKey: ref.key, UserID: member.UserID, Email: email, CSPAccountID: cspAccountID},
+		})
+	}
+}
+
+// pruneProjects は定義に含まれないプロジェクトを削除する（管理者プロジェクトは対象外）
+func (b *statePlanBuilder) pruneProjects() {
+	for _, project := range b.snapshot.Projects {
+		key := b.projectKeyByID(project.ID)
+		if _, declared := b.declaredProjects[key]; declared || project.ProjectType == model.ProjectTypeAdmin {
+			continue
+		}
+		b.plan.Add(model.StateChange{
+			Action: model.StateChangeDelete,
+			Kind:   model.StateResourceProject,
+			Key:    key,
+			Target: model.StateChangeTarget{ID: project.ID, ProjectID: project.ID, ProjectKey: key, OrganizationID: project.OrganizationID},
+		})
+	}
+}
+
+// pruneOrganizations は定義に含まれない組織を削除する（管理者組織と、管理者プロジェクトが残る組織は対象外）
+func (b *statePlanBuilder) pruneOrganizations() {
+	retained := make(map[uint]bool)
+	for _, project := range b.snapshot.Projects {
+		if project.ProjectType == model.ProjectTypeAdmin {
+			retained[project.OrganizationID] = true
+		}
+	}
+
+	for _, org := range b.snapshot.Organizations {
+		if _, declared := b.declaredOrganizations[org.Name]; declared {
+			continue
+		}
+		if org.OrganizationType == model.OrganizationTypeAdmin || retained[org.ID] {
+			continue
+		}
+		b.plan.Add(model.StateChange{
+			Action: model.StateChangeDelete,
+			Kind:   model.StateResourceOrganization,
+			Key:    model.StateOrganizationKey(org.Name),
+			Target: model.StateChangeTarget{ID: org.ID, OrganizationID: org.ID, OrganizationKey: org.Name},
+		})
+	}
+}
+
+func (b *statePlanBuilder) projectKeyByID(projectID uint) string {
+	for _, project := range b.snapshot.Projects {
+		if project.ID == projectID {
+			return model.StateProjectKey(b.organizationNames[project.OrganizationID], project.Name)
+		}
+	}
+	return fmt.Sprintf("project#%d", projectID)
+}
+
+// contractPeriodChanges は契約期間の変更内容を返す
+func contractPeriodChanges(currentStart, currentEnd, start, end string) []model.StateFieldChange {
+	var changes []model.StateFieldChange
+	if currentStart != start {
+		changes = append(changes, model.StateFieldChange{Field: "start_date", From: currentStart, To: start})
+	}
+	if currentEnd != end {
+		changes = append(changes, model.StateFieldChange{Field: "end_date", From: currentEnd, To: end})
+	}
+	return changes
+}
+
+// formatContractDate は契約日を定義と同じYYYY-MM-DD形式にする（未指定は空文字）
+func formatContractDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format("2006-01-02")
+}
+
+func equalProjectTags(a, b model.ProjectTags) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if other, ok := b[k]; !ok || other != v {
+			return false
+		}
+	}
+	return true
+}
+
+// formatProjectTags はタグをキー順の「key=value」形式で表現する
+func formatProjectTags(tags model.ProjectTags) string {
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -6,11 +6,12 @@ import (
 )
 
 type userService struct {
-	userRepo interfaces.UserRepository
+	userRepo        interfaces.UserRepository
+	deletionService interfaces.DeletionService
 }
 
-func NewUserService(userRepo interfaces.UserRepository) interfaces.UserService {
-	return &userService{userRepo: userRepo}
+func NewUserService(userRepo interfaces.UserRepository, deletionService interfaces.DeletionService) interfaces.UserService {
+	return &userService{userRepo: userRepo, deletionService: deletionService}
 }
 
 func (s *userService) GetAllUsers() ([]model.User, error) {
@@ -41,6 +42,11 @@ func (s *userService) DeleteUser(id uint) error {
 	// 存在確認
 	_, err := s.userRepo.SelectByID(id)
 	if err != nil {
+		return model.ErrUserNotFound
+	}
+
+	// オーナーのプロジェクトや未処理のCSP申請が残っている場合は削除できない
+	if err := s.deletionService.EnsureUserDeletable(id); err != nil {
 		return err
 	}
 
@@ -0,0 +1,619 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go-nextjs-api/internal/interfaces"
+	"go-nextjs-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type vendorRelationService struct {
+	projectRepo        interfaces.ProjectRepository
+	cspRepo            interfaces.CSPRepository
+	projectService     interfaces.ProjectService
+	provisioningClient interfaces.ProvisioningClient
+	notifier           interfaces.Notifier
+}
+
+func NewVendorRelationService(
+	projectRepo interfaces.ProjectRepository,
+	cspRepo interfaces.CSPRepository,
+	projectService interfaces.ProjectService,
+	provisioningClient interfaces.ProvisioningClient,
+	notifier interfaces.Notifier,
+) interfaces.VendorRelationService {
+	return &vendorRelationService{
+		projectRepo:        projectRepo,
+		cspRepo:            cspRepo,
+		projectService:     projectService,
+		provisioningClient: provisioningClient,
+		notifier:           notifier,
+	}
+}
+
+// GetVendorRelations はプロジェクトのベンダー紐付け一覧を取得（紐付け元・ベンダー側の両方）
+func (s *vendorRelationService) GetVendorRelations(userID, projectID uint) ([]model.ProjectVendorRelation, error) {
+	// プロジェクトメンバーかどうかを確認
+	isMember, err := s.projectRepo.IsMember(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, model.ErrUserNotProjectMember
+	}
+
+	relations, err := s.projectRepo.SelectVendorRelationsByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 契約期間による遷移の保存は定期実行に任せ、取得時は現在あるべきステータスで返す
+	applyScheduledStatus(relations, time.Now())
+	return relations, nil
+}
+
+// applyScheduledStatus は定期実行でまだ遷移していない紐付けのステータスを契約期間から求めたものに置き換える（保存はしない）
+func applyScheduledStatus(relations []model.ProjectVendorRelation, now time.Time) {
+	for i := range relations {
+		relation := &relations[i]
+		if next := relation.ScheduledStatus(now); next != relation.Status && relation.Status.CanTransitionTo(next) {
+			relation.Status = next
+		}
+	}
+}
+
+// GetVendorPortfolio はユーザーが所属するベンダープロジェクトの紐付けを通じて関わる全ての依頼元プロジェクトを集約
+// 依頼元プロジェクトごとに、自分がメンバーのCSPアカウント・未処理のCSP申請・契約終了日を返す
+func (s *vendorRelationService) GetVendorPortfolio(userID uint) (*model.VendorPortfolio, error) {
+	relations, err := s.projectRepo.SelectVendorRelationsForVendorMember(userID)
+	if err != nil {
+		return nil, err
+	}
+	// 契約期間による遷移の保存は定期実行に任せ、集約時は現在あるべきステータスで判定する
+	now := time.Now()
+	applyScheduledStatus(relations, now)
+
+	memberships, err := s.cspRepo.SelectCSPAccountMembersByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	accountsByProject := make(map[uint][]model.PortfolioCSPAccount)
+	for _, member := range memberships {
+		accountsByProject[member.ProjectID] = append(accountsByProject[member.ProjectID], model.PortfolioCSPAccount{
+			CSPAccountID: member.CSPAccountID,
+			Provider:     member.CSPAccount.Provider,
+			AccountName:  member.CSPAccount.AccountName,
+			Role:         member.Role,
+			Status:       member.Status,
+		})
+	}
+
+	// 同じ依頼元プロジェクトに複数のベンダープロジェクトから関わる場合があるため申請一覧はキャッシュする
+	type pendingResult struct {
+		requests []model.PendingCSPRequest
+		err      error
+	}
+	pendingByProject := make(map[uint]pendingResult)
+
+	today := now.Truncate(24 * time.Hour)
+	engagements := make([]model.VendorEngagement, 0, len(relations))
+	for _, relation := range relations {
+		// ゴミ箱に移動したプロジェクトとの紐付けは表示しない
+		if relation.Project.ID == 0 || relation.VendorProject.ID == 0 {
+			continue
+		}
+		// 定期実行前でも期限切れの紐付けは表示しない
+		if relation.Status == model.VendorRelationStatusExpired {
+			continue
+		}
+
+		engagement := model.VendorEngagement{
+			RelationID:     relation.ID,
+			RelationStatus: relation.Status,
+			StartDate:      relation.StartDate,
+			EndDate:        relation.EndDate,
+			VendorProject:  model.NewPortfolioProject(&relation.VendorProject),
+			ClientProject:  model.NewPortfolioProject(&relation.Project),
+			CSPAccounts:    accountsByProject[relation.ProjectID],
+		}
+		if engagement.CSPAccounts == nil {
+			engagement.CSPAccounts = []model.PortfolioCSPAccount{}
+		}
+		if relation.EndDate != nil {
+			days := int(relation.EndDate.Truncate(24*time.Hour).Sub(today).Hours() / 24)
+			engagement.DaysUntilExpiry = &days
+		}
+
+		pending, ok := pendingByProject[relation.ProjectID]
+		if !ok {
+			pending.requests, pending.err = s.provisioningClient.ListPendingCSPRequests(relation.ProjectID)
+			pendingByProject[relation.ProjectID] = pending
+		}
+		if pending.err != nil {
+			log.Printf("Failed to get pending CSP requests for project %d: %v", relation.ProjectID, pending.err)
+			engagement.PendingCSPRequests = []model.PendingCSPRequest{}
+			engagement.PendingCSPRequestsUnavailable = true
+		} else {
+			engagement.PendingCSPRequests = pending.requests
+			if engagement.PendingCSPRequests == nil {
+				engagement.PendingCSPRequests = []model.PendingCSPRequest{}
+			}
+		}
+
+		engagements = append(engagements, engagement)
+	}
+
+	return &model.VendorPortfolio{
+		Engagements: engagements,
+		Total:       len(engagements),
+	}, nil
+}
+
+// ProposeVendorRelation はベンダープロジェクトへ紐付けを提案（紐付け元プロジェクトの管理者）
+// ベンダー側が承諾するまで紐付けは有効にならない
+func (s *vendorRelationService) ProposeVendorRelation(userID, projectID uint, req *model.VendorRelationCreateRequest) (*model.ProjectVendorRelation, error) {
+	if err := s.requireManagePermission(userID, projectID); err != nil {
+		return nil, err
+	}
+	if err := ensureProjectWritable(s.projectRepo, projectID); err != nil {
+		return nil, err
+	}
+
+	vendorProject, err := s.projectRepo.SelectByID(req.VendorProjectID)
+	if err != nil {
+		return nil, model.ErrProjectNotFound
+	}
+	if vendorProject.ProjectType != model.ProjectTypeVendor || vendorProject.ID == projectID {
+		return nil, model.ErrNotVendorProject
+	}
+	if vendorProject.Status.IsReadOnly() {
+		return nil, model.ErrProjectArchived
+	}
+
+	startDate, endDate, err := model.ParseContractPeriod(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	if endDate != nil && endDate.AddDate(0, 0, 1).Before(time.Now()) {
+		return nil, model.ErrInvalidVendorRelationPeriod
+	}
+
+	_, err = s.projectRepo.SelectOpenVendorRelation(projectID, req.VendorProjectID)
+	if err == nil {
+		return nil, model.ErrVendorRelationAlreadyExists
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	relation := &model.ProjectVendorRelation{
+		ProjectID:       projectID,
+		VendorProjectID: req.VendorProjectID,
+		Status:          model.VendorRelationStatusProposed,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		Note:            req.Note,
+		ProposedBy:      &userID,
+	}
+	if err := s.projectRepo.InsertVendorRelation(relation); err != nil {
+		return nil, err
+	}
+
+	created, err := s.projectRepo.SelectVendorRelationByID(relation.ID)
+	if err != nil {
+		return nil, err
+	}
+	notifyVendorRelationEvent(s.projectRepo, s.notifier, created)
+
+	return created, nil
+}
+
+// AcceptVendorRelation はベンダー側が紐付けの提案を承諾
+// 契約開始日に達していれば即座に有効になる
+func (s *vendorRelationService) AcceptVendorRelation(userID, vendorProjectID, relationID uint) (*model.ProjectVendorRelation, error) {
+	relation, err := s.getVendorSideRelation(userID, vendorProjectID, relationID)
+	if err != nil {
+		return nil, err
+	}
+	if relation.Status != model.VendorRelationStatusProposed {
+		return nil, model.ErrInvalidVendorRelationTransition
+	}
+
+	now := time.Now()
+	relation.Status = model.VendorRelationStatusAccepted
+	relation.Status = relation.ScheduledStatus(now)
+	relation.RespondedBy = &userID
+	relation.RespondedAt = &now
+
+	if err := s.projectRepo.UpdateVendorRelation(relation); err != nil {
+		return nil, err
+	}
+	notifyVendorRelationEvent(s.projectRepo, s.notifier, relation)
+
+	return relation, nil
+}
+
+// DeclineVendorRelation はベンダー側が紐付けの提案を辞退
+func (s *vendorRelationService) DeclineVendorRelation(userID, vendorProjectID, relationID uint, reason string) (*model.ProjectVendorRelation, error) {
+	relation, err := s.getVendorSideRelation(userID, vendorProjectID, relationID)
+	if err != nil {
+		return nil, err
+	}
+	if !relation.Status.CanTransitionTo(model.VendorRelationStatusDeclined) {
+		return nil, model.ErrInvalidVendorRelationTransition
+	}
+
+	now := time.Now()
+	relation.Status = model.VendorRelationStatusDeclined
+	relation.RespondedBy = &userID
+	relation.RespondedAt = &now
+	relation.StatusReason = reason
+
+	if err := s.projectRepo.UpdateVendorRelation(relation); err != nil {
+		return nil, err
+	}
+	notifyVendorRelationEvent(s.projectRepo, s.notifier, relation)
+
+	return relation, nil
+}
+
+// TerminateVendorRelation は承諾済み・有効な紐付けを終了（紐付け元・ベンダー側のどちらの管理者も可能）
+// 担当者が委任で割り当てられていたCSPアカウントメンバーは終了と同時に削除される
+func (s *vendorRelationService) TerminateVendorRelation(userID, projectID, relationID uint, reason string) (*model.ProjectVendorRelation, error) {
+	if err := s.requireManagePermission(userID, projectID); err != nil {
+		return nil, err
+	}
+
+	relation, err := s.getRelation(relationID)
+	if err != nil {
+		return nil, err
+	}
+	if relation.ProjectID != projectID && relation.VendorProjectID != projectID {
+		return nil, model.ErrVendorRelationNotFound
+	}
+	if !relation.Status.CanTransitionTo(model.VendorRelationStatusTerminated) {
+		return nil, model.ErrInvalidVendorRelationTransition
+	}
+
+	now := time.Now()
+	relation.Status = model.VendorRelationStatusTerminated
+	relation.TerminatedBy = &userID
+	relation.TerminatedAt = &now
+	relation.StatusReason = reason
+
+	if err := s.projectRepo.UpdateVendorRelation(relation); err != nil {
+		return nil, err
+	}
+	notifyVendorRelationEvent(s.projectRepo, s.notifier, relation)
+
+	return relation, nil
+}
+
+// WithdrawVendorRelation はベンダーの承諾前の提案を取り下げ（紐付け元プロジェクトの管理者）
+func (s *vendorRelationService) WithdrawVendorRelation(userID, projectID, relationID uint) error {
+	if err := s.requireManagePermission(userID, projectID); err != nil {
+		return err
+	}
+	if err := ensureProjectWritable(s.projectRepo, projectID); err != nil {
+		return err
+	}
+
+	relation, err := s.getRelation(relationID)
+	if err != nil {
+		return err
+	}
+	if relation.ProjectID != projectID {
+		return model.ErrVendorRelationNotFound
+	}
+	if relation.Status != model.VendorRelationStatusProposed {
+		return model.ErrInvalidVendorRelationTransition
+	}
+
+	return s.projectRepo.DeleteVendorRelation(relationID)
+}
+
+// ProcessScheduledTransitions は契約期間に基づいて紐付けを有効化・期限切れにする
+// 期限切れになった紐付けの担当者のCSPアカウントメンバーは遷移と同時に削除される
+// 遷移した紐付けの双方に通知し、遷移件数を返す
+func (s *vendorRelationService) ProcessScheduledTransitions() (int, error) {
+	relations, err := s.projectRepo.SelectScheduledVendorRelations()
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	transitioned := 0
+	for i := range relations {
+		relation := &relations[i]
+		next := relation.ScheduledStatus(now)
+		if next == relation.Status || !relation.Status.CanTransitionTo(next) {
+			continue
+		}
+
+		relation.Status = next
+		if err := s.projectRepo.UpdateVendorRelation(relation); err != nil {
+			return transitioned, err
+		}
+		notifyVendorRelationEvent(s.projectRepo, s.notifier, relation)
+		transitioned++
+	}
+
+	return transitioned, nil
+}
+
+// UpdateAccessGrant は紐付け元プロジェクトがベンダーに委任する権限を設定（紐付け元プロジェクトの管理者）
+// 委任範囲を狭めた場合、担当者への割り当ては委任範囲との共通部分のみ有効になり、委任から外れたCSPアカウントメンバーは削除される
+func (s *vendorRelationService) UpdateAccessGrant(userID, projectID, relationID uint, req *model.VendorAccessGrantRequest) (*model.ProjectVendorRelation, error) {
+	if err := s.requireManagePermission(userID, projectID); err != nil {
+		return nil, err
+	}
+	if err := ensureProjectWritable(s.projectRepo, projectID); err != nil {
+		return nil, err
+	}
+
+	relation, err := s.getRelation(relationID)
+	if err != nil {
+		return nil, err
+	}
+	if relation.ProjectID != projectID {
+		return nil, model.ErrVendorRelationNotFound
+	}
+	if !relation.Status.IsOpen() {
+		return nil, model.ErrInvalidVendorRelationTransition
+	}
+
+	if err := req.Normalize(); err != nil {
+		return nil, err
+	}
+	for _, cspAccountID := range req.CSPAccountIDs {
+		if _, err := s.cspRepo.SelectProjectCSPAccountByProjectAndCSPAccount(projectID, cspAccountID); err != nil {
+			return nil, model.ErrCSPAccountNotInProject
+		}
+	}
+
+	relation.GrantedPermissions = req.Permissions
+	relation.GrantedCSPAccountIDs = req.CSPAccountIDs
+	if err := s.projectRepo.UpdateVendorRelation(relation); err != nil {
+		return nil, err
+	}
+
+	return relation, nil
+}
+
+// GetStaffAssignments はベンダー担当者への権限割り当て一覧を取得（紐付け元・ベンダー側のメンバー）
+func (s *vendorRelationService) GetStaffAssignments(userID, projectID, relationID uint) ([]model.VendorStaffAssignment, error) {
+	isMember, err := s.projectRepo.IsMember(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, model.ErrUserNotProjectMember
+	}
+
+	relation, err := s.getRelation(relationID)
+	if err != nil {
+		return nil, err
+	}
+	if relation.ProjectID != projectID && relation.VendorProjectID != projectID {
+		return nil, model.ErrVendorRelationNotFound
+	}
+
+	return s.projectRepo.SelectVendorStaffAssignments(relationID)
+}
+
+// AssignStaff はベンダープロジェクトのメンバーに委任範囲内の権限を割り当て（ベンダー側の管理者）
+// 同じ担当者への割り当てが既にある場合は置き換える
+func (s *vendorRelationService) AssignStaff(userID, vendorProjectID, relationID uint, req *model.VendorStaffAssignmentRequest) (*model.VendorStaffAssignment, error) {
+	relation, err := s.getVendorSideRelation(userID, vendorProjectID, relationID)
+	if err != nil {
+		return nil, err
+	}
+	if !relation.Status.IsOpen() {
+		return nil, model.ErrInvalidVendorRelationTransition
+	}
+
+	isMember, err := s.projectRepo.IsMember(vendorProjectID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, model.ErrUserNotProjectMember
+	}
+
+	if err := req.Normalize(); err != nil {
+		return nil, err
+	}
+	if !relation.Covers(req.Permissions, req.CSPAccountIDs) {
+		return nil, model.ErrVendorPermissionNotGranted
+	}
+
+	assignment, err := s.projectRepo.SelectVendorStaffAssignment(relationID, req.UserID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		assignment = &model.VendorStaffAssignment{RelationID: relationID, UserID: req.UserID}
+	}
+	assignment.Permissions = req.Permissions
+	assignment.CSPAccountIDs = req.CSPAccountIDs
+	assignment.AssignedBy = userID
+
+	if err := s.projectRepo.SaveVendorStaffAssignment(assignment); err != nil {
+		return nil, err
+	}
+
+	return assignment, nil
+}
+
+// RemoveStaff はベンダー担当者への権限割り当てを削除（ベンダー側の管理者）
+// 他の紐付けで委任されていないCSPアカウントメンバーも削除される
+func (s *vendorRelationService) RemoveStaff(userID, vendorProjectID, relationID, staffUserID uint) error {
+	if _, err := s.getVendorSideRelation(userID, vendorProjectID, relationID); err != nil {
+		return err
+	}
+
+	assignment, err := s.projectRepo.SelectVendorStaffAssignment(relationID, staffUserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrVendorStaffNotFound
+		}
+		return err
+	}
+
+	return s.projectRepo.DeleteVendorStaffAssignment(assignment.ID)
+}
+
+// GetDelegatedAccess はユーザーがベンダー紐付け経由でプロジェクトに持つ権限を取得
+func (s *vendorRelationService) GetDelegatedAccess(userID, projectID uint) (*model.DelegatedAccess, error) {
+	access, err := resolveDelegatedAccess(s.projectRepo, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if access == nil {
+		return &model.DelegatedAccess{RelationIDs: []uint{}, Permissions: model.VendorPermissions{}, CSPAccountIDs: []uint{}}, nil
+	}
+	return access, nil
+}
+
+// resolveDelegatedAccess は有効なベンダー紐付けの担当者割り当てからユーザーの委任権限を求める
+// 委任権限がない場合はnilを返す
+func resolveDelegatedAccess(projectRepo interfaces.ProjectRepository, userID, projectID uint) (*model.DelegatedAccess, error) {
+	assignments, err := projectRepo.SelectActiveStaffAssignments(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(assignments) == 0 {
+		return nil, nil
+	}
+
+	access := &model.DelegatedAccess{Permissions: model.VendorPermissions{}, CSPAccountIDs: []uint{}}
+	for i := range assignments {
+		access.Merge(&assignments[i].Relation, &assignments[i])
+	}
+	return access, nil
+}
+
+// requireManagePermission はプロジェクトの管理権限をチェック
+func (s *vendorRelationService) requireManagePermission(userID, projectID uint) error {
+	permission, err := s.projectService.CheckProjectPermission(userID, projectID)
+	if err != nil {
+		return err
+	}
+	if !permission.CanManage {
+		return model.ErrInsufficientPermission
+	}
+	return nil
+}
+
+// getRelation はベンダー紐付けを取得
+func (s *vendorRelationService) getRelation(relationID uint) (*model.ProjectVendorRelation, error) {
+	relation, err := s.projectRepo.SelectVendorRelationByID(relationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrVendorRelationNotFound
+		}
+		return nil, err
+	}
+	return relation, nil
+}
+
+// getVendorSideRelation はベンダー側プロジェクトの管理者として応答する紐付けを取得
+func (s *vendorRelationService) getVendorSideRelation(userID, vendorProjectID, relationID uint) (*model.ProjectVendorRelation, error) {
+	if err := s.requireManagePermission(userID, vendorProjectID); err != nil {
+		return nil, err
+	}
+	if err := ensureProjectWritable(s.projectRepo, vendorProjectID); err != nil {
+		return nil, err
+	}
+
+	relation, err := s.getRelation(relationID)
+	if err != nil {
+		return nil, err
+	}
+	if relation.VendorProjectID != vendorProjectID {
+		return nil, model.ErrVendorRelationNotFound
+	}
+	return relation, nil
+}
+
+// vendorRelationEventMessages はステータスごとの通知件名・本文
+var vendorRelationEventMessages = map[model.VendorRelationStatus][2]string{
+	model.VendorRelationStatusProposed:   {"ベンダー紐付けの提案", "プロジェクト「%s」からベンダープロジェクト「%s」への紐付けが提案されました。ベンダー側の管理者は承諾または辞退してください。"},
+	model.VendorRelationStatusAccepted:   {"ベンダー紐付けの承諾", "プロジェクト「%s」とベンダープロジェクト「%s」の紐付けが承諾されました。契約開始日から有効になります。"},
+	model.VendorRelationStatusDeclined:   {"ベンダー紐付けの辞退", "プロジェクト「%s」からベンダープロジェクト「%s」への紐付けの提案は辞退されました。"},
+	model.VendorRelationStatusActive:     {"ベンダー紐付けの開始", "プロジェクト「%s」とベンダープロジェクト「%s」の紐付けが有効になりました。"},
+	model.VendorRelationStatusExpired:    {"ベンダー紐付けの期限切れ", "プロジェクト「%s」とベンダープロジェクト「%s」の紐付けは契約終了日を過ぎたため終了しました。"},
+	model.VendorRelationStatusTerminated: {"ベンダー紐付けの終了", "プロジェクト「%s」とベンダープロジェクト「%s」の紐付けが終了されました。"},
+}
+
+// notifyVendorRelationEvent は紐付けの現在のステータスを双方のプロジェクト管理者に通知
+// 通知の失敗は紐付けの操作自体を失敗させない
+func notifyVendorRelationEvent(projectRepo interfaces.ProjectRepository, notifier interfaces.Notifier, relation *model.ProjectVendorRelation) {
+	message, ok := vendorRelationEventMessages[relation.Status]
+	if !ok {
+		return
+	}
+
+	projectName := relation.Project.Name
+	if projectName == "" {
+		if details, err := projectRepo.SelectByID(relation.ProjectID); err == nil {
+			projectName = details.Name
+		}
+	}
+	vendorProjectName := relation.VendorProject.Name
+	if vendorProjectName == "" {
+		if details, err := projectRepo.SelectByID(relation.VendorProjectID); err == nil {
+			vendorProjectName = details.Name
+		}
+	}
+
+	seen := make(map[string]bool)
+	var recipients []string
+	for _, projectID := range []uint{relation.ProjectID, relation.VendorProjectID} {
+		emails, err := projectRepo.SelectManagerEmails(projectID)
+		if err != nil {
+			log.Printf("Failed to get managers of project %d: %v", projectID, err)
+			continue
+		}
+		for _, email := range emails {
+			if !seen[email] {
+				seen[email] = true
+				recipients = append(recipients, email)
+			}
+		}
+	}
+	if len(recipients) == 0 {
+		return
+	}
+
+	body := fmt.Sprintf(message[1], projectName, vendorProjectName)
+	body += "\n\n契約期間: " + formatContractPeriod(relation.StartDate, relation.EndDate)
+	if relation.StatusReason != "" {
+		body += "\n理由: " + relation.StatusReason
+	}
+
+	if err := notifier.Send(&model.Notification{
+		To:      recipients,
+		Subject: fmt.Sprintf("[CGAS] %s（%s / %s）", message[0], projectName, vendorProjectName),
+		Body:    body,
+	}); err != nil {
+		log.Printf("Failed to send vendor relation notification (relation %d): %v", relation.ID, err)
+	}
+}
+
+// formatContractPeriod は契約期間を表示用の文字列にする
+func formatContractPeriod(startDate, endDate *time.Time) string {
+	start, end := "指定なし", "指定なし"
+	if startDate != nil {
+		start = startDate.Format("2006-01-02")
+	}
+	if endDate != nil {
+		end = endDate.Format("2006-01-02")
+	}
+	return start + " 〜 " + end
+}
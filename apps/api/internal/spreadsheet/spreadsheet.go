@@ -0,0 +1,96 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"go-nextjs-api/internal/model"
+)
+
+// Format は一括登録・出力で扱うファイル形式を定義する型
+type Format string
+
+// ファイル形式定数
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// ParseFormat はクエリパラメータなどで指定された形式を解析（未指定の場合はCSV）
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(value))) {
+	case "", FormatCSV:
+		return FormatCSV, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	}
+	return "", model.ErrUnsupportedFileFormat
+}
+
+// FormatFromFilename はファイル名の拡張子から形式を判定
+func FormatFromFilename(filename string) (Format, error) {
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	if ext == "" {
+		return "", model.ErrUnsupportedFileFormat
+	}
+	return ParseFormat(ext)
+}
+
+// ContentType は形式に対応するContent-Typeを返す
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Read はファイルの内容を行ごとのセルの配列として読み込む（XLSXは最初のシートのみ）
+// 各セルの前後の空白は取り除く（行番号を保つため空行もそのまま返す）
+func Read(data []byte, format Format) ([][]string, error) {
+	var rows [][]string
+	var err error
+	switch format {
+	case FormatCSV:
+		rows, err = readCSV(data)
+	case FormatXLSX:
+		rows, err = readXLSX(data)
+	default:
+		return nil, model.ErrUnsupportedFileFormat
+	}
+	if err != nil {
+		return nil, model.ErrInvalidImportFile
+	}
+
+	for _, row := range rows {
+		for i := range row {
+			row[i] = strings.TrimSpace(row[i])
+		}
+	}
+	return rows, nil
+}
+
+// Write は行ごとのセルの配列を指定形式で書き出す
+func Write(w io.Writer, format Format, rows [][]string) error {
+	switch format {
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	case FormatXLSX:
+		return writeXLSX(w, rows)
+	}
+	return model.ErrUnsupportedFileFormat
+}
+
+// readCSV はCSVを読み込む（Excelで保存されたUTF-8 BOM付きのファイルにも対応）
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	return reader.ReadAll()
+}
@@ -62,12 +62,21 @@ func main() {
 	protected := api.Group("")
 	protected.Use(middleware.AuthMiddleware())
 	{
+		// 申請できるプロバイダー（メインAPIのプロバイダーレジストリ）
+		protected.GET("/csp-providers", cspRequestHandler.GetCSPProviders)
+
 		// CSP申請管理
 		protected.GET("/csp-requests", cspRequestHandler.GetCSPRequests)
 		protected.GET("/csp-requests/:id", cspRequestHandler.GetCSPRequest)
 		protected.POST("/csp-requests", cspRequestHandler.CreateCSPRequest)
 		protected.PUT("/csp-requests/:id", cspRequestHandler.UpdateCSPRequest)
 		protected.DELETE("/csp-requests/:id", cspRequestHandler.DeleteCSPRequest)
+		protected.POST("/csp-requests/:id/submit", cspRequestHandler.SubmitCSPRequest)
+
+		// 依頼元スポンサーによるベンダー提出申請の承認
+		protected.GET("/csp-requests/awaiting-sponsor", cspRequestHandler.GetAwaitingSponsorCSPRequests)
+		protected.POST("/csp-requests/:id/co-sign", cspRequestHandler.CoSignCSPRequest)
+		protected.POST("/csp-requests/:id/sponsor-decline", cspRequestHandler.DeclineCSPRequestSponsorship)
 
 		// 管理者のみアクセス可能
 		adminOnly := protected.Group("")
@@ -77,6 +86,16 @@ func main() {
 		}
 	}
 
+	// 内部API（マイクロサービス間通信用、共有シークレットで認証）
+	internal := api.Group("/internal")
+	internal.Use(middleware.InternalAuthMiddleware())
+	{
+		internal.GET("/projects/:id/csp-requests/summary", cspRequestHandler.GetProjectCSPRequestSummary)
+		internal.GET("/projects/:id/csp-requests/pending", cspRequestHandler.GetProjectPendingCSPRequests)
+		internal.GET("/csp-requests/references", cspRequestHandler.GetCSPRequestReferences)
+		internal.POST("/csp-requests", cspRequestHandler.CreateInternalCSPRequest)
+	}
+
 	// サーバー起動
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -3,6 +3,7 @@ package handler
 import (
 	"csp-provisioning-service/internal/model"
 	"csp-provisioning-service/internal/service"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -17,6 +18,17 @@ func NewCSPRequestHandler(service service.CSPRequestService) *CSPRequestHandler
 	return &CSPRequestHandler{service: service}
 }
 
+// GetCSPProviders は申請できるプロバイダーの一覧を取得
+func (h *CSPRequestHandler) GetCSPProviders(c *gin.Context) {
+	providers, err := h.service.GetProviders(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": providers})
+}
+
 // GetCSPRequests はCSP Provisioning一覧を取得（ページング対応）
 func (h *CSPRequestHandler) GetCSPRequests(c *gin.Context) {
 	// クエリパラメータを取得
@@ -120,6 +132,20 @@ func (h *CSPRequestHandler) CreateCSPRequest(c *gin.Context) {
 
 	request, err := h.service.Create(c.Request.Context(), userID.(string), &req)
 	if err != nil {
+		if err == model.ErrProjectArchived {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if err == model.ErrInsufficientPermissions || err == model.ErrEnvironmentRequiresManager {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err == model.ErrInvalidCSPProvider || err == model.ErrVendorProjectNotProvisionable || err == model.ErrSponsorRequired || err == model.ErrInvalidSponsor ||
+			err == model.ErrEnvironmentNotFound || err == model.ErrRegionNotAllowed || err == model.ErrRegionNotOffered ||
+			errors.Is(err, model.ErrInvalidCSPAccountAttribute) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -150,6 +176,10 @@ func (h *CSPRequestHandler) UpdateCSPRequest(c *gin.Context) {
 
 	request, err := h.service.Update(c.Request.Context(), idStr, userID.(string), &req)
 	if err != nil {
+		if err == model.ErrProjectArchived {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -179,14 +209,132 @@ func (h *CSPRequestHandler) ReviewCSPRequest(c *gin.Context) {
 	}
 
 	request, err := h.service.Review(c.Request.Context(), idStr, reviewerID.(string), &req)
+	if err != nil {
+		if err == model.ErrProjectArchived || err == model.ErrCSPRequestNotReadyForReview {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if err == model.ErrInvalidCSPRequestStatus {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": request})
+}
+
+// SubmitCSPRequest は下書きのCSP Provisioningを提出
+func (h *CSPRequestHandler) SubmitCSPRequest(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	// ユーザーIDをコンテキストから取得
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	request, err := h.service.Submit(c.Request.Context(), idStr, userID.(string))
+	if err != nil {
+		respondSponsorshipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": request})
+}
+
+// GetAwaitingSponsorCSPRequests は自分がスポンサーとして承認待ちのCSP Provisioning一覧を取得
+func (h *CSPRequestHandler) GetAwaitingSponsorCSPRequests(c *gin.Context) {
+	// ユーザーIDをコンテキストから取得
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	requests, err := h.service.GetAwaitingSponsor(c.Request.Context(), userID.(string))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	c.JSON(http.StatusOK, gin.H{"data": requests})
+}
+
+// CoSignCSPRequest は依頼元スポンサーがベンダー提出のCSP Provisioningを承認
+func (h *CSPRequestHandler) CoSignCSPRequest(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	// ユーザーIDをコンテキストから取得
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	request, err := h.service.CoSign(c.Request.Context(), idStr, userID.(string))
+	if err != nil {
+		respondSponsorshipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": request})
+}
+
+// DeclineCSPRequestSponsorship は依頼元スポンサーがベンダー提出のCSP Provisioningを差し戻す
+func (h *CSPRequestHandler) DeclineCSPRequestSponsorship(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req model.CSPRequestSponsorDeclineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// ユーザーIDをコンテキストから取得
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	request, err := h.service.DeclineSponsorship(c.Request.Context(), idStr, userID.(string), &req)
+	if err != nil {
+		respondSponsorshipError(c, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"data": request})
 }
 
+// respondSponsorshipError は提出・スポンサー承認のエラーをHTTPレスポンスに変換
+func respondSponsorshipError(c *gin.Context, err error) {
+	switch err {
+	case model.ErrInsufficientPermissions, model.ErrInvalidSponsor, model.ErrEnvironmentRequiresManager:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case model.ErrSponsorRequired, model.ErrEnvironmentNotFound, model.ErrRegionNotAllowed:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case model.ErrProjectArchived, model.ErrCSPRequestNotDraft, model.ErrCSPRequestNotAwaitingSponsor:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
 // DeleteCSPRequest はCSP Provisioningを削除
 func (h *CSPRequestHandler) DeleteCSPRequest(c *gin.Context) {
 	idStr := c.Param("id")
@@ -204,9 +352,105 @@ func (h *CSPRequestHandler) DeleteCSPRequest(c *gin.Context) {
 
 	err := h.service.Delete(c.Request.Context(), idStr, userID.(string))
 	if err != nil {
+		if err == model.ErrProjectArchived {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "CSP request deleted successfully"})
 }
+
+// GetProjectPendingCSPRequests はプロジェクトの未処理のCSP申請一覧を取得（内部API用）
+func (h *CSPRequestHandler) GetProjectPendingCSPRequests(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	requests, err := h.service.GetPendingByProjectID(c.Request.Context(), projectID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": requests})
+}
+
+// GetCSPRequestReferences はプロジェクト（project_id）またはユーザー（email）を参照する申請一覧を取得（内部API用、削除の影響確認）
+func (h *CSPRequestHandler) GetCSPRequestReferences(c *gin.Context) {
+	var (
+		references []model.CSPRequestReference
+		err        error
+	)
+	switch {
+	case c.Query("project_id") != "":
+		projectID, convErr := strconv.Atoi(c.Query("project_id"))
+		if convErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+			return
+		}
+		references, err = h.service.GetReferencesByProjectID(c.Request.Context(), projectID)
+	case c.Query("email") != "":
+		references, err = h.service.GetReferencesByEmail(c.Request.Context(), c.Query("email"))
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "project_id or email is required"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": references})
+}
+
+// GetProjectCSPRequestSummary はプロジェクトのCSP申請件数をステータス別に取得（内部API用）
+func (h *CSPRequestHandler) GetProjectCSPRequestSummary(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	summary, err := h.service.GetSummaryByProjectID(c.Request.Context(), projectID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// CreateInternalCSPRequest は申請者を指定してCSP Provisioningを作成（内部API用）
+func (h *CSPRequestHandler) CreateInternalCSPRequest(c *gin.Context) {
+	var req model.InternalCSPRequestCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	request, err := h.service.Create(c.Request.Context(), req.RequestedBy, &req.CSPRequestCreateRequest)
+	if err != nil {
+		if err == model.ErrProjectArchived {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if err == model.ErrInsufficientPermissions {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err == model.ErrInvalidCSPProvider || err == model.ErrEnvironmentNotFound || err == model.ErrRegionNotAllowed || err == model.ErrRegionNotOffered ||
+			errors.Is(err, model.ErrInvalidCSPAccountAttribute) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": request})
+}
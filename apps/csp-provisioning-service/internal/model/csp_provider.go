@@ -0,0 +1,91 @@
+package model
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+)
+
+// CSPProviderRegion はプロバイダーのリージョン
+type CSPProviderRegion struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name"`
+}
+
+// CSPAccountAttributeDefinition はプロバイダー固有のアカウント属性のスキーマ
+type CSPAccountAttributeDefinition struct {
+	Key         string   `json:"key"`
+	Label       string   `json:"label"`
+	Type        string   `json:"type"`              // string / email / enum
+	Pattern     string   `json:"pattern,omitempty"` // string型の値の形式（正規表現）
+	Options     []string `json:"options,omitempty"` // enum型の選択肢
+	Required    bool     `json:"required"`          // CSPアカウントの登録に必須（申請時はドライバーが補完するため任意）
+	Description string   `json:"description,omitempty"`
+}
+
+// validateValue は値が属性の型・形式に合致するかチェック
+func (a *CSPAccountAttributeDefinition) validateValue(value string) bool {
+	switch a.Type {
+	case "email":
+		address, err := mail.ParseAddress(value)
+		return err == nil && address.Address == value
+	case "enum":
+		for _, option := range a.Options {
+			if value == option {
+				return true
+			}
+		}
+		return false
+	default:
+		if a.Pattern == "" {
+			return true
+		}
+		matched, err := regexp.MatchString(a.Pattern, value)
+		return err == nil && matched
+	}
+}
+
+// CSPProviderDefinition はメインAPIのプロバイダーレジストリから取得するプロバイダーの定義
+type CSPProviderDefinition struct {
+	ID               CSPProvider                     `json:"id"`
+	DisplayName      string                          `json:"display_name"`
+	AccountUnit      string                          `json:"account_unit"`
+	AccountIDPattern string                          `json:"account_id_pattern"`
+	AccountIDExample string                          `json:"account_id_example"`
+	GovernmentCloud  bool                            `json:"government_cloud"`
+	DefaultRegion    string                          `json:"default_region"`
+	Regions          []CSPProviderRegion             `json:"regions"`
+	Capabilities     []string                        `json:"capabilities"`
+	Attributes       []CSPAccountAttributeDefinition `json:"attributes"`
+}
+
+// ValidateAttributes はアカウント属性をプロバイダーのスキーマに照らして検証
+// 必須属性は承認後にドライバーが補完するため、申請時は未定義のキーと型・形式の不一致のみをエラーとする
+func (d *CSPProviderDefinition) ValidateAttributes(values map[string]string) error {
+	for key, value := range values {
+		var definition *CSPAccountAttributeDefinition
+		for i := range d.Attributes {
+			if d.Attributes[i].Key == key {
+				definition = &d.Attributes[i]
+				break
+			}
+		}
+		if definition == nil {
+			return fmt.Errorf("%w: %q is not an attribute of %s", ErrInvalidCSPAccountAttribute, key, d.ID)
+		}
+		if !definition.validateValue(value) {
+			return fmt.Errorf("%w: %q must be a valid %s", ErrInvalidCSPAccountAttribute, key, definition.Label)
+		}
+	}
+	return nil
+}
+
+// HasRegion はプロバイダーがリージョンを提供しているかをチェック
+func (d *CSPProviderDefinition) HasRegion(region string) bool {
+	for _, r := range d.Regions {
+		if r.ID == region {
+			return true
+		}
+	}
+	return false
+}
@@ -7,29 +7,8 @@ import (
 // CSPProvider はクラウドサービスプロバイダーを定義する型
 type CSPProvider string
 
-// クラウドサービスプロバイダー定数
-const (
-	CSPProviderAWS   CSPProvider = "aws"
-	CSPProviderGCP   CSPProvider = "gcp"
-	CSPProviderAzure CSPProvider = "azure"
-)
-
-// ValidCSPProviders は有効なCSPプロバイダーの一覧
-var ValidCSPProviders = []CSPProvider{
-	CSPProviderAWS,
-	CSPProviderGCP,
-	CSPProviderAzure,
-}
-
-// IsValid はCSPプロバイダーが有効かどうかをチェック
-func (cp CSPProvider) IsValid() bool {
-	for _, validProvider := range ValidCSPProviders {
-		if cp == validProvider {
-			return true
-		}
-	}
-	return false
-}
+// 利用できるプロバイダーとその定義（リージョン・対応機能など）はメインAPIのプロバイダーレジストリで管理する
+// （GET /api/internal/csp-providers から取得する。CSPProviderDefinitionを参照）
 
 // String はCSPプロバイダーの文字列表現を返す
 func (cp CSPProvider) String() string {
@@ -41,13 +20,17 @@ type CSPRequestStatus string
 
 // CSP Provisioningステータス定数
 const (
-	CSPRequestStatusPending  CSPRequestStatus = "pending"  // プロビジョニング中
-	CSPRequestStatusApproved CSPRequestStatus = "approved" // 承認済み
-	CSPRequestStatusRejected CSPRequestStatus = "rejected" // 却下
+	CSPRequestStatusDraft           CSPRequestStatus = "draft"            // 下書き（未提出）
+	CSPRequestStatusAwaitingSponsor CSPRequestStatus = "awaiting_sponsor" // 依頼元スポンサーの承認待ち（ベンダー提出）
+	CSPRequestStatusPending         CSPRequestStatus = "pending"          // プロビジョニング中
+	CSPRequestStatusApproved        CSPRequestStatus = "approved"         // 承認済み
+	CSPRequestStatusRejected        CSPRequestStatus = "rejected"         // 却下
 )
 
 // ValidCSPRequestStatuses は有効なCSP Provisioningステータスの一覧
 var ValidCSPRequestStatuses = []CSPRequestStatus{
+	CSPRequestStatusDraft,
+	CSPRequestStatusAwaitingSponsor,
 	CSPRequestStatusPending,
 	CSPRequestStatusApproved,
 	CSPRequestStatusRejected,
@@ -68,6 +51,16 @@ func (crs CSPRequestStatus) String() string {
 	return string(crs)
 }
 
+// IsOpen は申請が管理者のレビュー前（編集可能）かどうかを判定
+func (crs CSPRequestStatus) IsOpen() bool {
+	return crs == CSPRequestStatusDraft || crs == CSPRequestStatusAwaitingSponsor || crs == CSPRequestStatusPending
+}
+
+// IsReviewDecision は管理者のレビュー結果として指定できるステータスかどうかを判定
+func (crs CSPRequestStatus) IsReviewDecision() bool {
+	return crs == CSPRequestStatusApproved || crs == CSPRequestStatusRejected
+}
+
 // CSPRequest はCSP Provisioning情報を表す構造体
 type CSPRequest struct {
 	ID          string            `json:"id" firestore:"id"`                                                  // リスト内でのユニークID
@@ -75,11 +68,18 @@ type CSPRequest struct {
 	RequestedBy string            `json:"requested_by" firestore:"requested_by" validate:"required"`         // プロビジョニング依頼者（メールアドレス）
 	Provider    CSPProvider       `json:"provider" firestore:"provider" validate:"required"`
 	AccountName string            `json:"account_name" firestore:"account_name" validate:"required,min=1,max=255"`
+	EnvironmentID *int            `json:"environment_id" firestore:"environment_id"`                         // 払い出し先の環境（未指定の場合はプロジェクト直下）
+	Region      string            `json:"region" firestore:"region"`                                         // 希望リージョン（未指定の場合は環境の許可リージョンまたはプロバイダーのデフォルト）
+	Attributes  map[string]string `json:"attributes" firestore:"attributes"`                                 // プロバイダー固有のアカウント属性
 	Reason      string            `json:"reason" firestore:"reason" validate:"required"`
 	Status      CSPRequestStatus  `json:"status" firestore:"status" validate:"required"`
 	ReviewedBy  *string           `json:"reviewed_by" firestore:"reviewed_by"`                               // 承認者（メールアドレス）
 	ReviewedAt  *time.Time        `json:"reviewed_at" firestore:"reviewed_at"`
 	RejectReason *string          `json:"reject_reason" firestore:"reject_reason"`
+	VendorProjectID *int          `json:"vendor_project_id" firestore:"vendor_project_id"`                   // 提出したベンダープロジェクト（ベンダー提出の場合）
+	SponsorEmail    *string       `json:"sponsor_email" firestore:"sponsor_email"`                           // 承認が必要な依頼元スポンサー（メールアドレス）
+	SponsorSignedAt *time.Time    `json:"sponsor_signed_at" firestore:"sponsor_signed_at"`                   // スポンサーの承認日時
+	SubmittedAt     *time.Time    `json:"submitted_at" firestore:"submitted_at"`                             // 提出日時（下書きの場合はnil）
 	CreatedAt   time.Time         `json:"created_at" firestore:"created_at"`
 	UpdatedAt   time.Time         `json:"updated_at" firestore:"updated_at"`
 }
@@ -97,7 +97,7 @@ func (ProjectCSPRequests) CollectionName() string {
 
 // Validate はバリデーションを実行
 func (cr *CSPRequest) Validate() error {
-	if !cr.Provider.IsValid() {
+	if cr.Provider == "" {
 		return ErrInvalidCSPProvider
 	}
 	if !cr.Status.IsValid() {
@@ -114,6 +114,26 @@ type CSPRequestCreateRequest struct {
 	Provider    CSPProvider `json:"provider" validate:"required"`
 	AccountName string      `json:"account_name" validate:"required,min=1,max=255"`
 	Reason      string      `json:"reason" validate:"required"`
+	EnvironmentID *int      `json:"environment_id"` // 払い出し先の環境
+	Region      string      `json:"region"`         // 希望リージョン
+	Attributes  map[string]string `json:"attributes"` // プロバイダー固有のアカウント属性（AWSのルートメールアドレスなど）
+
+	// ベンダーが依頼元プロジェクトに代わって提出する場合
+	VendorProjectID *int    `json:"vendor_project_id"` // 提出するベンダープロジェクト
+	SponsorEmail    *string `json:"sponsor_email"`     // 承認する依頼元スポンサー（依頼元プロジェクトの管理者）
+	Draft           bool    `json:"draft"`             // trueの場合は下書きとして保存
+}
+
+// CSPRequestSponsorDeclineRequest はスポンサーによる申請差し戻しリクエストの構造体
+type CSPRequestSponsorDeclineRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// InternalCSPRequestCreateRequest は内部APIからのCSP Provisioning作成リクエストの構造体
+// （メインAPIがプロジェクトテンプレート適用時などに申請者を指定して作成する）
+type InternalCSPRequestCreateRequest struct {
+	CSPRequestCreateRequest
+	RequestedBy string `json:"requested_by" binding:"required"` // 申請者（メールアドレス）
 }
 
 // CSPRequestUpdateRequest はCSP Provisioning更新リクエストの構造体
@@ -135,15 +155,49 @@ type CSPRequestResponse struct {
 	RequestedBy string            `json:"requested_by"`
 	Provider    CSPProvider       `json:"provider"`
 	AccountName string            `json:"account_name"`
+	EnvironmentID *int            `json:"environment_id"`
+	Region      string            `json:"region"`
+	Attributes  map[string]string `json:"attributes"`
 	Reason      string            `json:"reason"`
 	Status      CSPRequestStatus  `json:"status"`
 	ReviewedBy  *string           `json:"reviewed_by"`
 	ReviewedAt  *time.Time        `json:"reviewed_at"`
 	RejectReason *string          `json:"reject_reason"`
+	VendorProjectID *int          `json:"vendor_project_id"`
+	SponsorEmail    *string       `json:"sponsor_email"`
+	SponsorSignedAt *time.Time    `json:"sponsor_signed_at"`
+	SubmittedAt     *time.Time    `json:"submitted_at"`
 	CreatedAt   time.Time         `json:"created_at"`
 	UpdatedAt   time.Time         `json:"updated_at"`
 }
 
+// CSPRequestSummary はプロジェクトのCSP申請のステータス別件数（内部API用）
+type CSPRequestSummary struct {
+	ProjectID int                      `json:"project_id"`
+	Counts    map[CSPRequestStatus]int `json:"counts"`
+	Total     int                      `json:"total"`
+}
+
+// CSPRequestReferenceRelation は申請が削除対象を参照している関係
+type CSPRequestReferenceRelation string
+
+// 参照関係定数
+const (
+	CSPRequestReferenceProject   CSPRequestReferenceRelation = "project"   // プロジェクトの申請
+	CSPRequestReferenceRequester CSPRequestReferenceRelation = "requester" // ユーザーが申請者
+	CSPRequestReferenceSponsor   CSPRequestReferenceRelation = "sponsor"   // ユーザーがスポンサー
+)
+
+// CSPRequestReference は削除の影響確認で使う申請の参照情報（内部API用）
+type CSPRequestReference struct {
+	ID          string                      `json:"id"`
+	ProjectID   int                         `json:"project_id"`
+	Provider    CSPProvider                 `json:"provider"`
+	AccountName string                      `json:"account_name"`
+	Status      CSPRequestStatus            `json:"status"`
+	Relation    CSPRequestReferenceRelation `json:"relation"`
+}
+
 // ページング情報
 type PaginationInfo struct {
 	Page       int  `json:"page"`
@@ -9,4 +9,16 @@ var (
 	ErrCSPRequestAlreadyReviewed = errors.New("CSP request is already reviewed")
 	ErrInsufficientPermissions  = errors.New("insufficient permissions")
 	ErrCSPRequestNotFound       = errors.New("CSP request not found")
+	ErrProjectArchived          = errors.New("project is archived and read-only")
+	ErrVendorProjectNotProvisionable = errors.New("CSP provisioning is not available for vendor projects; submit the request against the client project")
+	ErrSponsorRequired          = errors.New("a client sponsor is required for vendor-submitted requests")
+	ErrInvalidSponsor           = errors.New("sponsor must be an owner or admin of the client project")
+	ErrCSPRequestNotDraft       = errors.New("CSP request is not a draft")
+	ErrCSPRequestNotAwaitingSponsor = errors.New("CSP request is not awaiting sponsor approval")
+	ErrCSPRequestNotReadyForReview  = errors.New("CSP request has not been submitted or co-signed by the sponsor yet")
+	ErrEnvironmentNotFound          = errors.New("environment not found in this project")
+	ErrRegionNotAllowed             = errors.New("region is not allowed in this environment")
+	ErrRegionNotOffered             = errors.New("region is not offered by the CSP provider")
+	ErrInvalidCSPAccountAttribute   = errors.New("invalid CSP account attribute")
+	ErrEnvironmentRequiresManager   = errors.New("only project owners or admins can submit requests for this environment")
 )
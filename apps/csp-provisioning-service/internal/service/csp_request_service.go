@@ -11,7 +11,9 @@ import (
 	"io"
 	"log"
 	"net/http"
+	neturl "net/url"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -23,18 +25,28 @@ type CSPRequestService interface {
 	GetByProjectIDWithPagination(ctx context.Context, projectID int, page, limit int) ([]model.CSPRequest, *model.PaginationInfo, error)
 	GetByRequestedBy(ctx context.Context, requestedBy string) ([]model.CSPRequest, error)
 	GetByStatus(ctx context.Context, status model.CSPRequestStatus) ([]model.CSPRequest, error)
+	GetSummaryByProjectID(ctx context.Context, projectID int) (*model.CSPRequestSummary, error)
+	GetPendingByProjectID(ctx context.Context, projectID int) ([]model.CSPRequest, error)
+	GetReferencesByProjectID(ctx context.Context, projectID int) ([]model.CSPRequestReference, error)
+	GetReferencesByEmail(ctx context.Context, email string) ([]model.CSPRequestReference, error)
 	Create(ctx context.Context, requestedBy string, req *model.CSPRequestCreateRequest) (*model.CSPRequest, error)
+	Submit(ctx context.Context, id string, requestedBy string) (*model.CSPRequest, error)
+	GetAwaitingSponsor(ctx context.Context, sponsorEmail string) ([]model.CSPRequest, error)
+	CoSign(ctx context.Context, id string, sponsorEmail string) (*model.CSPRequest, error)
+	DeclineSponsorship(ctx context.Context, id string, sponsorEmail string, req *model.CSPRequestSponsorDeclineRequest) (*model.CSPRequest, error)
 	Update(ctx context.Context, id string, requestedBy string, req *model.CSPRequestUpdateRequest) (*model.CSPRequest, error)
 	Review(ctx context.Context, id string, reviewerID string, req *model.CSPRequestReviewRequest) (*model.CSPRequest, error)
 	Delete(ctx context.Context, id string, requestedBy string) error
 	CanUserAccessRequest(ctx context.Context, requestedBy string, requestID string) (bool, error)
 	CanUserManageProjectCSPAccount(requestedBy string, projectID int) (bool, error)
+	GetProviders(ctx context.Context) ([]model.CSPProviderDefinition, error)
 }
 
 type cspRequestService struct {
 	repo       repository.CSPRequestRepository
 	mainAPIURL string
 	httpClient *http.Client
+	providers  *providerRegistry
 }
 
 func NewCSPRequestService(repo repository.CSPRequestRepository) CSPRequestService {
@@ -43,13 +55,24 @@ func NewCSPRequestService(repo repository.CSPRequestRepository) CSPRequestServic
 		mainAPIURL = "http://localhost:8080"
 	}
 
+	// メインAPIの内部APIは共有シークレットで認証されるため、すべての呼び出しにトークンを付ける
+	httpClient := &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &internalTokenTransport{token: os.Getenv("INTERNAL_API_TOKEN"), base: http.DefaultTransport},
+	}
 	return &cspRequestService{
 		repo:       repo,
 		mainAPIURL: mainAPIURL,
-		httpClient: &http.Client{Timeout: 30 * time.Second},
+		httpClient: httpClient,
+		providers:  newProviderRegistry(mainAPIURL, httpClient),
 	}
 }
 
+// GetProviders は申請できるプロバイダーの一覧（メインAPIのプロバイダーレジストリ）を取得
+func (s *cspRequestService) GetProviders(ctx context.Context) ([]model.CSPProviderDefinition, error) {
+	return s.providers.list()
+}
+
 func (s *cspRequestService) GetAll(ctx context.Context) ([]model.CSPRequest, error) {
 	return s.repo.SelectAll(ctx)
 }
@@ -78,25 +101,111 @@ func (s *cspRequestService) GetByStatus(ctx context.Context, status model.CSPReq
 	return s.repo.SelectByStatus(ctx, status)
 }
 
+// GetSummaryByProjectID はプロジェクトのCSP申請件数をステータス別に集計
+func (s *cspRequestService) GetSummaryByProjectID(ctx context.Context, projectID int) (*model.CSPRequestSummary, error) {
+	requests, err := s.repo.SelectByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &model.CSPRequestSummary{
+		ProjectID: projectID,
+		Counts:    make(map[model.CSPRequestStatus]int, len(model.ValidCSPRequestStatuses)),
+		Total:     len(requests),
+	}
+	for _, status := range model.ValidCSPRequestStatuses {
+		summary.Counts[status] = 0
+	}
+	for _, request := range requests {
+		summary.Counts[request.Status]++
+	}
+
+	return summary, nil
+}
+
+// GetReferencesByProjectID はプロジェクトの全ての申請を参照情報として取得（削除の影響確認用）
+func (s *cspRequestService) GetReferencesByProjectID(ctx context.Context, projectID int) ([]model.CSPRequestReference, error) {
+	requests, err := s.repo.SelectByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	references := make([]model.CSPRequestReference, 0, len(requests))
+	for i := range requests {
+		references = append(references, newCSPRequestReference(&requests[i], model.CSPRequestReferenceProject))
+	}
+	return references, nil
+}
+
+// GetReferencesByEmail はユーザーが申請者またはスポンサーとなっている申請を参照情報として取得（削除の影響確認用）
+func (s *cspRequestService) GetReferencesByEmail(ctx context.Context, email string) ([]model.CSPRequestReference, error) {
+	requests, err := s.repo.SelectAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	references := []model.CSPRequestReference{}
+	for i := range requests {
+		request := &requests[i]
+		switch {
+		case strings.EqualFold(request.RequestedBy, email):
+			references = append(references, newCSPRequestReference(request, model.CSPRequestReferenceRequester))
+		case isSponsor(request, email):
+			references = append(references, newCSPRequestReference(request, model.CSPRequestReferenceSponsor))
+		}
+	}
+	return references, nil
+}
+
+func newCSPRequestReference(request *model.CSPRequest, relation model.CSPRequestReferenceRelation) model.CSPRequestReference {
+	return model.CSPRequestReference{
+		ID:          request.ID,
+		ProjectID:   request.ProjectID,
+		Provider:    request.Provider,
+		AccountName: request.AccountName,
+		Status:      request.Status,
+		Relation:    relation,
+	}
+}
+
+// GetPendingByProjectID はプロジェクトの未処理（スポンサー承認待ち・レビュー待ち）の申請一覧を取得
+func (s *cspRequestService) GetPendingByProjectID(ctx context.Context, projectID int) ([]model.CSPRequest, error) {
+	requests, err := s.repo.SelectByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []model.CSPRequest{}
+	for _, request := range requests {
+		if request.Status == model.CSPRequestStatusAwaitingSponsor || request.Status == model.CSPRequestStatusPending {
+			pending = append(pending, request)
+		}
+	}
+	return pending, nil
+}
+
 func (s *cspRequestService) Create(ctx context.Context, requestedBy string, req *model.CSPRequestCreateRequest) (*model.CSPRequest, error) {
-	// TODO: 権限チェックを一時的にスキップ（デバッグ用）
-	// プロジェクトへのアクセス権限をチェック（メインAPIサーバーに確認）
-	// hasAccess, err := s.CanUserManageProjectCSPAccount(userID, req.ProjectID)
-	// if err != nil {
-	// 	return nil, err
-	// }
-	// if !hasAccess {
-	// 	return nil, model.ErrInsufficientPermissions
-	// }
-
-	// プロジェクトがベンダータイプかチェック（メインAPIサーバーに確認）
-	// if err := s.checkProjectType(req.ProjectID); err != nil {
-	// 	return nil, err
-	// }
-
-	// プロバイダーの有効性をチェック
-	if !req.Provider.IsValid() {
-		return nil, model.ErrInvalidCSPProvider
+	// プロバイダーの有効性をレジストリでチェック
+	provider, err := s.providers.lookup(req.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// 申請者のプロジェクト権限を取得（メインAPIサーバーに確認）
+	access, err := s.getProjectAccess(requestedBy, req.ProjectID)
+	if err != nil {
+		log.Printf("Failed to check project permission: %v", err)
+		return nil, err
+	}
+
+	// ベンダープロジェクト自体にはCSPアカウントを払い出さない（依頼元プロジェクトに対して提出する）
+	if access.ProjectType == "vendor" {
+		return nil, model.ErrVendorProjectNotProvisionable
+	}
+
+	// アーカイブ済みプロジェクトには申請できない
+	if access.Status == "archived" {
+		return nil, model.ErrProjectArchived
 	}
 
 	cspRequest := &model.CSPRequest{
@@ -104,11 +213,56 @@ func (s *cspRequestService) Create(ctx context.Context, requestedBy string, req
 		RequestedBy: requestedBy,
 		Provider:    req.Provider,
 		AccountName: req.AccountName,
+		Region:      strings.TrimSpace(req.Region),
+		Attributes:  req.Attributes,
 		Reason:      req.Reason,
-		Status:      model.CSPRequestStatusPending,
+		Status:      model.CSPRequestStatusDraft,
+	}
+
+	// プロバイダー固有の属性はレジストリのスキーマで検証する
+	if err := provider.ValidateAttributes(cspRequest.Attributes); err != nil {
+		return nil, err
+	}
+
+	// 環境を指定した場合は環境の許可リージョンで検証する
+	var environment *environmentInfo
+	if req.EnvironmentID != nil {
+		environment, err = s.getEnvironment(req.ProjectID, *req.EnvironmentID)
+		if err != nil {
+			return nil, err
+		}
+		if cspRequest.Region == "" && len(environment.AllowedRegions) > 0 {
+			cspRequest.Region = environment.AllowedRegions[0]
+		}
+		if !environment.allowsRegion(cspRequest.Region) {
+			return nil, model.ErrRegionNotAllowed
+		}
+		cspRequest.EnvironmentID = req.EnvironmentID
+	}
+
+	// リージョンはプロバイダーが提供しているもののみ指定できる（未指定の場合はプロバイダーのデフォルト）
+	if cspRequest.Region == "" {
+		cspRequest.Region = provider.DefaultRegion
+	}
+	if !provider.HasRegion(cspRequest.Region) {
+		return nil, model.ErrRegionNotOffered
+	}
+
+	// プロジェクトメンバー以外はベンダーとしての提出のみ可能
+	if !access.IsMember {
+		if err := s.applyVendorSubmission(cspRequest, access, req); err != nil {
+			return nil, err
+		}
+	}
+
+	if !req.Draft {
+		if err := checkEnvironmentSubmission(environment, access, cspRequest); err != nil {
+			return nil, err
+		}
+		markSubmitted(cspRequest)
 	}
 
-	err := s.repo.Insert(ctx, cspRequest)
+	err = s.repo.Insert(ctx, cspRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -116,6 +270,210 @@ func (s *cspRequestService) Create(ctx context.Context, requestedBy string, req
 	return s.repo.SelectByID(ctx, cspRequest.ID)
 }
 
+// applyVendorSubmission はベンダーによる依頼元プロジェクトへの提出内容を検証して申請に記録
+// 申請提出の委任は申請の提出だけを許可するもので、委任の有無にかかわらず依頼元スポンサーの承認を必須とする
+func (s *cspRequestService) applyVendorSubmission(cspRequest *model.CSPRequest, access *projectAccess, req *model.CSPRequestCreateRequest) error {
+	if req.VendorProjectID == nil || !canVendorSubmit(access, *req.VendorProjectID) {
+		return model.ErrInsufficientPermissions
+	}
+	cspRequest.VendorProjectID = req.VendorProjectID
+
+	if req.SponsorEmail == nil || strings.TrimSpace(*req.SponsorEmail) == "" {
+		return model.ErrSponsorRequired
+	}
+
+	sponsorEmail := strings.TrimSpace(*req.SponsorEmail)
+	sponsorAccess, err := s.getProjectAccess(sponsorEmail, req.ProjectID)
+	if err != nil {
+		return err
+	}
+	if !sponsorAccess.CanManage {
+		return model.ErrInvalidSponsor
+	}
+	cspRequest.SponsorEmail = &sponsorEmail
+	return nil
+}
+
+// checkEnvironmentSubmission は環境の許可リージョンと承認の厳しさに従って申請を提出できるかチェック
+// strictの環境では管理権限を持つメンバーのみ提出できる（ベンダーの提出はスポンサーの承認を経る）
+func checkEnvironmentSubmission(environment *environmentInfo, access *projectAccess, cspRequest *model.CSPRequest) error {
+	if environment == nil {
+		return nil
+	}
+	if !environment.allowsRegion(cspRequest.Region) {
+		return model.ErrRegionNotAllowed
+	}
+	if environment.ApprovalStrictness != environmentApprovalStrict {
+		return nil
+	}
+	if cspRequest.VendorProjectID != nil {
+		if cspRequest.SponsorEmail == nil {
+			return model.ErrSponsorRequired
+		}
+		return nil
+	}
+	if !access.CanManage {
+		return model.ErrEnvironmentRequiresManager
+	}
+	return nil
+}
+
+// markSubmitted は申請を提出済みにする（ベンダーの提出はスポンサーが承認するまで承認待ち）
+func markSubmitted(cspRequest *model.CSPRequest) {
+	now := time.Now()
+	cspRequest.SubmittedAt = &now
+	if (cspRequest.VendorProjectID != nil || cspRequest.SponsorEmail != nil) && cspRequest.SponsorSignedAt == nil {
+		cspRequest.Status = model.CSPRequestStatusAwaitingSponsor
+		return
+	}
+	cspRequest.Status = model.CSPRequestStatusPending
+}
+
+// Submit は下書きの申請を提出
+func (s *cspRequestService) Submit(ctx context.Context, id string, requestedBy string) (*model.CSPRequest, error) {
+	existingRequest, err := s.repo.SelectByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingRequest.RequestedBy != requestedBy {
+		return nil, model.ErrInsufficientPermissions
+	}
+	if existingRequest.Status != model.CSPRequestStatusDraft {
+		return nil, model.ErrCSPRequestNotDraft
+	}
+
+	// 下書き作成後に権限や紐付けが変わっている可能性があるため再確認
+	access, err := s.getProjectAccess(requestedBy, existingRequest.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if access.Status == "archived" {
+		return nil, model.ErrProjectArchived
+	}
+	if existingRequest.VendorProjectID != nil {
+		if !canVendorSubmit(access, *existingRequest.VendorProjectID) {
+			return nil, model.ErrInsufficientPermissions
+		}
+		if existingRequest.SponsorEmail == nil {
+			return nil, model.ErrSponsorRequired
+		}
+	} else if !access.IsMember {
+		return nil, model.ErrInsufficientPermissions
+	}
+
+	// 下書き作成後に環境の設定が変わっている可能性があるため再確認
+	if existingRequest.EnvironmentID != nil {
+		environment, err := s.getEnvironment(existingRequest.ProjectID, *existingRequest.EnvironmentID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkEnvironmentSubmission(environment, access, existingRequest); err != nil {
+			return nil, err
+		}
+	}
+
+	markSubmitted(existingRequest)
+
+	err = s.repo.Update(ctx, existingRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.SelectByID(ctx, id)
+}
+
+// GetAwaitingSponsor は指定したスポンサーの承認待ちの申請一覧を取得
+func (s *cspRequestService) GetAwaitingSponsor(ctx context.Context, sponsorEmail string) ([]model.CSPRequest, error) {
+	requests, err := s.repo.SelectByStatus(ctx, model.CSPRequestStatusAwaitingSponsor)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []model.CSPRequest{}
+	for _, request := range requests {
+		if isSponsor(&request, sponsorEmail) {
+			result = append(result, request)
+		}
+	}
+	return result, nil
+}
+
+// CoSign は依頼元スポンサーがベンダー提出の申請を承認し、管理者のレビューに回す
+func (s *cspRequestService) CoSign(ctx context.Context, id string, sponsorEmail string) (*model.CSPRequest, error) {
+	existingRequest, err := s.getAwaitingSponsorRequest(ctx, id, sponsorEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	existingRequest.SponsorSignedAt = &now
+	existingRequest.Status = model.CSPRequestStatusPending
+
+	err = s.repo.Update(ctx, existingRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.SelectByID(ctx, id)
+}
+
+// DeclineSponsorship は依頼元スポンサーがベンダー提出の申請を差し戻す（却下として記録）
+func (s *cspRequestService) DeclineSponsorship(ctx context.Context, id string, sponsorEmail string, req *model.CSPRequestSponsorDeclineRequest) (*model.CSPRequest, error) {
+	existingRequest, err := s.getAwaitingSponsorRequest(ctx, id, sponsorEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	reason := req.Reason
+	existingRequest.Status = model.CSPRequestStatusRejected
+	existingRequest.ReviewedBy = &sponsorEmail
+	existingRequest.ReviewedAt = &now
+	existingRequest.RejectReason = &reason
+
+	err = s.repo.Update(ctx, existingRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.SelectByID(ctx, id)
+}
+
+// getAwaitingSponsorRequest はスポンサー本人が承認・差し戻しできる申請を取得
+func (s *cspRequestService) getAwaitingSponsorRequest(ctx context.Context, id string, sponsorEmail string) (*model.CSPRequest, error) {
+	existingRequest, err := s.repo.SelectByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isSponsor(existingRequest, sponsorEmail) {
+		return nil, model.ErrInsufficientPermissions
+	}
+	if existingRequest.Status != model.CSPRequestStatusAwaitingSponsor {
+		return nil, model.ErrCSPRequestNotAwaitingSponsor
+	}
+
+	// スポンサーが引き続き依頼元プロジェクトの管理者であることを確認
+	access, err := s.getProjectAccess(sponsorEmail, existingRequest.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if access.Status == "archived" {
+		return nil, model.ErrProjectArchived
+	}
+	if !access.CanManage {
+		return nil, model.ErrInvalidSponsor
+	}
+
+	return existingRequest, nil
+}
+
+// isSponsor は指定したユーザーが申請のスポンサーかどうかを判定
+func isSponsor(cspRequest *model.CSPRequest, email string) bool {
+	return cspRequest.SponsorEmail != nil && strings.EqualFold(*cspRequest.SponsorEmail, email)
+}
+
 func (s *cspRequestService) Update(ctx context.Context, id string, requestedBy string, req *model.CSPRequestUpdateRequest) (*model.CSPRequest, error) {
 	// 既存の申請を取得
 	existingRequest, err := s.repo.SelectByID(ctx, id)
@@ -135,10 +493,14 @@ func (s *cspRequestService) Update(ctx context.Context, id string, requestedBy s
 	}
 
 	// レビュー済みの申請は更新できない
-	if existingRequest.Status != model.CSPRequestStatusPending {
+	if !existingRequest.Status.IsOpen() {
 		return nil, model.ErrCSPRequestAlreadyReviewed
 	}
 
+	if err := s.checkProjectWritable(existingRequest.ProjectID); err != nil {
+		return nil, err
+	}
+
 	// フィールドを更新
 	if req.AccountName != nil {
 		existingRequest.AccountName = *req.AccountName
@@ -147,6 +509,12 @@ func (s *cspRequestService) Update(ctx context.Context, id string, requestedBy s
 		existingRequest.Reason = *req.Reason
 	}
 
+	// スポンサー承認後にスポンサー以外が内容を変更した場合は再承認を必要とする
+	if existingRequest.SponsorSignedAt != nil && !isSponsor(existingRequest, requestedBy) {
+		existingRequest.SponsorSignedAt = nil
+		existingRequest.Status = model.CSPRequestStatusAwaitingSponsor
+	}
+
 	err = s.repo.Update(ctx, existingRequest)
 	if err != nil {
 		return nil, err
@@ -162,16 +530,25 @@ func (s *cspRequestService) Review(ctx context.Context, id string, reviewerID st
 		return nil, err
 	}
 
+	// 下書き・スポンサー承認待ちの申請はまだレビューできない
+	if existingRequest.Status == model.CSPRequestStatusDraft || existingRequest.Status == model.CSPRequestStatusAwaitingSponsor {
+		return nil, model.ErrCSPRequestNotReadyForReview
+	}
+
 	// 既にレビュー済みかチェック
 	if existingRequest.Status != model.CSPRequestStatusPending {
 		return nil, model.ErrCSPRequestAlreadyReviewed
 	}
 
 	// ステータスの有効性をチェック
-	if !req.Status.IsValid() {
+	if !req.Status.IsReviewDecision() {
 		return nil, model.ErrInvalidCSPRequestStatus
 	}
 
+	if err := s.checkProjectWritable(existingRequest.ProjectID); err != nil {
+		return nil, err
+	}
+
 	// 却下の場合は理由が必要
 	if req.Status == model.CSPRequestStatusRejected && (req.RejectReason == nil || *req.RejectReason == "") {
 		return nil, errors.New("reject reason is required for rejection")
@@ -219,6 +596,9 @@ func (s *cspRequestService) createCSPAccount(ctx context.Context, cspRequest *mo
 		"provider":       string(cspRequest.Provider),
 		"account_name":   cspRequest.AccountName,
 		"project_id":     cspRequest.ProjectID,
+		"environment_id": cspRequest.EnvironmentID,
+		"region":         cspRequest.Region,
+		"attributes":     cspRequest.Attributes,
 	}
 
 	reqBody, err := json.Marshal(createReq)
@@ -269,6 +649,10 @@ func (s *cspRequestService) Delete(ctx context.Context, id string, requestedBy s
 		}
 	}
 
+	if err := s.checkProjectWritable(existingRequest.ProjectID); err != nil {
+		return err
+	}
+
 	return s.repo.Delete(ctx, id)
 }
 
@@ -279,8 +663,8 @@ func (s *cspRequestService) CanUserAccessRequest(ctx context.Context, requestedB
 		return false, err
 	}
 
-	// 申請者本人の場合はアクセス可能
-	if cspRequest.RequestedBy == requestedBy {
+	// 申請者本人またはスポンサーの場合はアクセス可能
+	if cspRequest.RequestedBy == requestedBy || isSponsor(cspRequest, requestedBy) {
 		return true, nil
 	}
 
@@ -290,53 +674,184 @@ func (s *cspRequestService) CanUserAccessRequest(ctx context.Context, requestedB
 
 func (s *cspRequestService) CanUserManageProjectCSPAccount(requestedBy string, projectID int) (bool, error) {
 	// メインAPIサーバーに権限確認を依頼
-	url := fmt.Sprintf("%s/api/internal/projects/%d/can-manage?user_id=%s", s.mainAPIURL, projectID, requestedBy)
-	
-	resp, err := s.httpClient.Get(url)
+	access, err := s.getProjectAccess(requestedBy, projectID)
 	if err != nil {
 		log.Printf("Failed to check project permission: %v", err)
 		return false, err
 	}
+
+	return access.CanManage, nil
+}
+
+// vendorPermissionSubmitCSPRequests はメインAPIのCSP申請提出の委任権限
+const vendorPermissionSubmitCSPRequests = "submit_csp_requests"
+
+// projectAccess はメインAPIサーバーから取得するユーザーのプロジェクト権限
+type projectAccess struct {
+	ProjectType          string   `json:"project_type"`
+	Status               string   `json:"status"`
+	IsMember             bool     `json:"is_member"`
+	Role                 string   `json:"role"`
+	CanView              bool     `json:"can_view"`
+	CanManage            bool     `json:"can_manage"`
+	DelegatedPermissions []string `json:"delegated_permissions"`
+	VendorProjectIDs     []int    `json:"vendor_project_ids"` // 有効な紐付けがありユーザーが所属するベンダープロジェクト
+}
+
+// hasDelegatedPermission はベンダー紐付け経由で指定した権限を委任されているかどうかを判定
+func (a *projectAccess) hasDelegatedPermission(permission string) bool {
+	for _, p := range a.DelegatedPermissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// canVendorSubmit はベンダーとして依頼元プロジェクトに申請を提出できるかどうかを判定
+// 有効な紐付けがあるベンダープロジェクトのメンバーで、申請の提出を委任されている必要がある
+func canVendorSubmit(access *projectAccess, vendorProjectID int) bool {
+	return access.isVendorMemberOf(vendorProjectID) && access.hasDelegatedPermission(vendorPermissionSubmitCSPRequests)
+}
+
+// isVendorMemberOf は有効な紐付けがある指定ベンダープロジェクトのメンバーかどうかを判定
+func (a *projectAccess) isVendorMemberOf(vendorProjectID int) bool {
+	for _, id := range a.VendorProjectIDs {
+		if id == vendorProjectID {
+			return true
+		}
+	}
+	return false
+}
+
+// getProjectAccess はメインAPIサーバーからユーザーのプロジェクト権限（ベンダー委任権限を含む）を取得
+func (s *cspRequestService) getProjectAccess(requestedBy string, projectID int) (*projectAccess, error) {
+	url := fmt.Sprintf("%s/api/internal/projects/%d/access?email=%s", s.mainAPIURL, projectID, neturl.QueryEscape(requestedBy))
+
+	resp, err := s.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusOK {
-		return true, nil
-	} else if resp.StatusCode == http.StatusForbidden {
-		return false, nil
-	} else {
-		return false, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	if resp.StatusCode == http.StatusNotFound {
+		// 未登録ユーザーは権限なしとして扱う
+		return &projectAccess{}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
 	}
+
+	var result projectAccess
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
 }
 
-// checkProjectType はプロジェクトがベンダータイプかチェック
-func (s *cspRequestService) checkProjectType(projectID int) error {
+// projectInfo はメインAPIサーバーから取得するプロジェクト情報
+type projectInfo struct {
+	ProjectType string `json:"project_type"`
+	Status      string `json:"status"`
+}
+
+// getProjectInfo はメインAPIサーバーからプロジェクトの種類とステータスを取得
+func (s *cspRequestService) getProjectInfo(projectID int) (*projectInfo, error) {
 	url := fmt.Sprintf("%s/api/internal/projects/%d/type", s.mainAPIURL, projectID)
 	
 	resp, err := s.httpClient.Get(url)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to get project type: status %d", resp.StatusCode)
+		return nil, fmt.Errorf("failed to get project info: status %d", resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	var result projectInfo
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
 	}
 
-	var result struct {
-		ProjectType string `json:"project_type"`
+	return &result, nil
+}
+
+// environmentApprovalStrict はメインAPIの承認の厳しさ（管理権限を持つメンバーのみ提出可能）
+const environmentApprovalStrict = "strict"
+
+// environmentInfo はメインAPIサーバーから取得する環境の設定
+type environmentInfo struct {
+	ID                 int      `json:"id"`
+	ProjectID          int      `json:"project_id"`
+	Name               string   `json:"name"`
+	ApprovalStrictness string   `json:"approval_strictness"`
+	AllowedRegions     []string `json:"allowed_regions"`
+}
+
+// allowsRegion は指定したリージョンを利用できるかどうかを判定（許可リージョンが空の場合は制限なし）
+func (e *environmentInfo) allowsRegion(region string) bool {
+	if len(e.AllowedRegions) == 0 {
+		return true
 	}
-	
+	for _, allowed := range e.AllowedRegions {
+		if allowed == region {
+			return true
+		}
+	}
+	return false
+}
+
+// getEnvironment はメインAPIサーバーからプロジェクトの環境の設定を取得
+func (s *cspRequestService) getEnvironment(projectID, environmentID int) (*environmentInfo, error) {
+	url := fmt.Sprintf("%s/api/internal/projects/%d/environments/%d", s.mainAPIURL, projectID, environmentID)
+
+	resp, err := s.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, model.ErrEnvironmentNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get environment: status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result environmentInfo
 	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// checkProjectWritable はプロジェクトがアーカイブ済み（読み取り専用）でないかチェック
+func (s *cspRequestService) checkProjectWritable(projectID int) error {
+	info, err := s.getProjectInfo(projectID)
+	if err != nil {
 		return err
 	}
 
-	if result.ProjectType == "vendor" {
-		return errors.New("ベンダープロジェクトではCSPプロビジョニングはご利用いただけません")
+	if info.Status == "archived" {
+		return model.ErrProjectArchived
 	}
 
 	return nil
@@ -0,0 +1,19 @@
+package service
+
+import "net/http"
+
+// internalTokenHeader はメインAPIの内部APIに共有シークレットを送るヘッダー（メインAPIのInternalAuthMiddlewareと同じ）
+const internalTokenHeader = "X-Internal-Token"
+
+// internalTokenTransport はメインAPIへのリクエストに内部APIの共有シークレットを付けるRoundTripper
+type internalTokenTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *internalTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTripperはリクエストを変更してはいけないため複製してからヘッダーを付ける
+	req = req.Clone(req.Context())
+	req.Header.Set(internalTokenHeader, t.token)
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"csp-provisioning-service/internal/model"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// providerRegistryTTL はメインAPIから取得したプロバイダーレジストリをキャッシュする期間
+const providerRegistryTTL = 5 * time.Minute
+
+// providerRegistry はメインAPIのプロバイダーレジストリをキャッシュして参照する
+type providerRegistry struct {
+	mainAPIURL string
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	providers []model.CSPProviderDefinition
+	fetchedAt time.Time
+}
+
+func newProviderRegistry(mainAPIURL string, httpClient *http.Client) *providerRegistry {
+	return &providerRegistry{mainAPIURL: mainAPIURL, httpClient: httpClient}
+}
+
+// list はプロバイダーの定義の一覧を返す（キャッシュが古い場合はメインAPIから取得し直す）
+func (r *providerRegistry) list() ([]model.CSPProviderDefinition, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.providers != nil && time.Since(r.fetchedAt) < providerRegistryTTL {
+		return r.providers, nil
+	}
+
+	providers, err := r.fetch()
+	if err != nil {
+		// メインAPIに接続できない場合は前回取得した定義を使い続ける
+		if r.providers != nil {
+			return r.providers, nil
+		}
+		return nil, err
+	}
+	r.providers = providers
+	r.fetchedAt = time.Now()
+	return providers, nil
+}
+
+// lookup はプロバイダーの定義を取得（レジストリに登録されていない場合はErrInvalidCSPProvider）
+func (r *providerRegistry) lookup(provider model.CSPProvider) (*model.CSPProviderDefinition, error) {
+	providers, err := r.list()
+	if err != nil {
+		return nil, err
+	}
+	for i := range providers {
+		if providers[i].ID == provider {
+			return &providers[i], nil
+		}
+	}
+	return nil, model.ErrInvalidCSPProvider
+}
+
+// fetch はメインAPIサーバーからプロバイダーレジストリを取得
+func (r *providerRegistry) fetch() ([]model.CSPProviderDefinition, error) {
+	resp, err := r.httpClient.Get(r.mainAPIURL + "/api/internal/csp-providers")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get CSP providers: status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Data []model.CSPProviderDefinition `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
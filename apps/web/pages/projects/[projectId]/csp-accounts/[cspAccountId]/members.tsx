@@ -22,7 +22,6 @@ interface CSPAccount {
   account_name: string
   provider: string
   account_id: string
-  access_key: string
   region: string
   status: string
   created_at: string
@@ -38,6 +37,9 @@ interface CSPAccountMember {
   sso_email: string
   role: string
   status: string
+  sync_status: string
+  sync_error?: string
+  synced_at?: string
   created_at: string
   updated_at: string
   csp_account?: CSPAccount
@@ -787,6 +789,29 @@ export default function CSPAccountMembers() {
                           {member.sso_enabled ? 'はい' : 'いいえ'}
                         </span>
                       </div>
+                      <div className="flex items-center justify-between">
+                        <span className="text-sm font-medium text-gray-600">
+                          プロバイダー反映
+                        </span>
+                        <span
+                          className={`text-sm font-medium ${
+                            member.sync_status === 'synced'
+                              ? 'text-green-600'
+                              : member.sync_status === 'failed'
+                                ? 'text-red-600'
+                                : 'text-gray-600'
+                          }`}
+                          title={member.sync_error || undefined}
+                        >
+                          {member.sync_status === 'synced'
+                            ? '反映済み'
+                            : member.sync_status === 'failed'
+                              ? '反映失敗'
+                              : member.sync_status === 'not_supported'
+                                ? '対象外'
+                                : '反映待ち'}
+                        </span>
+                      </div>
                       <div className="flex items-center justify-between">
                         <span className="text-sm font-medium text-gray-600">
                           登録日
@@ -20,7 +20,6 @@ interface CSPAccount {
   provider: 'aws' | 'gcp' | 'azure'
   account_name: string
   account_id: string
-  access_key: string
   region: string
   status: string
   csp_request_id: number
@@ -441,15 +440,6 @@ export default function ProjectCSPAccountsPage() {
                             </code>
                           </div>
 
-                          <div className="flex items-center justify-between">
-                            <span className="text-sm font-medium text-gray-600">
-                              アクセスキー
-                            </span>
-                            <code className="text-sm bg-gray-100 px-2 py-1 rounded">
-                              {account.access_key.slice(0, 8)}...
-                            </code>
-                          </div>
-
                           <div className="flex items-center justify-between">
                             <span className="text-sm font-medium text-gray-600">
                               リージョン
@@ -10,7 +10,6 @@ interface CSPAccount {
   provider: 'aws' | 'gcp' | 'azure';
   account_name: string;
   account_id: string;
-  access_key: string;
   region: string;
   status: string;
   csp_request_id: number;
@@ -122,9 +121,12 @@ export default function CSPAccountsPage() {
   // Status display helper
   const getStatusBadge = (status: string) => {
     const statusMap = {
+      provisioning: { label: '作成中', className: 'bg-blue-100 text-blue-800' },
       active: { label: 'アクティブ', className: 'bg-green-100 text-green-800' },
-      inactive: { label: '非アクティブ', className: 'bg-gray-100 text-gray-800' },
-      suspended: { label: '停止中', className: 'bg-red-100 text-red-800' },
+      suspended: { label: '停止中', className: 'bg-yellow-100 text-yellow-800' },
+      closing: { label: '閉鎖処理中', className: 'bg-orange-100 text-orange-800' },
+      closed: { label: '閉鎖済み', className: 'bg-gray-100 text-gray-800' },
+      failed: { label: '作成失敗', className: 'bg-red-100 text-red-800' },
     };
     
     const statusInfo = statusMap[status as keyof typeof statusMap] || { 
@@ -270,12 +272,15 @@ export default function CSPAccountsPage() {
                           >
                             詳細
                           </Link>
-                          <button
-                            onClick={() => handleDelete(account)}
-                            className="text-red-600 hover:text-red-900"
-                          >
-                            削除
-                          </button>
+                          {/* 削除できるのは作成に失敗したアカウントのみ（それ以外は閉鎖の手続きを行う） */}
+                          {account.status === 'failed' && (
+                            <button
+                              onClick={() => handleDelete(account)}
+                              className="text-red-600 hover:text-red-900"
+                            >
+                              削除
+                            </button>
+                          )}
                         </td>
                       </tr>
                     ))